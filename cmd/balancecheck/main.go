@@ -0,0 +1,62 @@
+// Command balancecheck recomputes the balance of every wallet from its
+// successful transactions and prints the wallets whose stored balance
+// drifted, one JSON object per line, followed by a summary on stderr. It
+// exits with status 1 when a drift was found.
+//
+//	DATABASE_URL='root:passwordxx@tcp(localhost:3307)/miniwallet?parseTime=true' go run ./cmd/balancecheck
+//
+// -incidents records every drift as a balance incident admins can follow up
+// on, a drift already on record is not recorded twice.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/app"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	openIncidents := flag.Bool("incidents", false, "open an incident for every drift found")
+	flag.Parse()
+
+	if os.Getenv("DATABASE_URL") == "" {
+		fmt.Fprintln(os.Stderr, "DATABASE_URL is not set")
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db := app.NewDB()
+	defer db.Close()
+
+	balanceService := service.NewBalanceService(repository.NewBalanceRepository(db), *openIncidents)
+
+	encoder := json.NewEncoder(os.Stdout)
+	result, err := balanceService.CheckBalances(ctx, *openIncidents, func(drift web.BalanceDriftResponse) {
+		err := encoder.Encode(drift)
+		if err != nil {
+			log.Println("error write drift", drift.WalletID+":", err.Error())
+		}
+	})
+	if err != nil {
+		log.Fatalln("error check balances:", err.Error())
+	}
+
+	fmt.Fprintf(os.Stderr, "checked %d wallets in %s, %d drifted\n", result.WalletCount, result.FinishedAt.Sub(result.StartedAt).Round(time.Millisecond), result.DriftCount)
+	if result.DriftCount > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+// Command banksim plays the bank side of virtual account top-ups. It sends
+// the signed callback a bank would send when money arrives on a virtual
+// account, so the flow can be exercised end to end without a bank.
+//
+//	go run ./cmd/banksim -va 8808123456789012 -amount 50000
+//
+// -repeat delivers the same callback several times at once, the wallet must
+// be credited only once.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+)
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:8080", "base URL of the wallet API")
+	secret := flag.String("secret", os.Getenv("BANK_CALLBACK_SECRET"), "shared callback secret, defaults to BANK_CALLBACK_SECRET")
+	bankCode := flag.String("bank", "BCA", "bank code the virtual account was opened with")
+	number := flag.String("va", "", "virtual account number to pay into")
+	amount := flag.Int64("amount", 0, "amount in minor units")
+	currency := flag.String("currency", "", "currency of the amount, defaults to the wallet currency on the server")
+	reference := flag.String("reference", "", "bank reference, generated when empty")
+	repeat := flag.Int("repeat", 1, "number of concurrent deliveries of the same callback")
+	flag.Parse()
+
+	if *number == "" || *amount <= 0 || *secret == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *reference == "" {
+		*reference = "SIM" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	form := url.Values{}
+	form.Set("bank_code", *bankCode)
+	form.Set("virtual_account_number", *number)
+	form.Set("bank_reference", *reference)
+	form.Set("amount", strconv.FormatInt(*amount, 10))
+	form.Set("paid_at", time.Now().UTC().Format(time.RFC3339))
+	if *currency != "" {
+		form.Set("currency", *currency)
+	}
+	body := form.Encode()
+
+	var wg sync.WaitGroup
+	for i := 0; i < *repeat; i++ {
+		wg.Add(1)
+		go func(delivery int) {
+			defer wg.Done()
+
+			status, response, err := send(*baseURL, *secret, body)
+			if err != nil {
+				log.Printf("delivery %d: %s", delivery, err.Error())
+				return
+			}
+			fmt.Printf("delivery %d: %d %s\n", delivery, status, strings.TrimSpace(response))
+		}(i + 1)
+	}
+	wg.Wait()
+}
+
+func send(baseURL, secret, body string) (int, string, error) {
+	request, err := http.NewRequest(http.MethodPost, strings.TrimRight(baseURL, "/")+"/api/v1/callbacks/virtual-accounts", strings.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set(bank.HeaderTimestamp, timestamp)
+	request.Header.Set(bank.HeaderSignature, bank.Sign(secret, timestamp, []byte(body)))
+
+	client := http.Client{Timeout: 10 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, "", err
+	}
+	defer response.Body.Close()
+
+	raw, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	return response.StatusCode, string(raw), nil
+}
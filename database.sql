@@ -5,25 +5,605 @@ USE miniwallet;
 CREATE TABLE IF NOT EXISTS `wallets` (
     id VARCHAR(36) NOT NULL,
     customer_xid VARCHAR(36),
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
     status VARCHAR(20) DEFAULT 'disabled',
     enabled_at TIMESTAMP,
-    balance INT DEFAULT 0,
+    balance BIGINT DEFAULT 0,
+    credit_limit BIGINT NOT NULL DEFAULT 0,
+    credit_used BIGINT NOT NULL DEFAULT 0,
+    interest_rate DECIMAL(10,6) NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
-    UNIQUE(`customer_xid`)
+    UNIQUE(`customer_xid`, `currency`),
+    INDEX(`balance`)
 ) ENGINE=INNODB;
 
 CREATE TABLE IF NOT EXISTS `transactions` (
     id VARCHAR(36) NOT NULL,
     wallet_id VARCHAR(36),
     customer_xid VARCHAR(36),
-    transaction_type ENUM('deposit', 'withdrawal'),
-    amount INT NOT NULL,
+    initiated_by VARCHAR(36) NOT NULL DEFAULT '',
+    pocket_id VARCHAR(36) NOT NULL DEFAULT '',
+    transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher', 'points_redemption', 'withdrawal_reversal', 'payout_batch_reservation', 'payout_batch_release', 'escrow_funding', 'escrow_release', 'escrow_refund', 'dispute_reversal'),
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    reference_id VARCHAR(75) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`transaction_type`, `reference_id`),
+    INDEX(`wallet_id`, `status`),
+    INDEX(`pocket_id`, `status`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `fx_rates` (
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    bid DECIMAL(20,8) NOT NULL,
+    ask DECIMAL(20,8) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`base_currency`, `quote_currency`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `fx_quotes` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(24,12) NOT NULL,
+    source_amount BIGINT NOT NULL,
+    target_amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `pockets` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    target_amount BIGINT NOT NULL DEFAULT 0,
+    balance BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`wallet_id`, `status`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `schedules` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    schedule_type VARCHAR(20) NOT NULL,
+    recipient_xid VARCHAR(36) NOT NULL DEFAULT '',
+    bank_code VARCHAR(10) NOT NULL DEFAULT '',
+    account_number VARCHAR(34) NOT NULL DEFAULT '',
+    account_name VARCHAR(100) NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    frequency VARCHAR(20) NOT NULL,
+    day_of_month INT NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`customer_xid`),
+    INDEX(`status`, `next_run_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `schedule_runs` (
+    id VARCHAR(36) NOT NULL,
+    schedule_id VARCHAR(36) NOT NULL,
+    run_at TIMESTAMP NOT NULL,
     reference_id VARCHAR(75) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    error_message VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`schedule_id`, `run_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payment_requests` (
+    id VARCHAR(36) NOT NULL,
+    split_bill_id VARCHAR(36) NOT NULL DEFAULT '',
+    requester_xid VARCHAR(36) NOT NULL,
+    payer_xid VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`payer_xid`),
+    INDEX(`requester_xid`),
+    INDEX(`split_bill_id`),
+    INDEX(`status`, `expires_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `split_bills` (
+    id VARCHAR(36) NOT NULL,
+    creator_xid VARCHAR(36) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    total_amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    split_type VARCHAR(20) NOT NULL,
+    expiry_policy VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`creator_xid`),
+    INDEX(`status`, `expires_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `merchants` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    name VARCHAR(25) NOT NULL,
+    city VARCHAR(15) NOT NULL,
+    category_code VARCHAR(4) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payment_intents` (
+    id VARCHAR(36) NOT NULL,
+    merchant_id VARCHAR(36) NOT NULL,
+    merchant_reference VARCHAR(50) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL DEFAULT '',
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`merchant_id`, `merchant_reference`),
+    INDEX(`merchant_id`, `status`, `created_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `settlements` (
+    id VARCHAR(36) NOT NULL,
+    merchant_id VARCHAR(36) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    cutoff_at TIMESTAMP NOT NULL,
+    gross_amount BIGINT NOT NULL,
+    fee_amount BIGINT NOT NULL,
+    refund_amount BIGINT NOT NULL,
+    carried_amount BIGINT NOT NULL DEFAULT 0,
+    carried_from VARCHAR(36) NOT NULL DEFAULT '',
+    net_amount BIGINT NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`merchant_id`, `currency`, `cutoff_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `settlement_items` (
+    transaction_id VARCHAR(36) NOT NULL,
+    settlement_id VARCHAR(36) NOT NULL,
+    payment_intent_id VARCHAR(36) NOT NULL,
+    transaction_type VARCHAR(20) NOT NULL,
+    amount BIGINT NOT NULL,
+    fee BIGINT NOT NULL,
+    transacted_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (`transaction_id`),
+    INDEX(`settlement_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `loans` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    reference_id VARCHAR(50) NOT NULL,
+    principal BIGINT NOT NULL,
+    interest_amount BIGINT NOT NULL,
+    late_fee BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    installment_count INT NOT NULL,
+    frequency VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`reference_id`),
+    INDEX(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `loan_installments` (
+    id VARCHAR(36) NOT NULL,
+    loan_id VARCHAR(36) NOT NULL,
+    sequence INT NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    amount BIGINT NOT NULL,
+    late_fee BIGINT NOT NULL DEFAULT 0,
+    paid_amount BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    next_collect_at TIMESTAMP NOT NULL,
+    paid_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`loan_id`, `sequence`),
+    INDEX(`status`, `next_collect_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `interest_accruals` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    pocket_id VARCHAR(36) NOT NULL DEFAULT '',
+    accrual_date DATE NOT NULL,
+    period CHAR(7) NOT NULL,
+    balance BIGINT NOT NULL,
+    rate DECIMAL(10,6) NOT NULL,
+    amount DECIMAL(30,6) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    carried_from VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`wallet_id`, `pocket_id`, `accrual_date`, `carried_from`),
+    INDEX(`transaction_id`, `period`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `campaigns` (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    transaction_type VARCHAR(20) NOT NULL,
+    merchant_id VARCHAR(36) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    min_amount BIGINT NOT NULL DEFAULT 0,
+    cashback_rate DECIMAL(10,6) NOT NULL,
+    max_cashback BIGINT NOT NULL DEFAULT 0,
+    customer_cap BIGINT NOT NULL DEFAULT 0,
+    budget BIGINT NOT NULL,
+    budget_used BIGINT NOT NULL DEFAULT 0,
+    hold_days INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`status`, `starts_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `cashbacks` (
+    id VARCHAR(36) NOT NULL,
+    campaign_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    source_transaction_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    release_at TIMESTAMP NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`source_transaction_id`),
+    INDEX(`campaign_id`, `customer_xid`),
+    INDEX(`status`, `release_at`),
+    INDEX(`customer_xid`, `created_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `voucher_batches` (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    value BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    code_count INT NOT NULL,
+    max_uses INT NOT NULL,
+    per_customer_limit INT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`created_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `vouchers` (
+    id VARCHAR(36) NOT NULL,
+    batch_id VARCHAR(36) NOT NULL,
+    code VARCHAR(30) NOT NULL,
+    used_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`code`),
+    INDEX(`batch_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `voucher_customer_usages` (
+    batch_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    redeemed_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (`batch_id`, `customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `voucher_redemptions` (
+    id VARCHAR(36) NOT NULL,
+    batch_id VARCHAR(36) NOT NULL,
+    voucher_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    transaction_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`batch_id`),
+    INDEX(`voucher_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `loyalty_accounts` (
+    customer_xid VARCHAR(36) NOT NULL,
+    balance BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `points_entries` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    entry_type ENUM('earn', 'redeem', 'expire', 'earn_reversal') NOT NULL,
+    points BIGINT NOT NULL,
+    remaining BIGINT NOT NULL DEFAULT 0,
+    reference_id VARCHAR(75) NOT NULL,
+    expires_at TIMESTAMP NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`entry_type`, `reference_id`),
+    INDEX(`customer_xid`, `created_at`),
+    INDEX(`customer_xid`, `expires_at`),
+    INDEX(`expires_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `virtual_accounts` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    number VARCHAR(20) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`number`),
+    UNIQUE(`wallet_id`, `bank_code`),
+    INDEX(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `virtual_account_payments` (
+    id VARCHAR(36) NOT NULL,
+    virtual_account_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    bank_reference VARCHAR(50) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    transaction_id VARCHAR(36) NOT NULL,
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`bank_code`, `bank_reference`),
+    INDEX(`virtual_account_id`, `paid_at`),
+    INDEX(`transaction_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `beneficiaries` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    account_number VARCHAR(34) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+    verified_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`customer_xid`, `bank_code`, `account_number`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payouts` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    beneficiary_id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    account_number VARCHAR(34) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    provider_reference VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`transaction_id`),
+    INDEX(`customer_xid`),
+    INDEX(`status`, `updated_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `statement_imports` (
+    id VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    file_hash CHAR(64) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    line_count INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`bank_code`, `file_hash`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `statement_lines` (
+    id VARCHAR(36) NOT NULL,
+    import_id VARCHAR(36) NOT NULL,
+    line_number INT NOT NULL,
+    value_date DATE NOT NULL,
+    direction VARCHAR(6) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`import_id`, `line_number`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `statement_matches` (
+    line_id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    match_type VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`line_id`),
+    UNIQUE(`transaction_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `balance_incidents` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    balance BIGINT NOT NULL,
+    computed_balance BIGINT NOT NULL,
+    drift BIGINT NOT NULL,
+    transaction_count INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE(`wallet_id`, `balance`, `computed_balance`),
+    INDEX(`status`, `detected_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payout_batches` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    reference VARCHAR(75) NOT NULL,
+    total_amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    row_count INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`customer_xid`, `reference`),
+    INDEX(`status`, `updated_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payout_batch_items` (
+    id VARCHAR(36) NOT NULL,
+    batch_id VARCHAR(36) NOT NULL,
+    line_number INT NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    account_number VARCHAR(34) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    reference_id VARCHAR(75) NOT NULL,
+    payout_id VARCHAR(36) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`batch_id`, `line_number`),
+    UNIQUE(`batch_id`, `reference_id`),
+    INDEX(`payout_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `escrows` (
+    id VARCHAR(36) NOT NULL,
+    buyer_xid VARCHAR(36) NOT NULL,
+    buyer_wallet_id VARCHAR(36) NOT NULL,
+    seller_xid VARCHAR(36) NOT NULL,
+    seller_wallet_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`buyer_xid`),
+    INDEX(`seller_xid`),
+    INDEX(`status`, `expires_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `escrow_events` (
+    id VARCHAR(36) NOT NULL,
+    escrow_id VARCHAR(36) NOT NULL,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(36) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`escrow_id`, `created_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `disputes` (
+    id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    transaction_type VARCHAR(30) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    reason VARCHAR(255) NOT NULL,
+    evidence JSON NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    resolution_note VARCHAR(255) NOT NULL DEFAULT '',
+    reversal_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE(`transaction_id`),
+    INDEX(`customer_xid`, `created_at`),
+    INDEX(`status`, `created_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `wallet_members` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    owner_xid VARCHAR(36) NOT NULL,
+    member_xid VARCHAR(36) NOT NULL,
+    spending_limit BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    allowed_types VARCHAR(255) NOT NULL DEFAULT '',
     status VARCHAR(20) NOT NULL,
+    spent BIGINT NOT NULL DEFAULT 0,
+    spent_period VARCHAR(7) NOT NULL DEFAULT '',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (`id`),
-    UNIQUE(`transaction_type`, `reference_id`)
+    UNIQUE(`wallet_id`, `member_xid`),
+    INDEX(`member_xid`)
 ) ENGINE=INNODB;
\ No newline at end of file
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/mozartmuhammad/julo-be-test/src/app"
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
 	"github.com/mozartmuhammad/julo-be-test/src/controller"
+	"github.com/mozartmuhammad/julo-be-test/src/job"
 	"github.com/mozartmuhammad/julo-be-test/src/repository"
 	"github.com/mozartmuhammad/julo-be-test/src/service"
 
@@ -16,11 +23,105 @@ import (
 func main() {
 	db := app.NewDB()
 	validate := validator.New()
+	location := businessLocation()
 	walletRepository := repository.NewWalletRepository(db)
-	walletService := service.NewWalletService(walletRepository, validate, 5*time.Second)
+	pocketRepository := repository.NewPocketRepository(db)
+	payoutRepository := repository.NewPayoutRepository(db)
+	walletMemberRepository := repository.NewWalletMemberRepository(db)
+	payoutProvider := bank.NewStubPayoutProvider(30 * time.Second)
+	walletService := service.NewWalletService(walletRepository, pocketRepository, payoutRepository, walletMemberRepository, payoutProvider, validate, 5*time.Second)
 	walletController := controller.NewWalletController(walletService)
+	fxRepository := repository.NewFxRepository(db)
+	fxService := service.NewFxService(walletRepository, fxRepository, validate, 30*time.Second)
+	fxController := controller.NewFxController(fxService)
+	pocketService := service.NewPocketService(walletRepository, pocketRepository, validate)
+	pocketController := controller.NewPocketController(pocketService)
+	scheduleRepository := repository.NewScheduleRepository(db)
+	scheduleService := service.NewScheduleService(scheduleRepository, walletRepository, walletService, validate)
+	scheduleController := controller.NewScheduleController(scheduleService)
+	paymentRequestRepository := repository.NewPaymentRequestRepository(db)
+	splitBillRepository := repository.NewSplitBillRepository(db)
+	paymentRequestService := service.NewPaymentRequestService(paymentRequestRepository, splitBillRepository, walletRepository, walletService, validate)
+	paymentRequestController := controller.NewPaymentRequestController(paymentRequestService)
+	splitBillService := service.NewSplitBillService(splitBillRepository, paymentRequestRepository, walletRepository, walletService, validate)
+	splitBillController := controller.NewSplitBillController(splitBillService)
+	merchantRepository := repository.NewMerchantRepository(db)
+	merchantService := service.NewMerchantService(merchantRepository, walletRepository, validate, 15*time.Minute)
+	merchantController := controller.NewMerchantController(merchantService)
+	qrService := service.NewQRService(merchantRepository, merchantService, validate)
+	qrController := controller.NewQRController(qrService)
+	settlementRepository := repository.NewSettlementRepository(db)
+	settlementService := service.NewSettlementService(settlementRepository, merchantRepository, walletRepository, envRate("MERCHANT_FEE_RATE"), location)
+	settlementController := controller.NewSettlementController(settlementService)
+	loanRepository := repository.NewLoanRepository(db)
+	loanService := service.NewLoanService(loanRepository, walletRepository, validate)
+	loanController := controller.NewLoanController(loanService)
+	creditLineRepository := repository.NewCreditLineRepository(db)
+	creditLineService := service.NewCreditLineService(creditLineRepository, walletRepository, validate, envRate("OVERDRAFT_INTEREST_RATE"), location)
+	creditLineController := controller.NewCreditLineController(creditLineService)
+	interestRepository := repository.NewInterestRepository(db)
+	interestService := service.NewInterestService(interestRepository, walletRepository, validate, envRate("POCKET_INTEREST_RATE"), location)
+	interestController := controller.NewInterestController(interestService)
+	campaignRepository := repository.NewCampaignRepository(db)
+	campaignService := service.NewCampaignService(campaignRepository, walletRepository, validate)
+	campaignController := controller.NewCampaignController(campaignService)
+	voucherRepository := repository.NewVoucherRepository(db)
+	voucherService := service.NewVoucherService(voucherRepository, walletRepository, validate)
+	voucherController := controller.NewVoucherController(voucherService)
+	loyaltyRepository := repository.NewLoyaltyRepository(db)
+	loyaltyService := service.NewLoyaltyService(loyaltyRepository, walletRepository, validate, envRate("LOYALTY_EARN_RATE"), envNumber("LOYALTY_REDEEM_RATE", 1), int(envNumber("LOYALTY_EXPIRY_MONTHS", 12)))
+	loyaltyController := controller.NewLoyaltyController(loyaltyService)
+	virtualAccountRepository := repository.NewVirtualAccountRepository(db)
+	virtualAccountService := service.NewVirtualAccountService(virtualAccountRepository, walletRepository, validate)
+	virtualAccountController := controller.NewVirtualAccountController(virtualAccountService)
+	payoutService := service.NewPayoutService(payoutRepository, payoutProvider)
+	payoutController := controller.NewPayoutController(payoutService)
+	nameResolver := bank.NewStubNameResolver()
+	beneficiaryService := service.NewBeneficiaryService(payoutRepository, nameResolver, validate)
+	beneficiaryController := controller.NewBeneficiaryController(beneficiaryService)
+	reconciliationRepository := repository.NewReconciliationRepository(db)
+	reconciliationService := service.NewReconciliationService(reconciliationRepository, walletRepository, validate, location, os.Getenv("PAYOUT_BANK_CODE"))
+	reconciliationController := controller.NewReconciliationController(reconciliationService)
+	balanceRepository := repository.NewBalanceRepository(db)
+	balanceService := service.NewBalanceService(balanceRepository, os.Getenv("BALANCE_CHECK_INCIDENTS") == "true")
+	balanceController := controller.NewBalanceController(balanceService)
+	accountStatementService := service.NewAccountStatementService(walletRepository, validate, location)
+	accountStatementController := controller.NewAccountStatementController(accountStatementService)
+	payoutBatchRepository := repository.NewPayoutBatchRepository(db)
+	payoutBatchService := service.NewPayoutBatchService(payoutBatchRepository, payoutRepository, walletRepository, payoutProvider, nameResolver, validate)
+	payoutBatchController := controller.NewPayoutBatchController(payoutBatchService)
+	escrowRepository := repository.NewEscrowRepository(db)
+	escrowService := service.NewEscrowService(escrowRepository, walletRepository, validate)
+	escrowController := controller.NewEscrowController(escrowService)
+	disputeRepository := repository.NewDisputeRepository(db)
+	disputeService := service.NewDisputeService(disputeRepository, walletRepository, payoutRepository, validate)
+	disputeController := controller.NewDisputeController(disputeService)
+	sharedWalletService := service.NewSharedWalletService(walletMemberRepository, walletRepository, validate)
+	sharedWalletController := controller.NewSharedWalletController(sharedWalletService)
 
-	router := app.NewRouter(walletController)
+	// seed exchange rates from file, admins can override them later through the API
+	if ratesFile := os.Getenv("FX_RATES_FILE"); ratesFile != "" {
+		err := fxService.LoadRates(context.Background(), ratesFile)
+		if err != nil {
+			log.Println("error load fx rates:", err.Error())
+		}
+	}
+
+	go job.Run(context.Background(), "schedules", time.Minute, scheduleService.RunDueSchedules)
+	go job.Run(context.Background(), "payment-requests", time.Minute, paymentRequestService.ExpirePaymentRequests)
+	go job.Run(context.Background(), "split-bills", time.Minute, splitBillService.ExpireSplitBills)
+	go job.Run(context.Background(), "settlements", time.Hour, settlementService.RunSettlements)
+	go job.Run(context.Background(), "loans", time.Minute, loanService.CollectDueInstallments)
+	go job.Run(context.Background(), "overdraft-interest", time.Hour, creditLineService.AccrueOverdraftInterest)
+	go job.Run(context.Background(), "interest", time.Hour, interestService.RunInterest)
+	go job.Run(context.Background(), "campaigns", time.Minute, campaignService.RunCampaigns)
+	go job.Run(context.Background(), "loyalty-points", time.Minute, loyaltyService.RunLoyaltyPoints)
+	go job.Run(context.Background(), "payouts", time.Minute, payoutService.RunPayouts)
+	go job.Run(context.Background(), "payout-batches", time.Minute, payoutBatchService.RunPayoutBatches)
+	go job.Run(context.Background(), "escrows", time.Minute, escrowService.ExpireEscrows)
+	go job.Run(context.Background(), "balance-check", 24*time.Hour, balanceService.RunBalanceCheck)
+
+	router := app.NewRouter(walletController, fxController, pocketController, scheduleController, paymentRequestController, splitBillController, merchantController, qrController, settlementController, loanController, creditLineController, interestController, campaignController, voucherController, loyaltyController, virtualAccountController, payoutController, beneficiaryController, reconciliationController, balanceController, accountStatementController, payoutBatchController, escrowController, disputeController, sharedWalletController)
 	server := http.Server{
 		Addr:    ":1323",
 		Handler: router,
@@ -31,3 +132,51 @@ func main() {
 		panic(err)
 	}
 }
+
+// envRate reads a rate such as 0.007 for 0.7% from the environment. An unset
+// or invalid rate is treated as zero.
+func envRate(key string) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate >= 1 {
+		log.Println("error invalid "+key+":", value)
+		return 0
+	}
+	return rate
+}
+
+// envNumber reads a positive number from the environment, falling back when
+// it is unset or invalid.
+func envNumber(key string, fallback float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		log.Println("error invalid "+key+":", value)
+		return fallback
+	}
+	return number
+}
+
+// businessLocation reads BUSINESS_TIMEZONE, the timezone whose midnight closes
+// a day for settlements and interest.
+func businessLocation() *time.Location {
+	name := os.Getenv("BUSINESS_TIMEZONE")
+	if name == "" {
+		name = "Asia/Jakarta"
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		log.Println("error load BUSINESS_TIMEZONE:", err.Error())
+		return time.UTC
+	}
+	return location
+}
@@ -0,0 +1,37 @@
+-- Upgrades a database created from the single-currency schema, where
+-- customer_xid alone was unique, to hold a wallet per currency and exchange
+-- between them at quoted rates. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `wallets`
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'IDR' AFTER customer_xid,
+    DROP INDEX `customer_xid`,
+    ADD UNIQUE KEY `customer_xid` (`customer_xid`, `currency`);
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit');
+
+CREATE TABLE IF NOT EXISTS `fx_rates` (
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    bid DECIMAL(20,8) NOT NULL,
+    ask DECIMAL(20,8) NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`base_currency`, `quote_currency`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `fx_quotes` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    from_currency VARCHAR(3) NOT NULL,
+    to_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(24,12) NOT NULL,
+    source_amount INT NOT NULL,
+    target_amount INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`customer_xid`)
+) ENGINE=INNODB;
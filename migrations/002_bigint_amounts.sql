@@ -0,0 +1,15 @@
+-- Widens amounts to BIGINT for databases created while they were INT, and
+-- adds the currency of every transaction. Fresh databases get this from
+-- database.sql.
+USE miniwallet;
+
+ALTER TABLE `wallets`
+    MODIFY balance BIGINT DEFAULT 0;
+
+ALTER TABLE `transactions`
+    MODIFY amount BIGINT NOT NULL,
+    ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'IDR' AFTER amount;
+
+ALTER TABLE `fx_quotes`
+    MODIFY source_amount BIGINT NOT NULL,
+    MODIFY target_amount BIGINT NOT NULL;
@@ -0,0 +1,19 @@
+-- Adds savings pockets under the main wallet and the transactions moving
+-- money in and out of them. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release');
+
+CREATE TABLE IF NOT EXISTS `pockets` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    target_amount BIGINT NOT NULL DEFAULT 0,
+    balance BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`wallet_id`, `status`)
+) ENGINE=INNODB;
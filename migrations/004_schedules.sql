@@ -0,0 +1,37 @@
+-- Adds scheduled withdrawals and transfers, their runs and the transfer
+-- transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in');
+
+CREATE TABLE IF NOT EXISTS `schedules` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    schedule_type VARCHAR(20) NOT NULL,
+    recipient_xid VARCHAR(36) NOT NULL DEFAULT '',
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    frequency VARCHAR(20) NOT NULL,
+    day_of_month INT NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`customer_xid`),
+    INDEX(`status`, `next_run_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `schedule_runs` (
+    id VARCHAR(36) NOT NULL,
+    schedule_id VARCHAR(36) NOT NULL,
+    run_at TIMESTAMP NOT NULL,
+    reference_id VARCHAR(75) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    error_message VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`schedule_id`, `run_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,21 @@
+-- Adds payment requests between customers. Fresh databases get this from
+-- database.sql.
+USE miniwallet;
+
+CREATE TABLE IF NOT EXISTS `payment_requests` (
+    id VARCHAR(36) NOT NULL,
+    requester_xid VARCHAR(36) NOT NULL,
+    payer_xid VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`payer_xid`),
+    INDEX(`requester_xid`),
+    INDEX(`status`, `expires_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,24 @@
+-- Adds split bills, whose shares are payment requests to every participant.
+-- Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `payment_requests`
+    ADD COLUMN split_bill_id VARCHAR(36) NOT NULL DEFAULT '' AFTER id,
+    ADD INDEX split_bill_id (split_bill_id);
+
+CREATE TABLE IF NOT EXISTS `split_bills` (
+    id VARCHAR(36) NOT NULL,
+    creator_xid VARCHAR(36) NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    total_amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    split_type VARCHAR(20) NOT NULL,
+    expiry_policy VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`creator_xid`),
+    INDEX(`status`, `expires_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,37 @@
+-- Adds merchants, their payment intents and the payment transactions.
+-- Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_received');
+
+CREATE TABLE IF NOT EXISTS `merchants` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    name VARCHAR(25) NOT NULL,
+    city VARCHAR(15) NOT NULL,
+    category_code VARCHAR(4) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payment_intents` (
+    id VARCHAR(36) NOT NULL,
+    merchant_id VARCHAR(36) NOT NULL,
+    merchant_reference VARCHAR(50) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL DEFAULT '',
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`merchant_id`, `merchant_reference`),
+    INDEX(`merchant_id`, `status`, `created_at`)
+) ENGINE=INNODB;
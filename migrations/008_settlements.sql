@@ -0,0 +1,58 @@
+-- Adds daily merchant settlements. Merchants used to be credited every
+-- payment right away as payment_received; those payments count as settled,
+-- in one settlement per merchant and currency, and their credits become the
+-- settlement_payout of that settlement. Fresh databases get this from
+-- database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_received', 'payment_refund', 'settlement_payout');
+
+CREATE TABLE IF NOT EXISTS `settlements` (
+    id VARCHAR(36) NOT NULL,
+    merchant_id VARCHAR(36) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    cutoff_at TIMESTAMP NOT NULL,
+    gross_amount BIGINT NOT NULL,
+    fee_amount BIGINT NOT NULL,
+    refund_amount BIGINT NOT NULL,
+    net_amount BIGINT NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`merchant_id`, `currency`, `cutoff_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `settlement_items` (
+    transaction_id VARCHAR(36) NOT NULL,
+    settlement_id VARCHAR(36) NOT NULL,
+    payment_intent_id VARCHAR(36) NOT NULL,
+    transaction_type VARCHAR(20) NOT NULL,
+    amount BIGINT NOT NULL,
+    fee BIGINT NOT NULL,
+    transacted_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (`transaction_id`),
+    INDEX(`settlement_id`)
+) ENGINE=INNODB;
+
+INSERT INTO settlements
+    (id, merchant_id, currency, cutoff_at, gross_amount, fee_amount, refund_amount, net_amount, transaction_id, status, created_at)
+    SELECT UUID(), pi.merchant_id, r.currency, MAX(r.created_at) + INTERVAL 1 SECOND, SUM(r.amount), 0, 0, SUM(r.amount), '', 'success', CURRENT_TIMESTAMP
+    FROM transactions r JOIN payment_intents pi ON pi.id = r.reference_id
+    WHERE r.transaction_type = 'payment_received'
+    GROUP BY pi.merchant_id, r.currency;
+
+INSERT INTO settlement_items
+    (transaction_id, settlement_id, payment_intent_id, transaction_type, amount, fee, transacted_at)
+    SELECT t.id, s.id, pi.id, t.transaction_type, t.amount, 0, t.created_at
+    FROM transactions t
+    JOIN payment_intents pi ON pi.id = t.reference_id
+    JOIN transactions r ON r.transaction_type = 'payment_received' AND r.reference_id = t.reference_id
+    JOIN settlements s ON s.merchant_id = pi.merchant_id AND s.currency = r.currency
+    WHERE t.transaction_type = 'payment';
+
+UPDATE transactions SET transaction_type = 'settlement_payout' WHERE transaction_type = 'payment_received';
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout');
@@ -0,0 +1,8 @@
+-- Keeps settlements whose refunds outweighed their payments, to be taken out
+-- of the merchant's next settlement. Fresh databases get this from
+-- database.sql.
+USE miniwallet;
+
+ALTER TABLE `settlements`
+    ADD COLUMN carried_amount BIGINT NOT NULL DEFAULT 0 AFTER refund_amount,
+    ADD COLUMN carried_from VARCHAR(36) NOT NULL DEFAULT '' AFTER carried_amount;
@@ -0,0 +1,44 @@
+-- Adds loans, their installments and the disbursement and repayment
+-- transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment');
+
+CREATE TABLE IF NOT EXISTS `loans` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    reference_id VARCHAR(50) NOT NULL,
+    principal BIGINT NOT NULL,
+    interest_amount BIGINT NOT NULL,
+    late_fee BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    installment_count INT NOT NULL,
+    frequency VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`reference_id`),
+    INDEX(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `loan_installments` (
+    id VARCHAR(36) NOT NULL,
+    loan_id VARCHAR(36) NOT NULL,
+    sequence INT NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    amount BIGINT NOT NULL,
+    late_fee BIGINT NOT NULL DEFAULT 0,
+    paid_amount BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    next_collect_at TIMESTAMP NOT NULL,
+    paid_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`loan_id`, `sequence`),
+    INDEX(`status`, `next_collect_at`)
+) ENGINE=INNODB;
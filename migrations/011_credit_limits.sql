@@ -0,0 +1,10 @@
+-- Adds the credit limit admins give a wallet and the overdraft interest
+-- charged on it. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `wallets`
+    ADD COLUMN credit_limit BIGINT NOT NULL DEFAULT 0 AFTER balance,
+    ADD INDEX balance (balance);
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest');
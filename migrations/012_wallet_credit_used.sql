@@ -0,0 +1,8 @@
+-- Tracks the overdraft drawn on every wallet in its own column, filled from
+-- the balances already overdrawn. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `wallets`
+    ADD COLUMN credit_used BIGINT NOT NULL DEFAULT 0 AFTER credit_limit;
+
+UPDATE wallets SET credit_used = GREATEST(-balance, 0);
@@ -0,0 +1,25 @@
+-- Adds the interest rate of every wallet, the daily interest accruals and the
+-- interest transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `wallets`
+    ADD COLUMN interest_rate DECIMAL(10,6) NOT NULL DEFAULT 0 AFTER credit_used;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest');
+
+CREATE TABLE IF NOT EXISTS `interest_accruals` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    pocket_id VARCHAR(36) NOT NULL DEFAULT '',
+    accrual_date DATE NOT NULL,
+    period CHAR(7) NOT NULL,
+    balance BIGINT NOT NULL,
+    rate DECIMAL(10,6) NOT NULL,
+    amount DECIMAL(30,6) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`wallet_id`, `pocket_id`, `accrual_date`),
+    INDEX(`transaction_id`, `period`)
+) ENGINE=INNODB;
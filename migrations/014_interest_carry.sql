@@ -0,0 +1,8 @@
+-- Keeps the fraction of a minor unit rounded away when interest is posted as
+-- an accrual of the next period. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `interest_accruals`
+    ADD COLUMN carried_from VARCHAR(36) NOT NULL DEFAULT '' AFTER transaction_id,
+    DROP INDEX wallet_id,
+    ADD UNIQUE KEY wallet_id (wallet_id, pocket_id, accrual_date, carried_from);
@@ -0,0 +1,47 @@
+-- Adds cashback campaigns, the cashbacks they hold and release and the
+-- cashback transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback');
+
+CREATE TABLE IF NOT EXISTS `campaigns` (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    transaction_type VARCHAR(20) NOT NULL,
+    merchant_id VARCHAR(36) NOT NULL DEFAULT '',
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    min_amount BIGINT NOT NULL DEFAULT 0,
+    cashback_rate DECIMAL(10,6) NOT NULL,
+    max_cashback BIGINT NOT NULL DEFAULT 0,
+    customer_cap BIGINT NOT NULL DEFAULT 0,
+    budget BIGINT NOT NULL,
+    budget_used BIGINT NOT NULL DEFAULT 0,
+    hold_days INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`status`, `starts_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `cashbacks` (
+    id VARCHAR(36) NOT NULL,
+    campaign_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    source_transaction_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    release_at TIMESTAMP NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`source_transaction_id`),
+    INDEX(`campaign_id`, `customer_xid`),
+    INDEX(`status`, `release_at`),
+    INDEX(`customer_xid`, `created_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,55 @@
+-- Adds voucher batches, their codes and redemptions and the voucher
+-- transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher');
+
+CREATE TABLE IF NOT EXISTS `voucher_batches` (
+    id VARCHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    value BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    code_count INT NOT NULL,
+    max_uses INT NOT NULL,
+    per_customer_limit INT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`created_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `vouchers` (
+    id VARCHAR(36) NOT NULL,
+    batch_id VARCHAR(36) NOT NULL,
+    code VARCHAR(30) NOT NULL,
+    used_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`code`),
+    INDEX(`batch_id`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `voucher_customer_usages` (
+    batch_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    redeemed_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (`batch_id`, `customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `voucher_redemptions` (
+    id VARCHAR(36) NOT NULL,
+    batch_id VARCHAR(36) NOT NULL,
+    voucher_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    transaction_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`batch_id`),
+    INDEX(`voucher_id`)
+) ENGINE=INNODB;
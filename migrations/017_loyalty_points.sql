@@ -0,0 +1,31 @@
+-- Adds loyalty accounts, their points ledger and the points redemption
+-- transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher', 'points_redemption');
+
+CREATE TABLE IF NOT EXISTS `loyalty_accounts` (
+    customer_xid VARCHAR(36) NOT NULL,
+    balance BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `points_entries` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    entry_type ENUM('earn', 'redeem', 'expire') NOT NULL,
+    points BIGINT NOT NULL,
+    remaining BIGINT NOT NULL DEFAULT 0,
+    reference_id VARCHAR(75) NOT NULL,
+    expires_at TIMESTAMP NULL,
+    transaction_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`entry_type`, `reference_id`),
+    INDEX(`customer_xid`, `created_at`),
+    INDEX(`customer_xid`, `expires_at`),
+    INDEX(`expires_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,6 @@
+-- Lets points earned on a refunded payment be taken back. Fresh databases get
+-- this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `points_entries`
+    MODIFY entry_type ENUM('earn', 'redeem', 'expire', 'earn_reversal') NOT NULL;
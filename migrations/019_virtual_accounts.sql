@@ -0,0 +1,33 @@
+-- Adds virtual accounts and the bank payments made into them. Fresh
+-- databases get this from database.sql.
+USE miniwallet;
+
+CREATE TABLE IF NOT EXISTS `virtual_accounts` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    number VARCHAR(20) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`number`),
+    UNIQUE(`wallet_id`, `bank_code`),
+    INDEX(`customer_xid`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `virtual_account_payments` (
+    id VARCHAR(36) NOT NULL,
+    virtual_account_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    bank_reference VARCHAR(50) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    transaction_id VARCHAR(36) NOT NULL,
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`bank_code`, `bank_reference`),
+    INDEX(`virtual_account_id`, `paid_at`)
+) ENGINE=INNODB;
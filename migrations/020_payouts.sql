@@ -0,0 +1,46 @@
+-- Adds bank payouts of withdrawals, the beneficiaries they pay, scheduled
+-- withdrawals to a bank account and the reversal of failed payouts. Fresh
+-- databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `schedules`
+    ADD COLUMN bank_code VARCHAR(10) NOT NULL DEFAULT '' AFTER recipient_xid,
+    ADD COLUMN account_number VARCHAR(34) NOT NULL DEFAULT '' AFTER bank_code,
+    ADD COLUMN account_name VARCHAR(100) NOT NULL DEFAULT '' AFTER account_number;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher', 'points_redemption', 'withdrawal_reversal');
+
+CREATE TABLE IF NOT EXISTS `beneficiaries` (
+    id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    account_number VARCHAR(34) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`customer_xid`, `bank_code`, `account_number`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payouts` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    beneficiary_id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    account_number VARCHAR(34) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    provider_reference VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`transaction_id`),
+    INDEX(`customer_xid`),
+    INDEX(`status`, `updated_at`)
+) ENGINE=INNODB;
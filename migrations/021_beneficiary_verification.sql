@@ -0,0 +1,7 @@
+-- Records when a beneficiary's holder name was confirmed. Beneficiaries
+-- saved before stay unverified until they are verified. Fresh databases
+-- get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `beneficiaries`
+    ADD COLUMN verified_at TIMESTAMP NULL AFTER account_name;
@@ -0,0 +1,42 @@
+-- Adds imported bank statements, their lines and the transactions they are
+-- matched to. Fresh databases get this from database.sql.
+USE miniwallet;
+
+CREATE TABLE IF NOT EXISTS `statement_imports` (
+    id VARCHAR(36) NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    file_hash CHAR(64) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    line_count INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`bank_code`, `file_hash`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `statement_lines` (
+    id VARCHAR(36) NOT NULL,
+    import_id VARCHAR(36) NOT NULL,
+    line_number INT NOT NULL,
+    value_date DATE NOT NULL,
+    direction VARCHAR(6) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`import_id`, `line_number`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `statement_matches` (
+    line_id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    match_type VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`line_id`),
+    UNIQUE(`transaction_id`)
+) ENGINE=INNODB;
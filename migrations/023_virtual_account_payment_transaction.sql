@@ -0,0 +1,6 @@
+-- Lets reconciliation find the bank of a virtual account deposit from its
+-- transaction. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `virtual_account_payments`
+    ADD INDEX transaction_id (transaction_id);
@@ -0,0 +1,23 @@
+-- Adds the incidents the balance check records and lets it sum a wallet's
+-- transactions by index. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    ADD INDEX wallet_id (wallet_id, status);
+
+CREATE TABLE IF NOT EXISTS `balance_incidents` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    balance BIGINT NOT NULL,
+    computed_balance BIGINT NOT NULL,
+    drift BIGINT NOT NULL,
+    transaction_count INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    detected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE(`wallet_id`, `balance`, `computed_balance`),
+    INDEX(`status`, `detected_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,42 @@
+-- Adds payout batches, their rows and the transactions reserving and
+-- releasing a batch's amount. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher', 'points_redemption', 'withdrawal_reversal', 'payout_batch_reservation', 'payout_batch_release');
+
+CREATE TABLE IF NOT EXISTS `payout_batches` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    reference VARCHAR(75) NOT NULL,
+    total_amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    row_count INT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`customer_xid`, `reference`),
+    INDEX(`status`, `updated_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `payout_batch_items` (
+    id VARCHAR(36) NOT NULL,
+    batch_id VARCHAR(36) NOT NULL,
+    line_number INT NOT NULL,
+    bank_code VARCHAR(10) NOT NULL,
+    account_number VARCHAR(34) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    reference_id VARCHAR(75) NOT NULL,
+    payout_id VARCHAR(36) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    failure_reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`batch_id`, `line_number`),
+    UNIQUE(`batch_id`, `reference_id`)
+) ENGINE=INNODB;
@@ -0,0 +1,20 @@
+-- Batch rows follow their payout: a row is submitted while its payout is with
+-- the bank and paid or failed once the bank settles it. Rows already handed
+-- to the bank are moved to submitted and their batches back to pending, so
+-- the payout-batches job finishes them once the bank settles. Rows whose
+-- payout already failed were credited back to the wallet and stay as they
+-- are. Fresh databases get the index from database.sql.
+USE miniwallet;
+
+ALTER TABLE `payout_batch_items`
+    ADD INDEX payout_id (payout_id);
+
+UPDATE payout_batch_items i JOIN payouts p ON p.id = i.payout_id
+    SET i.status = 'submitted'
+    WHERE i.status = 'paid' AND p.status IN ('pending', 'submitted');
+
+UPDATE payout_batches b
+    SET b.status = 'pending'
+    WHERE b.status IN ('completed', 'partial', 'failed') AND EXISTS (
+        SELECT 1 FROM payout_batch_items i WHERE i.batch_id = b.id AND i.status = 'submitted'
+    );
@@ -0,0 +1,37 @@
+-- Adds escrows between buyers and sellers, their status history and the
+-- escrow transactions. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher', 'points_redemption', 'withdrawal_reversal', 'payout_batch_reservation', 'payout_batch_release', 'escrow_funding', 'escrow_release', 'escrow_refund');
+
+CREATE TABLE IF NOT EXISTS `escrows` (
+    id VARCHAR(36) NOT NULL,
+    buyer_xid VARCHAR(36) NOT NULL,
+    buyer_wallet_id VARCHAR(36) NOT NULL,
+    seller_xid VARCHAR(36) NOT NULL,
+    seller_wallet_id VARCHAR(36) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`buyer_xid`),
+    INDEX(`seller_xid`),
+    INDEX(`status`, `expires_at`)
+) ENGINE=INNODB;
+
+CREATE TABLE IF NOT EXISTS `escrow_events` (
+    id VARCHAR(36) NOT NULL,
+    escrow_id VARCHAR(36) NOT NULL,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(36) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    INDEX(`escrow_id`, `created_at`)
+) ENGINE=INNODB;
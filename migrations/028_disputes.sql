@@ -0,0 +1,28 @@
+-- Adds disputes of transactions and the reversals of disputes resolved in
+-- the customer's favor. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    MODIFY transaction_type ENUM('deposit', 'withdrawal', 'exchange_debit', 'exchange_credit', 'pocket_allocation', 'pocket_release', 'transfer_out', 'transfer_in', 'payment', 'payment_refund', 'settlement_payout', 'loan_disbursement', 'loan_repayment', 'overdraft_interest', 'interest', 'cashback', 'voucher', 'points_redemption', 'withdrawal_reversal', 'payout_batch_reservation', 'payout_batch_release', 'escrow_funding', 'escrow_release', 'escrow_refund', 'dispute_reversal');
+
+CREATE TABLE IF NOT EXISTS `disputes` (
+    id VARCHAR(36) NOT NULL,
+    transaction_id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    customer_xid VARCHAR(36) NOT NULL,
+    transaction_type VARCHAR(30) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    reason VARCHAR(255) NOT NULL,
+    evidence JSON NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    resolution_note VARCHAR(255) NOT NULL DEFAULT '',
+    reversal_id VARCHAR(36) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE(`transaction_id`),
+    INDEX(`customer_xid`, `created_at`),
+    INDEX(`status`, `created_at`)
+) ENGINE=INNODB;
@@ -0,0 +1,24 @@
+-- Adds members of shared wallets and records on every transaction the member
+-- who made it. Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    ADD COLUMN initiated_by VARCHAR(36) NOT NULL DEFAULT '' AFTER customer_xid;
+
+CREATE TABLE IF NOT EXISTS `wallet_members` (
+    id VARCHAR(36) NOT NULL,
+    wallet_id VARCHAR(36) NOT NULL,
+    owner_xid VARCHAR(36) NOT NULL,
+    member_xid VARCHAR(36) NOT NULL,
+    spending_limit BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    allowed_types VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    spent BIGINT NOT NULL DEFAULT 0,
+    spent_period VARCHAR(7) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE(`wallet_id`, `member_xid`),
+    INDEX(`member_xid`)
+) ENGINE=INNODB;
@@ -0,0 +1,9 @@
+-- Records on every pocket allocation and release the pocket it moved money
+-- in or out of, so the balance of a pocket on an earlier day can be rebuilt
+-- for interest. Moves made before this migration keep an empty pocket_id.
+-- Fresh databases get this from database.sql.
+USE miniwallet;
+
+ALTER TABLE `transactions`
+    ADD COLUMN pocket_id VARCHAR(36) NOT NULL DEFAULT '' AFTER initiated_by,
+    ADD INDEX pocket_id (pocket_id, status);
@@ -6,7 +6,7 @@ import (
 	"github.com/mozartmuhammad/julo-be-test/src/middleware"
 )
 
-func NewRouter(walletController controller.WalletController) *mux.Router {
+func NewRouter(walletController controller.WalletController, fxController controller.FxController, pocketController controller.PocketController, scheduleController controller.ScheduleController, paymentRequestController controller.PaymentRequestController, splitBillController controller.SplitBillController, merchantController controller.MerchantController, qrController controller.QRController, settlementController controller.SettlementController, loanController controller.LoanController, creditLineController controller.CreditLineController, interestController controller.InterestController, campaignController controller.CampaignController, voucherController controller.VoucherController, loyaltyController controller.LoyaltyController, virtualAccountController controller.VirtualAccountController, payoutController controller.PayoutController, beneficiaryController controller.BeneficiaryController, reconciliationController controller.ReconciliationController, balanceController controller.BalanceController, accountStatementController controller.AccountStatementController, payoutBatchController controller.PayoutBatchController, escrowController controller.EscrowController, disputeController controller.DisputeController, sharedWalletController controller.SharedWalletController) *mux.Router {
 	router := mux.NewRouter()
 
 	router.HandleFunc("/api/v1/init", walletController.InitializeWallet).Methods("POST")
@@ -14,8 +14,123 @@ func NewRouter(walletController controller.WalletController) *mux.Router {
 	router.HandleFunc("/api/v1/wallet", middleware.AuthorizeRequest(walletController.GetWalletBalance)).Methods("GET")
 	router.HandleFunc("/api/v1/wallet", middleware.AuthorizeRequest(walletController.DisableWallet)).Methods("PATCH")
 	router.HandleFunc("/api/v1/wallet/transactions", middleware.AuthorizeRequest(walletController.GetWalletTransactions)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/statement", middleware.AuthorizeRequest(accountStatementController.GetAccountStatement)).Methods("GET")
 	router.HandleFunc("/api/v1/wallet/deposits", middleware.AuthorizeRequest(walletController.AddMoneyToWallet)).Methods("POST")
-	router.HandleFunc("/api/v1/wallet/withdrawals", middleware.AuthorizeRequest(walletController.WithdrawFromWallet)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/withdrawals", middleware.AuthorizeMember(walletController.WithdrawFromWallet)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/transfers", middleware.AuthorizeMember(walletController.TransferToCustomer)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/interest", middleware.AuthorizeRequest(interestController.GetInterestPreview)).Methods("GET")
+	router.HandleFunc("/api/v1/wallets", middleware.AuthorizeRequest(walletController.GetWallets)).Methods("GET")
+	router.HandleFunc("/api/v1/wallets", middleware.AuthorizeRequest(walletController.OpenCurrencyWallet)).Methods("POST")
+
+	router.HandleFunc("/api/v1/wallet/pockets", middleware.AuthorizeRequest(pocketController.GetPockets)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/pockets", middleware.AuthorizeRequest(pocketController.CreatePocket)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/pockets/{pocket_id}", middleware.AuthorizeRequest(pocketController.UpdatePocket)).Methods("PATCH")
+	router.HandleFunc("/api/v1/wallet/pockets/{pocket_id}", middleware.AuthorizeRequest(pocketController.ClosePocket)).Methods("DELETE")
+	router.HandleFunc("/api/v1/wallet/pockets/{pocket_id}/allocations", middleware.AuthorizeRequest(pocketController.AllocateToPocket)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/pockets/{pocket_id}/releases", middleware.AuthorizeRequest(pocketController.ReleaseFromPocket)).Methods("POST")
+
+	router.HandleFunc("/api/v1/wallet/schedules", middleware.AuthorizeRequest(scheduleController.GetSchedules)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/schedules", middleware.AuthorizeRequest(scheduleController.CreateSchedule)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/schedules/{schedule_id}", middleware.AuthorizeRequest(scheduleController.CancelSchedule)).Methods("DELETE")
+	router.HandleFunc("/api/v1/wallet/schedules/{schedule_id}/runs", middleware.AuthorizeRequest(scheduleController.GetScheduleRuns)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/schedules/{schedule_id}/pause", middleware.AuthorizeRequest(scheduleController.PauseSchedule)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/schedules/{schedule_id}/resume", middleware.AuthorizeRequest(scheduleController.ResumeSchedule)).Methods("POST")
+
+	router.HandleFunc("/api/v1/wallet/payment-requests", middleware.AuthorizeRequest(paymentRequestController.CreatePaymentRequest)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/payment-requests/incoming", middleware.AuthorizeRequest(paymentRequestController.GetIncomingPaymentRequests)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/payment-requests/outgoing", middleware.AuthorizeRequest(paymentRequestController.GetOutgoingPaymentRequests)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/payment-requests/{payment_request_id}/accept", middleware.AuthorizeRequest(paymentRequestController.AcceptPaymentRequest)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/payment-requests/{payment_request_id}/decline", middleware.AuthorizeRequest(paymentRequestController.DeclinePaymentRequest)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/payment-requests/{payment_request_id}/cancel", middleware.AuthorizeRequest(paymentRequestController.CancelPaymentRequest)).Methods("POST")
+
+	router.HandleFunc("/api/v1/wallet/split-bills", middleware.AuthorizeRequest(splitBillController.GetSplitBills)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/split-bills", middleware.AuthorizeRequest(splitBillController.CreateSplitBill)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/split-bills/{split_bill_id}", middleware.AuthorizeRequest(splitBillController.GetSplitBill)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/split-bills/{split_bill_id}/cancel", middleware.AuthorizeRequest(splitBillController.CancelSplitBill)).Methods("POST")
+
+	router.HandleFunc("/api/v1/merchant", middleware.AuthorizeRequest(merchantController.GetMerchant)).Methods("GET")
+	router.HandleFunc("/api/v1/merchant", middleware.AuthorizeRequest(merchantController.RegisterMerchant)).Methods("POST")
+	router.HandleFunc("/api/v1/merchant/payment-intents", middleware.AuthorizeRequest(merchantController.GetPaymentIntents)).Methods("GET")
+	router.HandleFunc("/api/v1/merchant/payment-intents", middleware.AuthorizeRequest(merchantController.CreatePaymentIntent)).Methods("POST")
+	router.HandleFunc("/api/v1/merchant/payment-intents/{payment_intent_id}/cancel", middleware.AuthorizeRequest(merchantController.CancelPaymentIntent)).Methods("POST")
+	router.HandleFunc("/api/v1/merchant/payment-intents/{payment_intent_id}/refund", middleware.AuthorizeRequest(merchantController.RefundPaymentIntent)).Methods("POST")
+	router.HandleFunc("/api/v1/merchant/payment-intents/{payment_intent_id}/qr", middleware.AuthorizeRequest(qrController.GetPaymentIntentQR)).Methods("GET")
+	router.HandleFunc("/api/v1/merchant/qr", middleware.AuthorizeRequest(qrController.GetMerchantQR)).Methods("GET")
+	router.HandleFunc("/api/v1/merchant/settlements", middleware.AuthorizeRequest(settlementController.GetSettlements)).Methods("GET")
+	router.HandleFunc("/api/v1/merchant/settlements/{settlement_id}/report", middleware.AuthorizeRequest(settlementController.GetSettlementReport)).Methods("GET")
+	router.HandleFunc("/api/v1/payment-intents/{payment_intent_id}", middleware.AuthorizeRequest(merchantController.GetPaymentIntent)).Methods("GET")
+	router.HandleFunc("/api/v1/payment-intents/{payment_intent_id}/confirm", middleware.AuthorizeRequest(merchantController.ConfirmPaymentIntent)).Methods("POST")
+
+	router.HandleFunc("/api/v1/wallet/qr-payments", middleware.AuthorizeRequest(qrController.PayQR)).Methods("POST")
+
+	router.HandleFunc("/api/v1/wallet/loans", middleware.AuthorizeRequest(loanController.GetLoans)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/loans/{loan_id}", middleware.AuthorizeRequest(loanController.GetLoan)).Methods("GET")
+
+	router.HandleFunc("/api/v1/wallet/cashbacks", middleware.AuthorizeRequest(campaignController.GetCashbacks)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/vouchers/redeem", middleware.AuthorizeRequest(voucherController.RedeemVoucher)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/points", middleware.AuthorizeRequest(loyaltyController.GetPoints)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/points/history", middleware.AuthorizeRequest(loyaltyController.GetPointsHistory)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/points/redeem", middleware.AuthorizeRequest(loyaltyController.RedeemPoints)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/virtual-accounts", middleware.AuthorizeRequest(virtualAccountController.GetVirtualAccounts)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/virtual-accounts", middleware.AuthorizeRequest(virtualAccountController.CreateVirtualAccount)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/beneficiaries", middleware.AuthorizeRequest(beneficiaryController.GetBeneficiaries)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/beneficiaries", middleware.AuthorizeRequest(beneficiaryController.CreateBeneficiary)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/beneficiaries/{beneficiary_id}", middleware.AuthorizeRequest(beneficiaryController.GetBeneficiary)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/beneficiaries/{beneficiary_id}", middleware.AuthorizeRequest(beneficiaryController.UpdateBeneficiary)).Methods("PATCH")
+	router.HandleFunc("/api/v1/wallet/beneficiaries/{beneficiary_id}", middleware.AuthorizeRequest(beneficiaryController.DeleteBeneficiary)).Methods("DELETE")
+	router.HandleFunc("/api/v1/wallet/beneficiaries/{beneficiary_id}/verify", middleware.AuthorizeRequest(beneficiaryController.VerifyBeneficiary)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/payouts", middleware.AuthorizeRequest(payoutController.GetPayouts)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/payouts/{payout_id}", middleware.AuthorizeRequest(payoutController.GetPayout)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/payout-batches", middleware.AuthorizeRequest(payoutBatchController.GetPayoutBatches)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/payout-batches", middleware.AuthorizeRequest(payoutBatchController.CreatePayoutBatch)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/payout-batches/{batch_id}", middleware.AuthorizeRequest(payoutBatchController.GetPayoutBatch)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/payout-batches/{batch_id}/retry", middleware.AuthorizeRequest(payoutBatchController.RetryPayoutBatch)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/payout-batches/{batch_id}/cancel", middleware.AuthorizeRequest(payoutBatchController.CancelPayoutBatch)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/escrows", middleware.AuthorizeRequest(escrowController.GetEscrows)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/escrows", middleware.AuthorizeRequest(escrowController.CreateEscrow)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/escrows/{escrow_id}", middleware.AuthorizeRequest(escrowController.GetEscrow)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/escrows/{escrow_id}/release", middleware.AuthorizeRequest(escrowController.ReleaseEscrow)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/escrows/{escrow_id}/refund", middleware.AuthorizeRequest(escrowController.RefundEscrow)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/escrows/{escrow_id}/dispute", middleware.AuthorizeRequest(escrowController.DisputeEscrow)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/disputes", middleware.AuthorizeRequest(disputeController.GetDisputes)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/disputes", middleware.AuthorizeRequest(disputeController.OpenDispute)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/disputes/{dispute_id}", middleware.AuthorizeRequest(disputeController.GetDispute)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/members", middleware.AuthorizeRequest(sharedWalletController.GetWalletMembers)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/members", middleware.AuthorizeRequest(sharedWalletController.AddWalletMember)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/members/{member_xid}", middleware.AuthorizeRequest(sharedWalletController.UpdateWalletMember)).Methods("PATCH")
+	router.HandleFunc("/api/v1/wallet/members/{member_xid}/suspend", middleware.AuthorizeRequest(sharedWalletController.SuspendWalletMember)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/members/{member_xid}/resume", middleware.AuthorizeRequest(sharedWalletController.ResumeWalletMember)).Methods("POST")
+	router.HandleFunc("/api/v1/shared-wallets", middleware.AuthorizeRequest(sharedWalletController.GetSharedWallets)).Methods("GET")
+	router.HandleFunc("/api/v1/shared-wallets/{wallet_id}/token", middleware.AuthorizeRequest(sharedWalletController.CreateMemberToken)).Methods("POST")
+
+	router.HandleFunc("/api/v1/callbacks/virtual-accounts", middleware.VerifyBankSignature(virtualAccountController.HandleCallback)).Methods("POST")
+
+	router.HandleFunc("/api/v1/fx/rates", middleware.AuthorizeRequest(fxController.GetRates)).Methods("GET")
+	router.HandleFunc("/api/v1/wallet/fx/quotes", middleware.AuthorizeRequest(fxController.CreateQuote)).Methods("POST")
+	router.HandleFunc("/api/v1/wallet/fx/exchanges", middleware.AuthorizeRequest(fxController.ExecuteExchange)).Methods("POST")
+
+	router.HandleFunc("/api/v1/admin/fx/rates", middleware.AuthorizeAdmin(fxController.SetRate)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/credit-limits", middleware.AuthorizeAdmin(creditLineController.SetCreditLimit)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/interest-rates", middleware.AuthorizeAdmin(interestController.SetInterestRate)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/loans", middleware.AuthorizeAdmin(loanController.DisburseLoan)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/campaigns", middleware.AuthorizeAdmin(campaignController.CreateCampaign)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/campaigns", middleware.AuthorizeAdmin(campaignController.GetCampaigns)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/campaigns/{campaign_id}/end", middleware.AuthorizeAdmin(campaignController.EndCampaign)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/voucher-batches", middleware.AuthorizeAdmin(voucherController.CreateVoucherBatch)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/voucher-batches", middleware.AuthorizeAdmin(voucherController.GetVoucherBatches)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/voucher-batches/{batch_id}/report", middleware.AuthorizeAdmin(voucherController.GetVoucherBatchReport)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/statements", middleware.AuthorizeAdmin(reconciliationController.ImportStatement)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/statements", middleware.AuthorizeAdmin(reconciliationController.GetStatementImports)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/statements/{statement_id}/report", middleware.AuthorizeAdmin(reconciliationController.GetReconciliationReport)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/statements/{statement_id}/lines/{line_id}/match", middleware.AuthorizeAdmin(reconciliationController.MatchStatementLine)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/statements/{statement_id}/lines/{line_id}/match", middleware.AuthorizeAdmin(reconciliationController.UnmatchStatementLine)).Methods("DELETE")
+	router.HandleFunc("/api/v1/admin/balance-incidents", middleware.AuthorizeAdmin(balanceController.GetBalanceIncidents)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/balance-incidents/{incident_id}/resolve", middleware.AuthorizeAdmin(balanceController.ResolveBalanceIncident)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/escrows/{escrow_id}/resolve", middleware.AuthorizeAdmin(escrowController.ResolveEscrow)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/disputes", middleware.AuthorizeAdmin(disputeController.GetDisputesByStatus)).Methods("GET")
+	router.HandleFunc("/api/v1/admin/disputes/{dispute_id}/investigate", middleware.AuthorizeAdmin(disputeController.InvestigateDispute)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/disputes/{dispute_id}/resolve", middleware.AuthorizeAdmin(disputeController.ResolveDispute)).Methods("POST")
+	router.HandleFunc("/api/v1/admin/customers/{customer_xid}/statement", middleware.AuthorizeAdmin(accountStatementController.GetCustomerAccountStatement)).Methods("GET")
 
 	return router
 }
@@ -0,0 +1,40 @@
+package bank
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+var ErrAccountNotFound = errors.New("bank account not found")
+
+// PayoutRequest asks a provider to send Amount to a bank account. ID is the
+// wallet's payout ID, submitting the same payout again must not pay twice.
+type PayoutRequest struct {
+	ID          string
+	BankAccount domain.BankAccount
+	Amount      domain.Money
+}
+
+// PayoutStatus is what a provider knows about a payout, Status is one of
+// submitted, succeeded or failed.
+type PayoutStatus struct {
+	Status        string
+	FailureReason string
+}
+
+// PayoutProvider disburses withdrawals to bank accounts. SubmitPayout only
+// hands the payout over and returns the provider's reference, the outcome
+// is learned later through GetPayoutStatus.
+type PayoutProvider interface {
+	SubmitPayout(ctx context.Context, request PayoutRequest) (string, error)
+	GetPayoutStatus(ctx context.Context, reference string) (PayoutStatus, error)
+}
+
+// NameResolver answers a name inquiry, telling who holds a bank account
+// before money is sent to it. It returns ErrAccountNotFound for accounts the
+// bank does not know.
+type NameResolver interface {
+	ResolveAccountName(ctx context.Context, bankCode, accountNumber string) (string, error)
+}
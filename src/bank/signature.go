@@ -0,0 +1,53 @@
+// Package bank holds what the wallet and the banks it works with agree on.
+// A callback is signed with HMAC-SHA256 over its timestamp and raw body
+// using a secret shared with the bank. Withdrawals leave through a
+// PayoutProvider.
+package bank
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderTimestamp = "X-Bank-Timestamp"
+	HeaderSignature = "X-Bank-Signature"
+
+	// MaxClockSkew is how old or early a signed callback may be, a replay
+	// outside of it is rejected before it reaches the handler
+	MaxClockSkew = 5 * time.Minute
+)
+
+// Sign returns the hex encoded signature of body sent at timestamp, a unix
+// time in seconds.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature against body and that timestamp is within
+// MaxClockSkew of now.
+func Verify(secret string, timestamp string, signature string, body []byte, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid timestamp")
+	}
+
+	skew := now.Sub(time.Unix(unix, 0))
+	if skew > MaxClockSkew || skew < -MaxClockSkew {
+		return errors.New("timestamp out of range")
+	}
+
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+)
+
+// StubClosedAccountSuffix marks account numbers the stub treats as closed,
+// payouts to them fail.
+const StubClosedAccountSuffix = "0000"
+
+const stubReferencePrefix = "STUB"
+
+// StubPayoutProvider settles payouts locally so withdrawals can be tried
+// without a bank. A payout succeeds once Delay has passed since it was
+// submitted, unless its account number ends in StubClosedAccountSuffix.
+// The outcome and submission time are kept in the reference, so payouts
+// survive a restart.
+type StubPayoutProvider struct {
+	Delay time.Duration
+}
+
+func NewStubPayoutProvider(delay time.Duration) *StubPayoutProvider {
+	return &StubPayoutProvider{
+		Delay: delay,
+	}
+}
+
+func (p *StubPayoutProvider) SubmitPayout(ctx context.Context, request PayoutRequest) (string, error) {
+	outcome := "S"
+	if strings.HasSuffix(request.BankAccount.AccountNumber, StubClosedAccountSuffix) {
+		outcome = "F"
+	}
+	return fmt.Sprintf("%s-%s-%d-%s", stubReferencePrefix, outcome, time.Now().Unix(), request.ID), nil
+}
+
+func (p *StubPayoutProvider) GetPayoutStatus(ctx context.Context, reference string) (PayoutStatus, error) {
+	parts := strings.SplitN(reference, "-", 4)
+	if len(parts) != 4 || parts[0] != stubReferencePrefix {
+		return PayoutStatus{}, errors.New("unknown payout reference")
+	}
+
+	submittedAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return PayoutStatus{}, errors.New("unknown payout reference")
+	}
+
+	if time.Since(time.Unix(submittedAt, 0)) < p.Delay {
+		return PayoutStatus{Status: constants.STATUS_SUBMITTED}, nil
+	}
+	if parts[1] == "F" {
+		return PayoutStatus{Status: constants.STATUS_FAILED, FailureReason: "account closed"}, nil
+	}
+	return PayoutStatus{Status: constants.STATUS_SUCCEEDED}, nil
+}
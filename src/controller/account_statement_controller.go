@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type AccountStatementController interface {
+	GetAccountStatement(writer http.ResponseWriter, request *http.Request)
+	GetCustomerAccountStatement(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type AccountStatementControllerImpl struct {
+	AccountStatementService service.AccountStatementServiceItf
+}
+
+func NewAccountStatementController(accountStatementService service.AccountStatementServiceItf) AccountStatementController {
+	return &AccountStatementControllerImpl{
+		AccountStatementService: accountStatementService,
+	}
+}
+
+func (c *AccountStatementControllerImpl) GetAccountStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	c.writeAccountStatement(w, r, customerXID)
+}
+
+// GetCustomerAccountStatement lets auditors pull the statement of any
+// customer.
+func (c *AccountStatementControllerImpl) GetCustomerAccountStatement(w http.ResponseWriter, r *http.Request) {
+	c.writeAccountStatement(w, r, mux.Vars(r)["customer_xid"])
+}
+
+// writeAccountStatement answers with the statement, or with a download of it
+// when format is csv or pdf.
+func (c *AccountStatementControllerImpl) writeAccountStatement(w http.ResponseWriter, r *http.Request, customerXID string) {
+	ctx := r.Context()
+
+	from, err := helper.ParseTime(r.FormValue("from"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	to, err := helper.ParseTime(r.FormValue("to"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request := web.AccountStatementRequest{
+		Currency: r.FormValue("currency"),
+		From:     from,
+		To:       to,
+		Format:   r.FormValue("format"),
+	}
+
+	if request.Format == "" || request.Format == constants.STATEMENT_FORMAT_JSON {
+		result, err := c.AccountStatementService.GetAccountStatement(ctx, customerXID, request)
+		if err != nil {
+			helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helper.WriteSuccess(w, map[string]interface{}{
+			"statement": result,
+		})
+		return
+	}
+
+	file, err := c.AccountStatementService.ExportAccountStatement(ctx, customerXID, request)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteFile(w, file.ContentType, file.FileName, file.Data)
+}
@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type BalanceController interface {
+	GetBalanceIncidents(writer http.ResponseWriter, request *http.Request)
+	ResolveBalanceIncident(writer http.ResponseWriter, request *http.Request)
+}
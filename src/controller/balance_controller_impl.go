@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type BalanceControllerImpl struct {
+	BalanceService service.BalanceServiceItf
+}
+
+func NewBalanceController(balanceService service.BalanceServiceItf) BalanceController {
+	return &BalanceControllerImpl{
+		BalanceService: balanceService,
+	}
+}
+
+func (c *BalanceControllerImpl) GetBalanceIncidents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.BalanceService.GetBalanceIncidents(ctx, r.FormValue("status"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"incidents": result,
+	})
+}
+
+func (c *BalanceControllerImpl) ResolveBalanceIncident(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.BalanceService.ResolveBalanceIncident(ctx, mux.Vars(r)["incident_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"incident": result,
+	})
+}
@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type BeneficiaryController interface {
+	CreateBeneficiary(writer http.ResponseWriter, request *http.Request)
+	GetBeneficiaries(writer http.ResponseWriter, request *http.Request)
+	GetBeneficiary(writer http.ResponseWriter, request *http.Request)
+	UpdateBeneficiary(writer http.ResponseWriter, request *http.Request)
+	DeleteBeneficiary(writer http.ResponseWriter, request *http.Request)
+	VerifyBeneficiary(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type BeneficiaryControllerImpl struct {
+	BeneficiaryService service.BeneficiaryServiceItf
+}
+
+func NewBeneficiaryController(beneficiaryService service.BeneficiaryServiceItf) BeneficiaryController {
+	return &BeneficiaryControllerImpl{
+		BeneficiaryService: beneficiaryService,
+	}
+}
+
+func (c *BeneficiaryControllerImpl) CreateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.BeneficiaryService.CreateBeneficiary(ctx, customerXID, web.BeneficiaryRequest{
+		BankCode:      r.FormValue("bank_code"),
+		AccountNumber: r.FormValue("account_number"),
+		AccountName:   r.FormValue("account_name"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"beneficiary": result,
+	})
+}
+
+func (c *BeneficiaryControllerImpl) GetBeneficiaries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.BeneficiaryService.GetBeneficiaries(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"beneficiaries": result,
+	})
+}
+
+func (c *BeneficiaryControllerImpl) GetBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.BeneficiaryService.GetBeneficiary(ctx, customerXID, mux.Vars(r)["beneficiary_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"beneficiary": result,
+	})
+}
+
+func (c *BeneficiaryControllerImpl) UpdateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.BeneficiaryService.UpdateBeneficiary(ctx, customerXID, mux.Vars(r)["beneficiary_id"], web.BeneficiaryRequest{
+		BankCode:      r.FormValue("bank_code"),
+		AccountNumber: r.FormValue("account_number"),
+		AccountName:   r.FormValue("account_name"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"beneficiary": result,
+	})
+}
+
+func (c *BeneficiaryControllerImpl) DeleteBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.BeneficiaryService.DeleteBeneficiary(ctx, customerXID, mux.Vars(r)["beneficiary_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"beneficiary": result,
+	})
+}
+
+func (c *BeneficiaryControllerImpl) VerifyBeneficiary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.BeneficiaryService.VerifyBeneficiary(ctx, customerXID, mux.Vars(r)["beneficiary_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"beneficiary": result,
+	})
+}
@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type CampaignController interface {
+	CreateCampaign(writer http.ResponseWriter, request *http.Request)
+	GetCampaigns(writer http.ResponseWriter, request *http.Request)
+	EndCampaign(writer http.ResponseWriter, request *http.Request)
+	GetCashbacks(writer http.ResponseWriter, request *http.Request)
+}
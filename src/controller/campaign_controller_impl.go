@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type CampaignControllerImpl struct {
+	CampaignService service.CampaignServiceItf
+}
+
+func NewCampaignController(campaignService service.CampaignServiceItf) CampaignController {
+	return &CampaignControllerImpl{
+		CampaignService: campaignService,
+	}
+}
+
+func (c *CampaignControllerImpl) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	minAmount, err := helper.ParseAmount(r.FormValue("min_amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	maxCashback, err := helper.ParseAmount(r.FormValue("max_cashback"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	customerCap, err := helper.ParseAmount(r.FormValue("customer_cap"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	budget, err := helper.ParseAmount(r.FormValue("budget"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	startsAt, err := helper.ParseTime(r.FormValue("starts_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	endsAt, err := helper.ParseTime(r.FormValue("ends_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cashbackRate, _ := strconv.ParseFloat(r.FormValue("cashback_rate"), 64)
+	holdDays, _ := strconv.Atoi(r.FormValue("hold_days"))
+
+	result, err := c.CampaignService.CreateCampaign(ctx, web.CampaignCreateRequest{
+		Name:            r.FormValue("name"),
+		TransactionType: r.FormValue("transaction_type"),
+		MerchantID:      r.FormValue("merchant_id"),
+		Currency:        r.FormValue("currency"),
+		MinAmount:       minAmount,
+		CashbackRate:    cashbackRate,
+		MaxCashback:     maxCashback,
+		CustomerCap:     customerCap,
+		Budget:          budget,
+		HoldDays:        holdDays,
+		StartsAt:        startsAt,
+		EndsAt:          endsAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"campaign": result,
+	})
+}
+
+func (c *CampaignControllerImpl) GetCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.CampaignService.GetCampaigns(ctx)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"campaigns": result,
+	})
+}
+
+func (c *CampaignControllerImpl) EndCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.CampaignService.EndCampaign(ctx, mux.Vars(r)["campaign_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"campaign": result,
+	})
+}
+
+func (c *CampaignControllerImpl) GetCashbacks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.CampaignService.GetCashbacks(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"cashbacks": result,
+	})
+}
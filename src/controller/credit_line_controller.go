@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type CreditLineController interface {
+	SetCreditLimit(writer http.ResponseWriter, request *http.Request)
+}
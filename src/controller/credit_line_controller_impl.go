@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type CreditLineControllerImpl struct {
+	CreditLineService service.CreditLineServiceItf
+}
+
+func NewCreditLineController(creditLineService service.CreditLineServiceItf) CreditLineController {
+	return &CreditLineControllerImpl{
+		CreditLineService: creditLineService,
+	}
+}
+
+func (c *CreditLineControllerImpl) SetCreditLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	creditLimit, err := helper.ParseAmount(r.FormValue("credit_limit"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.CreditLineService.SetCreditLimit(ctx, web.CreditLimitRequest{
+		CustomerXID: r.FormValue("customer_xid"),
+		Currency:    r.FormValue("currency"),
+		CreditLimit: creditLimit,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"wallet": result,
+	})
+}
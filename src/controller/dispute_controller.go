@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type DisputeController interface {
+	OpenDispute(writer http.ResponseWriter, request *http.Request)
+	GetDisputes(writer http.ResponseWriter, request *http.Request)
+	GetDispute(writer http.ResponseWriter, request *http.Request)
+	GetDisputesByStatus(writer http.ResponseWriter, request *http.Request)
+	InvestigateDispute(writer http.ResponseWriter, request *http.Request)
+	ResolveDispute(writer http.ResponseWriter, request *http.Request)
+}
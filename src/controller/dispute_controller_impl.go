@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type DisputeControllerImpl struct {
+	DisputeService service.DisputeServiceItf
+}
+
+func NewDisputeController(disputeService service.DisputeServiceItf) DisputeController {
+	return &DisputeControllerImpl{
+		DisputeService: disputeService,
+	}
+}
+
+func (c *DisputeControllerImpl) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	// evidence is sent as a JSON object of strings
+	var evidence map[string]string
+	if raw := r.FormValue("evidence"); raw != "" {
+		err := json.Unmarshal([]byte(raw), &evidence)
+		if err != nil {
+			helper.ErrorResponse(w, http.StatusBadRequest, "evidence must be a JSON object of strings")
+			return
+		}
+	}
+
+	result, err := c.DisputeService.OpenDispute(ctx, customerXID, web.DisputeCreateRequest{
+		TransactionID: r.FormValue("transaction_id"),
+		Reason:        r.FormValue("reason"),
+		Evidence:      evidence,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"dispute": result,
+	})
+}
+
+func (c *DisputeControllerImpl) GetDisputes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.DisputeService.GetDisputes(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"disputes": result,
+	})
+}
+
+func (c *DisputeControllerImpl) GetDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.DisputeService.GetDispute(ctx, customerXID, mux.Vars(r)["dispute_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"dispute": result,
+	})
+}
+
+func (c *DisputeControllerImpl) GetDisputesByStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.DisputeService.GetDisputesByStatus(ctx, r.FormValue("status"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"disputes": result,
+	})
+}
+
+func (c *DisputeControllerImpl) InvestigateDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.DisputeService.InvestigateDispute(ctx, mux.Vars(r)["dispute_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"dispute": result,
+	})
+}
+
+func (c *DisputeControllerImpl) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.DisputeService.ResolveDispute(ctx, mux.Vars(r)["dispute_id"], web.DisputeResolveRequest{
+		Outcome: r.FormValue("outcome"),
+		Note:    r.FormValue("note"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"dispute": result,
+	})
+}
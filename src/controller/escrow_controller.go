@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type EscrowController interface {
+	CreateEscrow(writer http.ResponseWriter, request *http.Request)
+	GetEscrows(writer http.ResponseWriter, request *http.Request)
+	GetEscrow(writer http.ResponseWriter, request *http.Request)
+	ReleaseEscrow(writer http.ResponseWriter, request *http.Request)
+	RefundEscrow(writer http.ResponseWriter, request *http.Request)
+	DisputeEscrow(writer http.ResponseWriter, request *http.Request)
+	ResolveEscrow(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type EscrowControllerImpl struct {
+	EscrowService service.EscrowServiceItf
+}
+
+func NewEscrowController(escrowService service.EscrowServiceItf) EscrowController {
+	return &EscrowControllerImpl{
+		EscrowService: escrowService,
+	}
+}
+
+func (c *EscrowControllerImpl) CreateEscrow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	expiresAt, err := helper.ParseTime(r.FormValue("expires_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.EscrowService.CreateEscrow(ctx, customerXID, web.EscrowCreateRequest{
+		SellerXID:   r.FormValue("seller_xid"),
+		Amount:      amount,
+		Description: r.FormValue("description"),
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrow": result,
+	})
+}
+
+func (c *EscrowControllerImpl) GetEscrows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.EscrowService.GetEscrows(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrows": result,
+	})
+}
+
+func (c *EscrowControllerImpl) GetEscrow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.EscrowService.GetEscrow(ctx, customerXID, mux.Vars(r)["escrow_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrow": result,
+	})
+}
+
+func (c *EscrowControllerImpl) ReleaseEscrow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.EscrowService.ReleaseEscrow(ctx, customerXID, mux.Vars(r)["escrow_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrow": result,
+	})
+}
+
+func (c *EscrowControllerImpl) RefundEscrow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.EscrowService.RefundEscrow(ctx, customerXID, mux.Vars(r)["escrow_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrow": result,
+	})
+}
+
+func (c *EscrowControllerImpl) DisputeEscrow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.EscrowService.DisputeEscrow(ctx, customerXID, mux.Vars(r)["escrow_id"], web.EscrowDisputeRequest{
+		Reason: r.FormValue("reason"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrow": result,
+	})
+}
+
+func (c *EscrowControllerImpl) ResolveEscrow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.EscrowService.ResolveEscrow(ctx, mux.Vars(r)["escrow_id"], web.EscrowResolveRequest{
+		Outcome: r.FormValue("outcome"),
+		Note:    r.FormValue("note"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"escrow": result,
+	})
+}
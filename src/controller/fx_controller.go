@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type FxController interface {
+	SetRate(writer http.ResponseWriter, request *http.Request)
+	GetRates(writer http.ResponseWriter, request *http.Request)
+	CreateQuote(writer http.ResponseWriter, request *http.Request)
+	ExecuteExchange(writer http.ResponseWriter, request *http.Request)
+}
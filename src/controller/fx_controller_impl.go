@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type FxControllerImpl struct {
+	FxService service.FxServiceItf
+}
+
+func NewFxController(fxService service.FxServiceItf) FxController {
+	return &FxControllerImpl{
+		FxService: fxService,
+	}
+}
+
+func (c *FxControllerImpl) SetRate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bid, _ := strconv.ParseFloat(r.FormValue("bid"), 64)
+	ask, _ := strconv.ParseFloat(r.FormValue("ask"), 64)
+
+	result, err := c.FxService.SetRate(ctx, web.FxRateRequest{
+		BaseCurrency:  r.FormValue("base_currency"),
+		QuoteCurrency: r.FormValue("quote_currency"),
+		Bid:           bid,
+		Ask:           ask,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"rate": result,
+	})
+}
+
+func (c *FxControllerImpl) GetRates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.FxService.GetRates(ctx)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"rates": result,
+	})
+}
+
+func (c *FxControllerImpl) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.FxService.CreateQuote(ctx, customerXID, web.FxQuoteRequest{
+		FromCurrency: r.FormValue("from_currency"),
+		ToCurrency:   r.FormValue("to_currency"),
+		Amount:       amount,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"quote": result,
+	})
+}
+
+func (c *FxControllerImpl) ExecuteExchange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.FxService.ExecuteExchange(ctx, customerXID, web.FxExchangeRequest{
+		QuoteID: r.FormValue("quote_id"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"exchange": result,
+	})
+}
@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type InterestController interface {
+	SetInterestRate(writer http.ResponseWriter, request *http.Request)
+	GetInterestPreview(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type InterestControllerImpl struct {
+	InterestService service.InterestServiceItf
+}
+
+func NewInterestController(interestService service.InterestServiceItf) InterestController {
+	return &InterestControllerImpl{
+		InterestService: interestService,
+	}
+}
+
+func (c *InterestControllerImpl) SetInterestRate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	interestRate, _ := strconv.ParseFloat(r.FormValue("interest_rate"), 64)
+
+	result, err := c.InterestService.SetInterestRate(ctx, web.InterestRateRequest{
+		CustomerXID:  r.FormValue("customer_xid"),
+		Currency:     r.FormValue("currency"),
+		InterestRate: interestRate,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"interest_rate": result,
+	})
+}
+
+func (c *InterestControllerImpl) GetInterestPreview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.InterestService.GetInterestPreview(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"interest": result,
+	})
+}
@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type LoanController interface {
+	DisburseLoan(writer http.ResponseWriter, request *http.Request)
+	GetLoans(writer http.ResponseWriter, request *http.Request)
+	GetLoan(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type LoanControllerImpl struct {
+	LoanService service.LoanServiceItf
+}
+
+func NewLoanController(loanService service.LoanServiceItf) LoanController {
+	return &LoanControllerImpl{
+		LoanService: loanService,
+	}
+}
+
+func (c *LoanControllerImpl) DisburseLoan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	principal, err := helper.ParseAmount(r.FormValue("principal"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	lateFee, err := helper.ParseAmount(r.FormValue("late_fee"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	firstDueAt, err := helper.ParseTime(r.FormValue("first_due_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	interestRate, _ := strconv.ParseFloat(r.FormValue("interest_rate"), 64)
+	installmentCount, _ := strconv.Atoi(r.FormValue("installment_count"))
+
+	result, err := c.LoanService.DisburseLoan(ctx, web.LoanCreateRequest{
+		CustomerXID:      r.FormValue("customer_xid"),
+		ReferenceID:      r.FormValue("reference_id"),
+		Principal:        principal,
+		InterestRate:     interestRate,
+		LateFee:          lateFee,
+		InstallmentCount: installmentCount,
+		Frequency:        r.FormValue("frequency"),
+		FirstDueAt:       firstDueAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"loan": result,
+	})
+}
+
+func (c *LoanControllerImpl) GetLoans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.LoanService.GetLoans(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"loans": result,
+	})
+}
+
+func (c *LoanControllerImpl) GetLoan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.LoanService.GetLoan(ctx, customerXID, mux.Vars(r)["loan_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"loan": result,
+	})
+}
@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type LoyaltyController interface {
+	GetPoints(writer http.ResponseWriter, request *http.Request)
+	GetPointsHistory(writer http.ResponseWriter, request *http.Request)
+	RedeemPoints(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type LoyaltyControllerImpl struct {
+	LoyaltyService service.LoyaltyServiceItf
+}
+
+func NewLoyaltyController(loyaltyService service.LoyaltyServiceItf) LoyaltyController {
+	return &LoyaltyControllerImpl{
+		LoyaltyService: loyaltyService,
+	}
+}
+
+func (c *LoyaltyControllerImpl) GetPoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.LoyaltyService.GetPoints(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"points": result,
+	})
+}
+
+func (c *LoyaltyControllerImpl) GetPointsHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.LoyaltyService.GetPointsHistory(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"entries": result,
+	})
+}
+
+func (c *LoyaltyControllerImpl) RedeemPoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	points, err := helper.ParseAmount(r.FormValue("points"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.LoyaltyService.RedeemPoints(ctx, customerXID, web.PointsRedeemRequest{
+		Points: points,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"redemption": result,
+	})
+}
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type MerchantController interface {
+	RegisterMerchant(writer http.ResponseWriter, request *http.Request)
+	GetMerchant(writer http.ResponseWriter, request *http.Request)
+	CreatePaymentIntent(writer http.ResponseWriter, request *http.Request)
+	GetPaymentIntents(writer http.ResponseWriter, request *http.Request)
+	CancelPaymentIntent(writer http.ResponseWriter, request *http.Request)
+	RefundPaymentIntent(writer http.ResponseWriter, request *http.Request)
+	GetPaymentIntent(writer http.ResponseWriter, request *http.Request)
+	ConfirmPaymentIntent(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type MerchantControllerImpl struct {
+	MerchantService service.MerchantServiceItf
+}
+
+func NewMerchantController(merchantService service.MerchantServiceItf) MerchantController {
+	return &MerchantControllerImpl{
+		MerchantService: merchantService,
+	}
+}
+
+func (c *MerchantControllerImpl) RegisterMerchant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.RegisterMerchant(ctx, customerXID, web.MerchantRequest{
+		Name:         r.FormValue("name"),
+		City:         r.FormValue("city"),
+		CategoryCode: r.FormValue("category_code"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"merchant": result,
+	})
+}
+
+func (c *MerchantControllerImpl) GetMerchant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.GetMerchant(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"merchant": result,
+	})
+}
+
+func (c *MerchantControllerImpl) CreatePaymentIntent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.MerchantService.CreatePaymentIntent(ctx, customerXID, web.PaymentIntentCreateRequest{
+		MerchantReference: r.FormValue("merchant_reference"),
+		Amount:            amount,
+		Currency:          r.FormValue("currency"),
+		Description:       r.FormValue("description"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intent": result,
+	})
+}
+
+func (c *MerchantControllerImpl) GetPaymentIntents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.GetPaymentIntents(ctx, customerXID, r.FormValue("status"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intents": result,
+	})
+}
+
+func (c *MerchantControllerImpl) CancelPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.CancelPaymentIntent(ctx, customerXID, mux.Vars(r)["payment_intent_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intent": result,
+	})
+}
+
+func (c *MerchantControllerImpl) RefundPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.RefundPaymentIntent(ctx, customerXID, mux.Vars(r)["payment_intent_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intent": result,
+	})
+}
+
+func (c *MerchantControllerImpl) GetPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.GetPaymentIntent(ctx, customerXID, mux.Vars(r)["payment_intent_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intent": result,
+	})
+}
+
+func (c *MerchantControllerImpl) ConfirmPaymentIntent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.MerchantService.ConfirmPaymentIntent(ctx, customerXID, mux.Vars(r)["payment_intent_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intent": result,
+	})
+}
@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type PaymentRequestController interface {
+	CreatePaymentRequest(writer http.ResponseWriter, request *http.Request)
+	GetIncomingPaymentRequests(writer http.ResponseWriter, request *http.Request)
+	GetOutgoingPaymentRequests(writer http.ResponseWriter, request *http.Request)
+	AcceptPaymentRequest(writer http.ResponseWriter, request *http.Request)
+	DeclinePaymentRequest(writer http.ResponseWriter, request *http.Request)
+	CancelPaymentRequest(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type PaymentRequestControllerImpl struct {
+	PaymentRequestService service.PaymentRequestServiceItf
+}
+
+func NewPaymentRequestController(paymentRequestService service.PaymentRequestServiceItf) PaymentRequestController {
+	return &PaymentRequestControllerImpl{
+		PaymentRequestService: paymentRequestService,
+	}
+}
+
+func (c *PaymentRequestControllerImpl) CreatePaymentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	expiresAt, err := helper.ParseTime(r.FormValue("expires_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.PaymentRequestService.CreatePaymentRequest(ctx, customerXID, web.PaymentRequestCreateRequest{
+		PayerXID:  r.FormValue("payer_xid"),
+		Amount:    amount,
+		Note:      r.FormValue("note"),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_request": result,
+	})
+}
+
+func (c *PaymentRequestControllerImpl) GetIncomingPaymentRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PaymentRequestService.GetIncomingPaymentRequests(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_requests": result,
+	})
+}
+
+func (c *PaymentRequestControllerImpl) GetOutgoingPaymentRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PaymentRequestService.GetOutgoingPaymentRequests(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_requests": result,
+	})
+}
+
+func (c *PaymentRequestControllerImpl) AcceptPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PaymentRequestService.AcceptPaymentRequest(ctx, customerXID, mux.Vars(r)["payment_request_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_request": result,
+	})
+}
+
+func (c *PaymentRequestControllerImpl) DeclinePaymentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PaymentRequestService.DeclinePaymentRequest(ctx, customerXID, mux.Vars(r)["payment_request_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_request": result,
+	})
+}
+
+func (c *PaymentRequestControllerImpl) CancelPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PaymentRequestService.CancelPaymentRequest(ctx, customerXID, mux.Vars(r)["payment_request_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_request": result,
+	})
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type PayoutBatchController interface {
+	CreatePayoutBatch(writer http.ResponseWriter, request *http.Request)
+	GetPayoutBatches(writer http.ResponseWriter, request *http.Request)
+	GetPayoutBatch(writer http.ResponseWriter, request *http.Request)
+	RetryPayoutBatch(writer http.ResponseWriter, request *http.Request)
+	CancelPayoutBatch(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+// maxPayoutBatchFile bounds an uploaded batch, a thousand rows fit well
+// within it.
+const maxPayoutBatchFile = 1 << 20
+
+type PayoutBatchControllerImpl struct {
+	PayoutBatchService service.PayoutBatchServiceItf
+}
+
+func NewPayoutBatchController(payoutBatchService service.PayoutBatchServiceItf) PayoutBatchController {
+	return &PayoutBatchControllerImpl{
+		PayoutBatchService: payoutBatchService,
+	}
+}
+
+func (c *PayoutBatchControllerImpl) CreatePayoutBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPayoutBatchFile+(1<<20))
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, "batch file is required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxPayoutBatchFile+1))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(data) > maxPayoutBatchFile {
+		helper.ErrorResponse(w, http.StatusBadRequest, "batch file too large")
+		return
+	}
+
+	result, err := c.PayoutBatchService.CreatePayoutBatch(ctx, customerXID, web.PayoutBatchRequest{
+		Reference: r.FormValue("reference"),
+		Format:    r.FormValue("format"),
+		FileName:  header.Filename,
+		Data:      data,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payout_batch": result,
+	})
+}
+
+func (c *PayoutBatchControllerImpl) GetPayoutBatches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PayoutBatchService.GetPayoutBatches(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payout_batches": result,
+	})
+}
+
+func (c *PayoutBatchControllerImpl) GetPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PayoutBatchService.GetPayoutBatch(ctx, customerXID, mux.Vars(r)["batch_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payout_batch": result,
+	})
+}
+
+func (c *PayoutBatchControllerImpl) RetryPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PayoutBatchService.RetryPayoutBatch(ctx, customerXID, mux.Vars(r)["batch_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payout_batch": result,
+	})
+}
+
+func (c *PayoutBatchControllerImpl) CancelPayoutBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PayoutBatchService.CancelPayoutBatch(ctx, customerXID, mux.Vars(r)["batch_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payout_batch": result,
+	})
+}
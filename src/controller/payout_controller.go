@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type PayoutController interface {
+	GetPayouts(writer http.ResponseWriter, request *http.Request)
+	GetPayout(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type PayoutControllerImpl struct {
+	PayoutService service.PayoutServiceItf
+}
+
+func NewPayoutController(payoutService service.PayoutServiceItf) PayoutController {
+	return &PayoutControllerImpl{
+		PayoutService: payoutService,
+	}
+}
+
+func (c *PayoutControllerImpl) GetPayouts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PayoutService.GetPayouts(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payouts": result,
+	})
+}
+
+func (c *PayoutControllerImpl) GetPayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PayoutService.GetPayout(ctx, customerXID, mux.Vars(r)["payout_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payout": result,
+	})
+}
@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type PocketController interface {
+	CreatePocket(writer http.ResponseWriter, request *http.Request)
+	GetPockets(writer http.ResponseWriter, request *http.Request)
+	UpdatePocket(writer http.ResponseWriter, request *http.Request)
+	AllocateToPocket(writer http.ResponseWriter, request *http.Request)
+	ReleaseFromPocket(writer http.ResponseWriter, request *http.Request)
+	ClosePocket(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type PocketControllerImpl struct {
+	PocketService service.PocketServiceItf
+}
+
+func NewPocketController(pocketService service.PocketServiceItf) PocketController {
+	return &PocketControllerImpl{
+		PocketService: pocketService,
+	}
+}
+
+func (c *PocketControllerImpl) CreatePocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	targetAmount, err := helper.ParseAmount(r.FormValue("target_amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.PocketService.CreatePocket(ctx, customerXID, web.PocketCreateRequest{
+		Name:         r.FormValue("name"),
+		TargetAmount: targetAmount,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"pocket": result,
+	})
+}
+
+func (c *PocketControllerImpl) GetPockets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PocketService.GetPockets(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"pockets": result,
+	})
+}
+
+func (c *PocketControllerImpl) UpdatePocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	targetAmount, err := helper.ParseAmount(r.FormValue("target_amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.PocketService.UpdatePocket(ctx, customerXID, mux.Vars(r)["pocket_id"], web.PocketUpdateRequest{
+		Name:         r.FormValue("name"),
+		TargetAmount: targetAmount,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"pocket": result,
+	})
+}
+
+func (c *PocketControllerImpl) AllocateToPocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.PocketService.AllocateToPocket(ctx, customerXID, mux.Vars(r)["pocket_id"], web.TransactionRequest{
+		Amount:      amount,
+		ReferenceID: r.FormValue("reference_id"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"allocation": result,
+	})
+}
+
+func (c *PocketControllerImpl) ReleaseFromPocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.PocketService.ReleaseFromPocket(ctx, customerXID, mux.Vars(r)["pocket_id"], web.TransactionRequest{
+		Amount:      amount,
+		ReferenceID: r.FormValue("reference_id"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"release": result,
+	})
+}
+
+func (c *PocketControllerImpl) ClosePocket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.PocketService.ClosePocket(ctx, customerXID, mux.Vars(r)["pocket_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"release": result,
+	})
+}
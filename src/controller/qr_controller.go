@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type QRController interface {
+	GetMerchantQR(writer http.ResponseWriter, request *http.Request)
+	GetPaymentIntentQR(writer http.ResponseWriter, request *http.Request)
+	PayQR(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/qris"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+// qrImageSize is the width and height of rendered QR images in pixels.
+const qrImageSize = 512
+
+type QRControllerImpl struct {
+	QRService service.QRServiceItf
+}
+
+func NewQRController(qrService service.QRServiceItf) QRController {
+	return &QRControllerImpl{
+		QRService: qrService,
+	}
+}
+
+func (c *QRControllerImpl) GetMerchantQR(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.QRService.GetMerchantQR(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeQR(w, r, result)
+}
+
+func (c *QRControllerImpl) GetPaymentIntentQR(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.QRService.GetPaymentIntentQR(ctx, customerXID, mux.Vars(r)["payment_intent_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeQR(w, r, result)
+}
+
+func (c *QRControllerImpl) PayQR(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	var amount int64
+	if r.FormValue("amount") != "" {
+		var err error
+		amount, err = helper.ParseAmount(r.FormValue("amount"))
+		if err != nil {
+			helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	result, err := c.QRService.PayQR(ctx, customerXID, web.QRPaymentRequest{
+		Payload:     r.FormValue("payload"),
+		Amount:      amount,
+		ReferenceID: r.FormValue("reference_id"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment_intent": result,
+	})
+}
+
+// writeQR answers with the payload, or with a PNG of it when format=png.
+func writeQR(w http.ResponseWriter, r *http.Request, result web.QRResponse) {
+	if r.FormValue("format") != "png" {
+		helper.WriteSuccess(w, map[string]interface{}{
+			"qr": result,
+		})
+		return
+	}
+
+	image, err := qris.PNG(result.Payload, qrImageSize)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	helper.WriteFile(w, "image/png", "", image)
+}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type ReconciliationController interface {
+	ImportStatement(writer http.ResponseWriter, request *http.Request)
+	GetStatementImports(writer http.ResponseWriter, request *http.Request)
+	GetReconciliationReport(writer http.ResponseWriter, request *http.Request)
+	MatchStatementLine(writer http.ResponseWriter, request *http.Request)
+	UnmatchStatementLine(writer http.ResponseWriter, request *http.Request)
+}
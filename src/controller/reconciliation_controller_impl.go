@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+// maxStatementFile bounds an uploaded statement, a month of bookings of a
+// busy account fits comfortably.
+const maxStatementFile = 10 << 20
+
+type ReconciliationControllerImpl struct {
+	ReconciliationService service.ReconciliationServiceItf
+}
+
+func NewReconciliationController(reconciliationService service.ReconciliationServiceItf) ReconciliationController {
+	return &ReconciliationControllerImpl{
+		ReconciliationService: reconciliationService,
+	}
+}
+
+func (c *ReconciliationControllerImpl) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxStatementFile+(1<<20))
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, "statement file is required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxStatementFile+1))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(data) > maxStatementFile {
+		helper.ErrorResponse(w, http.StatusBadRequest, "statement file too large")
+		return
+	}
+
+	result, err := c.ReconciliationService.ImportStatement(ctx, web.StatementImportRequest{
+		BankCode: r.FormValue("bank_code"),
+		Format:   r.FormValue("format"),
+		FileName: header.Filename,
+		Data:     data,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"statement": result,
+	})
+}
+
+func (c *ReconciliationControllerImpl) GetStatementImports(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.ReconciliationService.GetStatementImports(ctx)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"statements": result,
+	})
+}
+
+func (c *ReconciliationControllerImpl) GetReconciliationReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.ReconciliationService.GetReconciliationReport(ctx, mux.Vars(r)["statement_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"report": result,
+	})
+}
+
+func (c *ReconciliationControllerImpl) MatchStatementLine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	result, err := c.ReconciliationService.MatchStatementLine(ctx, vars["statement_id"], vars["line_id"], web.StatementMatchRequest{
+		TransactionID: r.FormValue("transaction_id"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"line": result,
+	})
+}
+
+func (c *ReconciliationControllerImpl) UnmatchStatementLine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	result, err := c.ReconciliationService.UnmatchStatementLine(ctx, vars["statement_id"], vars["line_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"line": result,
+	})
+}
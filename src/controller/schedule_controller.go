@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type ScheduleController interface {
+	CreateSchedule(writer http.ResponseWriter, request *http.Request)
+	GetSchedules(writer http.ResponseWriter, request *http.Request)
+	GetScheduleRuns(writer http.ResponseWriter, request *http.Request)
+	PauseSchedule(writer http.ResponseWriter, request *http.Request)
+	ResumeSchedule(writer http.ResponseWriter, request *http.Request)
+	CancelSchedule(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type ScheduleControllerImpl struct {
+	ScheduleService service.ScheduleServiceItf
+}
+
+func NewScheduleController(scheduleService service.ScheduleServiceItf) ScheduleController {
+	return &ScheduleControllerImpl{
+		ScheduleService: scheduleService,
+	}
+}
+
+func (c *ScheduleControllerImpl) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	startAt, err := helper.ParseTime(r.FormValue("start_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dayOfMonth, _ := strconv.Atoi(r.FormValue("day_of_month"))
+
+	result, err := c.ScheduleService.CreateSchedule(ctx, customerXID, web.ScheduleCreateRequest{
+		ScheduleType:  r.FormValue("type"),
+		RecipientXID:  r.FormValue("recipient_xid"),
+		BankCode:      r.FormValue("bank_code"),
+		AccountNumber: r.FormValue("account_number"),
+		AccountName:   r.FormValue("account_name"),
+		Amount:        amount,
+		Frequency:     r.FormValue("frequency"),
+		DayOfMonth:    dayOfMonth,
+		StartAt:       startAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"schedule": result,
+	})
+}
+
+func (c *ScheduleControllerImpl) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.ScheduleService.GetSchedules(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"schedules": result,
+	})
+}
+
+func (c *ScheduleControllerImpl) GetScheduleRuns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.ScheduleService.GetScheduleRuns(ctx, customerXID, mux.Vars(r)["schedule_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"runs": result,
+	})
+}
+
+func (c *ScheduleControllerImpl) PauseSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.ScheduleService.PauseSchedule(ctx, customerXID, mux.Vars(r)["schedule_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"schedule": result,
+	})
+}
+
+func (c *ScheduleControllerImpl) ResumeSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.ScheduleService.ResumeSchedule(ctx, customerXID, mux.Vars(r)["schedule_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"schedule": result,
+	})
+}
+
+func (c *ScheduleControllerImpl) CancelSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.ScheduleService.CancelSchedule(ctx, customerXID, mux.Vars(r)["schedule_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"schedule": result,
+	})
+}
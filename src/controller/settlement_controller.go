@@ -0,0 +1,10 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type SettlementController interface {
+	GetSettlements(writer http.ResponseWriter, request *http.Request)
+	GetSettlementReport(writer http.ResponseWriter, request *http.Request)
+}
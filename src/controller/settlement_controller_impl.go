@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type SettlementControllerImpl struct {
+	SettlementService service.SettlementServiceItf
+}
+
+func NewSettlementController(settlementService service.SettlementServiceItf) SettlementController {
+	return &SettlementControllerImpl{
+		SettlementService: settlementService,
+	}
+}
+
+func (c *SettlementControllerImpl) GetSettlements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SettlementService.GetSettlements(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"settlements": result,
+	})
+}
+
+func (c *SettlementControllerImpl) GetSettlementReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+	settlementID := mux.Vars(r)["settlement_id"]
+
+	result, err := c.SettlementService.GetSettlementReport(ctx, customerXID, settlementID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteFile(w, "text/csv", "settlement-"+settlementID+".csv", result)
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type SharedWalletController interface {
+	GetWalletMembers(writer http.ResponseWriter, request *http.Request)
+	AddWalletMember(writer http.ResponseWriter, request *http.Request)
+	UpdateWalletMember(writer http.ResponseWriter, request *http.Request)
+	SuspendWalletMember(writer http.ResponseWriter, request *http.Request)
+	ResumeWalletMember(writer http.ResponseWriter, request *http.Request)
+	GetSharedWallets(writer http.ResponseWriter, request *http.Request)
+	CreateMemberToken(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type SharedWalletControllerImpl struct {
+	SharedWalletService service.SharedWalletServiceItf
+}
+
+func NewSharedWalletController(sharedWalletService service.SharedWalletServiceItf) SharedWalletController {
+	return &SharedWalletControllerImpl{
+		SharedWalletService: sharedWalletService,
+	}
+}
+
+func (c *SharedWalletControllerImpl) GetWalletMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SharedWalletService.GetWalletMembers(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"members": result,
+	})
+}
+
+func (c *SharedWalletControllerImpl) AddWalletMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	request, err := walletMemberRequest(r, r.FormValue("member_xid"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.SharedWalletService.AddWalletMember(ctx, customerXID, request)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"member": result,
+	})
+}
+
+func (c *SharedWalletControllerImpl) UpdateWalletMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	request, err := walletMemberRequest(r, mux.Vars(r)["member_xid"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.SharedWalletService.UpdateWalletMember(ctx, customerXID, request)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"member": result,
+	})
+}
+
+func (c *SharedWalletControllerImpl) SuspendWalletMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SharedWalletService.SuspendWalletMember(ctx, customerXID, mux.Vars(r)["member_xid"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"member": result,
+	})
+}
+
+func (c *SharedWalletControllerImpl) ResumeWalletMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SharedWalletService.ResumeWalletMember(ctx, customerXID, mux.Vars(r)["member_xid"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"member": result,
+	})
+}
+
+func (c *SharedWalletControllerImpl) GetSharedWallets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SharedWalletService.GetMemberships(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"shared_wallets": result,
+	})
+}
+
+// CreateMemberToken hands an active member a token to spend from the shared
+// wallet with, the member's controls are checked again on every request.
+func (c *SharedWalletControllerImpl) CreateMemberToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SharedWalletService.AuthorizeMember(ctx, customerXID, mux.Vars(r)["wallet_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := signToken(jwt.MapClaims{
+		"customer_xid": result.OwnerXID,
+		"member_xid":   result.MemberXID,
+		"exp":          time.Now().Add(time.Hour * 24).Unix(),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"token":  token,
+		"member": result,
+	})
+}
+
+// walletMemberRequest reads the member controls, allowed_types is a comma
+// separated list.
+func walletMemberRequest(r *http.Request, memberXID string) (web.WalletMemberRequest, error) {
+	spendingLimit, err := helper.ParseAmount(r.FormValue("spending_limit"))
+	if err != nil {
+		return web.WalletMemberRequest{}, err
+	}
+
+	var allowedTypes []string
+	for _, transactionType := range strings.Split(r.FormValue("allowed_types"), ",") {
+		if transactionType = strings.TrimSpace(transactionType); transactionType != "" {
+			allowedTypes = append(allowedTypes, transactionType)
+		}
+	}
+
+	return web.WalletMemberRequest{
+		MemberXID:     memberXID,
+		SpendingLimit: spendingLimit,
+		AllowedTypes:  allowedTypes,
+	}, nil
+}
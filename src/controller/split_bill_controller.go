@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type SplitBillController interface {
+	CreateSplitBill(writer http.ResponseWriter, request *http.Request)
+	GetSplitBills(writer http.ResponseWriter, request *http.Request)
+	GetSplitBill(writer http.ResponseWriter, request *http.Request)
+	CancelSplitBill(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type SplitBillControllerImpl struct {
+	SplitBillService service.SplitBillServiceItf
+}
+
+func NewSplitBillController(splitBillService service.SplitBillServiceItf) SplitBillController {
+	return &SplitBillControllerImpl{
+		SplitBillService: splitBillService,
+	}
+}
+
+// CreateSplitBill takes one participant_xid per participant. Custom splits
+// pair them, in order, with the same number of share_amount values.
+func (c *SplitBillControllerImpl) CreateSplitBill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	totalAmount, err := helper.ParseAmount(r.FormValue("total_amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	expiresAt, err := helper.ParseTime(r.FormValue("expires_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	participantXIDs := r.Form["participant_xid"]
+	shareAmounts := r.Form["share_amount"]
+	if len(shareAmounts) > 0 && len(shareAmounts) != len(participantXIDs) {
+		helper.ErrorResponse(w, http.StatusBadRequest, "every participant_xid needs a share_amount")
+		return
+	}
+
+	participants := []web.SplitBillParticipant{}
+	for i, participantXID := range participantXIDs {
+		participant := web.SplitBillParticipant{CustomerXID: participantXID}
+		if len(shareAmounts) > 0 {
+			participant.Amount, err = helper.ParseAmount(shareAmounts[i])
+			if err != nil {
+				helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		participants = append(participants, participant)
+	}
+
+	result, err := c.SplitBillService.CreateSplitBill(ctx, customerXID, web.SplitBillCreateRequest{
+		Title:        r.FormValue("title"),
+		TotalAmount:  totalAmount,
+		SplitType:    r.FormValue("split_type"),
+		ExpiryPolicy: r.FormValue("expiry_policy"),
+		ExpiresAt:    expiresAt,
+		Participants: participants,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"split_bill": result,
+	})
+}
+
+func (c *SplitBillControllerImpl) GetSplitBills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SplitBillService.GetSplitBills(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"split_bills": result,
+	})
+}
+
+func (c *SplitBillControllerImpl) GetSplitBill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SplitBillService.GetSplitBill(ctx, customerXID, mux.Vars(r)["split_bill_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"split_bill": result,
+	})
+}
+
+func (c *SplitBillControllerImpl) CancelSplitBill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.SplitBillService.CancelSplitBill(ctx, customerXID, mux.Vars(r)["split_bill_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"split_bill": result,
+	})
+}
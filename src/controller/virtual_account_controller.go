@@ -0,0 +1,11 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type VirtualAccountController interface {
+	CreateVirtualAccount(writer http.ResponseWriter, request *http.Request)
+	GetVirtualAccounts(writer http.ResponseWriter, request *http.Request)
+	HandleCallback(writer http.ResponseWriter, request *http.Request)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type VirtualAccountControllerImpl struct {
+	VirtualAccountService service.VirtualAccountServiceItf
+}
+
+func NewVirtualAccountController(virtualAccountService service.VirtualAccountServiceItf) VirtualAccountController {
+	return &VirtualAccountControllerImpl{
+		VirtualAccountService: virtualAccountService,
+	}
+}
+
+func (c *VirtualAccountControllerImpl) CreateVirtualAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.VirtualAccountService.CreateVirtualAccount(ctx, customerXID, web.VirtualAccountCreateRequest{
+		BankCode: r.FormValue("bank_code"),
+		Currency: r.FormValue("currency"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"virtual_account": result,
+	})
+}
+
+func (c *VirtualAccountControllerImpl) GetVirtualAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.VirtualAccountService.GetVirtualAccounts(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"virtual_accounts": result,
+	})
+}
+
+func (c *VirtualAccountControllerImpl) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	amount, err := helper.ParseAmount(r.PostFormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	paidAt, err := helper.ParseTime(r.PostFormValue("paid_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.VirtualAccountService.HandleCallback(ctx, web.VirtualAccountCallbackRequest{
+		BankCode:             r.PostFormValue("bank_code"),
+		VirtualAccountNumber: r.PostFormValue("virtual_account_number"),
+		BankReference:        r.PostFormValue("bank_reference"),
+		Amount:               amount,
+		Currency:             r.PostFormValue("currency"),
+		PaidAt:               paidAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"payment": result,
+	})
+}
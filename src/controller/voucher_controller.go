@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+)
+
+type VoucherController interface {
+	CreateVoucherBatch(writer http.ResponseWriter, request *http.Request)
+	GetVoucherBatches(writer http.ResponseWriter, request *http.Request)
+	GetVoucherBatchReport(writer http.ResponseWriter, request *http.Request)
+	RedeemVoucher(writer http.ResponseWriter, request *http.Request)
+}
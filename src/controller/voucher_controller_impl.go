@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mozartmuhammad/julo-be-test/src/helper"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+type VoucherControllerImpl struct {
+	VoucherService service.VoucherServiceItf
+}
+
+func NewVoucherController(voucherService service.VoucherServiceItf) VoucherController {
+	return &VoucherControllerImpl{
+		VoucherService: voucherService,
+	}
+}
+
+func (c *VoucherControllerImpl) CreateVoucherBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	value, err := helper.ParseAmount(r.FormValue("value"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	expiresAt, err := helper.ParseTime(r.FormValue("expires_at"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codeCount, _ := strconv.Atoi(r.FormValue("code_count"))
+	maxUses, _ := strconv.Atoi(r.FormValue("max_uses"))
+	perCustomerLimit, _ := strconv.Atoi(r.FormValue("per_customer_limit"))
+
+	result, err := c.VoucherService.CreateVoucherBatch(ctx, web.VoucherBatchCreateRequest{
+		Name:             r.FormValue("name"),
+		Currency:         r.FormValue("currency"),
+		Value:            value,
+		Prefix:           r.FormValue("prefix"),
+		CodeCount:        codeCount,
+		MaxUses:          maxUses,
+		PerCustomerLimit: perCustomerLimit,
+		ExpiresAt:        expiresAt,
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"voucher_batch": result,
+	})
+}
+
+func (c *VoucherControllerImpl) GetVoucherBatches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.VoucherService.GetVoucherBatches(ctx)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"voucher_batches": result,
+	})
+}
+
+func (c *VoucherControllerImpl) GetVoucherBatchReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := c.VoucherService.GetVoucherBatchReport(ctx, mux.Vars(r)["batch_id"])
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"report": result,
+	})
+}
+
+func (c *VoucherControllerImpl) RedeemVoucher(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	result, err := c.VoucherService.RedeemVoucher(ctx, customerXID, web.VoucherRedeemRequest{
+		Code: r.FormValue("code"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"redemption": result,
+	})
+}
@@ -8,8 +8,11 @@ type WalletController interface {
 	InitializeWallet(writer http.ResponseWriter, request *http.Request)
 	EnableWallet(writer http.ResponseWriter, request *http.Request)
 	GetWalletBalance(writer http.ResponseWriter, request *http.Request)
+	GetWallets(writer http.ResponseWriter, request *http.Request)
+	OpenCurrencyWallet(writer http.ResponseWriter, request *http.Request)
 	GetWalletTransactions(writer http.ResponseWriter, request *http.Request)
 	AddMoneyToWallet(writer http.ResponseWriter, request *http.Request)
 	WithdrawFromWallet(writer http.ResponseWriter, request *http.Request)
+	TransferToCustomer(writer http.ResponseWriter, request *http.Request)
 	DisableWallet(writer http.ResponseWriter, request *http.Request)
 }
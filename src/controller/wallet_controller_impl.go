@@ -87,6 +87,36 @@ func (c *WalletControllerImpl) GetWalletBalance(w http.ResponseWriter, r *http.R
 	})
 }
 
+func (c *WalletControllerImpl) GetWallets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+	result, err := c.WalletService.GetWallets(ctx, customerXID)
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"wallets": result,
+	})
+}
+
+func (c *WalletControllerImpl) OpenCurrencyWallet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+	result, err := c.WalletService.OpenCurrencyWallet(ctx, customerXID, web.CurrencyWalletRequest{
+		Currency: r.FormValue("currency"),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"wallet": result,
+	})
+}
+
 func (c *WalletControllerImpl) GetWalletTransactions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	customerXID := helper.GetCustomerXID(ctx)
@@ -107,8 +137,11 @@ func (c *WalletControllerImpl) AddMoneyToWallet(w http.ResponseWriter, r *http.R
 	customerXID := helper.GetCustomerXID(ctx)
 
 	referenceID := r.FormValue("reference_id")
-	amountStr := r.FormValue("amount")
-	amount, _ := strconv.Atoi(amountStr)
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	result, err := c.WalletService.AddWalletBalance(ctx, customerXID, web.TransactionRequest{
 		Amount:      amount,
@@ -129,12 +162,20 @@ func (c *WalletControllerImpl) WithdrawFromWallet(w http.ResponseWriter, r *http
 	customerXID := helper.GetCustomerXID(ctx)
 
 	referenceID := r.FormValue("reference_id")
-	amountStr := r.FormValue("amount")
-	amount, _ := strconv.Atoi(amountStr)
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	result, err := c.WalletService.DeductWalletBalance(ctx, customerXID, web.TransactionRequest{
-		Amount:      amount,
-		ReferenceID: referenceID,
+	result, err := c.WalletService.DeductWalletBalance(ctx, customerXID, web.WithdrawalRequest{
+		Amount:        amount,
+		ReferenceID:   referenceID,
+		BeneficiaryID: r.FormValue("beneficiary_id"),
+		BankCode:      r.FormValue("bank_code"),
+		AccountNumber: r.FormValue("account_number"),
+		AccountName:   r.FormValue("account_name"),
+		MemberXID:     helper.GetMemberXID(ctx),
 	})
 	if err != nil {
 		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -146,14 +187,40 @@ func (c *WalletControllerImpl) WithdrawFromWallet(w http.ResponseWriter, r *http
 	})
 }
 
+func (c *WalletControllerImpl) TransferToCustomer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	customerXID := helper.GetCustomerXID(ctx)
+
+	amount, err := helper.ParseAmount(r.FormValue("amount"))
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.WalletService.TransferBalance(ctx, customerXID, web.TransferRequest{
+		RecipientXID: r.FormValue("recipient_xid"),
+		Amount:       amount,
+		ReferenceID:  r.FormValue("reference_id"),
+		MemberXID:    helper.GetMemberXID(ctx),
+	})
+	if err != nil {
+		helper.ErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	helper.WriteSuccess(w, map[string]interface{}{
+		"transfer": result,
+	})
+}
+
 func (c *WalletControllerImpl) GenerateToken(customerXID string) (token string, err error) {
-	secret := os.Getenv("SECRET")
-	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	return signToken(jwt.MapClaims{
 		"customer_xid": customerXID,
 		"exp":          time.Now().Add(time.Hour * 24).Unix(),
 	})
+}
 
-	token, err = claims.SignedString([]byte(secret))
-
-	return
+func signToken(claims jwt.MapClaims) (string, error) {
+	secret := os.Getenv("SECRET")
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
 }
@@ -0,0 +1,19 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// ParseAmount reads a minor-unit amount from a form value. Malformed input is
+// treated as zero and left to request validation, values that do not fit in
+// an int64 are rejected outright.
+func ParseAmount(value string) (int64, error) {
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, domain.ErrAmountOverflow
+	}
+	return amount, nil
+}
@@ -8,6 +8,7 @@ type key string
 
 const (
 	CustomerXID key = "customer-xid"
+	MemberXID   key = "member-xid"
 )
 
 func SetCustomerXID(ctx context.Context, value string) context.Context {
@@ -20,3 +21,16 @@ func GetCustomerXID(ctx context.Context) string {
 	}
 	return ""
 }
+
+// SetMemberXID marks the request as made by a member of the shared wallet of
+// the customer in ctx.
+func SetMemberXID(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, MemberXID, value)
+}
+
+func GetMemberXID(ctx context.Context) string {
+	if v, ok := ctx.Value(MemberXID).(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package helper
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// WriteFile sends data as a download when filename is set, inline otherwise.
+func WriteFile(w http.ResponseWriter, contentType, filename string, data []byte) {
+	w.Header().Add("Content-Type", contentType)
+	w.Header().Add("Content-Length", strconv.Itoa(len(data)))
+	if filename != "" {
+		w.Header().Add("Content-Disposition", `attachment; filename="`+filename+`"`)
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
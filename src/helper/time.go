@@ -0,0 +1,20 @@
+package helper
+
+import (
+	"errors"
+	"time"
+)
+
+// ParseTime reads an RFC3339 timestamp from a form value. An empty value
+// yields the zero time and is left to request validation.
+func ParseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid time format, expected RFC3339")
+	}
+	return result, nil
+}
@@ -0,0 +1,30 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Func is a unit of background work. now is the tick time so a job can be
+// exercised deterministically outside of the runner.
+type Func func(ctx context.Context, now time.Time) error
+
+// Run calls fn every interval until ctx is cancelled. A failing run is logged
+// and retried on the next tick.
+func Run(ctx context.Context, name string, interval time.Duration, fn Func) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			err := fn(ctx, now)
+			if err != nil {
+				log.Println("error run job "+name+":", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// AuthorizeAdmin guards back-office endpoints with the shared ADMIN_KEY
+// passed in the X-Admin-Key header.
+func AuthorizeAdmin(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminKey := os.Getenv("ADMIN_KEY")
+		if adminKey == "" {
+			fmt.Println("ADMIN_KEY is not set in .env file")
+			http.Error(w, "Admin access is not configured", http.StatusUnauthorized)
+			return
+		}
+
+		requestKey := r.Header.Get("X-Admin-Key")
+		if subtle.ConstantTimeCompare([]byte(requestKey), []byte(adminKey)) != 1 {
+			http.Error(w, "Invalid admin key", http.StatusUnauthorized)
+			return
+		}
+
+		fn(w, r)
+	}
+}
@@ -10,47 +10,84 @@ import (
 	"github.com/mozartmuhammad/julo-be-test/src/helper"
 )
 
-// JWTClaims represents the claims in the JWT token
+// JWTClaims represents the claims in the JWT token. A member of a shared
+// wallet acts on it with a member token, CustomerXID is then the wallet
+// owner and MemberXID the member.
 type JWTClaims struct {
 	CustomerXID string `json:"customer_xid"`
+	MemberXID   string `json:"member_xid,omitempty"`
 	jwt.StandardClaims
 }
 
 func AuthorizeRequest(fn http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "Authorization header is missing", http.StatusUnauthorized)
+		claims, ok := parseClaims(w, r)
+		if !ok {
 			return
 		}
 
-		// Read secret key from environment variable
-		secretKey := os.Getenv("SECRET")
-		if secretKey == "" {
-			fmt.Println("SECRET is not set in .env file")
-		}
-
-		tokenString := strings.Split(authHeader, " ")[1]
-		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secretKey), nil
-		})
-		if err != nil {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+		// member tokens would otherwise act as the owner
+		if claims.MemberXID != "" {
+			http.Error(w, "Member token is not allowed", http.StatusForbidden)
 			return
 		}
 
-		if !token.Valid {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
-			return
-		}
+		ctx := helper.SetCustomerXID(r.Context(), claims.CustomerXID)
+		fn(w, r.WithContext(ctx))
+	}
+}
 
-		claims, ok := token.Claims.(*JWTClaims)
+// AuthorizeMember guards the routes members of a shared wallet may spend
+// through. It takes customer tokens as well as member tokens, the handler
+// has to enforce the member's controls.
+func AuthorizeMember(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := parseClaims(w, r)
 		if !ok {
-			http.Error(w, "Invalid token claims", http.StatusUnauthorized)
 			return
 		}
 
 		ctx := helper.SetCustomerXID(r.Context(), claims.CustomerXID)
+		if claims.MemberXID != "" {
+			ctx = helper.SetMemberXID(ctx, claims.MemberXID)
+		}
 		fn(w, r.WithContext(ctx))
 	}
 }
+
+// parseClaims reads the claims of the bearer token, writing the error
+// response when there are none.
+func parseClaims(w http.ResponseWriter, r *http.Request) (*JWTClaims, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		http.Error(w, "Authorization header is missing", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	// Read secret key from environment variable
+	secretKey := os.Getenv("SECRET")
+	if secretKey == "" {
+		fmt.Println("SECRET is not set in .env file")
+	}
+
+	tokenString := strings.Split(authHeader, " ")[1]
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	})
+	if err != nil {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	if !token.Valid {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok {
+		http.Error(w, "Invalid token claims", http.StatusUnauthorized)
+		return nil, false
+	}
+	return claims, true
+}
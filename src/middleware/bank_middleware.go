@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+)
+
+// maxCallbackBody bounds what is read from a bank before it is verified.
+const maxCallbackBody = 64 << 10
+
+// VerifyBankSignature guards bank callbacks with the HMAC signature over the
+// raw body, keyed by BANK_CALLBACK_SECRET.
+func VerifyBankSignature(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secret := os.Getenv("BANK_CALLBACK_SECRET")
+		if secret == "" {
+			fmt.Println("BANK_CALLBACK_SECRET is not set in .env file")
+			http.Error(w, "Bank callbacks are not configured", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBody))
+		if err != nil {
+			http.Error(w, "Invalid body", http.StatusBadRequest)
+			return
+		}
+
+		err = bank.Verify(secret, r.Header.Get(bank.HeaderTimestamp), r.Header.Get(bank.HeaderSignature), body, time.Now())
+		if err != nil {
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		// the handler parses the body that was verified
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		fn(w, r)
+	}
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/balance_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockBalanceRepository is a mock of BalanceRepository interface.
+type MockBalanceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceRepositoryMockRecorder
+}
+
+// MockBalanceRepositoryMockRecorder is the mock recorder for MockBalanceRepository.
+type MockBalanceRepositoryMockRecorder struct {
+	mock *MockBalanceRepository
+}
+
+// NewMockBalanceRepository creates a new mock instance.
+func NewMockBalanceRepository(ctrl *gomock.Controller) *MockBalanceRepository {
+	mock := &MockBalanceRepository{ctrl: ctrl}
+	mock.recorder = &MockBalanceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceRepository) EXPECT() *MockBalanceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBalanceIncident mocks base method.
+func (m *MockBalanceRepository) CreateBalanceIncident(ctx context.Context, incident domain.BalanceIncident) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBalanceIncident", ctx, incident)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBalanceIncident indicates an expected call of CreateBalanceIncident.
+func (mr *MockBalanceRepositoryMockRecorder) CreateBalanceIncident(ctx, incident interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalanceIncident", reflect.TypeOf((*MockBalanceRepository)(nil).CreateBalanceIncident), ctx, incident)
+}
+
+// GetBalanceCheck mocks base method.
+func (m *MockBalanceRepository) GetBalanceCheck(ctx context.Context, walletID string) (domain.BalanceCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceCheck", ctx, walletID)
+	ret0, _ := ret[0].(domain.BalanceCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceCheck indicates an expected call of GetBalanceCheck.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceCheck(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceCheck", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceCheck), ctx, walletID)
+}
+
+// GetBalanceChecks mocks base method.
+func (m *MockBalanceRepository) GetBalanceChecks(ctx context.Context, afterID string, limit int) ([]domain.BalanceCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceChecks", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.BalanceCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceChecks indicates an expected call of GetBalanceChecks.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceChecks(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceChecks", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceChecks), ctx, afterID, limit)
+}
+
+// GetBalanceIncident mocks base method.
+func (m *MockBalanceRepository) GetBalanceIncident(ctx context.Context, incidentID string) (domain.BalanceIncident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceIncident", ctx, incidentID)
+	ret0, _ := ret[0].(domain.BalanceIncident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceIncident indicates an expected call of GetBalanceIncident.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceIncident(ctx, incidentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceIncident", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceIncident), ctx, incidentID)
+}
+
+// GetBalanceIncidents mocks base method.
+func (m *MockBalanceRepository) GetBalanceIncidents(ctx context.Context, status string) ([]domain.BalanceIncident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceIncidents", ctx, status)
+	ret0, _ := ret[0].([]domain.BalanceIncident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceIncidents indicates an expected call of GetBalanceIncidents.
+func (mr *MockBalanceRepositoryMockRecorder) GetBalanceIncidents(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceIncidents", reflect.TypeOf((*MockBalanceRepository)(nil).GetBalanceIncidents), ctx, status)
+}
+
+// ResolveBalanceIncident mocks base method.
+func (m *MockBalanceRepository) ResolveBalanceIncident(ctx context.Context, incidentID string, resolvedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveBalanceIncident", ctx, incidentID, resolvedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveBalanceIncident indicates an expected call of ResolveBalanceIncident.
+func (mr *MockBalanceRepositoryMockRecorder) ResolveBalanceIncident(ctx, incidentID, resolvedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveBalanceIncident", reflect.TypeOf((*MockBalanceRepository)(nil).ResolveBalanceIncident), ctx, incidentID, resolvedAt)
+}
@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/campaign_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockCampaignRepository is a mock of CampaignRepository interface.
+type MockCampaignRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCampaignRepositoryMockRecorder
+}
+
+// MockCampaignRepositoryMockRecorder is the mock recorder for MockCampaignRepository.
+type MockCampaignRepositoryMockRecorder struct {
+	mock *MockCampaignRepository
+}
+
+// NewMockCampaignRepository creates a new mock instance.
+func NewMockCampaignRepository(ctrl *gomock.Controller) *MockCampaignRepository {
+	mock := &MockCampaignRepository{ctrl: ctrl}
+	mock.recorder = &MockCampaignRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCampaignRepository) EXPECT() *MockCampaignRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelCashback mocks base method.
+func (m *MockCampaignRepository) CancelCashback(ctx context.Context, cashback domain.Cashback) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCashback", ctx, cashback)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCashback indicates an expected call of CancelCashback.
+func (mr *MockCampaignRepositoryMockRecorder) CancelCashback(ctx, cashback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCashback", reflect.TypeOf((*MockCampaignRepository)(nil).CancelCashback), ctx, cashback)
+}
+
+// CreateCampaign mocks base method.
+func (m *MockCampaignRepository) CreateCampaign(ctx context.Context, campaign domain.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockCampaignRepositoryMockRecorder) CreateCampaign(ctx, campaign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockCampaignRepository)(nil).CreateCampaign), ctx, campaign)
+}
+
+// CreateCashback mocks base method.
+func (m *MockCampaignRepository) CreateCashback(ctx context.Context, cashback domain.Cashback, credit *domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCashback", ctx, cashback, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCashback indicates an expected call of CreateCashback.
+func (mr *MockCampaignRepositoryMockRecorder) CreateCashback(ctx, cashback, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCashback", reflect.TypeOf((*MockCampaignRepository)(nil).CreateCashback), ctx, cashback, credit)
+}
+
+// GetActiveCampaigns mocks base method.
+func (m *MockCampaignRepository) GetActiveCampaigns(ctx context.Context, now time.Time) ([]domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveCampaigns", ctx, now)
+	ret0, _ := ret[0].([]domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveCampaigns indicates an expected call of GetActiveCampaigns.
+func (mr *MockCampaignRepositoryMockRecorder) GetActiveCampaigns(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCampaigns", reflect.TypeOf((*MockCampaignRepository)(nil).GetActiveCampaigns), ctx, now)
+}
+
+// GetCampaign mocks base method.
+func (m *MockCampaignRepository) GetCampaign(ctx context.Context, campaignID string) (domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaign", ctx, campaignID)
+	ret0, _ := ret[0].(domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaign indicates an expected call of GetCampaign.
+func (mr *MockCampaignRepositoryMockRecorder) GetCampaign(ctx, campaignID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaign", reflect.TypeOf((*MockCampaignRepository)(nil).GetCampaign), ctx, campaignID)
+}
+
+// GetCampaigns mocks base method.
+func (m *MockCampaignRepository) GetCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaigns", ctx)
+	ret0, _ := ret[0].([]domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaigns indicates an expected call of GetCampaigns.
+func (mr *MockCampaignRepositoryMockRecorder) GetCampaigns(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaigns", reflect.TypeOf((*MockCampaignRepository)(nil).GetCampaigns), ctx)
+}
+
+// GetCashbacks mocks base method.
+func (m *MockCampaignRepository) GetCashbacks(ctx context.Context, customerXID string) ([]domain.Cashback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashbacks", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Cashback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashbacks indicates an expected call of GetCashbacks.
+func (mr *MockCampaignRepositoryMockRecorder) GetCashbacks(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashbacks", reflect.TypeOf((*MockCampaignRepository)(nil).GetCashbacks), ctx, customerXID)
+}
+
+// GetCustomerCashbackTotal mocks base method.
+func (m *MockCampaignRepository) GetCustomerCashbackTotal(ctx context.Context, campaignID, customerXID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerCashbackTotal", ctx, campaignID, customerXID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerCashbackTotal indicates an expected call of GetCustomerCashbackTotal.
+func (mr *MockCampaignRepositoryMockRecorder) GetCustomerCashbackTotal(ctx, campaignID, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerCashbackTotal", reflect.TypeOf((*MockCampaignRepository)(nil).GetCustomerCashbackTotal), ctx, campaignID, customerXID)
+}
+
+// GetDueCashbacks mocks base method.
+func (m *MockCampaignRepository) GetDueCashbacks(ctx context.Context, now time.Time, limit int) ([]domain.Cashback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCashbacks", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Cashback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCashbacks indicates an expected call of GetDueCashbacks.
+func (mr *MockCampaignRepositoryMockRecorder) GetDueCashbacks(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCashbacks", reflect.TypeOf((*MockCampaignRepository)(nil).GetDueCashbacks), ctx, now, limit)
+}
+
+// GetEligibleTransactions mocks base method.
+func (m *MockCampaignRepository) GetEligibleTransactions(ctx context.Context, campaign domain.Campaign, limit int) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEligibleTransactions", ctx, campaign, limit)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEligibleTransactions indicates an expected call of GetEligibleTransactions.
+func (mr *MockCampaignRepositoryMockRecorder) GetEligibleTransactions(ctx, campaign, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEligibleTransactions", reflect.TypeOf((*MockCampaignRepository)(nil).GetEligibleTransactions), ctx, campaign, limit)
+}
+
+// ReleaseCashback mocks base method.
+func (m *MockCampaignRepository) ReleaseCashback(ctx context.Context, cashback domain.Cashback, credit domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseCashback", ctx, cashback, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseCashback indicates an expected call of ReleaseCashback.
+func (mr *MockCampaignRepositoryMockRecorder) ReleaseCashback(ctx, cashback, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCashback", reflect.TypeOf((*MockCampaignRepository)(nil).ReleaseCashback), ctx, cashback, credit)
+}
+
+// UpdateCampaignStatus mocks base method.
+func (m *MockCampaignRepository) UpdateCampaignStatus(ctx context.Context, campaignID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaignStatus", ctx, campaignID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCampaignStatus indicates an expected call of UpdateCampaignStatus.
+func (mr *MockCampaignRepositoryMockRecorder) UpdateCampaignStatus(ctx, campaignID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaignStatus", reflect.TypeOf((*MockCampaignRepository)(nil).UpdateCampaignStatus), ctx, campaignID, status)
+}
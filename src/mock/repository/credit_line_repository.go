@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/credit_line_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockCreditLineRepository is a mock of CreditLineRepository interface.
+type MockCreditLineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCreditLineRepositoryMockRecorder
+}
+
+// MockCreditLineRepositoryMockRecorder is the mock recorder for MockCreditLineRepository.
+type MockCreditLineRepositoryMockRecorder struct {
+	mock *MockCreditLineRepository
+}
+
+// NewMockCreditLineRepository creates a new mock instance.
+func NewMockCreditLineRepository(ctrl *gomock.Controller) *MockCreditLineRepository {
+	mock := &MockCreditLineRepository{ctrl: ctrl}
+	mock.recorder = &MockCreditLineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCreditLineRepository) EXPECT() *MockCreditLineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ChargeOverdraftInterest mocks base method.
+func (m *MockCreditLineRepository) ChargeOverdraftInterest(ctx context.Context, interest domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeOverdraftInterest", ctx, interest)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeOverdraftInterest indicates an expected call of ChargeOverdraftInterest.
+func (mr *MockCreditLineRepositoryMockRecorder) ChargeOverdraftInterest(ctx, interest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeOverdraftInterest", reflect.TypeOf((*MockCreditLineRepository)(nil).ChargeOverdraftInterest), ctx, interest)
+}
+
+// GetOverdrawnWallets mocks base method.
+func (m *MockCreditLineRepository) GetOverdrawnWallets(ctx context.Context, afterID string, limit int) ([]domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdrawnWallets", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdrawnWallets indicates an expected call of GetOverdrawnWallets.
+func (mr *MockCreditLineRepositoryMockRecorder) GetOverdrawnWallets(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdrawnWallets", reflect.TypeOf((*MockCreditLineRepository)(nil).GetOverdrawnWallets), ctx, afterID, limit)
+}
+
+// UpdateCreditLimit mocks base method.
+func (m *MockCreditLineRepository) UpdateCreditLimit(ctx context.Context, walletID string, creditLimit domain.Money) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCreditLimit", ctx, walletID, creditLimit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCreditLimit indicates an expected call of UpdateCreditLimit.
+func (mr *MockCreditLineRepositoryMockRecorder) UpdateCreditLimit(ctx, walletID, creditLimit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCreditLimit", reflect.TypeOf((*MockCreditLineRepository)(nil).UpdateCreditLimit), ctx, walletID, creditLimit)
+}
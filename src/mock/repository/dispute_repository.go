@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/dispute_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockDisputeRepository is a mock of DisputeRepository interface.
+type MockDisputeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDisputeRepositoryMockRecorder
+}
+
+// MockDisputeRepositoryMockRecorder is the mock recorder for MockDisputeRepository.
+type MockDisputeRepositoryMockRecorder struct {
+	mock *MockDisputeRepository
+}
+
+// NewMockDisputeRepository creates a new mock instance.
+func NewMockDisputeRepository(ctrl *gomock.Controller) *MockDisputeRepository {
+	mock := &MockDisputeRepository{ctrl: ctrl}
+	mock.recorder = &MockDisputeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDisputeRepository) EXPECT() *MockDisputeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDispute mocks base method.
+func (m *MockDisputeRepository) CreateDispute(ctx context.Context, dispute domain.Dispute) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDispute", ctx, dispute)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDispute indicates an expected call of CreateDispute.
+func (mr *MockDisputeRepositoryMockRecorder) CreateDispute(ctx, dispute interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDispute", reflect.TypeOf((*MockDisputeRepository)(nil).CreateDispute), ctx, dispute)
+}
+
+// GetDispute mocks base method.
+func (m *MockDisputeRepository) GetDispute(ctx context.Context, disputeID string) (domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDispute", ctx, disputeID)
+	ret0, _ := ret[0].(domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDispute indicates an expected call of GetDispute.
+func (mr *MockDisputeRepositoryMockRecorder) GetDispute(ctx, disputeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDispute", reflect.TypeOf((*MockDisputeRepository)(nil).GetDispute), ctx, disputeID)
+}
+
+// GetDisputes mocks base method.
+func (m *MockDisputeRepository) GetDisputes(ctx context.Context, customerXID string) ([]domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisputes", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisputes indicates an expected call of GetDisputes.
+func (mr *MockDisputeRepositoryMockRecorder) GetDisputes(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisputes", reflect.TypeOf((*MockDisputeRepository)(nil).GetDisputes), ctx, customerXID)
+}
+
+// GetDisputesByStatus mocks base method.
+func (m *MockDisputeRepository) GetDisputesByStatus(ctx context.Context, status string) ([]domain.Dispute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisputesByStatus", ctx, status)
+	ret0, _ := ret[0].([]domain.Dispute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDisputesByStatus indicates an expected call of GetDisputesByStatus.
+func (mr *MockDisputeRepositoryMockRecorder) GetDisputesByStatus(ctx, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisputesByStatus", reflect.TypeOf((*MockDisputeRepository)(nil).GetDisputesByStatus), ctx, status)
+}
+
+// IsTransactionReversed mocks base method.
+func (m *MockDisputeRepository) IsTransactionReversed(ctx context.Context, transactionID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTransactionReversed", ctx, transactionID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTransactionReversed indicates an expected call of IsTransactionReversed.
+func (mr *MockDisputeRepositoryMockRecorder) IsTransactionReversed(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTransactionReversed", reflect.TypeOf((*MockDisputeRepository)(nil).IsTransactionReversed), ctx, transactionID)
+}
+
+// ResolveDispute mocks base method.
+func (m *MockDisputeRepository) ResolveDispute(ctx context.Context, dispute domain.Dispute, fromStatus string, reversal *domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDispute", ctx, dispute, fromStatus, reversal)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDispute indicates an expected call of ResolveDispute.
+func (mr *MockDisputeRepositoryMockRecorder) ResolveDispute(ctx, dispute, fromStatus, reversal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDispute", reflect.TypeOf((*MockDisputeRepository)(nil).ResolveDispute), ctx, dispute, fromStatus, reversal)
+}
+
+// UpdateDisputeStatus mocks base method.
+func (m *MockDisputeRepository) UpdateDisputeStatus(ctx context.Context, disputeID, fromStatus, toStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDisputeStatus", ctx, disputeID, fromStatus, toStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDisputeStatus indicates an expected call of UpdateDisputeStatus.
+func (mr *MockDisputeRepositoryMockRecorder) UpdateDisputeStatus(ctx, disputeID, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDisputeStatus", reflect.TypeOf((*MockDisputeRepository)(nil).UpdateDisputeStatus), ctx, disputeID, fromStatus, toStatus)
+}
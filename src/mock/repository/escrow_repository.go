@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/escrow_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockEscrowRepository is a mock of EscrowRepository interface.
+type MockEscrowRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEscrowRepositoryMockRecorder
+}
+
+// MockEscrowRepositoryMockRecorder is the mock recorder for MockEscrowRepository.
+type MockEscrowRepositoryMockRecorder struct {
+	mock *MockEscrowRepository
+}
+
+// NewMockEscrowRepository creates a new mock instance.
+func NewMockEscrowRepository(ctrl *gomock.Controller) *MockEscrowRepository {
+	mock := &MockEscrowRepository{ctrl: ctrl}
+	mock.recorder = &MockEscrowRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEscrowRepository) EXPECT() *MockEscrowRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateEscrow mocks base method.
+func (m *MockEscrowRepository) CreateEscrow(ctx context.Context, escrow domain.Escrow, event domain.EscrowEvent, funding domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEscrow", ctx, escrow, event, funding)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEscrow indicates an expected call of CreateEscrow.
+func (mr *MockEscrowRepositoryMockRecorder) CreateEscrow(ctx, escrow, event, funding interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEscrow", reflect.TypeOf((*MockEscrowRepository)(nil).CreateEscrow), ctx, escrow, event, funding)
+}
+
+// GetEscrow mocks base method.
+func (m *MockEscrowRepository) GetEscrow(ctx context.Context, escrowID string) (domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrow", ctx, escrowID)
+	ret0, _ := ret[0].(domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrow indicates an expected call of GetEscrow.
+func (mr *MockEscrowRepositoryMockRecorder) GetEscrow(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrow", reflect.TypeOf((*MockEscrowRepository)(nil).GetEscrow), ctx, escrowID)
+}
+
+// GetEscrowEvents mocks base method.
+func (m *MockEscrowRepository) GetEscrowEvents(ctx context.Context, escrowID string) ([]domain.EscrowEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrowEvents", ctx, escrowID)
+	ret0, _ := ret[0].([]domain.EscrowEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrowEvents indicates an expected call of GetEscrowEvents.
+func (mr *MockEscrowRepositoryMockRecorder) GetEscrowEvents(ctx, escrowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrowEvents", reflect.TypeOf((*MockEscrowRepository)(nil).GetEscrowEvents), ctx, escrowID)
+}
+
+// GetEscrows mocks base method.
+func (m *MockEscrowRepository) GetEscrows(ctx context.Context, customerXID string) ([]domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEscrows", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEscrows indicates an expected call of GetEscrows.
+func (mr *MockEscrowRepositoryMockRecorder) GetEscrows(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEscrows", reflect.TypeOf((*MockEscrowRepository)(nil).GetEscrows), ctx, customerXID)
+}
+
+// GetExpiredEscrows mocks base method.
+func (m *MockEscrowRepository) GetExpiredEscrows(ctx context.Context, now time.Time, limit int) ([]domain.Escrow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredEscrows", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Escrow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredEscrows indicates an expected call of GetExpiredEscrows.
+func (mr *MockEscrowRepositoryMockRecorder) GetExpiredEscrows(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredEscrows", reflect.TypeOf((*MockEscrowRepository)(nil).GetExpiredEscrows), ctx, now, limit)
+}
+
+// TransitionEscrow mocks base method.
+func (m *MockEscrowRepository) TransitionEscrow(ctx context.Context, event domain.EscrowEvent, credit *domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionEscrow", ctx, event, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionEscrow indicates an expected call of TransitionEscrow.
+func (mr *MockEscrowRepositoryMockRecorder) TransitionEscrow(ctx, event, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionEscrow", reflect.TypeOf((*MockEscrowRepository)(nil).TransitionEscrow), ctx, event, credit)
+}
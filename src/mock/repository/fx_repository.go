@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/fx_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockFxRepository is a mock of FxRepository interface.
+type MockFxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRepositoryMockRecorder
+}
+
+// MockFxRepositoryMockRecorder is the mock recorder for MockFxRepository.
+type MockFxRepositoryMockRecorder struct {
+	mock *MockFxRepository
+}
+
+// NewMockFxRepository creates a new mock instance.
+func NewMockFxRepository(ctrl *gomock.Controller) *MockFxRepository {
+	mock := &MockFxRepository{ctrl: ctrl}
+	mock.recorder = &MockFxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRepository) EXPECT() *MockFxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateQuote mocks base method.
+func (m *MockFxRepository) CreateQuote(ctx context.Context, quote domain.FxQuote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuote", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateQuote indicates an expected call of CreateQuote.
+func (mr *MockFxRepositoryMockRecorder) CreateQuote(ctx, quote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuote", reflect.TypeOf((*MockFxRepository)(nil).CreateQuote), ctx, quote)
+}
+
+// ExecuteExchange mocks base method.
+func (m *MockFxRepository) ExecuteExchange(ctx context.Context, quoteID string, debit, credit domain.Transaction, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteExchange", ctx, quoteID, debit, credit, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteExchange indicates an expected call of ExecuteExchange.
+func (mr *MockFxRepositoryMockRecorder) ExecuteExchange(ctx, quoteID, debit, credit, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteExchange", reflect.TypeOf((*MockFxRepository)(nil).ExecuteExchange), ctx, quoteID, debit, credit, now)
+}
+
+// GetQuote mocks base method.
+func (m *MockFxRepository) GetQuote(ctx context.Context, quoteID string) (domain.FxQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuote", ctx, quoteID)
+	ret0, _ := ret[0].(domain.FxQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuote indicates an expected call of GetQuote.
+func (mr *MockFxRepositoryMockRecorder) GetQuote(ctx, quoteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuote", reflect.TypeOf((*MockFxRepository)(nil).GetQuote), ctx, quoteID)
+}
+
+// GetRate mocks base method.
+func (m *MockFxRepository) GetRate(ctx context.Context, baseCurrency, quoteCurrency string) (domain.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRate", ctx, baseCurrency, quoteCurrency)
+	ret0, _ := ret[0].(domain.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRate indicates an expected call of GetRate.
+func (mr *MockFxRepositoryMockRecorder) GetRate(ctx, baseCurrency, quoteCurrency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRate", reflect.TypeOf((*MockFxRepository)(nil).GetRate), ctx, baseCurrency, quoteCurrency)
+}
+
+// GetRates mocks base method.
+func (m *MockFxRepository) GetRates(ctx context.Context) ([]domain.FxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRates", ctx)
+	ret0, _ := ret[0].([]domain.FxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRates indicates an expected call of GetRates.
+func (mr *MockFxRepositoryMockRecorder) GetRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockFxRepository)(nil).GetRates), ctx)
+}
+
+// UpsertRate mocks base method.
+func (m *MockFxRepository) UpsertRate(ctx context.Context, rate domain.FxRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRate", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertRate indicates an expected call of UpsertRate.
+func (mr *MockFxRepositoryMockRecorder) UpsertRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRate", reflect.TypeOf((*MockFxRepository)(nil).UpsertRate), ctx, rate)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/interest_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockInterestRepository is a mock of InterestRepository interface.
+type MockInterestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInterestRepositoryMockRecorder
+}
+
+// MockInterestRepositoryMockRecorder is the mock recorder for MockInterestRepository.
+type MockInterestRepositoryMockRecorder struct {
+	mock *MockInterestRepository
+}
+
+// NewMockInterestRepository creates a new mock instance.
+func NewMockInterestRepository(ctrl *gomock.Controller) *MockInterestRepository {
+	mock := &MockInterestRepository{ctrl: ctrl}
+	mock.recorder = &MockInterestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInterestRepository) EXPECT() *MockInterestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddInterestAccrual mocks base method.
+func (m *MockInterestRepository) AddInterestAccrual(ctx context.Context, accrual domain.InterestAccrual) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInterestAccrual", ctx, accrual)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInterestAccrual indicates an expected call of AddInterestAccrual.
+func (mr *MockInterestRepositoryMockRecorder) AddInterestAccrual(ctx, accrual interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInterestAccrual", reflect.TypeOf((*MockInterestRepository)(nil).AddInterestAccrual), ctx, accrual)
+}
+
+// GetInterestBearingPockets mocks base method.
+func (m *MockInterestRepository) GetInterestBearingPockets(ctx context.Context, afterID string, limit int) ([]domain.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestBearingPockets", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestBearingPockets indicates an expected call of GetInterestBearingPockets.
+func (mr *MockInterestRepositoryMockRecorder) GetInterestBearingPockets(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestBearingPockets", reflect.TypeOf((*MockInterestRepository)(nil).GetInterestBearingPockets), ctx, afterID, limit)
+}
+
+// GetInterestBearingWallets mocks base method.
+func (m *MockInterestRepository) GetInterestBearingWallets(ctx context.Context, afterID string, limit int) ([]domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterestBearingWallets", ctx, afterID, limit)
+	ret0, _ := ret[0].([]domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInterestBearingWallets indicates an expected call of GetInterestBearingWallets.
+func (mr *MockInterestRepositoryMockRecorder) GetInterestBearingWallets(ctx, afterID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterestBearingWallets", reflect.TypeOf((*MockInterestRepository)(nil).GetInterestBearingWallets), ctx, afterID, limit)
+}
+
+// GetLastInterestAccrualDate mocks base method.
+func (m *MockInterestRepository) GetLastInterestAccrualDate(ctx context.Context, walletID, pocketID string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastInterestAccrualDate", ctx, walletID, pocketID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastInterestAccrualDate indicates an expected call of GetLastInterestAccrualDate.
+func (mr *MockInterestRepositoryMockRecorder) GetLastInterestAccrualDate(ctx, walletID, pocketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastInterestAccrualDate", reflect.TypeOf((*MockInterestRepository)(nil).GetLastInterestAccrualDate), ctx, walletID, pocketID)
+}
+
+// GetPocketBalanceHistory mocks base method.
+func (m *MockInterestRepository) GetPocketBalanceHistory(ctx context.Context, pocketID string, since time.Time) (domain.BalanceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPocketBalanceHistory", ctx, pocketID, since)
+	ret0, _ := ret[0].(domain.BalanceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPocketBalanceHistory indicates an expected call of GetPocketBalanceHistory.
+func (mr *MockInterestRepositoryMockRecorder) GetPocketBalanceHistory(ctx, pocketID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPocketBalanceHistory", reflect.TypeOf((*MockInterestRepository)(nil).GetPocketBalanceHistory), ctx, pocketID, since)
+}
+
+// GetUnpostedInterest mocks base method.
+func (m *MockInterestRepository) GetUnpostedInterest(ctx context.Context, period, afterWalletID, afterPocketID string, limit int) ([]domain.InterestPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpostedInterest", ctx, period, afterWalletID, afterPocketID, limit)
+	ret0, _ := ret[0].([]domain.InterestPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpostedInterest indicates an expected call of GetUnpostedInterest.
+func (mr *MockInterestRepositoryMockRecorder) GetUnpostedInterest(ctx, period, afterWalletID, afterPocketID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpostedInterest", reflect.TypeOf((*MockInterestRepository)(nil).GetUnpostedInterest), ctx, period, afterWalletID, afterPocketID, limit)
+}
+
+// GetUnpostedInterestAccruals mocks base method.
+func (m *MockInterestRepository) GetUnpostedInterestAccruals(ctx context.Context, walletID string) ([]domain.InterestAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpostedInterestAccruals", ctx, walletID)
+	ret0, _ := ret[0].([]domain.InterestAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpostedInterestAccruals indicates an expected call of GetUnpostedInterestAccruals.
+func (mr *MockInterestRepositoryMockRecorder) GetUnpostedInterestAccruals(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpostedInterestAccruals", reflect.TypeOf((*MockInterestRepository)(nil).GetUnpostedInterestAccruals), ctx, walletID)
+}
+
+// GetWalletBalanceHistory mocks base method.
+func (m *MockInterestRepository) GetWalletBalanceHistory(ctx context.Context, walletID string, since time.Time) (domain.BalanceHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalanceHistory", ctx, walletID, since)
+	ret0, _ := ret[0].(domain.BalanceHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalanceHistory indicates an expected call of GetWalletBalanceHistory.
+func (mr *MockInterestRepositoryMockRecorder) GetWalletBalanceHistory(ctx, walletID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalanceHistory", reflect.TypeOf((*MockInterestRepository)(nil).GetWalletBalanceHistory), ctx, walletID, since)
+}
+
+// PostInterest mocks base method.
+func (m *MockInterestRepository) PostInterest(ctx context.Context, posting domain.InterestPosting, interest domain.Transaction, allocation *domain.Transaction, carried domain.InterestAccrual) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInterest", ctx, posting, interest, allocation, carried)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInterest indicates an expected call of PostInterest.
+func (mr *MockInterestRepositoryMockRecorder) PostInterest(ctx, posting, interest, allocation, carried interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInterest", reflect.TypeOf((*MockInterestRepository)(nil).PostInterest), ctx, posting, interest, allocation, carried)
+}
+
+// UpdateInterestRate mocks base method.
+func (m *MockInterestRepository) UpdateInterestRate(ctx context.Context, walletID string, interestRate float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInterestRate", ctx, walletID, interestRate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInterestRate indicates an expected call of UpdateInterestRate.
+func (mr *MockInterestRepositoryMockRecorder) UpdateInterestRate(ctx, walletID, interestRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInterestRate", reflect.TypeOf((*MockInterestRepository)(nil).UpdateInterestRate), ctx, walletID, interestRate)
+}
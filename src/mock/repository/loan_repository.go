@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/loan_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockLoanRepository is a mock of LoanRepository interface.
+type MockLoanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepositoryMockRecorder
+}
+
+// MockLoanRepositoryMockRecorder is the mock recorder for MockLoanRepository.
+type MockLoanRepositoryMockRecorder struct {
+	mock *MockLoanRepository
+}
+
+// NewMockLoanRepository creates a new mock instance.
+func NewMockLoanRepository(ctrl *gomock.Controller) *MockLoanRepository {
+	mock := &MockLoanRepository{ctrl: ctrl}
+	mock.recorder = &MockLoanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepository) EXPECT() *MockLoanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CollectLoanInstallment mocks base method.
+func (m *MockLoanRepository) CollectLoanInstallment(ctx context.Context, installment domain.LoanInstallment, collectAt time.Time, repayment *domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectLoanInstallment", ctx, installment, collectAt, repayment)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectLoanInstallment indicates an expected call of CollectLoanInstallment.
+func (mr *MockLoanRepositoryMockRecorder) CollectLoanInstallment(ctx, installment, collectAt, repayment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectLoanInstallment", reflect.TypeOf((*MockLoanRepository)(nil).CollectLoanInstallment), ctx, installment, collectAt, repayment)
+}
+
+// CreateLoan mocks base method.
+func (m *MockLoanRepository) CreateLoan(ctx context.Context, loan domain.Loan, installments []domain.LoanInstallment, disbursement domain.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoan", ctx, loan, installments, disbursement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoan indicates an expected call of CreateLoan.
+func (mr *MockLoanRepositoryMockRecorder) CreateLoan(ctx, loan, installments, disbursement interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoan", reflect.TypeOf((*MockLoanRepository)(nil).CreateLoan), ctx, loan, installments, disbursement)
+}
+
+// GetDueLoanInstallments mocks base method.
+func (m *MockLoanRepository) GetDueLoanInstallments(ctx context.Context, now time.Time, limit int) ([]domain.LoanInstallment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueLoanInstallments", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.LoanInstallment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueLoanInstallments indicates an expected call of GetDueLoanInstallments.
+func (mr *MockLoanRepositoryMockRecorder) GetDueLoanInstallments(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueLoanInstallments", reflect.TypeOf((*MockLoanRepository)(nil).GetDueLoanInstallments), ctx, now, limit)
+}
+
+// GetLoan mocks base method.
+func (m *MockLoanRepository) GetLoan(ctx context.Context, loanID string) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoan", ctx, loanID)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoan indicates an expected call of GetLoan.
+func (mr *MockLoanRepositoryMockRecorder) GetLoan(ctx, loanID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoan", reflect.TypeOf((*MockLoanRepository)(nil).GetLoan), ctx, loanID)
+}
+
+// GetLoanByReference mocks base method.
+func (m *MockLoanRepository) GetLoanByReference(ctx context.Context, referenceID string) (domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanByReference", ctx, referenceID)
+	ret0, _ := ret[0].(domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanByReference indicates an expected call of GetLoanByReference.
+func (mr *MockLoanRepositoryMockRecorder) GetLoanByReference(ctx, referenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanByReference", reflect.TypeOf((*MockLoanRepository)(nil).GetLoanByReference), ctx, referenceID)
+}
+
+// GetLoanInstallments mocks base method.
+func (m *MockLoanRepository) GetLoanInstallments(ctx context.Context, loanID string) ([]domain.LoanInstallment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoanInstallments", ctx, loanID)
+	ret0, _ := ret[0].([]domain.LoanInstallment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoanInstallments indicates an expected call of GetLoanInstallments.
+func (mr *MockLoanRepositoryMockRecorder) GetLoanInstallments(ctx, loanID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoanInstallments", reflect.TypeOf((*MockLoanRepository)(nil).GetLoanInstallments), ctx, loanID)
+}
+
+// GetLoans mocks base method.
+func (m *MockLoanRepository) GetLoans(ctx context.Context, customerXID string) ([]domain.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoans", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoans indicates an expected call of GetLoans.
+func (mr *MockLoanRepositoryMockRecorder) GetLoans(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoans", reflect.TypeOf((*MockLoanRepository)(nil).GetLoans), ctx, customerXID)
+}
@@ -0,0 +1,187 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/loyalty_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockLoyaltyRepository is a mock of LoyaltyRepository interface.
+type MockLoyaltyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoyaltyRepositoryMockRecorder
+}
+
+// MockLoyaltyRepositoryMockRecorder is the mock recorder for MockLoyaltyRepository.
+type MockLoyaltyRepositoryMockRecorder struct {
+	mock *MockLoyaltyRepository
+}
+
+// NewMockLoyaltyRepository creates a new mock instance.
+func NewMockLoyaltyRepository(ctrl *gomock.Controller) *MockLoyaltyRepository {
+	mock := &MockLoyaltyRepository{ctrl: ctrl}
+	mock.recorder = &MockLoyaltyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoyaltyRepository) EXPECT() *MockLoyaltyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// EarnPoints mocks base method.
+func (m *MockLoyaltyRepository) EarnPoints(ctx context.Context, entry domain.PointsEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EarnPoints", ctx, entry)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EarnPoints indicates an expected call of EarnPoints.
+func (mr *MockLoyaltyRepositoryMockRecorder) EarnPoints(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EarnPoints", reflect.TypeOf((*MockLoyaltyRepository)(nil).EarnPoints), ctx, entry)
+}
+
+// ExpirePoints mocks base method.
+func (m *MockLoyaltyRepository) ExpirePoints(ctx context.Context, lot, entry domain.PointsEntry) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePoints", ctx, lot, entry)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePoints indicates an expected call of ExpirePoints.
+func (mr *MockLoyaltyRepositoryMockRecorder) ExpirePoints(ctx, lot, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePoints", reflect.TypeOf((*MockLoyaltyRepository)(nil).ExpirePoints), ctx, lot, entry)
+}
+
+// GetAvailablePointsLots mocks base method.
+func (m *MockLoyaltyRepository) GetAvailablePointsLots(ctx context.Context, customerXID string, now time.Time) ([]domain.PointsEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvailablePointsLots", ctx, customerXID, now)
+	ret0, _ := ret[0].([]domain.PointsEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvailablePointsLots indicates an expected call of GetAvailablePointsLots.
+func (mr *MockLoyaltyRepositoryMockRecorder) GetAvailablePointsLots(ctx, customerXID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailablePointsLots", reflect.TypeOf((*MockLoyaltyRepository)(nil).GetAvailablePointsLots), ctx, customerXID, now)
+}
+
+// GetExpiredPointsLots mocks base method.
+func (m *MockLoyaltyRepository) GetExpiredPointsLots(ctx context.Context, now time.Time, limit int) ([]domain.PointsEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredPointsLots", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.PointsEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredPointsLots indicates an expected call of GetExpiredPointsLots.
+func (mr *MockLoyaltyRepositoryMockRecorder) GetExpiredPointsLots(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredPointsLots", reflect.TypeOf((*MockLoyaltyRepository)(nil).GetExpiredPointsLots), ctx, now, limit)
+}
+
+// GetPointsBalance mocks base method.
+func (m *MockLoyaltyRepository) GetPointsBalance(ctx context.Context, customerXID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsBalance", ctx, customerXID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsBalance indicates an expected call of GetPointsBalance.
+func (mr *MockLoyaltyRepositoryMockRecorder) GetPointsBalance(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsBalance", reflect.TypeOf((*MockLoyaltyRepository)(nil).GetPointsBalance), ctx, customerXID)
+}
+
+// GetPointsEligibleTransactions mocks base method.
+func (m *MockLoyaltyRepository) GetPointsEligibleTransactions(ctx context.Context, currency string, minAmount int64, since time.Time, limit int) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsEligibleTransactions", ctx, currency, minAmount, since, limit)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsEligibleTransactions indicates an expected call of GetPointsEligibleTransactions.
+func (mr *MockLoyaltyRepositoryMockRecorder) GetPointsEligibleTransactions(ctx, currency, minAmount, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsEligibleTransactions", reflect.TypeOf((*MockLoyaltyRepository)(nil).GetPointsEligibleTransactions), ctx, currency, minAmount, since, limit)
+}
+
+// GetPointsEntries mocks base method.
+func (m *MockLoyaltyRepository) GetPointsEntries(ctx context.Context, customerXID string) ([]domain.PointsEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPointsEntries", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.PointsEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPointsEntries indicates an expected call of GetPointsEntries.
+func (mr *MockLoyaltyRepositoryMockRecorder) GetPointsEntries(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPointsEntries", reflect.TypeOf((*MockLoyaltyRepository)(nil).GetPointsEntries), ctx, customerXID)
+}
+
+// GetRefundedPointsLots mocks base method.
+func (m *MockLoyaltyRepository) GetRefundedPointsLots(ctx context.Context, now time.Time, limit int) ([]domain.PointsEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefundedPointsLots", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.PointsEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefundedPointsLots indicates an expected call of GetRefundedPointsLots.
+func (mr *MockLoyaltyRepositoryMockRecorder) GetRefundedPointsLots(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefundedPointsLots", reflect.TypeOf((*MockLoyaltyRepository)(nil).GetRefundedPointsLots), ctx, now, limit)
+}
+
+// RedeemPoints mocks base method.
+func (m *MockLoyaltyRepository) RedeemPoints(ctx context.Context, entry domain.PointsEntry, now time.Time, credit domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemPoints", ctx, entry, now, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemPoints indicates an expected call of RedeemPoints.
+func (mr *MockLoyaltyRepositoryMockRecorder) RedeemPoints(ctx, entry, now, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemPoints", reflect.TypeOf((*MockLoyaltyRepository)(nil).RedeemPoints), ctx, entry, now, credit)
+}
+
+// ReversePoints mocks base method.
+func (m *MockLoyaltyRepository) ReversePoints(ctx context.Context, lot, entry domain.PointsEntry, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReversePoints", ctx, lot, entry, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReversePoints indicates an expected call of ReversePoints.
+func (mr *MockLoyaltyRepositoryMockRecorder) ReversePoints(ctx, lot, entry, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReversePoints", reflect.TypeOf((*MockLoyaltyRepository)(nil).ReversePoints), ctx, lot, entry, now)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/merchant_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockMerchantRepository is a mock of MerchantRepository interface.
+type MockMerchantRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantRepositoryMockRecorder
+}
+
+// MockMerchantRepositoryMockRecorder is the mock recorder for MockMerchantRepository.
+type MockMerchantRepositoryMockRecorder struct {
+	mock *MockMerchantRepository
+}
+
+// NewMockMerchantRepository creates a new mock instance.
+func NewMockMerchantRepository(ctrl *gomock.Controller) *MockMerchantRepository {
+	mock := &MockMerchantRepository{ctrl: ctrl}
+	mock.recorder = &MockMerchantRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantRepository) EXPECT() *MockMerchantRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConfirmPaymentIntent mocks base method.
+func (m *MockMerchantRepository) ConfirmPaymentIntent(ctx context.Context, paymentIntentID string, debit domain.Transaction, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPaymentIntent", ctx, paymentIntentID, debit, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPaymentIntent indicates an expected call of ConfirmPaymentIntent.
+func (mr *MockMerchantRepositoryMockRecorder) ConfirmPaymentIntent(ctx, paymentIntentID, debit, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPaymentIntent", reflect.TypeOf((*MockMerchantRepository)(nil).ConfirmPaymentIntent), ctx, paymentIntentID, debit, now)
+}
+
+// CreateMerchant mocks base method.
+func (m *MockMerchantRepository) CreateMerchant(ctx context.Context, merchant domain.Merchant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMerchant", ctx, merchant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMerchant indicates an expected call of CreateMerchant.
+func (mr *MockMerchantRepositoryMockRecorder) CreateMerchant(ctx, merchant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMerchant", reflect.TypeOf((*MockMerchantRepository)(nil).CreateMerchant), ctx, merchant)
+}
+
+// CreatePaymentIntent mocks base method.
+func (m *MockMerchantRepository) CreatePaymentIntent(ctx context.Context, paymentIntent domain.PaymentIntent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentIntent", ctx, paymentIntent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePaymentIntent indicates an expected call of CreatePaymentIntent.
+func (mr *MockMerchantRepositoryMockRecorder) CreatePaymentIntent(ctx, paymentIntent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentIntent", reflect.TypeOf((*MockMerchantRepository)(nil).CreatePaymentIntent), ctx, paymentIntent)
+}
+
+// GetMerchant mocks base method.
+func (m *MockMerchantRepository) GetMerchant(ctx context.Context, merchantID string) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, merchantID)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockMerchantRepositoryMockRecorder) GetMerchant(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockMerchantRepository)(nil).GetMerchant), ctx, merchantID)
+}
+
+// GetMerchantByCustomerXID mocks base method.
+func (m *MockMerchantRepository) GetMerchantByCustomerXID(ctx context.Context, customerXID string) (domain.Merchant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchantByCustomerXID", ctx, customerXID)
+	ret0, _ := ret[0].(domain.Merchant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchantByCustomerXID indicates an expected call of GetMerchantByCustomerXID.
+func (mr *MockMerchantRepositoryMockRecorder) GetMerchantByCustomerXID(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchantByCustomerXID", reflect.TypeOf((*MockMerchantRepository)(nil).GetMerchantByCustomerXID), ctx, customerXID)
+}
+
+// GetPaymentIntent mocks base method.
+func (m *MockMerchantRepository) GetPaymentIntent(ctx context.Context, paymentIntentID string) (domain.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentIntent", ctx, paymentIntentID)
+	ret0, _ := ret[0].(domain.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentIntent indicates an expected call of GetPaymentIntent.
+func (mr *MockMerchantRepositoryMockRecorder) GetPaymentIntent(ctx, paymentIntentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentIntent", reflect.TypeOf((*MockMerchantRepository)(nil).GetPaymentIntent), ctx, paymentIntentID)
+}
+
+// GetPaymentIntentByReference mocks base method.
+func (m *MockMerchantRepository) GetPaymentIntentByReference(ctx context.Context, merchantID, merchantReference string) (domain.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentIntentByReference", ctx, merchantID, merchantReference)
+	ret0, _ := ret[0].(domain.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentIntentByReference indicates an expected call of GetPaymentIntentByReference.
+func (mr *MockMerchantRepositoryMockRecorder) GetPaymentIntentByReference(ctx, merchantID, merchantReference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentIntentByReference", reflect.TypeOf((*MockMerchantRepository)(nil).GetPaymentIntentByReference), ctx, merchantID, merchantReference)
+}
+
+// GetPaymentIntents mocks base method.
+func (m *MockMerchantRepository) GetPaymentIntents(ctx context.Context, merchantID, status string) ([]domain.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentIntents", ctx, merchantID, status)
+	ret0, _ := ret[0].([]domain.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentIntents indicates an expected call of GetPaymentIntents.
+func (mr *MockMerchantRepositoryMockRecorder) GetPaymentIntents(ctx, merchantID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentIntents", reflect.TypeOf((*MockMerchantRepository)(nil).GetPaymentIntents), ctx, merchantID, status)
+}
+
+// RefundPaymentIntent mocks base method.
+func (m *MockMerchantRepository) RefundPaymentIntent(ctx context.Context, paymentIntentID string, credit domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPaymentIntent", ctx, paymentIntentID, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPaymentIntent indicates an expected call of RefundPaymentIntent.
+func (mr *MockMerchantRepositoryMockRecorder) RefundPaymentIntent(ctx, paymentIntentID, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPaymentIntent", reflect.TypeOf((*MockMerchantRepository)(nil).RefundPaymentIntent), ctx, paymentIntentID, credit)
+}
+
+// UpdatePaymentIntentStatus mocks base method.
+func (m *MockMerchantRepository) UpdatePaymentIntentStatus(ctx context.Context, paymentIntentID, fromStatus, toStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentIntentStatus", ctx, paymentIntentID, fromStatus, toStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentIntentStatus indicates an expected call of UpdatePaymentIntentStatus.
+func (mr *MockMerchantRepositoryMockRecorder) UpdatePaymentIntentStatus(ctx, paymentIntentID, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentIntentStatus", reflect.TypeOf((*MockMerchantRepository)(nil).UpdatePaymentIntentStatus), ctx, paymentIntentID, fromStatus, toStatus)
+}
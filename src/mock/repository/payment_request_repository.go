@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/payment_request_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockPaymentRequestRepository is a mock of PaymentRequestRepository interface.
+type MockPaymentRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRequestRepositoryMockRecorder
+}
+
+// MockPaymentRequestRepositoryMockRecorder is the mock recorder for MockPaymentRequestRepository.
+type MockPaymentRequestRepositoryMockRecorder struct {
+	mock *MockPaymentRequestRepository
+}
+
+// NewMockPaymentRequestRepository creates a new mock instance.
+func NewMockPaymentRequestRepository(ctrl *gomock.Controller) *MockPaymentRequestRepository {
+	mock := &MockPaymentRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRequestRepository) EXPECT() *MockPaymentRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreatePaymentRequest mocks base method.
+func (m *MockPaymentRequestRepository) CreatePaymentRequest(ctx context.Context, paymentRequest domain.PaymentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentRequest", ctx, paymentRequest)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePaymentRequest indicates an expected call of CreatePaymentRequest.
+func (mr *MockPaymentRequestRepositoryMockRecorder) CreatePaymentRequest(ctx, paymentRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentRequest", reflect.TypeOf((*MockPaymentRequestRepository)(nil).CreatePaymentRequest), ctx, paymentRequest)
+}
+
+// ExpirePaymentRequests mocks base method.
+func (m *MockPaymentRequestRepository) ExpirePaymentRequests(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePaymentRequests", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePaymentRequests indicates an expected call of ExpirePaymentRequests.
+func (mr *MockPaymentRequestRepositoryMockRecorder) ExpirePaymentRequests(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePaymentRequests", reflect.TypeOf((*MockPaymentRequestRepository)(nil).ExpirePaymentRequests), ctx, now)
+}
+
+// GetIncomingPaymentRequests mocks base method.
+func (m *MockPaymentRequestRepository) GetIncomingPaymentRequests(ctx context.Context, payerXID string) ([]domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingPaymentRequests", ctx, payerXID)
+	ret0, _ := ret[0].([]domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingPaymentRequests indicates an expected call of GetIncomingPaymentRequests.
+func (mr *MockPaymentRequestRepositoryMockRecorder) GetIncomingPaymentRequests(ctx, payerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingPaymentRequests", reflect.TypeOf((*MockPaymentRequestRepository)(nil).GetIncomingPaymentRequests), ctx, payerXID)
+}
+
+// GetOutgoingPaymentRequests mocks base method.
+func (m *MockPaymentRequestRepository) GetOutgoingPaymentRequests(ctx context.Context, requesterXID string) ([]domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingPaymentRequests", ctx, requesterXID)
+	ret0, _ := ret[0].([]domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingPaymentRequests indicates an expected call of GetOutgoingPaymentRequests.
+func (mr *MockPaymentRequestRepositoryMockRecorder) GetOutgoingPaymentRequests(ctx, requesterXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingPaymentRequests", reflect.TypeOf((*MockPaymentRequestRepository)(nil).GetOutgoingPaymentRequests), ctx, requesterXID)
+}
+
+// GetPaymentRequest mocks base method.
+func (m *MockPaymentRequestRepository) GetPaymentRequest(ctx context.Context, paymentRequestID string) (domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentRequest", ctx, paymentRequestID)
+	ret0, _ := ret[0].(domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentRequest indicates an expected call of GetPaymentRequest.
+func (mr *MockPaymentRequestRepositoryMockRecorder) GetPaymentRequest(ctx, paymentRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentRequest", reflect.TypeOf((*MockPaymentRequestRepository)(nil).GetPaymentRequest), ctx, paymentRequestID)
+}
+
+// UpdatePaymentRequestStatus mocks base method.
+func (m *MockPaymentRequestRepository) UpdatePaymentRequestStatus(ctx context.Context, paymentRequestID, fromStatus, toStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentRequestStatus", ctx, paymentRequestID, fromStatus, toStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentRequestStatus indicates an expected call of UpdatePaymentRequestStatus.
+func (mr *MockPaymentRequestRepositoryMockRecorder) UpdatePaymentRequestStatus(ctx, paymentRequestID, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentRequestStatus", reflect.TypeOf((*MockPaymentRequestRepository)(nil).UpdatePaymentRequestStatus), ctx, paymentRequestID, fromStatus, toStatus)
+}
+
+// UpdatePaymentRequestTransaction mocks base method.
+func (m *MockPaymentRequestRepository) UpdatePaymentRequestTransaction(ctx context.Context, paymentRequestID, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentRequestTransaction", ctx, paymentRequestID, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymentRequestTransaction indicates an expected call of UpdatePaymentRequestTransaction.
+func (mr *MockPaymentRequestRepositoryMockRecorder) UpdatePaymentRequestTransaction(ctx, paymentRequestID, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentRequestTransaction", reflect.TypeOf((*MockPaymentRequestRepository)(nil).UpdatePaymentRequestTransaction), ctx, paymentRequestID, transactionID)
+}
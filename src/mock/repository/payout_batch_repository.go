@@ -0,0 +1,217 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/payout_batch_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockPayoutBatchRepository is a mock of PayoutBatchRepository interface.
+type MockPayoutBatchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayoutBatchRepositoryMockRecorder
+}
+
+// MockPayoutBatchRepositoryMockRecorder is the mock recorder for MockPayoutBatchRepository.
+type MockPayoutBatchRepositoryMockRecorder struct {
+	mock *MockPayoutBatchRepository
+}
+
+// NewMockPayoutBatchRepository creates a new mock instance.
+func NewMockPayoutBatchRepository(ctrl *gomock.Controller) *MockPayoutBatchRepository {
+	mock := &MockPayoutBatchRepository{ctrl: ctrl}
+	mock.recorder = &MockPayoutBatchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayoutBatchRepository) EXPECT() *MockPayoutBatchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelPayoutBatch mocks base method.
+func (m *MockPayoutBatchRepository) CancelPayoutBatch(ctx context.Context, batchID, fromStatus string, itemIDs []string, release domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPayoutBatch", ctx, batchID, fromStatus, itemIDs, release)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPayoutBatch indicates an expected call of CancelPayoutBatch.
+func (mr *MockPayoutBatchRepositoryMockRecorder) CancelPayoutBatch(ctx, batchID, fromStatus, itemIDs, release interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPayoutBatch", reflect.TypeOf((*MockPayoutBatchRepository)(nil).CancelPayoutBatch), ctx, batchID, fromStatus, itemIDs, release)
+}
+
+// ClaimPayoutBatch mocks base method.
+func (m *MockPayoutBatchRepository) ClaimPayoutBatch(ctx context.Context, batchID string, staleBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPayoutBatch", ctx, batchID, staleBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPayoutBatch indicates an expected call of ClaimPayoutBatch.
+func (mr *MockPayoutBatchRepositoryMockRecorder) ClaimPayoutBatch(ctx, batchID, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPayoutBatch", reflect.TypeOf((*MockPayoutBatchRepository)(nil).ClaimPayoutBatch), ctx, batchID, staleBefore)
+}
+
+// CreatePayoutBatch mocks base method.
+func (m *MockPayoutBatchRepository) CreatePayoutBatch(ctx context.Context, batch domain.PayoutBatch, items []domain.PayoutBatchItem, reservation domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayoutBatch", ctx, batch, items, reservation)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayoutBatch indicates an expected call of CreatePayoutBatch.
+func (mr *MockPayoutBatchRepositoryMockRecorder) CreatePayoutBatch(ctx, batch, items, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayoutBatch", reflect.TypeOf((*MockPayoutBatchRepository)(nil).CreatePayoutBatch), ctx, batch, items, reservation)
+}
+
+// FailPayoutBatchItem mocks base method.
+func (m *MockPayoutBatchRepository) FailPayoutBatchItem(ctx context.Context, itemID, reason string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPayoutBatchItem", ctx, itemID, reason)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailPayoutBatchItem indicates an expected call of FailPayoutBatchItem.
+func (mr *MockPayoutBatchRepositoryMockRecorder) FailPayoutBatchItem(ctx, itemID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPayoutBatchItem", reflect.TypeOf((*MockPayoutBatchRepository)(nil).FailPayoutBatchItem), ctx, itemID, reason)
+}
+
+// GetDuePayoutBatches mocks base method.
+func (m *MockPayoutBatchRepository) GetDuePayoutBatches(ctx context.Context, staleBefore time.Time, limit int) ([]domain.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuePayoutBatches", ctx, staleBefore, limit)
+	ret0, _ := ret[0].([]domain.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuePayoutBatches indicates an expected call of GetDuePayoutBatches.
+func (mr *MockPayoutBatchRepositoryMockRecorder) GetDuePayoutBatches(ctx, staleBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuePayoutBatches", reflect.TypeOf((*MockPayoutBatchRepository)(nil).GetDuePayoutBatches), ctx, staleBefore, limit)
+}
+
+// GetPayoutBatch mocks base method.
+func (m *MockPayoutBatchRepository) GetPayoutBatch(ctx context.Context, batchID string) (domain.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutBatch", ctx, batchID)
+	ret0, _ := ret[0].(domain.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutBatch indicates an expected call of GetPayoutBatch.
+func (mr *MockPayoutBatchRepositoryMockRecorder) GetPayoutBatch(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutBatch", reflect.TypeOf((*MockPayoutBatchRepository)(nil).GetPayoutBatch), ctx, batchID)
+}
+
+// GetPayoutBatchByReference mocks base method.
+func (m *MockPayoutBatchRepository) GetPayoutBatchByReference(ctx context.Context, customerXID, reference string) (domain.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutBatchByReference", ctx, customerXID, reference)
+	ret0, _ := ret[0].(domain.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutBatchByReference indicates an expected call of GetPayoutBatchByReference.
+func (mr *MockPayoutBatchRepositoryMockRecorder) GetPayoutBatchByReference(ctx, customerXID, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutBatchByReference", reflect.TypeOf((*MockPayoutBatchRepository)(nil).GetPayoutBatchByReference), ctx, customerXID, reference)
+}
+
+// GetPayoutBatchItems mocks base method.
+func (m *MockPayoutBatchRepository) GetPayoutBatchItems(ctx context.Context, batchID string) ([]domain.PayoutBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutBatchItems", ctx, batchID)
+	ret0, _ := ret[0].([]domain.PayoutBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutBatchItems indicates an expected call of GetPayoutBatchItems.
+func (mr *MockPayoutBatchRepositoryMockRecorder) GetPayoutBatchItems(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutBatchItems", reflect.TypeOf((*MockPayoutBatchRepository)(nil).GetPayoutBatchItems), ctx, batchID)
+}
+
+// GetPayoutBatches mocks base method.
+func (m *MockPayoutBatchRepository) GetPayoutBatches(ctx context.Context, customerXID string) ([]domain.PayoutBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutBatches", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.PayoutBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutBatches indicates an expected call of GetPayoutBatches.
+func (mr *MockPayoutBatchRepositoryMockRecorder) GetPayoutBatches(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutBatches", reflect.TypeOf((*MockPayoutBatchRepository)(nil).GetPayoutBatches), ctx, customerXID)
+}
+
+// PayPayoutBatchItem mocks base method.
+func (m *MockPayoutBatchRepository) PayPayoutBatchItem(ctx context.Context, itemID string, payout domain.Payout, release, withdrawal domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayPayoutBatchItem", ctx, itemID, payout, release, withdrawal)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayPayoutBatchItem indicates an expected call of PayPayoutBatchItem.
+func (mr *MockPayoutBatchRepositoryMockRecorder) PayPayoutBatchItem(ctx, itemID, payout, release, withdrawal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayPayoutBatchItem", reflect.TypeOf((*MockPayoutBatchRepository)(nil).PayPayoutBatchItem), ctx, itemID, payout, release, withdrawal)
+}
+
+// RetryPayoutBatch mocks base method.
+func (m *MockPayoutBatchRepository) RetryPayoutBatch(ctx context.Context, batchID, fromStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryPayoutBatch", ctx, batchID, fromStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryPayoutBatch indicates an expected call of RetryPayoutBatch.
+func (mr *MockPayoutBatchRepositoryMockRecorder) RetryPayoutBatch(ctx, batchID, fromStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryPayoutBatch", reflect.TypeOf((*MockPayoutBatchRepository)(nil).RetryPayoutBatch), ctx, batchID, fromStatus)
+}
+
+// UpdatePayoutBatchStatus mocks base method.
+func (m *MockPayoutBatchRepository) UpdatePayoutBatchStatus(ctx context.Context, batchID, fromStatus, toStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePayoutBatchStatus", ctx, batchID, fromStatus, toStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePayoutBatchStatus indicates an expected call of UpdatePayoutBatchStatus.
+func (mr *MockPayoutBatchRepositoryMockRecorder) UpdatePayoutBatchStatus(ctx, batchID, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePayoutBatchStatus", reflect.TypeOf((*MockPayoutBatchRepository)(nil).UpdatePayoutBatchStatus), ctx, batchID, fromStatus, toStatus)
+}
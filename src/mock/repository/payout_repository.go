@@ -0,0 +1,258 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/payout_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockPayoutRepository is a mock of PayoutRepository interface.
+type MockPayoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPayoutRepositoryMockRecorder
+}
+
+// MockPayoutRepositoryMockRecorder is the mock recorder for MockPayoutRepository.
+type MockPayoutRepositoryMockRecorder struct {
+	mock *MockPayoutRepository
+}
+
+// NewMockPayoutRepository creates a new mock instance.
+func NewMockPayoutRepository(ctrl *gomock.Controller) *MockPayoutRepository {
+	mock := &MockPayoutRepository{ctrl: ctrl}
+	mock.recorder = &MockPayoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPayoutRepository) EXPECT() *MockPayoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateBeneficiary mocks base method.
+func (m *MockPayoutRepository) CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBeneficiary", ctx, beneficiary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBeneficiary indicates an expected call of CreateBeneficiary.
+func (mr *MockPayoutRepositoryMockRecorder) CreateBeneficiary(ctx, beneficiary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBeneficiary", reflect.TypeOf((*MockPayoutRepository)(nil).CreateBeneficiary), ctx, beneficiary)
+}
+
+// CreatePayout mocks base method.
+func (m *MockPayoutRepository) CreatePayout(ctx context.Context, payout domain.Payout, withdrawal domain.Transaction, balance, newBalance domain.Money) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayout", ctx, payout, withdrawal, balance, newBalance)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayout indicates an expected call of CreatePayout.
+func (mr *MockPayoutRepositoryMockRecorder) CreatePayout(ctx, payout, withdrawal, balance, newBalance interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayout", reflect.TypeOf((*MockPayoutRepository)(nil).CreatePayout), ctx, payout, withdrawal, balance, newBalance)
+}
+
+// DeleteBeneficiary mocks base method.
+func (m *MockPayoutRepository) DeleteBeneficiary(ctx context.Context, beneficiaryID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBeneficiary", ctx, beneficiaryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBeneficiary indicates an expected call of DeleteBeneficiary.
+func (mr *MockPayoutRepositoryMockRecorder) DeleteBeneficiary(ctx, beneficiaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBeneficiary", reflect.TypeOf((*MockPayoutRepository)(nil).DeleteBeneficiary), ctx, beneficiaryID)
+}
+
+// FailPayout mocks base method.
+func (m *MockPayoutRepository) FailPayout(ctx context.Context, payoutID, reason string, reversal, reservation domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPayout", ctx, payoutID, reason, reversal, reservation)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailPayout indicates an expected call of FailPayout.
+func (mr *MockPayoutRepositoryMockRecorder) FailPayout(ctx, payoutID, reason, reversal, reservation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPayout", reflect.TypeOf((*MockPayoutRepository)(nil).FailPayout), ctx, payoutID, reason, reversal, reservation)
+}
+
+// GetBeneficiaries mocks base method.
+func (m *MockPayoutRepository) GetBeneficiaries(ctx context.Context, customerXID string) ([]domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiaries", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaries indicates an expected call of GetBeneficiaries.
+func (mr *MockPayoutRepositoryMockRecorder) GetBeneficiaries(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaries", reflect.TypeOf((*MockPayoutRepository)(nil).GetBeneficiaries), ctx, customerXID)
+}
+
+// GetBeneficiary mocks base method.
+func (m *MockPayoutRepository) GetBeneficiary(ctx context.Context, beneficiaryID string) (domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiary", ctx, beneficiaryID)
+	ret0, _ := ret[0].(domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiary indicates an expected call of GetBeneficiary.
+func (mr *MockPayoutRepositoryMockRecorder) GetBeneficiary(ctx, beneficiaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiary", reflect.TypeOf((*MockPayoutRepository)(nil).GetBeneficiary), ctx, beneficiaryID)
+}
+
+// GetBeneficiaryByAccount mocks base method.
+func (m *MockPayoutRepository) GetBeneficiaryByAccount(ctx context.Context, customerXID, bankCode, accountNumber string) (domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeneficiaryByAccount", ctx, customerXID, bankCode, accountNumber)
+	ret0, _ := ret[0].(domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeneficiaryByAccount indicates an expected call of GetBeneficiaryByAccount.
+func (mr *MockPayoutRepositoryMockRecorder) GetBeneficiaryByAccount(ctx, customerXID, bankCode, accountNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeneficiaryByAccount", reflect.TypeOf((*MockPayoutRepository)(nil).GetBeneficiaryByAccount), ctx, customerXID, bankCode, accountNumber)
+}
+
+// GetPayout mocks base method.
+func (m *MockPayoutRepository) GetPayout(ctx context.Context, payoutID string) (domain.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayout", ctx, payoutID)
+	ret0, _ := ret[0].(domain.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayout indicates an expected call of GetPayout.
+func (mr *MockPayoutRepositoryMockRecorder) GetPayout(ctx, payoutID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayout", reflect.TypeOf((*MockPayoutRepository)(nil).GetPayout), ctx, payoutID)
+}
+
+// GetPayoutByTransaction mocks base method.
+func (m *MockPayoutRepository) GetPayoutByTransaction(ctx context.Context, transactionID string) (domain.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutByTransaction", ctx, transactionID)
+	ret0, _ := ret[0].(domain.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutByTransaction indicates an expected call of GetPayoutByTransaction.
+func (mr *MockPayoutRepositoryMockRecorder) GetPayoutByTransaction(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutByTransaction", reflect.TypeOf((*MockPayoutRepository)(nil).GetPayoutByTransaction), ctx, transactionID)
+}
+
+// GetPayouts mocks base method.
+func (m *MockPayoutRepository) GetPayouts(ctx context.Context, customerXID string) ([]domain.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayouts", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayouts indicates an expected call of GetPayouts.
+func (mr *MockPayoutRepositoryMockRecorder) GetPayouts(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayouts", reflect.TypeOf((*MockPayoutRepository)(nil).GetPayouts), ctx, customerXID)
+}
+
+// GetPayoutsByStatus mocks base method.
+func (m *MockPayoutRepository) GetPayoutsByStatus(ctx context.Context, status string, limit int) ([]domain.Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPayoutsByStatus", ctx, status, limit)
+	ret0, _ := ret[0].([]domain.Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPayoutsByStatus indicates an expected call of GetPayoutsByStatus.
+func (mr *MockPayoutRepositoryMockRecorder) GetPayoutsByStatus(ctx, status, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPayoutsByStatus", reflect.TypeOf((*MockPayoutRepository)(nil).GetPayoutsByStatus), ctx, status, limit)
+}
+
+// MarkPayoutSubmitted mocks base method.
+func (m *MockPayoutRepository) MarkPayoutSubmitted(ctx context.Context, payoutID, providerReference string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPayoutSubmitted", ctx, payoutID, providerReference)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPayoutSubmitted indicates an expected call of MarkPayoutSubmitted.
+func (mr *MockPayoutRepositoryMockRecorder) MarkPayoutSubmitted(ctx, payoutID, providerReference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPayoutSubmitted", reflect.TypeOf((*MockPayoutRepository)(nil).MarkPayoutSubmitted), ctx, payoutID, providerReference)
+}
+
+// MarkPayoutSucceeded mocks base method.
+func (m *MockPayoutRepository) MarkPayoutSucceeded(ctx context.Context, payoutID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPayoutSucceeded", ctx, payoutID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPayoutSucceeded indicates an expected call of MarkPayoutSucceeded.
+func (mr *MockPayoutRepositoryMockRecorder) MarkPayoutSucceeded(ctx, payoutID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPayoutSucceeded", reflect.TypeOf((*MockPayoutRepository)(nil).MarkPayoutSucceeded), ctx, payoutID)
+}
+
+// SaveBeneficiary mocks base method.
+func (m *MockPayoutRepository) SaveBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBeneficiary", ctx, beneficiary)
+	ret0, _ := ret[0].(domain.Beneficiary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBeneficiary indicates an expected call of SaveBeneficiary.
+func (mr *MockPayoutRepositoryMockRecorder) SaveBeneficiary(ctx, beneficiary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBeneficiary", reflect.TypeOf((*MockPayoutRepository)(nil).SaveBeneficiary), ctx, beneficiary)
+}
+
+// UpdateBeneficiary mocks base method.
+func (m *MockPayoutRepository) UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBeneficiary", ctx, beneficiary)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBeneficiary indicates an expected call of UpdateBeneficiary.
+func (mr *MockPayoutRepositoryMockRecorder) UpdateBeneficiary(ctx, beneficiary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBeneficiary", reflect.TypeOf((*MockPayoutRepository)(nil).UpdateBeneficiary), ctx, beneficiary)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/pocket_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockPocketRepository is a mock of PocketRepository interface.
+type MockPocketRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPocketRepositoryMockRecorder
+}
+
+// MockPocketRepositoryMockRecorder is the mock recorder for MockPocketRepository.
+type MockPocketRepositoryMockRecorder struct {
+	mock *MockPocketRepository
+}
+
+// NewMockPocketRepository creates a new mock instance.
+func NewMockPocketRepository(ctrl *gomock.Controller) *MockPocketRepository {
+	mock := &MockPocketRepository{ctrl: ctrl}
+	mock.recorder = &MockPocketRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPocketRepository) EXPECT() *MockPocketRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AllocateToPocket mocks base method.
+func (m *MockPocketRepository) AllocateToPocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateToPocket", ctx, pocketID, transaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateToPocket indicates an expected call of AllocateToPocket.
+func (mr *MockPocketRepositoryMockRecorder) AllocateToPocket(ctx, pocketID, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateToPocket", reflect.TypeOf((*MockPocketRepository)(nil).AllocateToPocket), ctx, pocketID, transaction)
+}
+
+// ClosePocket mocks base method.
+func (m *MockPocketRepository) ClosePocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePocket", ctx, pocketID, transaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePocket indicates an expected call of ClosePocket.
+func (mr *MockPocketRepositoryMockRecorder) ClosePocket(ctx, pocketID, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePocket", reflect.TypeOf((*MockPocketRepository)(nil).ClosePocket), ctx, pocketID, transaction)
+}
+
+// CreatePocket mocks base method.
+func (m *MockPocketRepository) CreatePocket(ctx context.Context, pocket domain.Pocket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", ctx, pocket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockPocketRepositoryMockRecorder) CreatePocket(ctx, pocket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockPocketRepository)(nil).CreatePocket), ctx, pocket)
+}
+
+// GetPocket mocks base method.
+func (m *MockPocketRepository) GetPocket(ctx context.Context, pocketID string) (domain.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPocket", ctx, pocketID)
+	ret0, _ := ret[0].(domain.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPocket indicates an expected call of GetPocket.
+func (mr *MockPocketRepositoryMockRecorder) GetPocket(ctx, pocketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPocket", reflect.TypeOf((*MockPocketRepository)(nil).GetPocket), ctx, pocketID)
+}
+
+// GetPockets mocks base method.
+func (m *MockPocketRepository) GetPockets(ctx context.Context, walletID string) ([]domain.Pocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPockets", ctx, walletID)
+	ret0, _ := ret[0].([]domain.Pocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPockets indicates an expected call of GetPockets.
+func (mr *MockPocketRepositoryMockRecorder) GetPockets(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPockets", reflect.TypeOf((*MockPocketRepository)(nil).GetPockets), ctx, walletID)
+}
+
+// ReleaseFromPocket mocks base method.
+func (m *MockPocketRepository) ReleaseFromPocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseFromPocket", ctx, pocketID, transaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseFromPocket indicates an expected call of ReleaseFromPocket.
+func (mr *MockPocketRepositoryMockRecorder) ReleaseFromPocket(ctx, pocketID, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseFromPocket", reflect.TypeOf((*MockPocketRepository)(nil).ReleaseFromPocket), ctx, pocketID, transaction)
+}
+
+// UpdatePocket mocks base method.
+func (m *MockPocketRepository) UpdatePocket(ctx context.Context, pocket domain.Pocket) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePocket", ctx, pocket)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePocket indicates an expected call of UpdatePocket.
+func (mr *MockPocketRepositoryMockRecorder) UpdatePocket(ctx, pocket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePocket", reflect.TypeOf((*MockPocketRepository)(nil).UpdatePocket), ctx, pocket)
+}
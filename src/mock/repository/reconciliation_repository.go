@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/reconciliation_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockReconciliationRepository is a mock of ReconciliationRepository interface.
+type MockReconciliationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconciliationRepositoryMockRecorder
+}
+
+// MockReconciliationRepositoryMockRecorder is the mock recorder for MockReconciliationRepository.
+type MockReconciliationRepositoryMockRecorder struct {
+	mock *MockReconciliationRepository
+}
+
+// NewMockReconciliationRepository creates a new mock instance.
+func NewMockReconciliationRepository(ctrl *gomock.Controller) *MockReconciliationRepository {
+	mock := &MockReconciliationRepository{ctrl: ctrl}
+	mock.recorder = &MockReconciliationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconciliationRepository) EXPECT() *MockReconciliationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateStatementImport mocks base method.
+func (m *MockReconciliationRepository) CreateStatementImport(ctx context.Context, statementImport domain.StatementImport, lines []domain.StatementLine) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStatementImport", ctx, statementImport, lines)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStatementImport indicates an expected call of CreateStatementImport.
+func (mr *MockReconciliationRepositoryMockRecorder) CreateStatementImport(ctx, statementImport, lines interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStatementImport", reflect.TypeOf((*MockReconciliationRepository)(nil).CreateStatementImport), ctx, statementImport, lines)
+}
+
+// FindMatchableTransaction mocks base method.
+func (m *MockReconciliationRepository) FindMatchableTransaction(ctx context.Context, transactionType string, amount domain.Money, reference, depositReferenceID string) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMatchableTransaction", ctx, transactionType, amount, reference, depositReferenceID)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMatchableTransaction indicates an expected call of FindMatchableTransaction.
+func (mr *MockReconciliationRepositoryMockRecorder) FindMatchableTransaction(ctx, transactionType, amount, reference, depositReferenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMatchableTransaction", reflect.TypeOf((*MockReconciliationRepository)(nil).FindMatchableTransaction), ctx, transactionType, amount, reference, depositReferenceID)
+}
+
+// GetStatementImport mocks base method.
+func (m *MockReconciliationRepository) GetStatementImport(ctx context.Context, importID string) (domain.StatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementImport", ctx, importID)
+	ret0, _ := ret[0].(domain.StatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementImport indicates an expected call of GetStatementImport.
+func (mr *MockReconciliationRepositoryMockRecorder) GetStatementImport(ctx, importID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementImport", reflect.TypeOf((*MockReconciliationRepository)(nil).GetStatementImport), ctx, importID)
+}
+
+// GetStatementImports mocks base method.
+func (m *MockReconciliationRepository) GetStatementImports(ctx context.Context) ([]domain.StatementImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementImports", ctx)
+	ret0, _ := ret[0].([]domain.StatementImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementImports indicates an expected call of GetStatementImports.
+func (mr *MockReconciliationRepositoryMockRecorder) GetStatementImports(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementImports", reflect.TypeOf((*MockReconciliationRepository)(nil).GetStatementImports), ctx)
+}
+
+// GetStatementLine mocks base method.
+func (m *MockReconciliationRepository) GetStatementLine(ctx context.Context, lineID string) (domain.StatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementLine", ctx, lineID)
+	ret0, _ := ret[0].(domain.StatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementLine indicates an expected call of GetStatementLine.
+func (mr *MockReconciliationRepositoryMockRecorder) GetStatementLine(ctx, lineID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementLine", reflect.TypeOf((*MockReconciliationRepository)(nil).GetStatementLine), ctx, lineID)
+}
+
+// GetStatementLines mocks base method.
+func (m *MockReconciliationRepository) GetStatementLines(ctx context.Context, importID string) ([]domain.StatementLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatementLines", ctx, importID)
+	ret0, _ := ret[0].([]domain.StatementLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatementLines indicates an expected call of GetStatementLines.
+func (mr *MockReconciliationRepositoryMockRecorder) GetStatementLines(ctx, importID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatementLines", reflect.TypeOf((*MockReconciliationRepository)(nil).GetStatementLines), ctx, importID)
+}
+
+// GetUnmatchedTransactions mocks base method.
+func (m *MockReconciliationRepository) GetUnmatchedTransactions(ctx context.Context, bankCode, currency string, withPayouts bool, from, to time.Time) ([]domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnmatchedTransactions", ctx, bankCode, currency, withPayouts, from, to)
+	ret0, _ := ret[0].([]domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnmatchedTransactions indicates an expected call of GetUnmatchedTransactions.
+func (mr *MockReconciliationRepositoryMockRecorder) GetUnmatchedTransactions(ctx, bankCode, currency, withPayouts, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnmatchedTransactions", reflect.TypeOf((*MockReconciliationRepository)(nil).GetUnmatchedTransactions), ctx, bankCode, currency, withPayouts, from, to)
+}
+
+// MatchStatementLine mocks base method.
+func (m *MockReconciliationRepository) MatchStatementLine(ctx context.Context, lineID, transactionID, matchType string, matchedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchStatementLine", ctx, lineID, transactionID, matchType, matchedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchStatementLine indicates an expected call of MatchStatementLine.
+func (mr *MockReconciliationRepositoryMockRecorder) MatchStatementLine(ctx, lineID, transactionID, matchType, matchedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchStatementLine", reflect.TypeOf((*MockReconciliationRepository)(nil).MatchStatementLine), ctx, lineID, transactionID, matchType, matchedAt)
+}
+
+// UnmatchStatementLine mocks base method.
+func (m *MockReconciliationRepository) UnmatchStatementLine(ctx context.Context, lineID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmatchStatementLine", ctx, lineID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnmatchStatementLine indicates an expected call of UnmatchStatementLine.
+func (mr *MockReconciliationRepositoryMockRecorder) UnmatchStatementLine(ctx, lineID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmatchStatementLine", reflect.TypeOf((*MockReconciliationRepository)(nil).UnmatchStatementLine), ctx, lineID)
+}
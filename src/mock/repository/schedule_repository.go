@@ -0,0 +1,170 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/schedule_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockScheduleRepository is a mock of ScheduleRepository interface.
+type MockScheduleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleRepositoryMockRecorder
+}
+
+// MockScheduleRepositoryMockRecorder is the mock recorder for MockScheduleRepository.
+type MockScheduleRepositoryMockRecorder struct {
+	mock *MockScheduleRepository
+}
+
+// NewMockScheduleRepository creates a new mock instance.
+func NewMockScheduleRepository(ctrl *gomock.Controller) *MockScheduleRepository {
+	mock := &MockScheduleRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleRepository) EXPECT() *MockScheduleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddScheduleRun mocks base method.
+func (m *MockScheduleRepository) AddScheduleRun(ctx context.Context, run domain.ScheduleRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddScheduleRun", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddScheduleRun indicates an expected call of AddScheduleRun.
+func (mr *MockScheduleRepositoryMockRecorder) AddScheduleRun(ctx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScheduleRun", reflect.TypeOf((*MockScheduleRepository)(nil).AddScheduleRun), ctx, run)
+}
+
+// AdvanceSchedule mocks base method.
+func (m *MockScheduleRepository) AdvanceSchedule(ctx context.Context, scheduleID string, runAt, nextRunAt time.Time, status string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceSchedule", ctx, scheduleID, runAt, nextRunAt, status)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceSchedule indicates an expected call of AdvanceSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) AdvanceSchedule(ctx, scheduleID, runAt, nextRunAt, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).AdvanceSchedule), ctx, scheduleID, runAt, nextRunAt, status)
+}
+
+// ClaimSchedule mocks base method.
+func (m *MockScheduleRepository) ClaimSchedule(ctx context.Context, scheduleID string, runAt, staleBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimSchedule", ctx, scheduleID, runAt, staleBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimSchedule indicates an expected call of ClaimSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) ClaimSchedule(ctx, scheduleID, runAt, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).ClaimSchedule), ctx, scheduleID, runAt, staleBefore)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockScheduleRepository) CreateSchedule(ctx context.Context, schedule domain.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) CreateSchedule(ctx, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).CreateSchedule), ctx, schedule)
+}
+
+// GetDueSchedules mocks base method.
+func (m *MockScheduleRepository) GetDueSchedules(ctx context.Context, now, staleBefore time.Time, limit int) ([]domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueSchedules", ctx, now, staleBefore, limit)
+	ret0, _ := ret[0].([]domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueSchedules indicates an expected call of GetDueSchedules.
+func (mr *MockScheduleRepositoryMockRecorder) GetDueSchedules(ctx, now, staleBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueSchedules", reflect.TypeOf((*MockScheduleRepository)(nil).GetDueSchedules), ctx, now, staleBefore, limit)
+}
+
+// GetSchedule mocks base method.
+func (m *MockScheduleRepository) GetSchedule(ctx context.Context, scheduleID string) (domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockScheduleRepositoryMockRecorder) GetSchedule(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockScheduleRepository)(nil).GetSchedule), ctx, scheduleID)
+}
+
+// GetScheduleRuns mocks base method.
+func (m *MockScheduleRepository) GetScheduleRuns(ctx context.Context, scheduleID string) ([]domain.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleRuns", ctx, scheduleID)
+	ret0, _ := ret[0].([]domain.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleRuns indicates an expected call of GetScheduleRuns.
+func (mr *MockScheduleRepositoryMockRecorder) GetScheduleRuns(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleRuns", reflect.TypeOf((*MockScheduleRepository)(nil).GetScheduleRuns), ctx, scheduleID)
+}
+
+// GetSchedules mocks base method.
+func (m *MockScheduleRepository) GetSchedules(ctx context.Context, customerXID string) ([]domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *MockScheduleRepositoryMockRecorder) GetSchedules(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockScheduleRepository)(nil).GetSchedules), ctx, customerXID)
+}
+
+// UpdateScheduleStatus mocks base method.
+func (m *MockScheduleRepository) UpdateScheduleStatus(ctx context.Context, schedule domain.Schedule, status string, nextRunAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduleStatus", ctx, schedule, status, nextRunAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduleStatus indicates an expected call of UpdateScheduleStatus.
+func (mr *MockScheduleRepositoryMockRecorder) UpdateScheduleStatus(ctx, schedule, status, nextRunAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduleStatus", reflect.TypeOf((*MockScheduleRepository)(nil).UpdateScheduleStatus), ctx, schedule, status, nextRunAt)
+}
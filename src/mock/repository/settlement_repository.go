@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/settlement_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockSettlementRepository is a mock of SettlementRepository interface.
+type MockSettlementRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSettlementRepositoryMockRecorder
+}
+
+// MockSettlementRepositoryMockRecorder is the mock recorder for MockSettlementRepository.
+type MockSettlementRepositoryMockRecorder struct {
+	mock *MockSettlementRepository
+}
+
+// NewMockSettlementRepository creates a new mock instance.
+func NewMockSettlementRepository(ctrl *gomock.Controller) *MockSettlementRepository {
+	mock := &MockSettlementRepository{ctrl: ctrl}
+	mock.recorder = &MockSettlementRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSettlementRepository) EXPECT() *MockSettlementRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateSettlement mocks base method.
+func (m *MockSettlementRepository) CreateSettlement(ctx context.Context, settlement domain.Settlement, items []domain.SettlementItem, payout *domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSettlement", ctx, settlement, items, payout)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSettlement indicates an expected call of CreateSettlement.
+func (mr *MockSettlementRepositoryMockRecorder) CreateSettlement(ctx, settlement, items, payout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSettlement", reflect.TypeOf((*MockSettlementRepository)(nil).CreateSettlement), ctx, settlement, items, payout)
+}
+
+// GetCarriedSettlement mocks base method.
+func (m *MockSettlementRepository) GetCarriedSettlement(ctx context.Context, merchantID, currency string) (domain.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCarriedSettlement", ctx, merchantID, currency)
+	ret0, _ := ret[0].(domain.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCarriedSettlement indicates an expected call of GetCarriedSettlement.
+func (mr *MockSettlementRepositoryMockRecorder) GetCarriedSettlement(ctx, merchantID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarriedSettlement", reflect.TypeOf((*MockSettlementRepository)(nil).GetCarriedSettlement), ctx, merchantID, currency)
+}
+
+// GetSettlement mocks base method.
+func (m *MockSettlementRepository) GetSettlement(ctx context.Context, settlementID string) (domain.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlement", ctx, settlementID)
+	ret0, _ := ret[0].(domain.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlement indicates an expected call of GetSettlement.
+func (mr *MockSettlementRepositoryMockRecorder) GetSettlement(ctx, settlementID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlement", reflect.TypeOf((*MockSettlementRepository)(nil).GetSettlement), ctx, settlementID)
+}
+
+// GetSettlementItems mocks base method.
+func (m *MockSettlementRepository) GetSettlementItems(ctx context.Context, settlementID string) ([]domain.SettlementItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlementItems", ctx, settlementID)
+	ret0, _ := ret[0].([]domain.SettlementItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlementItems indicates an expected call of GetSettlementItems.
+func (mr *MockSettlementRepositoryMockRecorder) GetSettlementItems(ctx, settlementID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlementItems", reflect.TypeOf((*MockSettlementRepository)(nil).GetSettlementItems), ctx, settlementID)
+}
+
+// GetSettlements mocks base method.
+func (m *MockSettlementRepository) GetSettlements(ctx context.Context, merchantID string) ([]domain.Settlement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlements", ctx, merchantID)
+	ret0, _ := ret[0].([]domain.Settlement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlements indicates an expected call of GetSettlements.
+func (mr *MockSettlementRepositoryMockRecorder) GetSettlements(ctx, merchantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlements", reflect.TypeOf((*MockSettlementRepository)(nil).GetSettlements), ctx, merchantID)
+}
+
+// GetUnsettledItems mocks base method.
+func (m *MockSettlementRepository) GetUnsettledItems(ctx context.Context, merchantID string, cutoffAt time.Time) ([]domain.SettlementItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsettledItems", ctx, merchantID, cutoffAt)
+	ret0, _ := ret[0].([]domain.SettlementItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnsettledItems indicates an expected call of GetUnsettledItems.
+func (mr *MockSettlementRepositoryMockRecorder) GetUnsettledItems(ctx, merchantID, cutoffAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsettledItems", reflect.TypeOf((*MockSettlementRepository)(nil).GetUnsettledItems), ctx, merchantID, cutoffAt)
+}
+
+// GetUnsettledMerchantIDs mocks base method.
+func (m *MockSettlementRepository) GetUnsettledMerchantIDs(ctx context.Context, cutoffAt time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsettledMerchantIDs", ctx, cutoffAt)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnsettledMerchantIDs indicates an expected call of GetUnsettledMerchantIDs.
+func (mr *MockSettlementRepositoryMockRecorder) GetUnsettledMerchantIDs(ctx, cutoffAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsettledMerchantIDs", reflect.TypeOf((*MockSettlementRepository)(nil).GetUnsettledMerchantIDs), ctx, cutoffAt)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/split_bill_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockSplitBillRepository is a mock of SplitBillRepository interface.
+type MockSplitBillRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitBillRepositoryMockRecorder
+}
+
+// MockSplitBillRepositoryMockRecorder is the mock recorder for MockSplitBillRepository.
+type MockSplitBillRepositoryMockRecorder struct {
+	mock *MockSplitBillRepository
+}
+
+// NewMockSplitBillRepository creates a new mock instance.
+func NewMockSplitBillRepository(ctrl *gomock.Controller) *MockSplitBillRepository {
+	mock := &MockSplitBillRepository{ctrl: ctrl}
+	mock.recorder = &MockSplitBillRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSplitBillRepository) EXPECT() *MockSplitBillRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteSplitBill mocks base method.
+func (m *MockSplitBillRepository) CompleteSplitBill(ctx context.Context, splitBillID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSplitBill", ctx, splitBillID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteSplitBill indicates an expected call of CompleteSplitBill.
+func (mr *MockSplitBillRepositoryMockRecorder) CompleteSplitBill(ctx, splitBillID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSplitBill", reflect.TypeOf((*MockSplitBillRepository)(nil).CompleteSplitBill), ctx, splitBillID)
+}
+
+// CreateSplitBill mocks base method.
+func (m *MockSplitBillRepository) CreateSplitBill(ctx context.Context, splitBill domain.SplitBill, shares []domain.PaymentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSplitBill", ctx, splitBill, shares)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSplitBill indicates an expected call of CreateSplitBill.
+func (mr *MockSplitBillRepositoryMockRecorder) CreateSplitBill(ctx, splitBill, shares interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSplitBill", reflect.TypeOf((*MockSplitBillRepository)(nil).CreateSplitBill), ctx, splitBill, shares)
+}
+
+// GetExpiredSplitBills mocks base method.
+func (m *MockSplitBillRepository) GetExpiredSplitBills(ctx context.Context, now time.Time, limit int) ([]domain.SplitBill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredSplitBills", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.SplitBill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredSplitBills indicates an expected call of GetExpiredSplitBills.
+func (mr *MockSplitBillRepositoryMockRecorder) GetExpiredSplitBills(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredSplitBills", reflect.TypeOf((*MockSplitBillRepository)(nil).GetExpiredSplitBills), ctx, now, limit)
+}
+
+// GetSplitBill mocks base method.
+func (m *MockSplitBillRepository) GetSplitBill(ctx context.Context, splitBillID string) (domain.SplitBill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSplitBill", ctx, splitBillID)
+	ret0, _ := ret[0].(domain.SplitBill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSplitBill indicates an expected call of GetSplitBill.
+func (mr *MockSplitBillRepositoryMockRecorder) GetSplitBill(ctx, splitBillID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSplitBill", reflect.TypeOf((*MockSplitBillRepository)(nil).GetSplitBill), ctx, splitBillID)
+}
+
+// GetSplitBillShares mocks base method.
+func (m *MockSplitBillRepository) GetSplitBillShares(ctx context.Context, splitBillID string) ([]domain.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSplitBillShares", ctx, splitBillID)
+	ret0, _ := ret[0].([]domain.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSplitBillShares indicates an expected call of GetSplitBillShares.
+func (mr *MockSplitBillRepositoryMockRecorder) GetSplitBillShares(ctx, splitBillID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSplitBillShares", reflect.TypeOf((*MockSplitBillRepository)(nil).GetSplitBillShares), ctx, splitBillID)
+}
+
+// GetSplitBills mocks base method.
+func (m *MockSplitBillRepository) GetSplitBills(ctx context.Context, creatorXID string) ([]domain.SplitBill, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSplitBills", ctx, creatorXID)
+	ret0, _ := ret[0].([]domain.SplitBill)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSplitBills indicates an expected call of GetSplitBills.
+func (mr *MockSplitBillRepositoryMockRecorder) GetSplitBills(ctx, creatorXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSplitBills", reflect.TypeOf((*MockSplitBillRepository)(nil).GetSplitBills), ctx, creatorXID)
+}
+
+// UpdateSplitBillSharesStatus mocks base method.
+func (m *MockSplitBillRepository) UpdateSplitBillSharesStatus(ctx context.Context, splitBillID, fromStatus, toStatus string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSplitBillSharesStatus", ctx, splitBillID, fromStatus, toStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSplitBillSharesStatus indicates an expected call of UpdateSplitBillSharesStatus.
+func (mr *MockSplitBillRepositoryMockRecorder) UpdateSplitBillSharesStatus(ctx, splitBillID, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSplitBillSharesStatus", reflect.TypeOf((*MockSplitBillRepository)(nil).UpdateSplitBillSharesStatus), ctx, splitBillID, fromStatus, toStatus)
+}
+
+// UpdateSplitBillStatus mocks base method.
+func (m *MockSplitBillRepository) UpdateSplitBillStatus(ctx context.Context, splitBillID, fromStatus, toStatus string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSplitBillStatus", ctx, splitBillID, fromStatus, toStatus)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSplitBillStatus indicates an expected call of UpdateSplitBillStatus.
+func (mr *MockSplitBillRepositoryMockRecorder) UpdateSplitBillStatus(ctx, splitBillID, fromStatus, toStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSplitBillStatus", reflect.TypeOf((*MockSplitBillRepository)(nil).UpdateSplitBillStatus), ctx, splitBillID, fromStatus, toStatus)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/virtual_account_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockVirtualAccountRepository is a mock of VirtualAccountRepository interface.
+type MockVirtualAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVirtualAccountRepositoryMockRecorder
+}
+
+// MockVirtualAccountRepositoryMockRecorder is the mock recorder for MockVirtualAccountRepository.
+type MockVirtualAccountRepositoryMockRecorder struct {
+	mock *MockVirtualAccountRepository
+}
+
+// NewMockVirtualAccountRepository creates a new mock instance.
+func NewMockVirtualAccountRepository(ctrl *gomock.Controller) *MockVirtualAccountRepository {
+	mock := &MockVirtualAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockVirtualAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVirtualAccountRepository) EXPECT() *MockVirtualAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateVirtualAccount mocks base method.
+func (m *MockVirtualAccountRepository) CreateVirtualAccount(ctx context.Context, virtualAccount domain.VirtualAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVirtualAccount", ctx, virtualAccount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVirtualAccount indicates an expected call of CreateVirtualAccount.
+func (mr *MockVirtualAccountRepositoryMockRecorder) CreateVirtualAccount(ctx, virtualAccount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVirtualAccount", reflect.TypeOf((*MockVirtualAccountRepository)(nil).CreateVirtualAccount), ctx, virtualAccount)
+}
+
+// CreditVirtualAccountPayment mocks base method.
+func (m *MockVirtualAccountRepository) CreditVirtualAccountPayment(ctx context.Context, payment domain.VirtualAccountPayment, credit domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditVirtualAccountPayment", ctx, payment, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreditVirtualAccountPayment indicates an expected call of CreditVirtualAccountPayment.
+func (mr *MockVirtualAccountRepositoryMockRecorder) CreditVirtualAccountPayment(ctx, payment, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditVirtualAccountPayment", reflect.TypeOf((*MockVirtualAccountRepository)(nil).CreditVirtualAccountPayment), ctx, payment, credit)
+}
+
+// GetVirtualAccountByNumber mocks base method.
+func (m *MockVirtualAccountRepository) GetVirtualAccountByNumber(ctx context.Context, number string) (domain.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualAccountByNumber", ctx, number)
+	ret0, _ := ret[0].(domain.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualAccountByNumber indicates an expected call of GetVirtualAccountByNumber.
+func (mr *MockVirtualAccountRepositoryMockRecorder) GetVirtualAccountByNumber(ctx, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualAccountByNumber", reflect.TypeOf((*MockVirtualAccountRepository)(nil).GetVirtualAccountByNumber), ctx, number)
+}
+
+// GetVirtualAccountByWallet mocks base method.
+func (m *MockVirtualAccountRepository) GetVirtualAccountByWallet(ctx context.Context, walletID, bankCode string) (domain.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualAccountByWallet", ctx, walletID, bankCode)
+	ret0, _ := ret[0].(domain.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualAccountByWallet indicates an expected call of GetVirtualAccountByWallet.
+func (mr *MockVirtualAccountRepositoryMockRecorder) GetVirtualAccountByWallet(ctx, walletID, bankCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualAccountByWallet", reflect.TypeOf((*MockVirtualAccountRepository)(nil).GetVirtualAccountByWallet), ctx, walletID, bankCode)
+}
+
+// GetVirtualAccountPayment mocks base method.
+func (m *MockVirtualAccountRepository) GetVirtualAccountPayment(ctx context.Context, bankCode, bankReference string) (domain.VirtualAccountPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualAccountPayment", ctx, bankCode, bankReference)
+	ret0, _ := ret[0].(domain.VirtualAccountPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualAccountPayment indicates an expected call of GetVirtualAccountPayment.
+func (mr *MockVirtualAccountRepositoryMockRecorder) GetVirtualAccountPayment(ctx, bankCode, bankReference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualAccountPayment", reflect.TypeOf((*MockVirtualAccountRepository)(nil).GetVirtualAccountPayment), ctx, bankCode, bankReference)
+}
+
+// GetVirtualAccounts mocks base method.
+func (m *MockVirtualAccountRepository) GetVirtualAccounts(ctx context.Context, customerXID string) ([]domain.VirtualAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirtualAccounts", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.VirtualAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVirtualAccounts indicates an expected call of GetVirtualAccounts.
+func (mr *MockVirtualAccountRepositoryMockRecorder) GetVirtualAccounts(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirtualAccounts", reflect.TypeOf((*MockVirtualAccountRepository)(nil).GetVirtualAccounts), ctx, customerXID)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/voucher_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockVoucherRepository is a mock of VoucherRepository interface.
+type MockVoucherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoucherRepositoryMockRecorder
+}
+
+// MockVoucherRepositoryMockRecorder is the mock recorder for MockVoucherRepository.
+type MockVoucherRepositoryMockRecorder struct {
+	mock *MockVoucherRepository
+}
+
+// NewMockVoucherRepository creates a new mock instance.
+func NewMockVoucherRepository(ctrl *gomock.Controller) *MockVoucherRepository {
+	mock := &MockVoucherRepository{ctrl: ctrl}
+	mock.recorder = &MockVoucherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoucherRepository) EXPECT() *MockVoucherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateVoucherBatch mocks base method.
+func (m *MockVoucherRepository) CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch, vouchers []domain.Voucher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVoucherBatch", ctx, batch, vouchers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVoucherBatch indicates an expected call of CreateVoucherBatch.
+func (mr *MockVoucherRepositoryMockRecorder) CreateVoucherBatch(ctx, batch, vouchers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVoucherBatch", reflect.TypeOf((*MockVoucherRepository)(nil).CreateVoucherBatch), ctx, batch, vouchers)
+}
+
+// GetCustomerVoucherUsage mocks base method.
+func (m *MockVoucherRepository) GetCustomerVoucherUsage(ctx context.Context, batchID, customerXID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerVoucherUsage", ctx, batchID, customerXID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerVoucherUsage indicates an expected call of GetCustomerVoucherUsage.
+func (mr *MockVoucherRepositoryMockRecorder) GetCustomerVoucherUsage(ctx, batchID, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerVoucherUsage", reflect.TypeOf((*MockVoucherRepository)(nil).GetCustomerVoucherUsage), ctx, batchID, customerXID)
+}
+
+// GetVoucherBatch mocks base method.
+func (m *MockVoucherRepository) GetVoucherBatch(ctx context.Context, batchID string) (domain.VoucherBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucherBatch", ctx, batchID)
+	ret0, _ := ret[0].(domain.VoucherBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucherBatch indicates an expected call of GetVoucherBatch.
+func (mr *MockVoucherRepositoryMockRecorder) GetVoucherBatch(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucherBatch", reflect.TypeOf((*MockVoucherRepository)(nil).GetVoucherBatch), ctx, batchID)
+}
+
+// GetVoucherBatchCustomerCount mocks base method.
+func (m *MockVoucherRepository) GetVoucherBatchCustomerCount(ctx context.Context, batchID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucherBatchCustomerCount", ctx, batchID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucherBatchCustomerCount indicates an expected call of GetVoucherBatchCustomerCount.
+func (mr *MockVoucherRepositoryMockRecorder) GetVoucherBatchCustomerCount(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucherBatchCustomerCount", reflect.TypeOf((*MockVoucherRepository)(nil).GetVoucherBatchCustomerCount), ctx, batchID)
+}
+
+// GetVoucherBatches mocks base method.
+func (m *MockVoucherRepository) GetVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucherBatches", ctx)
+	ret0, _ := ret[0].([]domain.VoucherBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucherBatches indicates an expected call of GetVoucherBatches.
+func (mr *MockVoucherRepositoryMockRecorder) GetVoucherBatches(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucherBatches", reflect.TypeOf((*MockVoucherRepository)(nil).GetVoucherBatches), ctx)
+}
+
+// GetVoucherByCode mocks base method.
+func (m *MockVoucherRepository) GetVoucherByCode(ctx context.Context, code string) (domain.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucherByCode", ctx, code)
+	ret0, _ := ret[0].(domain.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucherByCode indicates an expected call of GetVoucherByCode.
+func (mr *MockVoucherRepositoryMockRecorder) GetVoucherByCode(ctx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucherByCode", reflect.TypeOf((*MockVoucherRepository)(nil).GetVoucherByCode), ctx, code)
+}
+
+// GetVouchers mocks base method.
+func (m *MockVoucherRepository) GetVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVouchers", ctx, batchID)
+	ret0, _ := ret[0].([]domain.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVouchers indicates an expected call of GetVouchers.
+func (mr *MockVoucherRepositoryMockRecorder) GetVouchers(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVouchers", reflect.TypeOf((*MockVoucherRepository)(nil).GetVouchers), ctx, batchID)
+}
+
+// RedeemVoucher mocks base method.
+func (m *MockVoucherRepository) RedeemVoucher(ctx context.Context, batch domain.VoucherBatch, redemption domain.VoucherRedemption, credit domain.Transaction) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemVoucher", ctx, batch, redemption, credit)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeemVoucher indicates an expected call of RedeemVoucher.
+func (mr *MockVoucherRepositoryMockRecorder) RedeemVoucher(ctx, batch, redemption, credit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemVoucher", reflect.TypeOf((*MockVoucherRepository)(nil).RedeemVoucher), ctx, batch, redemption, credit)
+}
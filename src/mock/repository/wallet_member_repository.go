@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/repository/wallet_member_repository.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// MockWalletMemberRepository is a mock of WalletMemberRepository interface.
+type MockWalletMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletMemberRepositoryMockRecorder
+}
+
+// MockWalletMemberRepositoryMockRecorder is the mock recorder for MockWalletMemberRepository.
+type MockWalletMemberRepositoryMockRecorder struct {
+	mock *MockWalletMemberRepository
+}
+
+// NewMockWalletMemberRepository creates a new mock instance.
+func NewMockWalletMemberRepository(ctrl *gomock.Controller) *MockWalletMemberRepository {
+	mock := &MockWalletMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletMemberRepository) EXPECT() *MockWalletMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateWalletMember mocks base method.
+func (m *MockWalletMemberRepository) CreateWalletMember(ctx context.Context, member domain.WalletMember) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWalletMember", ctx, member)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWalletMember indicates an expected call of CreateWalletMember.
+func (mr *MockWalletMemberRepositoryMockRecorder) CreateWalletMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWalletMember", reflect.TypeOf((*MockWalletMemberRepository)(nil).CreateWalletMember), ctx, member)
+}
+
+// GetMemberships mocks base method.
+func (m *MockWalletMemberRepository) GetMemberships(ctx context.Context, memberXID string) ([]domain.WalletMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberships", ctx, memberXID)
+	ret0, _ := ret[0].([]domain.WalletMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberships indicates an expected call of GetMemberships.
+func (mr *MockWalletMemberRepositoryMockRecorder) GetMemberships(ctx, memberXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberships", reflect.TypeOf((*MockWalletMemberRepository)(nil).GetMemberships), ctx, memberXID)
+}
+
+// GetWalletMember mocks base method.
+func (m *MockWalletMemberRepository) GetWalletMember(ctx context.Context, walletID, memberXID string) (domain.WalletMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletMember", ctx, walletID, memberXID)
+	ret0, _ := ret[0].(domain.WalletMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletMember indicates an expected call of GetWalletMember.
+func (mr *MockWalletMemberRepositoryMockRecorder) GetWalletMember(ctx, walletID, memberXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletMember", reflect.TypeOf((*MockWalletMemberRepository)(nil).GetWalletMember), ctx, walletID, memberXID)
+}
+
+// GetWalletMembers mocks base method.
+func (m *MockWalletMemberRepository) GetWalletMembers(ctx context.Context, walletID string) ([]domain.WalletMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletMembers", ctx, walletID)
+	ret0, _ := ret[0].([]domain.WalletMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletMembers indicates an expected call of GetWalletMembers.
+func (mr *MockWalletMemberRepositoryMockRecorder) GetWalletMembers(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletMembers", reflect.TypeOf((*MockWalletMemberRepository)(nil).GetWalletMembers), ctx, walletID)
+}
+
+// ReleaseMemberSpend mocks base method.
+func (m *MockWalletMemberRepository) ReleaseMemberSpend(ctx context.Context, memberID string, amount domain.Money, period string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMemberSpend", ctx, memberID, amount, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseMemberSpend indicates an expected call of ReleaseMemberSpend.
+func (mr *MockWalletMemberRepositoryMockRecorder) ReleaseMemberSpend(ctx, memberID, amount, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMemberSpend", reflect.TypeOf((*MockWalletMemberRepository)(nil).ReleaseMemberSpend), ctx, memberID, amount, period)
+}
+
+// ReserveMemberSpend mocks base method.
+func (m *MockWalletMemberRepository) ReserveMemberSpend(ctx context.Context, memberID string, amount domain.Money, period string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveMemberSpend", ctx, memberID, amount, period)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveMemberSpend indicates an expected call of ReserveMemberSpend.
+func (mr *MockWalletMemberRepositoryMockRecorder) ReserveMemberSpend(ctx, memberID, amount, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveMemberSpend", reflect.TypeOf((*MockWalletMemberRepository)(nil).ReserveMemberSpend), ctx, memberID, amount, period)
+}
+
+// UpdateWalletMemberControls mocks base method.
+func (m *MockWalletMemberRepository) UpdateWalletMemberControls(ctx context.Context, member domain.WalletMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWalletMemberControls", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWalletMemberControls indicates an expected call of UpdateWalletMemberControls.
+func (mr *MockWalletMemberRepositoryMockRecorder) UpdateWalletMemberControls(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWalletMemberControls", reflect.TypeOf((*MockWalletMemberRepository)(nil).UpdateWalletMemberControls), ctx, member)
+}
+
+// UpdateWalletMemberStatus mocks base method.
+func (m *MockWalletMemberRepository) UpdateWalletMemberStatus(ctx context.Context, memberID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWalletMemberStatus", ctx, memberID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWalletMemberStatus indicates an expected call of UpdateWalletMemberStatus.
+func (mr *MockWalletMemberRepositoryMockRecorder) UpdateWalletMemberStatus(ctx, memberID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWalletMemberStatus", reflect.TypeOf((*MockWalletMemberRepository)(nil).UpdateWalletMemberStatus), ctx, memberID, status)
+}
@@ -64,6 +64,36 @@ func (mr *MockWalletRepositoryMockRecorder) CreateWallet(ctx, wallet interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockWalletRepository)(nil).CreateWallet), ctx, wallet)
 }
 
+// GetTransaction mocks base method.
+func (m *MockWalletRepository) GetTransaction(ctx context.Context, transactionID string) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, transactionID)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockWalletRepositoryMockRecorder) GetTransaction(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockWalletRepository)(nil).GetTransaction), ctx, transactionID)
+}
+
+// GetTransactionByReference mocks base method.
+func (m *MockWalletRepository) GetTransactionByReference(ctx context.Context, transactionType, referenceID string) (domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionByReference", ctx, transactionType, referenceID)
+	ret0, _ := ret[0].(domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionByReference indicates an expected call of GetTransactionByReference.
+func (mr *MockWalletRepositoryMockRecorder) GetTransactionByReference(ctx, transactionType, referenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByReference", reflect.TypeOf((*MockWalletRepository)(nil).GetTransactionByReference), ctx, transactionType, referenceID)
+}
+
 // GetWallet mocks base method.
 func (m *MockWalletRepository) GetWallet(ctx context.Context, customerXID string) (domain.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +109,21 @@ func (mr *MockWalletRepositoryMockRecorder) GetWallet(ctx, customerXID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockWalletRepository)(nil).GetWallet), ctx, customerXID)
 }
 
+// GetWalletByCurrency mocks base method.
+func (m *MockWalletRepository) GetWalletByCurrency(ctx context.Context, customerXID, currency string) (domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletByCurrency", ctx, customerXID, currency)
+	ret0, _ := ret[0].(domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletByCurrency indicates an expected call of GetWalletByCurrency.
+func (mr *MockWalletRepositoryMockRecorder) GetWalletByCurrency(ctx, customerXID, currency interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletByCurrency", reflect.TypeOf((*MockWalletRepository)(nil).GetWalletByCurrency), ctx, customerXID, currency)
+}
+
 // GetWalletTransactions mocks base method.
 func (m *MockWalletRepository) GetWalletTransactions(ctx context.Context, walletID string) ([]domain.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +139,36 @@ func (mr *MockWalletRepositoryMockRecorder) GetWalletTransactions(ctx, walletID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletTransactions", reflect.TypeOf((*MockWalletRepository)(nil).GetWalletTransactions), ctx, walletID)
 }
 
+// GetWallets mocks base method.
+func (m *MockWalletRepository) GetWallets(ctx context.Context, customerXID string) ([]domain.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallets", ctx, customerXID)
+	ret0, _ := ret[0].([]domain.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallets indicates an expected call of GetWallets.
+func (mr *MockWalletRepositoryMockRecorder) GetWallets(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallets", reflect.TypeOf((*MockWalletRepository)(nil).GetWallets), ctx, customerXID)
+}
+
+// TransferBalance mocks base method.
+func (m *MockWalletRepository) TransferBalance(ctx context.Context, debit, credit domain.Transaction, guard domain.TransferGuard) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBalance", ctx, debit, credit, guard)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBalance indicates an expected call of TransferBalance.
+func (mr *MockWalletRepositoryMockRecorder) TransferBalance(ctx, debit, credit, guard interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBalance", reflect.TypeOf((*MockWalletRepository)(nil).TransferBalance), ctx, debit, credit, guard)
+}
+
 // UpdateTransactionStatus mocks base method.
 func (m *MockWalletRepository) UpdateTransactionStatus(ctx context.Context, transactionID, status string) error {
 	m.ctrl.T.Helper()
@@ -109,7 +184,7 @@ func (mr *MockWalletRepositoryMockRecorder) UpdateTransactionStatus(ctx, transac
 }
 
 // UpdateWalletBalance mocks base method.
-func (m *MockWalletRepository) UpdateWalletBalance(ctx context.Context, walletID string, initialAmount, finalAmount int) (bool, error) {
+func (m *MockWalletRepository) UpdateWalletBalance(ctx context.Context, walletID string, initialAmount, finalAmount domain.Money) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateWalletBalance", ctx, walletID, initialAmount, finalAmount)
 	ret0, _ := ret[0].(bool)
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/service/merchant_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	web "github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+// MockMerchantServiceItf is a mock of MerchantServiceItf interface.
+type MockMerchantServiceItf struct {
+	ctrl     *gomock.Controller
+	recorder *MockMerchantServiceItfMockRecorder
+}
+
+// MockMerchantServiceItfMockRecorder is the mock recorder for MockMerchantServiceItf.
+type MockMerchantServiceItfMockRecorder struct {
+	mock *MockMerchantServiceItf
+}
+
+// NewMockMerchantServiceItf creates a new mock instance.
+func NewMockMerchantServiceItf(ctrl *gomock.Controller) *MockMerchantServiceItf {
+	mock := &MockMerchantServiceItf{ctrl: ctrl}
+	mock.recorder = &MockMerchantServiceItfMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMerchantServiceItf) EXPECT() *MockMerchantServiceItfMockRecorder {
+	return m.recorder
+}
+
+// CancelPaymentIntent mocks base method.
+func (m *MockMerchantServiceItf) CancelPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPaymentIntent", ctx, customerXID, paymentIntentID)
+	ret0, _ := ret[0].(web.PaymentIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelPaymentIntent indicates an expected call of CancelPaymentIntent.
+func (mr *MockMerchantServiceItfMockRecorder) CancelPaymentIntent(ctx, customerXID, paymentIntentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPaymentIntent", reflect.TypeOf((*MockMerchantServiceItf)(nil).CancelPaymentIntent), ctx, customerXID, paymentIntentID)
+}
+
+// ConfirmPaymentIntent mocks base method.
+func (m *MockMerchantServiceItf) ConfirmPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPaymentIntent", ctx, customerXID, paymentIntentID)
+	ret0, _ := ret[0].(web.PaymentIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmPaymentIntent indicates an expected call of ConfirmPaymentIntent.
+func (mr *MockMerchantServiceItfMockRecorder) ConfirmPaymentIntent(ctx, customerXID, paymentIntentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPaymentIntent", reflect.TypeOf((*MockMerchantServiceItf)(nil).ConfirmPaymentIntent), ctx, customerXID, paymentIntentID)
+}
+
+// CreatePaymentIntent mocks base method.
+func (m *MockMerchantServiceItf) CreatePaymentIntent(ctx context.Context, customerXID string, request web.PaymentIntentCreateRequest) (web.PaymentIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePaymentIntent", ctx, customerXID, request)
+	ret0, _ := ret[0].(web.PaymentIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePaymentIntent indicates an expected call of CreatePaymentIntent.
+func (mr *MockMerchantServiceItfMockRecorder) CreatePaymentIntent(ctx, customerXID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePaymentIntent", reflect.TypeOf((*MockMerchantServiceItf)(nil).CreatePaymentIntent), ctx, customerXID, request)
+}
+
+// GetMerchant mocks base method.
+func (m *MockMerchantServiceItf) GetMerchant(ctx context.Context, customerXID string) (web.MerchantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMerchant", ctx, customerXID)
+	ret0, _ := ret[0].(web.MerchantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMerchant indicates an expected call of GetMerchant.
+func (mr *MockMerchantServiceItfMockRecorder) GetMerchant(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerchant", reflect.TypeOf((*MockMerchantServiceItf)(nil).GetMerchant), ctx, customerXID)
+}
+
+// GetPaymentIntent mocks base method.
+func (m *MockMerchantServiceItf) GetPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentIntent", ctx, customerXID, paymentIntentID)
+	ret0, _ := ret[0].(web.PaymentIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentIntent indicates an expected call of GetPaymentIntent.
+func (mr *MockMerchantServiceItfMockRecorder) GetPaymentIntent(ctx, customerXID, paymentIntentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentIntent", reflect.TypeOf((*MockMerchantServiceItf)(nil).GetPaymentIntent), ctx, customerXID, paymentIntentID)
+}
+
+// GetPaymentIntents mocks base method.
+func (m *MockMerchantServiceItf) GetPaymentIntents(ctx context.Context, customerXID, status string) ([]web.PaymentIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPaymentIntents", ctx, customerXID, status)
+	ret0, _ := ret[0].([]web.PaymentIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPaymentIntents indicates an expected call of GetPaymentIntents.
+func (mr *MockMerchantServiceItfMockRecorder) GetPaymentIntents(ctx, customerXID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPaymentIntents", reflect.TypeOf((*MockMerchantServiceItf)(nil).GetPaymentIntents), ctx, customerXID, status)
+}
+
+// RefundPaymentIntent mocks base method.
+func (m *MockMerchantServiceItf) RefundPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPaymentIntent", ctx, customerXID, paymentIntentID)
+	ret0, _ := ret[0].(web.PaymentIntentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPaymentIntent indicates an expected call of RefundPaymentIntent.
+func (mr *MockMerchantServiceItfMockRecorder) RefundPaymentIntent(ctx, customerXID, paymentIntentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPaymentIntent", reflect.TypeOf((*MockMerchantServiceItf)(nil).RefundPaymentIntent), ctx, customerXID, paymentIntentID)
+}
+
+// RegisterMerchant mocks base method.
+func (m *MockMerchantServiceItf) RegisterMerchant(ctx context.Context, customerXID string, request web.MerchantRequest) (web.MerchantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterMerchant", ctx, customerXID, request)
+	ret0, _ := ret[0].(web.MerchantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterMerchant indicates an expected call of RegisterMerchant.
+func (mr *MockMerchantServiceItfMockRecorder) RegisterMerchant(ctx, customerXID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterMerchant", reflect.TypeOf((*MockMerchantServiceItf)(nil).RegisterMerchant), ctx, customerXID, request)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: src/service/wallet_service.go
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	web "github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+// MockWalletServiceItf is a mock of WalletServiceItf interface.
+type MockWalletServiceItf struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletServiceItfMockRecorder
+}
+
+// MockWalletServiceItfMockRecorder is the mock recorder for MockWalletServiceItf.
+type MockWalletServiceItfMockRecorder struct {
+	mock *MockWalletServiceItf
+}
+
+// NewMockWalletServiceItf creates a new mock instance.
+func NewMockWalletServiceItf(ctrl *gomock.Controller) *MockWalletServiceItf {
+	mock := &MockWalletServiceItf{ctrl: ctrl}
+	mock.recorder = &MockWalletServiceItfMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletServiceItf) EXPECT() *MockWalletServiceItfMockRecorder {
+	return m.recorder
+}
+
+// AddWalletBalance mocks base method.
+func (m *MockWalletServiceItf) AddWalletBalance(ctx context.Context, customerXID string, request web.TransactionRequest) (web.DepositResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWalletBalance", ctx, customerXID, request)
+	ret0, _ := ret[0].(web.DepositResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWalletBalance indicates an expected call of AddWalletBalance.
+func (mr *MockWalletServiceItfMockRecorder) AddWalletBalance(ctx, customerXID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWalletBalance", reflect.TypeOf((*MockWalletServiceItf)(nil).AddWalletBalance), ctx, customerXID, request)
+}
+
+// DeductWalletBalance mocks base method.
+func (m *MockWalletServiceItf) DeductWalletBalance(ctx context.Context, customerXID string, request web.WithdrawalRequest) (web.WithdrawalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeductWalletBalance", ctx, customerXID, request)
+	ret0, _ := ret[0].(web.WithdrawalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeductWalletBalance indicates an expected call of DeductWalletBalance.
+func (mr *MockWalletServiceItfMockRecorder) DeductWalletBalance(ctx, customerXID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeductWalletBalance", reflect.TypeOf((*MockWalletServiceItf)(nil).DeductWalletBalance), ctx, customerXID, request)
+}
+
+// DisableWallet mocks base method.
+func (m *MockWalletServiceItf) DisableWallet(ctx context.Context, customerXID string) (web.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableWallet", ctx, customerXID)
+	ret0, _ := ret[0].(web.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableWallet indicates an expected call of DisableWallet.
+func (mr *MockWalletServiceItfMockRecorder) DisableWallet(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableWallet", reflect.TypeOf((*MockWalletServiceItf)(nil).DisableWallet), ctx, customerXID)
+}
+
+// EnableWallet mocks base method.
+func (m *MockWalletServiceItf) EnableWallet(ctx context.Context, customerXID string) (web.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableWallet", ctx, customerXID)
+	ret0, _ := ret[0].(web.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableWallet indicates an expected call of EnableWallet.
+func (mr *MockWalletServiceItfMockRecorder) EnableWallet(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableWallet", reflect.TypeOf((*MockWalletServiceItf)(nil).EnableWallet), ctx, customerXID)
+}
+
+// GetWalletBalance mocks base method.
+func (m *MockWalletServiceItf) GetWalletBalance(ctx context.Context, customerXID string) (web.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletBalance", ctx, customerXID)
+	ret0, _ := ret[0].(web.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletBalance indicates an expected call of GetWalletBalance.
+func (mr *MockWalletServiceItfMockRecorder) GetWalletBalance(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalance", reflect.TypeOf((*MockWalletServiceItf)(nil).GetWalletBalance), ctx, customerXID)
+}
+
+// GetWalletTransactions mocks base method.
+func (m *MockWalletServiceItf) GetWalletTransactions(ctx context.Context, customerXID string) ([]web.TransactionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletTransactions", ctx, customerXID)
+	ret0, _ := ret[0].([]web.TransactionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletTransactions indicates an expected call of GetWalletTransactions.
+func (mr *MockWalletServiceItfMockRecorder) GetWalletTransactions(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletTransactions", reflect.TypeOf((*MockWalletServiceItf)(nil).GetWalletTransactions), ctx, customerXID)
+}
+
+// GetWallets mocks base method.
+func (m *MockWalletServiceItf) GetWallets(ctx context.Context, customerXID string) ([]web.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallets", ctx, customerXID)
+	ret0, _ := ret[0].([]web.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallets indicates an expected call of GetWallets.
+func (mr *MockWalletServiceItfMockRecorder) GetWallets(ctx, customerXID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallets", reflect.TypeOf((*MockWalletServiceItf)(nil).GetWallets), ctx, customerXID)
+}
+
+// InitializeWallet mocks base method.
+func (m *MockWalletServiceItf) InitializeWallet(ctx context.Context, request web.WalletCreateRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitializeWallet", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InitializeWallet indicates an expected call of InitializeWallet.
+func (mr *MockWalletServiceItfMockRecorder) InitializeWallet(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeWallet", reflect.TypeOf((*MockWalletServiceItf)(nil).InitializeWallet), ctx, request)
+}
+
+// OpenCurrencyWallet mocks base method.
+func (m *MockWalletServiceItf) OpenCurrencyWallet(ctx context.Context, customerXID string, request web.CurrencyWalletRequest) (web.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCurrencyWallet", ctx, customerXID, request)
+	ret0, _ := ret[0].(web.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenCurrencyWallet indicates an expected call of OpenCurrencyWallet.
+func (mr *MockWalletServiceItfMockRecorder) OpenCurrencyWallet(ctx, customerXID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCurrencyWallet", reflect.TypeOf((*MockWalletServiceItf)(nil).OpenCurrencyWallet), ctx, customerXID, request)
+}
+
+// TransferBalance mocks base method.
+func (m *MockWalletServiceItf) TransferBalance(ctx context.Context, customerXID string, request web.TransferRequest) (web.TransferResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferBalance", ctx, customerXID, request)
+	ret0, _ := ret[0].(web.TransferResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferBalance indicates an expected call of TransferBalance.
+func (mr *MockWalletServiceItfMockRecorder) TransferBalance(ctx, customerXID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferBalance", reflect.TypeOf((*MockWalletServiceItf)(nil).TransferBalance), ctx, customerXID, request)
+}
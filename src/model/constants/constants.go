@@ -1,12 +1,185 @@
 package constants
 
 const (
-	STATUS_PENDING  = "pending"
-	STATUS_SUCCESS  = "success"
-	STATUS_FAILED   = "failed"
-	STATUS_ENABLED  = "enabled"
-	STATUS_DISABLED = "disabled"
+	STATUS_PENDING   = "pending"
+	STATUS_SUCCESS   = "success"
+	STATUS_FAILED    = "failed"
+	STATUS_ENABLED   = "enabled"
+	STATUS_DISABLED  = "disabled"
+	STATUS_EXECUTED  = "executed"
+	STATUS_ACTIVE    = "active"
+	STATUS_CLOSED    = "closed"
+	STATUS_PAUSED    = "paused"
+	STATUS_CANCELLED = "cancelled"
+	STATUS_COMPLETED = "completed"
+	STATUS_ACCEPTED  = "accepted"
+	STATUS_DECLINED  = "declined"
+	STATUS_EXPIRED   = "expired"
+	STATUS_REFUNDED  = "refunded"
+	STATUS_PARTIAL   = "partial"
+	STATUS_PAID      = "paid"
+	STATUS_UNUSED    = "unused"
+	STATUS_USED      = "used"
+	STATUS_SUBMITTED = "submitted"
+	STATUS_SUCCEEDED = "succeeded"
+	STATUS_OPEN      = "open"
+	STATUS_RESOLVED  = "resolved"
+	STATUS_FUNDED    = "funded"
+	STATUS_RELEASED  = "released"
+	STATUS_DISPUTED  = "disputed"
+	STATUS_SUSPENDED = "suspended"
+	STATUS_RUNNING   = "running"
+	STATUS_CARRIED   = "carried"
+	STATUS_RECOVERED = "recovered"
 
+	// a dispute is investigated and then resolved in favor of the customer
+	// or against them
+	STATUS_INVESTIGATING        = "investigating"
+	STATUS_RESOLVED_FAVORABLE   = "resolved_favorable"
+	STATUS_RESOLVED_UNFAVORABLE = "resolved_unfavorable"
+
+	// a transfer into a virtual account is a deposit referenced by
+	// "va-<bank code>-<bank reference>"
 	TRANSACTION_TYPE_DEPOSIT    = "deposit"
 	TRANSACTION_TYPE_WITHDRAWAL = "withdrawal"
+	// a payout the bank failed is credited back with the payout ID as
+	// reference_id
+	TRANSACTION_TYPE_WITHDRAWAL_REVERSAL = "withdrawal_reversal"
+	// a payout batch reserves its total with the batch ID as reference_id.
+	// Every row paid out releases its amount into a withdrawal of its own,
+	// both with the row ID as reference_id, rows cancelled are released back
+	// at once with the batch ID
+	TRANSACTION_TYPE_PAYOUT_BATCH_RESERVATION = "payout_batch_reservation"
+	TRANSACTION_TYPE_PAYOUT_BATCH_RELEASE     = "payout_batch_release"
+	// exchange legs share the quote ID as reference_id
+	TRANSACTION_TYPE_EXCHANGE_DEBIT  = "exchange_debit"
+	TRANSACTION_TYPE_EXCHANGE_CREDIT = "exchange_credit"
+	// pocket moves are recorded against the main balance
+	TRANSACTION_TYPE_POCKET_ALLOCATION = "pocket_allocation"
+	TRANSACTION_TYPE_POCKET_RELEASE    = "pocket_release"
+	// transfer legs share the sender's reference_id
+	TRANSACTION_TYPE_TRANSFER_OUT = "transfer_out"
+	TRANSACTION_TYPE_TRANSFER_IN  = "transfer_in"
+	// checkout debits and refunds use the payment intent ID as reference_id,
+	// merchants are paid out in settlement batches
+	TRANSACTION_TYPE_PAYMENT           = "payment"
+	TRANSACTION_TYPE_PAYMENT_REFUND    = "payment_refund"
+	TRANSACTION_TYPE_SETTLEMENT_PAYOUT = "settlement_payout"
+	// disbursements use the loan ID as reference_id, every installment
+	// collection gets its own
+	TRANSACTION_TYPE_LOAN_DISBURSEMENT = "loan_disbursement"
+	TRANSACTION_TYPE_LOAN_REPAYMENT    = "loan_repayment"
+	// charged daily on overdrawn wallets, one per wallet and day
+	TRANSACTION_TYPE_OVERDRAFT_INTEREST = "overdraft_interest"
+	// interest earned on the main balance and pockets, posted monthly
+	TRANSACTION_TYPE_INTEREST = "interest"
+	// escrow moves use the escrow ID as reference_id, an escrow is funded
+	// once and then either released to the seller or refunded to the buyer
+	TRANSACTION_TYPE_ESCROW_FUNDING = "escrow_funding"
+	TRANSACTION_TYPE_ESCROW_RELEASE = "escrow_release"
+	TRANSACTION_TYPE_ESCROW_REFUND  = "escrow_refund"
+	// a dispute resolved in favor of the customer credits back the disputed
+	// transaction with its ID as reference_id
+	TRANSACTION_TYPE_DISPUTE_REVERSAL = "dispute_reversal"
+	// cashback uses the ID of the transaction that earned it as reference_id
+	TRANSACTION_TYPE_CASHBACK = "cashback"
+	// voucher credits use the redemption ID as reference_id
+	TRANSACTION_TYPE_VOUCHER = "voucher"
+	// points redeemed into the wallet use the redeem entry ID as reference_id
+	TRANSACTION_TYPE_POINTS_REDEMPTION = "points_redemption"
+
+	POINTS_ENTRY_EARN   = "earn"
+	POINTS_ENTRY_REDEEM = "redeem"
+	POINTS_ENTRY_EXPIRE = "expire"
+	// points earned on a refunded payment are taken back with the payment
+	// transaction ID as reference_id
+	POINTS_ENTRY_EARN_REVERSAL = "earn_reversal"
+
+	SCHEDULE_TYPE_WITHDRAWAL = "withdrawal"
+	SCHEDULE_TYPE_TRANSFER   = "transfer"
+
+	FREQUENCY_ONCE    = "once"
+	FREQUENCY_DAILY   = "daily"
+	FREQUENCY_WEEKLY  = "weekly"
+	FREQUENCY_MONTHLY = "monthly"
+
+	SPLIT_TYPE_EQUAL  = "equal"
+	SPLIT_TYPE_CUSTOM = "custom"
+
+	// what happens to shares already paid when a split bill expires unsettled
+	EXPIRY_POLICY_KEEP   = "keep"
+	EXPIRY_POLICY_REFUND = "refund"
+
+	// merchant category code used when a merchant does not pick one
+	DEFAULT_MERCHANT_CATEGORY_CODE = "5999"
+
+	// identifies this wallet inside the merchant account field of QR payloads
+	QR_GLOBALLY_UNIQUE_ID = "ID.MINIWALLET.WWW"
+	QR_COUNTRY_CODE       = "ID"
+	QR_TYPE_STATIC        = "static"
+	QR_TYPE_DYNAMIC       = "dynamic"
+
+	// company code in front of every virtual account number
+	VIRTUAL_ACCOUNT_PREFIX = "8808"
+	VIRTUAL_ACCOUNT_LENGTH = 16
+
+	// which way money moves on a statement
+	DIRECTION_CREDIT = "credit"
+	DIRECTION_DEBIT  = "debit"
+
+	STATEMENT_FORMAT_CSV   = "csv"
+	STATEMENT_FORMAT_MT940 = "mt940"
+	STATEMENT_FORMAT_JSON  = "json"
+	STATEMENT_FORMAT_PDF   = "pdf"
+
+	// payout batches are uploaded as either
+	BATCH_FORMAT_CSV  = "csv"
+	BATCH_FORMAT_JSON = "json"
+
+	// who moved an escrow on, besides its buyer and seller
+	ESCROW_ACTOR_ADMIN  = "admin"
+	ESCROW_ACTOR_SYSTEM = "system"
+
+	// how a statement line got matched to a transaction
+	MATCH_TYPE_AUTO   = "auto"
+	MATCH_TYPE_MANUAL = "manual"
+
+	CURRENCY_IDR = "IDR"
+	CURRENCY_USD = "USD"
+	CURRENCY_SGD = "SGD"
+	CURRENCY_EUR = "EUR"
+	CURRENCY_JPY = "JPY"
+
+	DEFAULT_CURRENCY = CURRENCY_IDR
 )
+
+// CreditTransactionTypes are the transaction types that add to a wallet
+// balance, every other type takes from it.
+var CreditTransactionTypes = map[string]bool{
+	TRANSACTION_TYPE_DEPOSIT:              true,
+	TRANSACTION_TYPE_WITHDRAWAL_REVERSAL:  true,
+	TRANSACTION_TYPE_PAYOUT_BATCH_RELEASE: true,
+	TRANSACTION_TYPE_EXCHANGE_CREDIT:      true,
+	TRANSACTION_TYPE_POCKET_RELEASE:       true,
+	TRANSACTION_TYPE_TRANSFER_IN:          true,
+	TRANSACTION_TYPE_PAYMENT_REFUND:       true,
+	TRANSACTION_TYPE_SETTLEMENT_PAYOUT:    true,
+	TRANSACTION_TYPE_LOAN_DISBURSEMENT:    true,
+	TRANSACTION_TYPE_ESCROW_RELEASE:       true,
+	TRANSACTION_TYPE_ESCROW_REFUND:        true,
+	TRANSACTION_TYPE_DISPUTE_REVERSAL:     true,
+	TRANSACTION_TYPE_INTEREST:             true,
+	TRANSACTION_TYPE_CASHBACK:             true,
+	TRANSACTION_TYPE_VOUCHER:              true,
+	TRANSACTION_TYPE_POINTS_REDEMPTION:    true,
+}
+
+// CurrencyMinorUnits maps every supported currency to the number of decimal
+// places its amounts are stored with.
+var CurrencyMinorUnits = map[string]int{
+	CURRENCY_IDR: 0,
+	CURRENCY_USD: 2,
+	CURRENCY_SGD: 2,
+	CURRENCY_EUR: 2,
+	CURRENCY_JPY: 0,
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// BalanceCheck compares the balance stored on a wallet with ComputedBalance,
+// what its successful transactions add up to.
+type BalanceCheck struct {
+	WalletID          string
+	CustomerXID       string
+	Balance           Money
+	ComputedBalance   Money
+	TransactionCount  int
+	LastTransactionAt *time.Time
+}
+
+// Drift is how far the stored balance is off, positive when the wallet holds
+// more than its transactions explain.
+func (c BalanceCheck) Drift() (Money, error) {
+	return c.Balance.Sub(c.ComputedBalance)
+}
+
+// BalanceIncident records a drift found by a balance check. The same drift
+// of a wallet is recorded once, however often it is found again.
+type BalanceIncident struct {
+	ID               string
+	WalletID         string
+	CustomerXID      string
+	Balance          Money
+	ComputedBalance  Money
+	Drift            Money
+	TransactionCount int
+	Status           string
+	DetectedAt       time.Time
+	ResolvedAt       *time.Time
+}
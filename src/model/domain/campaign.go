@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Campaign pays cashback on qualifying transactions between StartsAt and
+// EndsAt until its budget runs out.
+type Campaign struct {
+	ID   string
+	Name string
+	// TransactionType and MerchantID decide which transactions qualify, an
+	// empty MerchantID accepts payments to any merchant
+	TransactionType string
+	MerchantID      string
+	MinAmount       Money
+	CashbackRate    float64
+	// MaxCashback caps a single cashback, CustomerCap the total a customer
+	// earns over the campaign. Zero means no cap.
+	MaxCashback Money
+	CustomerCap Money
+	Budget      Money
+	BudgetUsed  Money
+	// HoldDays keeps the cashback pending for the clawback window, a
+	// transaction reversed meanwhile earns nothing
+	HoldDays  int
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Cashback is the reward a campaign gives for SourceTransactionID. A
+// transaction earns cashback from one campaign at most.
+type Cashback struct {
+	ID                  string
+	CampaignID          string
+	CustomerXID         string
+	WalletID            string
+	SourceTransactionID string
+	Amount              Money
+	Status              string
+	ReleaseAt           time.Time
+	TransactionID       string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTransactionReversed is returned when a disputed transaction already got
+// its money back some other way, crediting it again would pay it twice.
+var ErrTransactionReversed = errors.New("transaction already reversed")
+
+// Dispute is a customer's claim against one of their transactions. Amount
+// and TransactionType are copied from the disputed transaction, Evidence
+// holds whatever the customer sent to back the claim. A dispute resolved in
+// the customer's favor records the reversal credited back in ReversalID.
+type Dispute struct {
+	ID              string
+	TransactionID   string
+	WalletID        string
+	CustomerXID     string
+	TransactionType string
+	Amount          Money
+	Reason          string
+	Evidence        map[string]string
+	Status          string
+	ResolutionNote  string
+	ReversalID      string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	ResolvedAt      *time.Time
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Escrow holds money of a buyer for a seller. Funding takes Amount from the
+// buyer's balance, the escrow is then released to the seller or refunded to
+// the buyer. A disputed escrow waits for an admin to decide which, a funded
+// one is refunded once ExpiresAt passes.
+type Escrow struct {
+	ID             string
+	BuyerXID       string
+	BuyerWalletID  string
+	SellerXID      string
+	SellerWalletID string
+	Amount         Money
+	Description    string
+	Status         string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// EscrowEvent records one move of an escrow from FromStatus to ToStatus.
+// Actor is the customer_xid of the buyer or seller, or admin or system.
+type EscrowEvent struct {
+	ID         string
+	EscrowID   string
+	FromStatus string
+	ToStatus   string
+	Actor      string
+	Note       string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// FxRate is the price of one unit of BaseCurrency in QuoteCurrency. Bid is
+// what we pay when buying BaseCurrency from a customer, Ask is what we charge
+// when selling it.
+type FxRate struct {
+	BaseCurrency  string
+	QuoteCurrency string
+	Bid           float64
+	Ask           float64
+	UpdatedAt     time.Time
+}
+
+type FxQuote struct {
+	ID           string
+	CustomerXID  string
+	FromCurrency string
+	ToCurrency   string
+	Rate         float64
+	SourceAmount Money
+	TargetAmount Money
+	Status       string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
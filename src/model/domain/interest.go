@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+)
+
+// InterestAccrual is one day of interest earned on the main balance of a
+// wallet or on one of its pockets. Amount keeps the interest in minor units
+// to six decimal places, it is only rounded when the accruals are posted.
+type InterestAccrual struct {
+	ID       string
+	WalletID string
+	// PocketID is empty for interest earned on the main balance
+	PocketID      string
+	AccrualDate   time.Time
+	Period        string
+	Balance       Money
+	Rate          float64
+	Amount        *big.Rat
+	TransactionID string
+	// CarriedFrom is the interest transaction that left this fraction of a
+	// minor unit behind when it was rounded, empty for a daily accrual
+	CarriedFrom string
+	CreatedAt   time.Time
+}
+
+// InterestPosting is the sum of the accruals of a wallet, or of one of its
+// pockets, not yet paid out, up to and including Period.
+type InterestPosting struct {
+	WalletID string
+	// PocketID is empty for interest earned on the main balance
+	PocketID    string
+	CustomerXID string
+	Currency    string
+	Period      string
+	Amount      *big.Rat
+}
+
+// BalanceHistory is a balance together with the successful transactions that
+// moved it from some point in time on, enough to tell what the balance was
+// at any moment since.
+type BalanceHistory struct {
+	Balance   Money
+	Movements []BalanceMovement
+}
+
+// BalanceMovement is what one transaction added to a balance, negative for a
+// debit.
+type BalanceMovement struct {
+	Amount    int64
+	CreatedAt time.Time
+}
+
+// BalanceAt is the balance as it stood at, before the movements made from
+// then on.
+func (h BalanceHistory) BalanceAt(at time.Time) (Money, error) {
+	result := h.Balance
+	for _, movement := range h.Movements {
+		if movement.CreatedAt.Before(at) {
+			continue
+		}
+
+		var err error
+		result, err = result.Sub(NewMoney(movement.Amount, h.Balance.Currency))
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+)
+
+// Loan is credit disbursed into the customer's wallet. It is repaid through
+// installments that are auto-debited from the same wallet on their due date.
+type Loan struct {
+	ID          string
+	CustomerXID string
+	WalletID    string
+	// ReferenceID is the lender's own reference, a disbursement is only ever
+	// made once for it
+	ReferenceID      string
+	Principal        Money
+	InterestAmount   Money
+	LateFee          Money
+	InstallmentCount int
+	Frequency        string
+	Status           string
+	TransactionID    string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Installments splits principal and interest evenly over the installments,
+// the first falling due at firstDueAt and the rest following the loan
+// frequency.
+func (l Loan) Installments(firstDueAt time.Time) ([]LoanInstallment, error) {
+	total, err := l.Principal.Add(l.InterestAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := EqualShares(total, l.InstallmentCount)
+	result := make([]LoanInstallment, len(shares))
+	for i := range shares {
+		dueAt := l.dueAt(firstDueAt, i)
+		result[i] = LoanInstallment{
+			LoanID:        l.ID,
+			Sequence:      i + 1,
+			DueAt:         dueAt,
+			Amount:        shares[i],
+			LateFee:       NewMoney(0, total.Currency),
+			PaidAmount:    NewMoney(0, total.Currency),
+			Status:        constants.STATUS_PENDING,
+			NextCollectAt: dueAt,
+		}
+	}
+	return result, nil
+}
+
+func (l Loan) dueAt(firstDueAt time.Time, index int) time.Time {
+	if l.Frequency == constants.FREQUENCY_WEEKLY {
+		return firstDueAt.AddDate(0, 0, 7*index)
+	}
+	return monthlyRunAt(firstDueAt.Year(), firstDueAt.Month()+time.Month(index), firstDueAt.Day(), firstDueAt)
+}
+
+type LoanInstallment struct {
+	ID         string
+	LoanID     string
+	Sequence   int
+	DueAt      time.Time
+	Amount     Money
+	LateFee    Money
+	PaidAmount Money
+	Status     string
+	// NextCollectAt is when the wallet is debited next, it moves a day ahead
+	// after every collection that leaves the installment unpaid
+	NextCollectAt time.Time
+	PaidAt        *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Outstanding is what is still owed on the installment, late fees included.
+func (i LoanInstallment) Outstanding() (Money, error) {
+	total, err := i.Amount.Add(i.LateFee)
+	if err != nil {
+		return Money{}, err
+	}
+	return total.Sub(i.PaidAmount)
+}
+
+// CollectionReferenceID is derived from the installment and the collection
+// it belongs to, so retrying the same collection can never debit twice.
+func (i LoanInstallment) CollectionReferenceID() string {
+	return fmt.Sprintf("loan-%s-%s", i.ID, i.NextCollectAt.UTC().Format("20060102T150405"))
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// PointsEntry is one movement on the loyalty points ledger of a customer,
+// kept apart from the money ledger. Earned points form lots that are spent
+// and expire oldest first, Remaining is what is left of a lot.
+type PointsEntry struct {
+	ID          string
+	CustomerXID string
+	EntryType   string
+	// Points is positive when earned and negative when redeemed or expired
+	Points      int64
+	Remaining   int64
+	ReferenceID string
+	ExpiresAt   *time.Time
+	// TransactionID is the wallet credit of a redemption
+	TransactionID string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// Merchant is the business profile of a customer. Payments to the merchant
+// settle into the wallets of CustomerXID.
+type Merchant struct {
+	ID           string
+	CustomerXID  string
+	Name         string
+	City         string
+	CategoryCode string
+	Status       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// PaymentIntent is a checkout created by a merchant and confirmed by the
+// paying customer. MerchantReference is the merchant's own order ID.
+type PaymentIntent struct {
+	ID                string
+	MerchantID        string
+	MerchantReference string
+	Amount            Money
+	Description       string
+	Status            string
+	CustomerXID       string
+	TransactionID     string
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
@@ -0,0 +1,283 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+func TestMoneyAdd(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		money      domain.Money
+		other      domain.Money
+		wantErr    error
+		wantResult domain.Money
+	}{
+		{
+			testID:     1,
+			testDesc:   "Success - add",
+			money:      domain.NewMoney(1000, "IDR"),
+			other:      domain.NewMoney(-250, "IDR"),
+			wantErr:    nil,
+			wantResult: domain.NewMoney(750, "IDR"),
+		},
+		{
+			testID:     2,
+			testDesc:   "Success - add up to the maximum",
+			money:      domain.NewMoney(math.MaxInt64-1, "IDR"),
+			other:      domain.NewMoney(1, "IDR"),
+			wantErr:    nil,
+			wantResult: domain.NewMoney(math.MaxInt64, "IDR"),
+		},
+		{
+			testID:     3,
+			testDesc:   "Failed - overflow",
+			money:      domain.NewMoney(math.MaxInt64, "IDR"),
+			other:      domain.NewMoney(1, "IDR"),
+			wantErr:    domain.ErrAmountOverflow,
+			wantResult: domain.Money{},
+		},
+		{
+			testID:     4,
+			testDesc:   "Failed - underflow",
+			money:      domain.NewMoney(math.MinInt64, "IDR"),
+			other:      domain.NewMoney(-1, "IDR"),
+			wantErr:    domain.ErrAmountOverflow,
+			wantResult: domain.Money{},
+		},
+		{
+			testID:     5,
+			testDesc:   "Failed - currency mismatch",
+			money:      domain.NewMoney(1000, "IDR"),
+			other:      domain.NewMoney(1000, "USD"),
+			wantErr:    domain.ErrCurrencyMismatch,
+			wantResult: domain.Money{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			got, err := tc.money.Add(tc.other)
+			assert.Equal(t, err, tc.wantErr)
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
+
+func TestMoneySub(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		money      domain.Money
+		other      domain.Money
+		wantErr    error
+		wantResult domain.Money
+	}{
+		{
+			testID:     1,
+			testDesc:   "Success - sub below zero",
+			money:      domain.NewMoney(1000, "IDR"),
+			other:      domain.NewMoney(2500, "IDR"),
+			wantErr:    nil,
+			wantResult: domain.NewMoney(-1500, "IDR"),
+		},
+		{
+			testID:     2,
+			testDesc:   "Success - sub down to the minimum",
+			money:      domain.NewMoney(math.MinInt64+1, "IDR"),
+			other:      domain.NewMoney(1, "IDR"),
+			wantErr:    nil,
+			wantResult: domain.NewMoney(math.MinInt64, "IDR"),
+		},
+		{
+			testID:     3,
+			testDesc:   "Failed - underflow",
+			money:      domain.NewMoney(math.MinInt64, "IDR"),
+			other:      domain.NewMoney(1, "IDR"),
+			wantErr:    domain.ErrAmountOverflow,
+			wantResult: domain.Money{},
+		},
+		{
+			testID:     4,
+			testDesc:   "Failed - overflow by subtracting a negative",
+			money:      domain.NewMoney(math.MaxInt64, "IDR"),
+			other:      domain.NewMoney(-1, "IDR"),
+			wantErr:    domain.ErrAmountOverflow,
+			wantResult: domain.Money{},
+		},
+		{
+			testID:     5,
+			testDesc:   "Failed - currency mismatch",
+			money:      domain.NewMoney(1000, "IDR"),
+			other:      domain.NewMoney(1000, "USD"),
+			wantErr:    domain.ErrCurrencyMismatch,
+			wantResult: domain.Money{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			got, err := tc.money.Sub(tc.other)
+			assert.Equal(t, err, tc.wantErr)
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
+
+func TestMoneyCmp(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		money      domain.Money
+		other      domain.Money
+		wantErr    error
+		wantResult int
+	}{
+		{
+			testID:     1,
+			testDesc:   "Success - less",
+			money:      domain.NewMoney(math.MinInt64, "IDR"),
+			other:      domain.NewMoney(math.MaxInt64, "IDR"),
+			wantErr:    nil,
+			wantResult: -1,
+		},
+		{
+			testID:     2,
+			testDesc:   "Success - equal",
+			money:      domain.NewMoney(1000, "USD"),
+			other:      domain.NewMoney(1000, "USD"),
+			wantErr:    nil,
+			wantResult: 0,
+		},
+		{
+			testID:     3,
+			testDesc:   "Success - greater",
+			money:      domain.NewMoney(1000, "IDR"),
+			other:      domain.NewMoney(-1000, "IDR"),
+			wantErr:    nil,
+			wantResult: 1,
+		},
+		{
+			testID:     4,
+			testDesc:   "Failed - currency mismatch",
+			money:      domain.NewMoney(1000, "IDR"),
+			other:      domain.NewMoney(1000, "USD"),
+			wantErr:    domain.ErrCurrencyMismatch,
+			wantResult: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			got, err := tc.money.Cmp(tc.other)
+			assert.Equal(t, err, tc.wantErr)
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		money      domain.Money
+		wantResult string
+	}{
+		{
+			testID:     1,
+			testDesc:   "Success - currency without decimals",
+			money:      domain.NewMoney(150000, "IDR"),
+			wantResult: "150000",
+		},
+		{
+			testID:     2,
+			testDesc:   "Success - currency with decimals",
+			money:      domain.NewMoney(-1250, "USD"),
+			wantResult: "-12.50",
+		},
+		{
+			testID:     3,
+			testDesc:   "Success - less than one major unit",
+			money:      domain.NewMoney(5, "USD"),
+			wantResult: "0.05",
+		},
+		{
+			testID:     4,
+			testDesc:   "Success - minimum amount",
+			money:      domain.NewMoney(math.MinInt64, "IDR"),
+			wantResult: "-9223372036854775808",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			assert.Equal(t, tc.money.Decimal(), tc.wantResult)
+		})
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		src        interface{}
+		wantErr    bool
+		wantResult int64
+	}{
+		{
+			testID:     1,
+			testDesc:   "Success - int64",
+			src:        int64(math.MaxInt64),
+			wantErr:    false,
+			wantResult: math.MaxInt64,
+		},
+		{
+			testID:     2,
+			testDesc:   "Success - bytes",
+			src:        []byte("-2500"),
+			wantErr:    false,
+			wantResult: -2500,
+		},
+		{
+			testID:     3,
+			testDesc:   "Success - null",
+			src:        nil,
+			wantErr:    false,
+			wantResult: 0,
+		},
+		{
+			testID:     4,
+			testDesc:   "Failed - bytes out of range",
+			src:        []byte("9223372036854775808"),
+			wantErr:    true,
+			wantResult: 0,
+		},
+		{
+			testID:     5,
+			testDesc:   "Failed - unsupported type",
+			src:        "2500",
+			wantErr:    true,
+			wantResult: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			money := domain.NewMoney(0, "IDR")
+			err := money.Scan(tc.src)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, money, domain.NewMoney(tc.wantResult, "IDR"))
+		})
+	}
+}
+
+func TestMoneyValue(t *testing.T) {
+	got, err := domain.NewMoney(-1250, "USD").Value()
+	assert.Nil(t, err)
+	assert.Equal(t, got, int64(-1250))
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// PaymentRequest is a request from RequesterXID asking PayerXID to transfer
+// Amount. Accepting it executes a regular wallet transfer.
+type PaymentRequest struct {
+	ID            string
+	SplitBillID   string
+	RequesterXID  string
+	PayerXID      string
+	Amount        Money
+	Note          string
+	Status        string
+	TransactionID string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ReferenceID is the reference_id of the transfer that settles the request.
+func (p PaymentRequest) ReferenceID() string {
+	return "payreq-" + p.ID
+}
+
+// RefundReferenceID is the reference_id of the transfer that pays the request
+// back to the payer.
+func (p PaymentRequest) RefundReferenceID() string {
+	return "payreq-refund-" + p.ID
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// BankAccount is the account a withdrawal is paid out to.
+type BankAccount struct {
+	BankCode      string
+	AccountNumber string
+	AccountName   string
+}
+
+// Beneficiary is a bank account a customer saved or withdrew to, kept once
+// per customer, bank and account number. It is verified once a name
+// inquiry confirmed the holder name, only then can withdrawals refer to it.
+type Beneficiary struct {
+	ID          string
+	CustomerXID string
+	BankAccount BankAccount
+	VerifiedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Payout sends a withdrawal to a bank account. The wallet is debited when
+// the payout is created, it is pending until the provider accepts it,
+// submitted until the bank settles it, then succeeded or failed. A failed
+// payout is credited back to the wallet.
+type Payout struct {
+	ID                string
+	WalletID          string
+	CustomerXID       string
+	BeneficiaryID     string
+	TransactionID     string
+	BankAccount       BankAccount
+	Amount            Money
+	ProviderReference string
+	Status            string
+	FailureReason     string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// PayoutBatch pays many bank accounts out of one wallet. Its total is
+// reserved when the batch is created and every row is then paid out as a
+// payout of its own. Reference is the customer's key for the batch, the
+// same reference is never reserved twice.
+type PayoutBatch struct {
+	ID          string
+	WalletID    string
+	CustomerXID string
+	Reference   string
+	TotalAmount Money
+	RowCount    int
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PayoutBatchItem is one row of a payout batch. It is pending until it is
+// paid out, a row that could not be paid is failed until it is retried or
+// cancelled. PayoutStatus follows the payout once the row is paid.
+type PayoutBatchItem struct {
+	ID            string
+	BatchID       string
+	LineNumber    int
+	BankAccount   BankAccount
+	Amount        Money
+	ReferenceID   string
+	PayoutID      string
+	PayoutStatus  string
+	Status        string
+	FailureReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Pocket is a named sub-balance of a wallet. Money in a pocket is not part of
+// Wallet.Balance, so withdrawals can only spend the main balance.
+type Pocket struct {
+	ID           string
+	WalletID     string
+	Name         string
+	TargetAmount Money
+	Balance      Money
+	Status       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+)
+
+type Schedule struct {
+	ID           string
+	CustomerXID  string
+	ScheduleType string
+	RecipientXID string
+	BankAccount  BankAccount
+	Amount       Money
+	Frequency    string
+	DayOfMonth   int
+	NextRunAt    time.Time
+	Status       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ReferenceID is derived from the schedule and the occurrence it executes, so
+// retrying the same run can never post a second transaction.
+func (s Schedule) ReferenceID(runAt time.Time) string {
+	return fmt.Sprintf("sched-%s-%s", s.ID, runAt.UTC().Format("20060102T150405"))
+}
+
+// FirstRunAt returns the first occurrence at or after start. Monthly
+// schedules move to their day of month, keeping the time of day of start.
+func (s Schedule) FirstRunAt(start time.Time) time.Time {
+	if s.Frequency != constants.FREQUENCY_MONTHLY {
+		return start
+	}
+
+	runAt := monthlyRunAt(start.Year(), start.Month(), s.DayOfMonth, start)
+	if runAt.Before(start) {
+		runAt = monthlyRunAt(start.Year(), start.Month()+1, s.DayOfMonth, start)
+	}
+	return runAt
+}
+
+// NextRunAfter returns the occurrence following runAt. One-off schedules have
+// no next occurrence and return the zero time.
+func (s Schedule) NextRunAfter(runAt time.Time) time.Time {
+	switch s.Frequency {
+	case constants.FREQUENCY_DAILY:
+		return runAt.AddDate(0, 0, 1)
+	case constants.FREQUENCY_WEEKLY:
+		return runAt.AddDate(0, 0, 7)
+	case constants.FREQUENCY_MONTHLY:
+		return monthlyRunAt(runAt.Year(), runAt.Month()+1, s.DayOfMonth, runAt)
+	}
+	return time.Time{}
+}
+
+// monthlyRunAt clamps day to the length of the month, so a schedule on the
+// 31st runs on the last day of shorter months.
+func monthlyRunAt(year int, month time.Month, day int, clock time.Time) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, clock.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), clock.Second(), 0, clock.Location())
+}
+
+type ScheduleRun struct {
+	ID            string
+	ScheduleID    string
+	RunAt         time.Time
+	ReferenceID   string
+	TransactionID string
+	Status        string
+	ErrorMessage  string
+	CreatedAt     time.Time
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Settlement pays a merchant every payment made up to CutoffAt that was not
+// settled before, less fees and refunds, in one payout transaction. When
+// refunds leave nothing to pay, the negative NetAmount is carried: it is
+// stored without a payout and taken out of the merchant's next settlement in
+// the same currency, which records it as CarriedAmount.
+type Settlement struct {
+	ID            string
+	MerchantID    string
+	CutoffAt      time.Time
+	GrossAmount   Money
+	FeeAmount     Money
+	RefundAmount  Money
+	CarriedAmount Money
+	CarriedFromID string
+	NetAmount     Money
+	TransactionID string
+	Status        string
+	CreatedAt     time.Time
+}
+
+// ReferenceID is the reference_id of the payout. It is derived from the
+// batch so a rerun of the same cutoff can never pay out twice.
+func (s Settlement) ReferenceID() string {
+	return fmt.Sprintf("settle-%s-%s-%s", s.MerchantID, s.NetAmount.Currency, s.CutoffAt.UTC().Format("20060102T150405"))
+}
+
+// SettlementItem is one payment or refund transaction included in a
+// settlement.
+type SettlementItem struct {
+	SettlementID    string
+	TransactionID   string
+	PaymentIntentID string
+	TransactionType string
+	Amount          Money
+	Fee             Money
+	TransactedAt    time.Time
+}
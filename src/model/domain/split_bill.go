@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrSplitBillClosed is returned when a share is paid after its split bill
+// was completed, cancelled or expired.
+var ErrSplitBillClosed = errors.New("split bill is no longer pending")
+
+// SplitBill divides TotalAmount among participants. Every share is a
+// PaymentRequest carrying the bill ID, so participants pay it like any other
+// request.
+type SplitBill struct {
+	ID           string
+	CreatorXID   string
+	Title        string
+	TotalAmount  Money
+	SplitType    string
+	ExpiryPolicy string
+	Status       string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// EqualShares divides total into n shares. The remainder that cannot be
+// divided is spread one minor unit at a time over the first shares, so the
+// shares always add up to total.
+func EqualShares(total Money, n int) []Money {
+	shares := make([]Money, n)
+	if n <= 0 {
+		return shares
+	}
+
+	quotient := total.Amount / int64(n)
+	remainder := total.Amount % int64(n)
+	for i := range shares {
+		shares[i] = NewMoney(quotient, total.Currency)
+		if int64(i) < remainder {
+			shares[i].Amount++
+		}
+	}
+	return shares
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// StatementImport is a bank statement file loaded for reconciliation. A file
+// is recognised by its hash, so the same statement is imported once per
+// bank. The period spans the value dates of its lines.
+type StatementImport struct {
+	ID          string
+	BankCode    string
+	Format      string
+	FileName    string
+	FileHash    string
+	Currency    string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	LineCount   int
+	CreatedAt   time.Time
+}
+
+// StatementLine is one booking of an imported statement. TransactionID,
+// MatchType and MatchedAt are set once the line is matched to a transaction,
+// a transaction is matched to at most one line.
+type StatementLine struct {
+	ID            string
+	ImportID      string
+	LineNumber    int
+	ValueDate     time.Time
+	Direction     string
+	Amount        Money
+	Reference     string
+	Description   string
+	TransactionID string
+	MatchType     string
+	MatchedAt     *time.Time
+	CreatedAt     time.Time
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// VirtualAccount is a bank account number that belongs to one wallet, money
+// a bank receives on it is credited to the wallet through a callback.
+type VirtualAccount struct {
+	ID          string
+	WalletID    string
+	CustomerXID string
+	BankCode    string
+	Number      string
+	Currency    string
+	CreatedAt   time.Time
+}
+
+// VirtualAccountPayment is a transfer a bank reported on a virtual account.
+// The bank reference is unique per bank, so a callback delivered twice
+// credits once.
+type VirtualAccountPayment struct {
+	ID               string
+	VirtualAccountID string
+	WalletID         string
+	BankCode         string
+	BankReference    string
+	Amount           Money
+	TransactionID    string
+	PaidAt           time.Time
+	CreatedAt        time.Time
+}
+
+// ReferenceID is the reference_id of the deposit the payment credits.
+func (p VirtualAccountPayment) ReferenceID() string {
+	return fmt.Sprintf("va-%s-%s", p.BankCode, p.BankReference)
+}
+
+// NewVirtualAccountNumber returns prefix followed by random digits up to
+// length.
+func NewVirtualAccountNumber(prefix string, length int) (string, error) {
+	digits := make([]byte, length-len(prefix))
+	for i := range digits {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + n.Int64())
+	}
+	return prefix + string(digits), nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+)
+
+// voucherAlphabet leaves out characters that are easily confused when a
+// code is typed, such as 0 and O or 1 and I.
+const voucherAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// VoucherBatch is a set of codes generated together. Every code credits
+// Value, can be redeemed MaxUses times in total and a customer may redeem
+// at most PerCustomerLimit codes of the batch.
+type VoucherBatch struct {
+	ID               string
+	Name             string
+	Value            Money
+	CodeCount        int
+	MaxUses          int
+	PerCustomerLimit int
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type Voucher struct {
+	ID        string
+	BatchID   string
+	Code      string
+	UsedCount int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type VoucherRedemption struct {
+	ID            string
+	BatchID       string
+	VoucherID     string
+	CustomerXID   string
+	WalletID      string
+	Amount        Money
+	TransactionID string
+	CreatedAt     time.Time
+}
+
+// NewVoucherCode returns a random code of length characters behind prefix.
+func NewVoucherCode(prefix string, length int) (string, error) {
+	code := make([]byte, length)
+	max := big.NewInt(int64(len(voucherAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = voucherAlphabet[n.Int64()]
+	}
+	return prefix + string(code), nil
+}
@@ -5,21 +5,46 @@ import "time"
 type Wallet struct {
 	ID          string
 	CustomerXID string
+	Currency    string
 	Status      string
 	EnabledAt   *time.Time
-	Balance     int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Balance     Money
+	// CreditLimit is how far below zero Balance may go on withdrawals
+	CreditLimit Money
+	// CreditUsed is the overdraft currently drawn. It is stored alongside
+	// Balance and kept equal to the part of it below zero.
+	CreditUsed Money
+	// InterestRate is the annual rate paid on a positive Balance
+	InterestRate float64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 type Transaction struct {
-	ID              string
-	WalletID        string
-	CustomerXID     string
+	ID          string
+	WalletID    string
+	CustomerXID string
+	// InitiatedBy is the customer_xid of the shared wallet member that made
+	// the transaction, empty when it was the wallet owner or the system
+	InitiatedBy string
+	// PocketID is the pocket a pocket allocation or release moved money in
+	// or out of, empty for every other transaction
+	PocketID        string
 	TransactionType string
-	Amount          int
+	Amount          Money
 	ReferenceID     string
 	Status          string
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
+
+// TransferGuard lists what must still hold when a transfer posts. It is
+// checked inside the database transaction that moves the money.
+type TransferGuard struct {
+	// SplitBillID is the split bill the transfer pays a share of, which
+	// must still be pending
+	SplitBillID string
+	// RefundedTransactionID is the transaction the transfer pays back, which
+	// no dispute may have paid back already
+	RefundedTransactionID string
+}
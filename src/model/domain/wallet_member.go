@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// WalletMember lets a customer other than the owner spend from a shared
+// wallet, limited to AllowedTypes of transactions. SpendingLimit caps what
+// the member spends per calendar month, zero leaves it uncapped. Spent is
+// what the member spent in SpentPeriod, formatted as YYYY-MM.
+type WalletMember struct {
+	ID            string
+	WalletID      string
+	OwnerXID      string
+	MemberXID     string
+	SpendingLimit Money
+	AllowedTypes  []string
+	Status        string
+	Spent         Money
+	SpentPeriod   string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Allows reports whether the member may make transactions of transactionType.
+func (m WalletMember) Allows(transactionType string) bool {
+	for i := range m.AllowedTypes {
+		if m.AllowedTypes[i] == transactionType {
+			return true
+		}
+	}
+	return false
+}
+
+// SpendingPeriod is the calendar month member spending limits apply to, in
+// the time zone of the server.
+func SpendingPeriod(at time.Time) string {
+	return at.Local().Format("2006-01")
+}
+
+// SpentIn returns what the member spent in period, nothing when the last
+// spending was in an earlier one.
+func (m WalletMember) SpentIn(period string) Money {
+	if m.SpentPeriod != period {
+		return NewMoney(0, m.Spent.Currency)
+	}
+	return m.Spent
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// AccountStatementRequest covers the transactions made from From up to, but
+// not including, To.
+type AccountStatementRequest struct {
+	Currency string    `json:"currency" validate:"omitempty,len=3"`
+	From     time.Time `json:"from" validate:"required"`
+	To       time.Time `json:"to" validate:"required,gtfield=From"`
+	Format   string    `json:"format" validate:"omitempty,oneof=json csv pdf"`
+}
+
+type AccountStatementResponse struct {
+	WalletID       string                          `json:"wallet_id"`
+	CustomerXID    string                          `json:"customer_xid"`
+	Currency       string                          `json:"currency"`
+	From           time.Time                       `json:"from"`
+	To             time.Time                       `json:"to"`
+	OpeningBalance domain.Money                    `json:"opening_balance"`
+	TotalCredits   domain.Money                    `json:"total_credits"`
+	TotalDebits    domain.Money                    `json:"total_debits"`
+	ClosingBalance domain.Money                    `json:"closing_balance"`
+	Entries        []AccountStatementEntryResponse `json:"entries"`
+	GeneratedAt    time.Time                       `json:"generated_at"`
+}
+
+// AccountStatementEntryResponse is a transaction of the statement, Balance
+// is the running balance right after it.
+type AccountStatementEntryResponse struct {
+	ID           string       `json:"id"`
+	TransactedAt time.Time    `json:"transacted_at"`
+	Type         string       `json:"type"`
+	ReferenceID  string       `json:"reference_id"`
+	Direction    string       `json:"direction"`
+	Amount       domain.Money `json:"amount"`
+	Balance      domain.Money `json:"balance"`
+}
+
+// AccountStatementFile is a statement rendered for download.
+type AccountStatementFile struct {
+	ContentType string
+	FileName    string
+	Data        []byte
+}
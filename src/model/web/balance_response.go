@@ -0,0 +1,41 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type BalanceDriftResponse struct {
+	WalletID          string       `json:"wallet_id"`
+	CustomerXID       string       `json:"customer_xid"`
+	Currency          string       `json:"currency"`
+	Balance           domain.Money `json:"balance"`
+	ComputedBalance   domain.Money `json:"computed_balance"`
+	Drift             domain.Money `json:"drift"`
+	TransactionCount  int          `json:"transaction_count"`
+	LastTransactionAt *time.Time   `json:"last_transaction_at"`
+	// IncidentID is set when the check opened an incident for the drift
+	IncidentID string `json:"incident_id,omitempty"`
+}
+
+type BalanceCheckResponse struct {
+	WalletCount int       `json:"wallet_count"`
+	DriftCount  int       `json:"drift_count"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+}
+
+type BalanceIncidentResponse struct {
+	ID               string       `json:"id"`
+	WalletID         string       `json:"wallet_id"`
+	CustomerXID      string       `json:"customer_xid"`
+	Currency         string       `json:"currency"`
+	Balance          domain.Money `json:"balance"`
+	ComputedBalance  domain.Money `json:"computed_balance"`
+	Drift            domain.Money `json:"drift"`
+	TransactionCount int          `json:"transaction_count"`
+	Status           string       `json:"status"`
+	DetectedAt       time.Time    `json:"detected_at"`
+	ResolvedAt       *time.Time   `json:"resolved_at"`
+}
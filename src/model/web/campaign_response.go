@@ -0,0 +1,53 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type CampaignCreateRequest struct {
+	Name            string  `json:"name" validate:"required,max=100"`
+	TransactionType string  `json:"transaction_type" validate:"required,oneof=payment transfer_out"`
+	MerchantID      string  `json:"merchant_id" validate:"excluded_unless=TransactionType payment,max=36"`
+	Currency        string  `json:"currency" validate:"omitempty,len=3"`
+	MinAmount       int64   `json:"min_amount" validate:"min=0"`
+	CashbackRate    float64 `json:"cashback_rate" validate:"gt=0,max=1"`
+	// zero means no cap
+	MaxCashback int64     `json:"max_cashback" validate:"min=0"`
+	CustomerCap int64     `json:"customer_cap" validate:"min=0"`
+	Budget      int64     `json:"budget" validate:"required,min=1"`
+	HoldDays    int       `json:"hold_days" validate:"min=0,max=90"`
+	StartsAt    time.Time `json:"starts_at" validate:"required"`
+	EndsAt      time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+}
+
+type CampaignResponse struct {
+	ID              string       `json:"id"`
+	Name            string       `json:"name"`
+	TransactionType string       `json:"transaction_type"`
+	MerchantID      string       `json:"merchant_id,omitempty"`
+	Currency        string       `json:"currency"`
+	MinAmount       domain.Money `json:"min_amount"`
+	CashbackRate    float64      `json:"cashback_rate"`
+	MaxCashback     domain.Money `json:"max_cashback"`
+	CustomerCap     domain.Money `json:"customer_cap"`
+	Budget          domain.Money `json:"budget"`
+	BudgetUsed      domain.Money `json:"budget_used"`
+	HoldDays        int          `json:"hold_days"`
+	StartsAt        time.Time    `json:"starts_at"`
+	EndsAt          time.Time    `json:"ends_at"`
+	Status          string       `json:"status"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+type CashbackResponse struct {
+	ID                  string       `json:"id"`
+	CampaignID          string       `json:"campaign_id"`
+	SourceTransactionID string       `json:"source_transaction_id"`
+	Amount              domain.Money `json:"amount"`
+	Status              string       `json:"status"`
+	ReleaseAt           time.Time    `json:"release_at"`
+	TransactionID       string       `json:"transaction_id,omitempty"`
+	CreatedAt           time.Time    `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// DisputeCreateRequest disputes one of the customer's transactions. Evidence
+// is free-form, such as an order number or the link to a receipt.
+type DisputeCreateRequest struct {
+	TransactionID string            `json:"transaction_id" validate:"required,max=36"`
+	Reason        string            `json:"reason" validate:"required,max=255"`
+	Evidence      map[string]string `json:"evidence" validate:"max=20,dive,keys,required,max=64,endkeys,max=1024"`
+}
+
+// DisputeResolveRequest closes a dispute under investigation, a favorable
+// outcome credits the disputed amount back to the customer.
+type DisputeResolveRequest struct {
+	Outcome string `json:"outcome" validate:"required,oneof=favorable unfavorable"`
+	Note    string `json:"note" validate:"max=255"`
+}
+
+type DisputeResponse struct {
+	ID              string            `json:"id"`
+	TransactionID   string            `json:"transaction_id"`
+	CustomerXID     string            `json:"customer_xid"`
+	TransactionType string            `json:"transaction_type"`
+	Amount          domain.Money      `json:"amount"`
+	Reason          string            `json:"reason"`
+	Evidence        map[string]string `json:"evidence"`
+	Status          string            `json:"status"`
+	ResolutionNote  string            `json:"resolution_note,omitempty"`
+	ReversalID      string            `json:"reversal_id,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+	ResolvedAt      *time.Time        `json:"resolved_at"`
+}
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// EscrowCreateRequest funds an escrow for the seller, it is refunded when
+// it is neither released nor disputed by ExpiresAt. Without ExpiresAt the
+// escrow runs for two weeks.
+type EscrowCreateRequest struct {
+	SellerXID   string    `json:"seller_xid" validate:"required,max=36"`
+	Amount      int64     `json:"amount" validate:"required,min=1"`
+	Description string    `json:"description" validate:"max=255"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type EscrowDisputeRequest struct {
+	Reason string `json:"reason" validate:"required,max=255"`
+}
+
+// EscrowResolveRequest settles a disputed escrow, releasing it to the seller
+// or refunding it to the buyer.
+type EscrowResolveRequest struct {
+	Outcome string `json:"outcome" validate:"required,oneof=release refund"`
+	Note    string `json:"note" validate:"max=255"`
+}
+
+type EscrowResponse struct {
+	ID          string                `json:"id"`
+	BuyerXID    string                `json:"buyer_xid"`
+	SellerXID   string                `json:"seller_xid"`
+	Amount      domain.Money          `json:"amount"`
+	Description string                `json:"description"`
+	Status      string                `json:"status"`
+	ExpiresAt   time.Time             `json:"expires_at"`
+	Events      []EscrowEventResponse `json:"events,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+type EscrowEventResponse struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	Note       string    `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
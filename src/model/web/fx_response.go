@@ -0,0 +1,49 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type FxRateRequest struct {
+	BaseCurrency  string  `json:"base_currency" validate:"required,len=3"`
+	QuoteCurrency string  `json:"quote_currency" validate:"required,len=3,nefield=BaseCurrency"`
+	Bid           float64 `json:"bid" validate:"required,gt=0"`
+	Ask           float64 `json:"ask" validate:"required,gtefield=Bid"`
+}
+
+type FxRateResponse struct {
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Bid           float64   `json:"bid"`
+	Ask           float64   `json:"ask"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type FxQuoteRequest struct {
+	FromCurrency string `json:"from_currency" validate:"required,len=3"`
+	ToCurrency   string `json:"to_currency" validate:"required,len=3,nefield=FromCurrency"`
+	Amount       int64  `json:"amount" validate:"required,min=1"`
+}
+
+type FxQuoteResponse struct {
+	ID           string       `json:"id"`
+	FromCurrency string       `json:"from_currency"`
+	ToCurrency   string       `json:"to_currency"`
+	Rate         float64      `json:"rate"`
+	SourceAmount domain.Money `json:"source_amount"`
+	TargetAmount domain.Money `json:"target_amount"`
+	Status       string       `json:"status"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+}
+
+type FxExchangeRequest struct {
+	QuoteID string `json:"quote_id" validate:"required"`
+}
+
+type FxExchangeResponse struct {
+	QuoteID string              `json:"quote_id"`
+	Debit   TransactionResponse `json:"debit"`
+	Credit  TransactionResponse `json:"credit"`
+}
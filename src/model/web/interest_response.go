@@ -0,0 +1,39 @@
+package web
+
+import (
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type InterestRateRequest struct {
+	CustomerXID  string  `json:"customer_xid" validate:"required,max=36"`
+	Currency     string  `json:"currency" validate:"omitempty,len=3"`
+	InterestRate float64 `json:"interest_rate" validate:"min=0,max=1"`
+}
+
+type InterestRateResponse struct {
+	WalletID     string  `json:"wallet_id"`
+	Currency     string  `json:"currency"`
+	InterestRate float64 `json:"interest_rate"`
+}
+
+type InterestPreviewResponse struct {
+	WalletID string `json:"wallet_id"`
+	Period   string `json:"period"`
+	Currency string `json:"currency"`
+	// AccruedAmount is what accrued so far in Period, ExactAmount keeps the
+	// fraction of a minor unit rounded away from it
+	AccruedAmount domain.Money `json:"accrued_amount"`
+	ExactAmount   string       `json:"exact_amount"`
+	// CarriedAmount is left over from earlier periods and is paid together
+	// with the next posting
+	CarriedAmount string                    `json:"carried_amount"`
+	Accruals      []InterestAccrualResponse `json:"accruals"`
+}
+
+type InterestAccrualResponse struct {
+	Date     string       `json:"date"`
+	PocketID string       `json:"pocket_id,omitempty"`
+	Balance  domain.Money `json:"balance"`
+	Rate     float64      `json:"rate"`
+	Amount   string       `json:"amount"`
+}
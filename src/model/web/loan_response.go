@@ -0,0 +1,47 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type LoanCreateRequest struct {
+	CustomerXID      string    `json:"customer_xid" validate:"required,max=36"`
+	ReferenceID      string    `json:"reference_id" validate:"required,max=50"`
+	Principal        int64     `json:"principal" validate:"required,min=1"`
+	InterestRate     float64   `json:"interest_rate" validate:"min=0,max=1"`
+	LateFee          int64     `json:"late_fee" validate:"min=0"`
+	InstallmentCount int       `json:"installment_count" validate:"required,min=1,max=120"`
+	Frequency        string    `json:"frequency" validate:"required,oneof=weekly monthly"`
+	FirstDueAt       time.Time `json:"first_due_at" validate:"required"`
+}
+
+type LoanResponse struct {
+	ID                string                    `json:"id"`
+	CustomerXID       string                    `json:"customer_xid"`
+	ReferenceID       string                    `json:"reference_id"`
+	Principal         domain.Money              `json:"principal"`
+	InterestAmount    domain.Money              `json:"interest_amount"`
+	LateFee           domain.Money              `json:"late_fee"`
+	Currency          string                    `json:"currency"`
+	InstallmentCount  int                       `json:"installment_count"`
+	Frequency         string                    `json:"frequency"`
+	Status            string                    `json:"status"`
+	TransactionID     string                    `json:"transaction_id"`
+	OutstandingAmount domain.Money              `json:"outstanding_amount"`
+	Installments      []LoanInstallmentResponse `json:"installments"`
+	CreatedAt         time.Time                 `json:"created_at"`
+}
+
+type LoanInstallmentResponse struct {
+	ID            string       `json:"id"`
+	Sequence      int          `json:"sequence"`
+	DueAt         time.Time    `json:"due_at"`
+	Amount        domain.Money `json:"amount"`
+	LateFee       domain.Money `json:"late_fee"`
+	PaidAmount    domain.Money `json:"paid_amount"`
+	Status        string       `json:"status"`
+	NextCollectAt *time.Time   `json:"next_collect_at"`
+	PaidAt        *time.Time   `json:"paid_at"`
+}
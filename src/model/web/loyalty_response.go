@@ -0,0 +1,38 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PointsResponse struct {
+	Balance int64 `json:"balance"`
+	// RedeemValue is what the balance is worth in the wallet
+	RedeemValue    domain.Money `json:"redeem_value"`
+	ExpiringPoints int64        `json:"expiring_points"`
+	NextExpiryAt   *time.Time   `json:"next_expiry_at"`
+}
+
+type PointsEntryResponse struct {
+	ID            string     `json:"id"`
+	Type          string     `json:"type"`
+	Points        int64      `json:"points"`
+	ReferenceID   string     `json:"reference_id"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type PointsRedeemRequest struct {
+	Points int64 `json:"points" validate:"required,min=1"`
+}
+
+type PointsRedemptionResponse struct {
+	ID            string       `json:"id"`
+	Points        int64        `json:"points"`
+	Amount        domain.Money `json:"amount"`
+	TransactionID string       `json:"transaction_id"`
+	Balance       int64        `json:"balance"`
+	RedeemedAt    time.Time    `json:"redeemed_at"`
+}
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type MerchantRequest struct {
+	Name         string `json:"name" validate:"required,max=25"`
+	City         string `json:"city" validate:"required,max=15"`
+	CategoryCode string `json:"category_code" validate:"omitempty,len=4,numeric"`
+}
+
+type MerchantResponse struct {
+	ID           string    `json:"id"`
+	CustomerXID  string    `json:"customer_xid"`
+	Name         string    `json:"name"`
+	City         string    `json:"city"`
+	CategoryCode string    `json:"category_code"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type PaymentIntentCreateRequest struct {
+	MerchantReference string `json:"merchant_reference" validate:"required,max=50"`
+	Amount            int64  `json:"amount" validate:"required,min=1"`
+	Currency          string `json:"currency" validate:"omitempty,len=3"`
+	Description       string `json:"description" validate:"max=255"`
+}
+
+type PaymentIntentResponse struct {
+	ID                string       `json:"id"`
+	MerchantID        string       `json:"merchant_id"`
+	MerchantName      string       `json:"merchant_name,omitempty"`
+	MerchantReference string       `json:"merchant_reference"`
+	Amount            domain.Money `json:"amount"`
+	Description       string       `json:"description"`
+	Status            string       `json:"status"`
+	CustomerXID       string       `json:"customer_xid,omitempty"`
+	TransactionID     string       `json:"transaction_id,omitempty"`
+	ExpiresAt         time.Time    `json:"expires_at"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
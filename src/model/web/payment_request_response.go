@@ -0,0 +1,27 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PaymentRequestCreateRequest struct {
+	PayerXID  string    `json:"payer_xid" validate:"required,min=1,max=36"`
+	Amount    int64     `json:"amount" validate:"required,min=1"`
+	Note      string    `json:"note" validate:"max=255"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PaymentRequestResponse struct {
+	ID            string       `json:"id"`
+	SplitBillID   string       `json:"split_bill_id,omitempty"`
+	RequesterXID  string       `json:"requester_xid"`
+	PayerXID      string       `json:"payer_xid"`
+	Amount        domain.Money `json:"amount"`
+	Note          string       `json:"note"`
+	Status        string       `json:"status"`
+	TransactionID string       `json:"transaction_id,omitempty"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
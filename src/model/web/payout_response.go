@@ -0,0 +1,84 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// BeneficiaryRequest saves a bank account. The holder name is confirmed by
+// a name inquiry, when it is left empty the name the bank knows is kept.
+type BeneficiaryRequest struct {
+	BankCode      string `json:"bank_code" validate:"required,alphanum,max=10"`
+	AccountNumber string `json:"account_number" validate:"required,numeric,max=34"`
+	AccountName   string `json:"account_name" validate:"max=100"`
+}
+
+type BeneficiaryResponse struct {
+	ID            string     `json:"id"`
+	BankCode      string     `json:"bank_code"`
+	AccountNumber string     `json:"account_number"`
+	AccountName   string     `json:"account_name"`
+	VerifiedAt    *time.Time `json:"verified_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type PayoutResponse struct {
+	ID            string       `json:"id"`
+	BeneficiaryID string       `json:"beneficiary_id"`
+	BankCode      string       `json:"bank_code"`
+	AccountNumber string       `json:"account_number"`
+	AccountName   string       `json:"account_name"`
+	Amount        domain.Money `json:"amount"`
+	TransactionID string       `json:"transaction_id"`
+	Status        string       `json:"status"`
+	FailureReason string       `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+}
+
+// PayoutBatchRequest uploads the rows of a payout batch as a csv or json
+// file. Reference identifies the batch, uploading it again does not pay
+// twice.
+type PayoutBatchRequest struct {
+	Reference string `json:"reference" validate:"required,max=75"`
+	Format    string `json:"format" validate:"required,oneof=csv json"`
+	FileName  string `json:"file_name" validate:"max=255"`
+	Data      []byte `json:"-" validate:"required"`
+}
+
+// PayoutBatchRow is one row of an uploaded batch, Amount is in minor units of
+// the wallet currency. A csv file has a header naming these columns.
+type PayoutBatchRow struct {
+	BankCode      string `json:"bank_code" validate:"required,alphanum,max=10"`
+	AccountNumber string `json:"account_number" validate:"required,numeric,max=34"`
+	AccountName   string `json:"account_name" validate:"max=100"`
+	Amount        int64  `json:"amount" validate:"required,min=1"`
+	ReferenceID   string `json:"reference_id" validate:"required,max=75"`
+}
+
+type PayoutBatchResponse struct {
+	ID          string                    `json:"id"`
+	Reference   string                    `json:"reference"`
+	TotalAmount domain.Money              `json:"total_amount"`
+	RowCount    int                       `json:"row_count"`
+	Status      string                    `json:"status"`
+	Items       []PayoutBatchItemResponse `json:"items,omitempty"`
+	CreatedAt   time.Time                 `json:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+}
+
+type PayoutBatchItemResponse struct {
+	ID            string       `json:"id"`
+	LineNumber    int          `json:"line_number"`
+	BankCode      string       `json:"bank_code"`
+	AccountNumber string       `json:"account_number"`
+	AccountName   string       `json:"account_name"`
+	Amount        domain.Money `json:"amount"`
+	ReferenceID   string       `json:"reference_id"`
+	Status        string       `json:"status"`
+	FailureReason string       `json:"failure_reason,omitempty"`
+	PayoutID      string       `json:"payout_id,omitempty"`
+	PayoutStatus  string       `json:"payout_status,omitempty"`
+}
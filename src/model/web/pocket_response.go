@@ -0,0 +1,31 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PocketCreateRequest struct {
+	Name         string `json:"name" validate:"required,min=1,max=50"`
+	TargetAmount int64  `json:"target_amount" validate:"min=0"`
+}
+
+type PocketUpdateRequest struct {
+	Name         string `json:"name" validate:"required,min=1,max=50"`
+	TargetAmount int64  `json:"target_amount" validate:"min=0"`
+}
+
+type PocketResponse struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	TargetAmount domain.Money `json:"target_amount"`
+	Balance      domain.Money `json:"balance"`
+	Status       string       `json:"status"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type PocketTransferResponse struct {
+	Pocket      PocketResponse      `json:"pocket"`
+	Transaction TransactionResponse `json:"transaction"`
+}
@@ -0,0 +1,15 @@
+package web
+
+type QRPaymentRequest struct {
+	Payload string `json:"payload" validate:"required,max=512"`
+	// Amount is entered by the customer for static QR without an amount
+	Amount int64 `json:"amount" validate:"min=0"`
+	// ReferenceID makes retrying a static QR payment safe
+	ReferenceID string `json:"reference_id" validate:"max=36"`
+}
+
+type QRResponse struct {
+	Type            string `json:"type"`
+	Payload         string `json:"payload"`
+	PaymentIntentID string `json:"payment_intent_id,omitempty"`
+}
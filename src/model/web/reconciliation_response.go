@@ -0,0 +1,55 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type StatementImportRequest struct {
+	BankCode string `json:"bank_code" validate:"required,alphanum,max=10"`
+	Format   string `json:"format" validate:"required,oneof=csv mt940"`
+	FileName string `json:"file_name" validate:"max=255"`
+	Data     []byte `json:"-" validate:"required"`
+}
+
+type StatementImportResponse struct {
+	ID          string    `json:"id"`
+	BankCode    string    `json:"bank_code"`
+	Format      string    `json:"format"`
+	FileName    string    `json:"file_name"`
+	Currency    string    `json:"currency"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	LineCount   int       `json:"line_count"`
+	// MatchedCount is only filled in by the import itself
+	MatchedCount int       `json:"matched_count,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type StatementLineResponse struct {
+	ID            string       `json:"id"`
+	LineNumber    int          `json:"line_number"`
+	ValueDate     time.Time    `json:"value_date"`
+	Direction     string       `json:"direction"`
+	Amount        domain.Money `json:"amount"`
+	Reference     string       `json:"reference"`
+	Description   string       `json:"description"`
+	TransactionID string       `json:"transaction_id,omitempty"`
+	MatchType     string       `json:"match_type,omitempty"`
+	MatchedAt     *time.Time   `json:"matched_at,omitempty"`
+}
+
+// ReconciliationReportResponse splits a statement into lines matched to a
+// transaction and lines only the bank knows about, next to the transactions
+// of the statement period only the wallet knows about.
+type ReconciliationReportResponse struct {
+	StatementImportResponse
+	Matched         []StatementLineResponse `json:"matched"`
+	UnmatchedBank   []StatementLineResponse `json:"unmatched_bank"`
+	UnmatchedWallet []TransactionResponse   `json:"unmatched_wallet"`
+}
+
+type StatementMatchRequest struct {
+	TransactionID string `json:"transaction_id" validate:"required,max=36"`
+}
@@ -0,0 +1,44 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type ScheduleCreateRequest struct {
+	ScheduleType  string    `json:"type" validate:"required,oneof=withdrawal transfer"`
+	RecipientXID  string    `json:"recipient_xid" validate:"required_if=ScheduleType transfer,max=36"`
+	BankCode      string    `json:"bank_code" validate:"required_if=ScheduleType withdrawal,omitempty,alphanum,max=10"`
+	AccountNumber string    `json:"account_number" validate:"required_if=ScheduleType withdrawal,omitempty,numeric,max=34"`
+	AccountName   string    `json:"account_name" validate:"required_if=ScheduleType withdrawal,max=100"`
+	Amount        int64     `json:"amount" validate:"required,min=1"`
+	Frequency     string    `json:"frequency" validate:"required,oneof=once daily weekly monthly"`
+	DayOfMonth    int       `json:"day_of_month" validate:"required_if=Frequency monthly,min=0,max=31"`
+	StartAt       time.Time `json:"start_at" validate:"required"`
+}
+
+type ScheduleResponse struct {
+	ID            string       `json:"id"`
+	Type          string       `json:"type"`
+	RecipientXID  string       `json:"recipient_xid,omitempty"`
+	BankCode      string       `json:"bank_code,omitempty"`
+	AccountNumber string       `json:"account_number,omitempty"`
+	AccountName   string       `json:"account_name,omitempty"`
+	Amount        domain.Money `json:"amount"`
+	Frequency     string       `json:"frequency"`
+	DayOfMonth    int          `json:"day_of_month,omitempty"`
+	NextRunAt     *time.Time   `json:"next_run_at"`
+	Status        string       `json:"status"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type ScheduleRunResponse struct {
+	ID            string    `json:"id"`
+	RunAt         time.Time `json:"run_at"`
+	ReferenceID   string    `json:"reference_id"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	Status        string    `json:"status"`
+	ErrorMessage  string    `json:"error_message,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type SettlementResponse struct {
+	ID            string       `json:"id"`
+	CutoffAt      time.Time    `json:"cutoff_at"`
+	GrossAmount   domain.Money `json:"gross_amount"`
+	FeeAmount     domain.Money `json:"fee_amount"`
+	RefundAmount  domain.Money `json:"refund_amount"`
+	CarriedAmount domain.Money `json:"carried_amount"`
+	NetAmount     domain.Money `json:"net_amount"`
+	Currency      string       `json:"currency"`
+	TransactionID string       `json:"transaction_id"`
+	Status        string       `json:"status"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
@@ -0,0 +1,32 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+// WalletMemberRequest adds a member to the owner's wallet or changes their
+// controls. SpendingLimit caps what the member spends per calendar month, in
+// minor units of the wallet currency, zero leaves it uncapped.
+type WalletMemberRequest struct {
+	MemberXID     string   `json:"member_xid" validate:"required,max=36"`
+	SpendingLimit int64    `json:"spending_limit" validate:"min=0"`
+	AllowedTypes  []string `json:"allowed_types" validate:"required,min=1,dive,oneof=withdrawal transfer_out"`
+}
+
+type WalletMemberResponse struct {
+	ID            string       `json:"id"`
+	WalletID      string       `json:"wallet_id"`
+	OwnerXID      string       `json:"owner_xid"`
+	MemberXID     string       `json:"member_xid"`
+	SpendingLimit domain.Money `json:"spending_limit"`
+	AllowedTypes  []string     `json:"allowed_types"`
+	Status        string       `json:"status"`
+	// Spent is what the member spent this calendar month
+	Spent domain.Money `json:"spent"`
+	// Balance of the shared wallet, shown to its active members
+	Balance   *domain.Money `json:"balance,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
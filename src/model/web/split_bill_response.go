@@ -0,0 +1,36 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type SplitBillCreateRequest struct {
+	Title        string                 `json:"title" validate:"required,max=100"`
+	TotalAmount  int64                  `json:"total_amount" validate:"required,min=1"`
+	SplitType    string                 `json:"split_type" validate:"required,oneof=equal custom"`
+	ExpiryPolicy string                 `json:"expiry_policy" validate:"omitempty,oneof=keep refund"`
+	ExpiresAt    time.Time              `json:"expires_at"`
+	Participants []SplitBillParticipant `json:"participants" validate:"required,min=1,max=50,dive"`
+}
+
+type SplitBillParticipant struct {
+	CustomerXID string `json:"customer_xid" validate:"required,min=1,max=36"`
+	// Amount is only used by custom splits
+	Amount int64 `json:"amount" validate:"min=0"`
+}
+
+type SplitBillResponse struct {
+	ID           string                   `json:"id"`
+	CreatorXID   string                   `json:"creator_xid"`
+	Title        string                   `json:"title"`
+	TotalAmount  domain.Money             `json:"total_amount"`
+	PaidAmount   domain.Money             `json:"paid_amount"`
+	SplitType    string                   `json:"split_type"`
+	ExpiryPolicy string                   `json:"expiry_policy"`
+	Status       string                   `json:"status"`
+	ExpiresAt    time.Time                `json:"expires_at"`
+	CreatedAt    time.Time                `json:"created_at"`
+	Shares       []PaymentRequestResponse `json:"shares"`
+}
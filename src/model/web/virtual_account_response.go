@@ -0,0 +1,39 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type VirtualAccountCreateRequest struct {
+	BankCode string `json:"bank_code" validate:"required,alphanum,max=10"`
+	Currency string `json:"currency" validate:"omitempty,len=3"`
+}
+
+type VirtualAccountResponse struct {
+	ID        string    `json:"id"`
+	BankCode  string    `json:"bank_code"`
+	Number    string    `json:"number"`
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// VirtualAccountCallbackRequest is what a bank reports when money arrives on
+// a virtual account.
+type VirtualAccountCallbackRequest struct {
+	BankCode             string    `json:"bank_code" validate:"required,alphanum,max=10"`
+	VirtualAccountNumber string    `json:"virtual_account_number" validate:"required,numeric,max=20"`
+	BankReference        string    `json:"bank_reference" validate:"required,max=50"`
+	Amount               int64     `json:"amount" validate:"required,min=1"`
+	Currency             string    `json:"currency" validate:"omitempty,len=3"`
+	PaidAt               time.Time `json:"paid_at" validate:"required"`
+}
+
+type VirtualAccountPaymentResponse struct {
+	ID            string       `json:"id"`
+	BankReference string       `json:"bank_reference"`
+	Amount        domain.Money `json:"amount"`
+	TransactionID string       `json:"transaction_id"`
+	PaidAt        time.Time    `json:"paid_at"`
+}
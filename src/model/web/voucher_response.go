@@ -0,0 +1,63 @@
+package web
+
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type VoucherBatchCreateRequest struct {
+	Name     string `json:"name" validate:"required,max=100"`
+	Currency string `json:"currency" validate:"omitempty,len=3"`
+	Value    int64  `json:"value" validate:"required,min=1"`
+	// Prefix is prepended to every generated code
+	Prefix    string `json:"prefix" validate:"omitempty,alphanum,max=10"`
+	CodeCount int    `json:"code_count" validate:"required,min=1,max=10000"`
+	// MaxUses is how often a single code can be redeemed, 1 for single use
+	MaxUses          int       `json:"max_uses" validate:"required,min=1"`
+	PerCustomerLimit int       `json:"per_customer_limit" validate:"required,min=1"`
+	ExpiresAt        time.Time `json:"expires_at" validate:"required"`
+}
+
+type VoucherBatchResponse struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	Value            domain.Money `json:"value"`
+	Currency         string       `json:"currency"`
+	CodeCount        int          `json:"code_count"`
+	MaxUses          int          `json:"max_uses"`
+	PerCustomerLimit int          `json:"per_customer_limit"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	Codes            []string     `json:"codes,omitempty"`
+}
+
+type VoucherBatchReportResponse struct {
+	VoucherBatchResponse
+	Status             string                `json:"status"`
+	UnusedCodes        int                   `json:"unused_codes"`
+	PartiallyUsedCodes int                   `json:"partially_used_codes"`
+	UsedCodes          int                   `json:"used_codes"`
+	Redemptions        int                   `json:"redemptions"`
+	RedeemedAmount     domain.Money          `json:"redeemed_amount"`
+	Customers          int                   `json:"customers"`
+	Vouchers           []VoucherCodeResponse `json:"vouchers"`
+}
+
+type VoucherCodeResponse struct {
+	Code      string `json:"code"`
+	UsedCount int    `json:"used_count"`
+	Status    string `json:"status"`
+}
+
+type VoucherRedeemRequest struct {
+	Code string `json:"code" validate:"required,max=30"`
+}
+
+type VoucherRedemptionResponse struct {
+	ID            string       `json:"id"`
+	Code          string       `json:"code"`
+	Amount        domain.Money `json:"amount"`
+	TransactionID string       `json:"transaction_id"`
+	RedeemedAt    time.Time    `json:"redeemed_at"`
+}
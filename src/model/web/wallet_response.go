@@ -1,6 +1,10 @@
 package web
 
-import "time"
+import (
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
 
 type WebResponse struct {
 	Status string      `json:"status"`
@@ -11,42 +15,100 @@ type WalletCreateRequest struct {
 	CustomerXID string `json:"name" validate:"required,min=1,max=36"`
 }
 
+type CurrencyWalletRequest struct {
+	Currency string `json:"currency" validate:"required,len=3"`
+}
+
 type WalletResponse struct {
-	ID        string     `json:"id"`
-	OwnedBy   string     `json:"owned_by"`
-	Status    string     `json:"status"`
-	EnabledAt *time.Time `json:"enabled_at"`
-	Balance   int        `json:"balance"`
+	ID        string       `json:"id"`
+	OwnedBy   string       `json:"owned_by"`
+	Currency  string       `json:"currency"`
+	Status    string       `json:"status"`
+	EnabledAt *time.Time   `json:"enabled_at"`
+	Balance   domain.Money `json:"balance"`
+	// CreditLimit and CreditUsed are only shown for wallets with a credit line
+	CreditLimit *domain.Money `json:"credit_limit,omitempty"`
+	CreditUsed  *domain.Money `json:"credit_used,omitempty"`
+	// Pockets hold money set aside from Balance, they are not spendable
+	Pockets []PocketResponse `json:"pockets,omitempty"`
 }
 
 type TransactionRequest struct {
-	Amount      int    `json:"amount" validate:"required,min=1,numeric"`
+	Amount      int64  `json:"amount" validate:"required,min=1,numeric"`
 	ReferenceID string `json:"reference_id" validate:"required,min=1"`
 }
 
+// WithdrawalRequest pays a withdrawal out to a verified beneficiary, or to
+// the bank account given in full.
+type WithdrawalRequest struct {
+	Amount        int64  `json:"amount" validate:"required,min=1,numeric"`
+	ReferenceID   string `json:"reference_id" validate:"required,min=1"`
+	BeneficiaryID string `json:"beneficiary_id" validate:"max=36"`
+	BankCode      string `json:"bank_code" validate:"required_without=BeneficiaryID,excluded_with=BeneficiaryID,omitempty,alphanum,max=10"`
+	AccountNumber string `json:"account_number" validate:"required_without=BeneficiaryID,excluded_with=BeneficiaryID,omitempty,numeric,max=34"`
+	AccountName   string `json:"account_name" validate:"required_without=BeneficiaryID,excluded_with=BeneficiaryID,max=100"`
+	// MemberXID is set when a member spends from a shared wallet
+	MemberXID string `json:"-"`
+}
+
+type CreditLimitRequest struct {
+	CustomerXID string `json:"customer_xid" validate:"required,max=36"`
+	Currency    string `json:"currency" validate:"omitempty,len=3"`
+	CreditLimit int64  `json:"credit_limit" validate:"min=0"`
+}
+
+type TransferRequest struct {
+	RecipientXID string `json:"recipient_xid" validate:"required,min=1,max=36"`
+	Amount       int64  `json:"amount" validate:"required,min=1,numeric"`
+	ReferenceID  string `json:"reference_id" validate:"required,min=1"`
+	// MemberXID is set when a member spends from a shared wallet
+	MemberXID string `json:"-"`
+	// SplitBillID is set when the transfer pays a share of a split bill
+	SplitBillID string `json:"-"`
+	// RefundedTransactionID is set when the transfer pays a transaction back
+	RefundedTransactionID string `json:"-"`
+}
+
 type TransactionResponse struct {
-	ID           string    `json:"id"`
-	Status       string    `json:"status"`
-	TransactedAt time.Time `json:"transacted_at"`
-	Type         string    `json:"type"`
-	Amount       int       `json:"amount"`
-	ReferenceID  string    `json:"reference_id"`
+	ID           string       `json:"id"`
+	Status       string       `json:"status"`
+	TransactedAt time.Time    `json:"transacted_at"`
+	Type         string       `json:"type"`
+	Amount       domain.Money `json:"amount"`
+	ReferenceID  string       `json:"reference_id"`
+	InitiatedBy  string       `json:"initiated_by,omitempty"`
 }
 
 type DepositResponse struct {
-	ID          string    `json:"id"`
-	DepositedBy string    `json:"deposited_by"`
-	Status      string    `json:"status"`
-	DepositedAt time.Time `json:"deposited_at"`
-	Amount      int       `json:"amount"`
-	ReferenceID string    `json:"reference_id"`
+	ID          string       `json:"id"`
+	DepositedBy string       `json:"deposited_by"`
+	Status      string       `json:"status"`
+	DepositedAt time.Time    `json:"deposited_at"`
+	Amount      domain.Money `json:"amount"`
+	ReferenceID string       `json:"reference_id"`
+	// OverdraftRepaid is the part of Amount that pays off the overdraft
+	OverdraftRepaid *domain.Money `json:"overdraft_repaid,omitempty"`
 }
 
 type WithdrawalResponse struct {
-	ID          string    `json:"id"`
-	WithdrawnBy string    `json:"withdrawn_by"`
-	Status      string    `json:"status"`
-	WithdrawnAt time.Time `json:"withdrawn_at"`
-	Amount      int       `json:"amount"`
-	ReferenceID string    `json:"reference_id"`
+	ID          string       `json:"id"`
+	WithdrawnBy string       `json:"withdrawn_by"`
+	Status      string       `json:"status"`
+	WithdrawnAt time.Time    `json:"withdrawn_at"`
+	Amount      domain.Money `json:"amount"`
+	ReferenceID string       `json:"reference_id"`
+	InitiatedBy string       `json:"initiated_by,omitempty"`
+	// Payout tracks the money on its way to the bank account
+	Payout PayoutResponse `json:"payout"`
+}
+
+type TransferResponse struct {
+	ID            string       `json:"id"`
+	TransferredBy string       `json:"transferred_by"`
+	RecipientXID  string       `json:"recipient_xid"`
+	Status        string       `json:"status"`
+	TransferredAt time.Time    `json:"transferred_at"`
+	Amount        domain.Money `json:"amount"`
+	ReferenceID   string       `json:"reference_id"`
+	InitiatedBy   string       `json:"initiated_by,omitempty"`
 }
@@ -0,0 +1,128 @@
+// Package pdf writes plain text documents as PDF. Text is set in Courier,
+// one of the standard fonts every PDF reader ships, so nothing has to be
+// embedded and columns line up like they do on a terminal.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595 // A4 in points
+	pageHeight = 842
+	margin     = 40
+	fontSize   = 9
+	lineHeight = 12
+
+	// LineWidth is how many characters fit on a line, longer lines are cut.
+	// Courier advances every character by 0.6 of the font size.
+	LineWidth = (pageWidth - 2*margin) * 10 / (fontSize * 6)
+
+	linesPerPage = (pageHeight - 2*margin) / lineHeight
+)
+
+// Document collects lines of text and lays them out on A4 pages.
+type Document struct {
+	title string
+	lines []string
+}
+
+// New starts a document. The title goes into the document properties.
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// Line appends a line of text, an empty string leaves a blank line.
+func (d *Document) Line(text string) {
+	d.lines = append(d.lines, text)
+}
+
+// Bytes renders the document. Every page is numbered in its footer.
+func (d *Document) Bytes() []byte {
+	var pages [][]string
+	for start := 0; start < len(d.lines) || start == 0; start += linesPerPage {
+		end := start + linesPerPage
+		if end > len(d.lines) {
+			end = len(d.lines)
+		}
+		pages = append(pages, d.lines[start:end])
+	}
+
+	// objects 1 to 4 are the catalog, the page tree, the font and the info
+	// dictionary, every page then takes a page object and a content stream
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title (%s) /Producer (miniwallet) >>", escape(d.title)),
+	)
+	for i, lines := range pages {
+		content := pageContent(lines, i+1, len(pages))
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+func pageContent(lines []string, page, pageCount int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", fontSize, lineHeight, margin, pageHeight-margin)
+	for _, line := range lines {
+		fmt.Fprintf(&b, "(%s) Tj T*\n", escape(cut(line, LineWidth)))
+	}
+	b.WriteString("ET\n")
+
+	footer := fmt.Sprintf("Page %d of %d", page, pageCount)
+	fmt.Fprintf(&b, "BT\n/F1 %d Tf\n%d %d Td\n(%s) Tj\nET", fontSize, pageWidth-margin-len(footer)*fontSize*6/10, margin/2, footer)
+	return b.String()
+}
+
+func cut(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return text
+}
+
+// escape makes text safe inside a PDF string. Characters outside of ASCII
+// are replaced, the standard fonts cannot show most of them.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
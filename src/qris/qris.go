@@ -0,0 +1,260 @@
+// Package qris encodes and parses EMVCo merchant-presented QR payloads, the
+// format QRIS is built on. A payload is a sequence of ID-length-value fields
+// closed by a CRC16 checksum over everything before it.
+package qris
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	idPayloadFormat       = "00"
+	idPointOfInitiation   = "01"
+	idMerchantAccount     = "26"
+	idMerchantCategory    = "52"
+	idTransactionCurrency = "53"
+	idTransactionAmount   = "54"
+	idCountryCode         = "58"
+	idMerchantName        = "59"
+	idMerchantCity        = "60"
+	idAdditionalData      = "62"
+	idCRC                 = "63"
+
+	// sub fields of the merchant account template
+	idGloballyUniqueID = "00"
+	idMerchantID       = "01"
+
+	// sub fields of the additional data template
+	idBillNumber     = "01"
+	idReferenceLabel = "05"
+
+	payloadFormatVersion = "01"
+
+	PointOfInitiationStatic  = "11"
+	PointOfInitiationDynamic = "12"
+)
+
+// Payload holds the fields this wallet reads and writes. Amount is the
+// decimal string carried in the QR, e.g. "25000" or "12.50".
+type Payload struct {
+	PointOfInitiation string
+	GloballyUniqueID  string
+	MerchantID        string
+	MerchantCategory  string
+	CurrencyCode      string
+	Amount            string
+	CountryCode       string
+	MerchantName      string
+	MerchantCity      string
+	BillNumber        string
+	ReferenceLabel    string
+}
+
+// IsDynamic reports whether the payload is for a single payment.
+func (p Payload) IsDynamic() bool {
+	return p.PointOfInitiation == PointOfInitiationDynamic
+}
+
+// Encode renders the payload and appends its checksum.
+func Encode(p Payload) (string, error) {
+	var b strings.Builder
+
+	fields := []struct{ id, value string }{
+		{idPayloadFormat, payloadFormatVersion},
+		{idPointOfInitiation, p.PointOfInitiation},
+		{idMerchantAccount, template(
+			idGloballyUniqueID, p.GloballyUniqueID,
+			idMerchantID, p.MerchantID,
+		)},
+		{idMerchantCategory, p.MerchantCategory},
+		{idTransactionCurrency, p.CurrencyCode},
+		{idTransactionAmount, p.Amount},
+		{idCountryCode, p.CountryCode},
+		{idMerchantName, p.MerchantName},
+		{idMerchantCity, p.MerchantCity},
+		{idAdditionalData, template(
+			idBillNumber, p.BillNumber,
+			idReferenceLabel, p.ReferenceLabel,
+		)},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		if len(field.value) > 99 {
+			return "", fmt.Errorf("field %s is longer than 99 characters", field.id)
+		}
+		fmt.Fprintf(&b, "%s%02d%s", field.id, len(field.value), field.value)
+	}
+
+	b.WriteString(idCRC + "04")
+	return b.String() + checksum(b.String()), nil
+}
+
+// Parse validates the checksum and structure of a scanned payload.
+func Parse(payload string) (Payload, error) {
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != idCRC+"04" {
+		return Payload{}, errors.New("qr payload has no checksum")
+	}
+	if !strings.EqualFold(checksum(payload[:len(payload)-4]), payload[len(payload)-4:]) {
+		return Payload{}, errors.New("qr payload checksum mismatch")
+	}
+
+	fields, err := split(payload[:len(payload)-8])
+	if err != nil {
+		return Payload{}, err
+	}
+	if fields[idPayloadFormat] != payloadFormatVersion {
+		return Payload{}, errors.New("unsupported qr payload format")
+	}
+
+	merchantAccount, err := split(fields[idMerchantAccount])
+	if err != nil {
+		return Payload{}, err
+	}
+	additionalData, err := split(fields[idAdditionalData])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	p := Payload{
+		PointOfInitiation: fields[idPointOfInitiation],
+		GloballyUniqueID:  merchantAccount[idGloballyUniqueID],
+		MerchantID:        merchantAccount[idMerchantID],
+		MerchantCategory:  fields[idMerchantCategory],
+		CurrencyCode:      fields[idTransactionCurrency],
+		Amount:            fields[idTransactionAmount],
+		CountryCode:       fields[idCountryCode],
+		MerchantName:      fields[idMerchantName],
+		MerchantCity:      fields[idMerchantCity],
+		BillNumber:        additionalData[idBillNumber],
+		ReferenceLabel:    additionalData[idReferenceLabel],
+	}
+	if p.PointOfInitiation != PointOfInitiationStatic && p.PointOfInitiation != PointOfInitiationDynamic {
+		return Payload{}, errors.New("invalid qr point of initiation")
+	}
+	for _, required := range []string{p.MerchantCategory, p.CurrencyCode, p.CountryCode, p.MerchantName, p.MerchantCity} {
+		if required == "" {
+			return Payload{}, errors.New("qr payload is missing a mandatory field")
+		}
+	}
+	return p, nil
+}
+
+// checksum is CRC-16/CCITT-FALSE as required by EMVCo, rendered as four
+// upper case hex digits.
+func checksum(data string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// template renders the non empty id and value pairs of a nested field.
+func template(pairs ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s%02d%s", pairs[i], len(pairs[i+1]), pairs[i+1])
+	}
+	return b.String()
+}
+
+// split reads a run of ID-length-value fields into a map.
+func split(data string) (map[string]string, error) {
+	fields := map[string]string{}
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, errors.New("qr payload is truncated")
+		}
+
+		id := data[:2]
+		length, err := strconv.Atoi(data[2:4])
+		if err != nil || length < 0 || len(data) < 4+length {
+			return nil, errors.New("qr payload has an invalid field length")
+		}
+		if _, ok := fields[id]; ok {
+			return nil, fmt.Errorf("qr payload repeats field %s", id)
+		}
+
+		fields[id] = data[4 : 4+length]
+		data = data[4+length:]
+	}
+	return fields, nil
+}
+
+// CurrencyCodes maps the currencies we hold to their ISO 4217 numeric codes.
+var CurrencyCodes = map[string]string{
+	"IDR": "360",
+	"USD": "840",
+	"SGD": "702",
+	"EUR": "978",
+	"JPY": "392",
+}
+
+// CurrencyByCode is the reverse of CurrencyCodes.
+func CurrencyByCode(code string) (string, bool) {
+	for currency, numeric := range CurrencyCodes {
+		if numeric == code {
+			return currency, true
+		}
+	}
+	return "", false
+}
+
+// FormatAmount renders a minor-unit amount as the decimal string of field 54.
+func FormatAmount(amount int64, minorUnits int) string {
+	if minorUnits == 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+
+	digits := fmt.Sprintf("%0*d", minorUnits+1, amount)
+	return digits[:len(digits)-minorUnits] + "." + digits[len(digits)-minorUnits:]
+}
+
+// ParseAmount reads field 54 into minor units. Trailing zero decimals beyond
+// the currency's minor units are accepted, any other precision is not.
+func ParseAmount(value string, minorUnits int) (int64, error) {
+	whole, fraction := value, ""
+	if i := strings.IndexByte(value, '.'); i >= 0 {
+		whole, fraction = value[:i], value[i+1:]
+	}
+
+	if len(fraction) > minorUnits {
+		if strings.Trim(fraction[minorUnits:], "0") != "" {
+			return 0, errors.New("qr amount has too many decimals")
+		}
+		fraction = fraction[:minorUnits]
+	}
+	fraction += strings.Repeat("0", minorUnits-len(fraction))
+
+	if whole == "" || strings.Trim(whole+fraction, "0123456789") != "" {
+		return 0, errors.New("invalid qr amount")
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid qr amount")
+	}
+	return amount, nil
+}
+
+// PNG renders the payload as a QR code image of size by size pixels.
+func PNG(payload string, size int) ([]byte, error) {
+	return qrcode.Encode(payload, qrcode.Medium, size)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+)
+
+// creditTransactionTypes are the arguments of the IN list that tells credits
+// from debits when a balance is recomputed.
+var creditTransactionTypes = func() []interface{} {
+	types := make([]string, 0, len(constants.CreditTransactionTypes))
+	for transactionType := range constants.CreditTransactionTypes {
+		types = append(types, transactionType)
+	}
+	sort.Strings(types)
+
+	result := make([]interface{}, len(types))
+	for i := range types {
+		result[i] = types[i]
+	}
+	return result
+}()
+
+var (
+	// the aggregate runs on the transactions index of a single wallet, the
+	// credit types and the success status come before the other arguments
+	selectBalanceCheckColumns = `SELECT 
+		w.id, COALESCE(w.customer_xid, ''), COALESCE(w.balance, 0), w.currency,
+		COALESCE(SUM(CASE WHEN t.transaction_type IN (?` + strings.Repeat(", ?", len(creditTransactionTypes)-1) + `) THEN t.amount ELSE -t.amount END), 0),
+		COUNT(t.id), MAX(t.created_at)
+		FROM wallets w
+		LEFT JOIN transactions t ON t.wallet_id = w.id AND t.status = ?`
+
+	getBalanceChecksQuery = selectBalanceCheckColumns + ` WHERE w.id > ? GROUP BY w.id order by w.id LIMIT ?`
+
+	getBalanceCheckQuery = selectBalanceCheckColumns + ` WHERE w.id = ? GROUP BY w.id`
+)
+
+const (
+	// a drift found again is ignored by the unique wallet and balances
+	insertBalanceIncidentQuery = `INSERT IGNORE INTO balance_incidents
+		(id, wallet_id, customer_xid, currency, balance, computed_balance, drift, transaction_count, status, detected_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectBalanceIncidentColumns = `SELECT 
+		id, wallet_id, customer_xid, balance, computed_balance, drift, currency, transaction_count, status, detected_at, resolved_at
+		FROM balance_incidents`
+
+	getBalanceIncidentQuery = selectBalanceIncidentColumns + ` WHERE id = ?`
+
+	getBalanceIncidentsQuery = selectBalanceIncidentColumns + ` WHERE status = ? order by detected_at DESC`
+
+	resolveBalanceIncidentQuery = `UPDATE balance_incidents
+		SET
+			status = ?,
+			resolved_at = ?
+		WHERE 
+			id = ? AND
+			status = ?`
+)
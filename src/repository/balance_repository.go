@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type BalanceRepository interface {
+	// GetBalanceChecks recomputes the balances of up to limit wallets with an
+	// ID after afterID, in ID order, so every wallet can be checked page by
+	// page.
+	GetBalanceChecks(ctx context.Context, afterID string, limit int) ([]domain.BalanceCheck, error)
+	GetBalanceCheck(ctx context.Context, walletID string) (domain.BalanceCheck, error)
+
+	// CreateBalanceIncident returns false when the same drift of the wallet
+	// is already on record.
+	CreateBalanceIncident(ctx context.Context, incident domain.BalanceIncident) (bool, error)
+	GetBalanceIncident(ctx context.Context, incidentID string) (domain.BalanceIncident, error)
+	GetBalanceIncidents(ctx context.Context, status string) ([]domain.BalanceIncident, error)
+	ResolveBalanceIncident(ctx context.Context, incidentID string, resolvedAt time.Time) (bool, error)
+}
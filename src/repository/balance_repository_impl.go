@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type BalanceRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewBalanceRepository(db *sql.DB) BalanceRepository {
+	return &BalanceRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *BalanceRepositoryImpl) GetBalanceChecks(ctx context.Context, afterID string, limit int) ([]domain.BalanceCheck, error) {
+	var result []domain.BalanceCheck
+	rows, err := repo.db.QueryContext(ctx, getBalanceChecksQuery, balanceCheckArgs(afterID, limit)...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.BalanceCheck{}
+		err := scanBalanceCheck(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, rows.Err()
+}
+
+func (repo *BalanceRepositoryImpl) GetBalanceCheck(ctx context.Context, walletID string) (domain.BalanceCheck, error) {
+	var result domain.BalanceCheck
+	err := scanBalanceCheck(repo.db.QueryRowContext(ctx, getBalanceCheckQuery, balanceCheckArgs(walletID)...), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *BalanceRepositoryImpl) CreateBalanceIncident(ctx context.Context, incident domain.BalanceIncident) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, insertBalanceIncidentQuery,
+		incident.ID,
+		incident.WalletID,
+		incident.CustomerXID,
+		incident.Balance.Currency,
+		incident.Balance,
+		incident.ComputedBalance,
+		incident.Drift,
+		incident.TransactionCount,
+		incident.Status,
+		incident.DetectedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *BalanceRepositoryImpl) GetBalanceIncident(ctx context.Context, incidentID string) (domain.BalanceIncident, error) {
+	var result domain.BalanceIncident
+	err := scanBalanceIncident(repo.db.QueryRowContext(ctx, getBalanceIncidentQuery, incidentID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *BalanceRepositoryImpl) GetBalanceIncidents(ctx context.Context, status string) ([]domain.BalanceIncident, error) {
+	var result []domain.BalanceIncident
+	rows, err := repo.db.QueryContext(ctx, getBalanceIncidentsQuery, status)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.BalanceIncident{}
+		err := scanBalanceIncident(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *BalanceRepositoryImpl) ResolveBalanceIncident(ctx context.Context, incidentID string, resolvedAt time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, resolveBalanceIncidentQuery, constants.STATUS_RESOLVED, resolvedAt, incidentID, constants.STATUS_OPEN)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+// balanceCheckArgs puts the arguments of the recomputed balance in front of
+// args.
+func balanceCheckArgs(args ...interface{}) []interface{} {
+	result := make([]interface{}, 0, len(creditTransactionTypes)+1+len(args))
+	result = append(result, creditTransactionTypes...)
+	result = append(result, constants.STATUS_SUCCESS)
+	return append(result, args...)
+}
+
+func scanBalanceCheck(row rowScanner, check *domain.BalanceCheck) error {
+	err := row.Scan(
+		&check.WalletID,
+		&check.CustomerXID,
+		&check.Balance,
+		&check.Balance.Currency,
+		&check.ComputedBalance,
+		&check.TransactionCount,
+		&check.LastTransactionAt,
+	)
+	check.ComputedBalance.Currency = check.Balance.Currency
+	return err
+}
+
+func scanBalanceIncident(row rowScanner, incident *domain.BalanceIncident) error {
+	err := row.Scan(
+		&incident.ID,
+		&incident.WalletID,
+		&incident.CustomerXID,
+		&incident.Balance,
+		&incident.ComputedBalance,
+		&incident.Drift,
+		&incident.Balance.Currency,
+		&incident.TransactionCount,
+		&incident.Status,
+		&incident.DetectedAt,
+		&incident.ResolvedAt,
+	)
+	incident.ComputedBalance.Currency = incident.Balance.Currency
+	incident.Drift.Currency = incident.Balance.Currency
+	return err
+}
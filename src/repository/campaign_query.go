@@ -0,0 +1,88 @@
+package repository
+
+const (
+	insertCampaignQuery = `INSERT INTO campaigns
+		(id, name, transaction_type, merchant_id, currency, min_amount, cashback_rate, max_cashback, customer_cap, budget, budget_used, hold_days, starts_at, ends_at, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectCampaignColumns = `SELECT 
+		id, name, transaction_type, merchant_id, currency, min_amount, cashback_rate, max_cashback, customer_cap, budget, budget_used, hold_days, starts_at, ends_at, status, created_at, updated_at
+		FROM campaigns`
+
+	getCampaignQuery = selectCampaignColumns + ` WHERE id = ?`
+
+	getCampaignsQuery = selectCampaignColumns + ` order by created_at DESC`
+
+	getActiveCampaignsQuery = selectCampaignColumns + ` WHERE status = ? AND starts_at <= ? order by starts_at`
+
+	updateCampaignStatusQuery = `UPDATE campaigns
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	// the budget is reserved with the cashback, so two cashbacks can never
+	// overspend it
+	reserveCampaignBudgetQuery = `UPDATE campaigns
+		SET
+			budget_used = budget_used + ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			budget_used + ? <= budget`
+
+	releaseCampaignBudgetQuery = `UPDATE campaigns
+		SET
+			budget_used = budget_used - ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	// successful transactions of the campaign window that did not earn
+	// cashback yet
+	selectEligibleTransactions = `SELECT 
+		t.id, t.wallet_id, t.customer_xid, t.transaction_type, t.amount, t.currency, t.reference_id, t.status, t.created_at, t.updated_at
+		FROM transactions t
+		LEFT JOIN cashbacks c ON c.source_transaction_id = t.id`
+
+	eligibleTransactionsFilter = ` 
+			t.transaction_type = ? AND
+			t.status = ? AND
+			t.currency = ? AND
+			t.amount >= ? AND
+			t.created_at >= ? AND
+			t.created_at < ? AND
+			c.id IS NULL
+		order by t.created_at LIMIT ?`
+
+	getEligibleTransactionsQuery = selectEligibleTransactions + ` WHERE` + eligibleTransactionsFilter
+
+	getEligibleMerchantTransactionsQuery = selectEligibleTransactions + `
+		JOIN payment_intents pi ON pi.id = t.reference_id
+		WHERE pi.merchant_id = ? AND` + eligibleTransactionsFilter
+
+	getCustomerCashbackTotalQuery = `SELECT COALESCE(SUM(amount), 0)
+		FROM cashbacks WHERE campaign_id = ? AND customer_xid = ? AND status != ?`
+
+	insertCashbackQuery = `INSERT INTO cashbacks
+		(id, campaign_id, customer_xid, wallet_id, source_transaction_id, amount, status, release_at, transaction_id, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectCashbackColumns = `SELECT 
+		c.id, c.campaign_id, c.customer_xid, c.wallet_id, c.source_transaction_id, c.amount, cp.currency, c.status, c.release_at, c.transaction_id, c.created_at, c.updated_at
+		FROM cashbacks c JOIN campaigns cp ON cp.id = c.campaign_id`
+
+	getDueCashbacksQuery = selectCashbackColumns + ` WHERE c.status = ? AND c.release_at <= ? order by c.release_at LIMIT ?`
+
+	getCashbacksQuery = selectCashbackColumns + ` WHERE c.customer_xid = ? order by c.created_at DESC`
+
+	updateCashbackStatusQuery = `UPDATE cashbacks
+		SET
+			status = ?,
+			transaction_id = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+)
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type CampaignRepository interface {
+	CreateCampaign(ctx context.Context, campaign domain.Campaign) error
+	GetCampaign(ctx context.Context, campaignID string) (domain.Campaign, error)
+	GetCampaigns(ctx context.Context) ([]domain.Campaign, error)
+	GetActiveCampaigns(ctx context.Context, now time.Time) ([]domain.Campaign, error)
+	UpdateCampaignStatus(ctx context.Context, campaignID, status string) error
+	// GetEligibleTransactions returns transactions matching the campaign
+	// rules that have not earned cashback from any campaign yet.
+	GetEligibleTransactions(ctx context.Context, campaign domain.Campaign, limit int) ([]domain.Transaction, error)
+	GetCustomerCashbackTotal(ctx context.Context, campaignID, customerXID string) (int64, error)
+
+	// CreateCashback reserves the cashback from the campaign budget, stores it
+	// and posts the credit, if any, in a single database transaction. It
+	// returns false when the budget cannot cover the cashback.
+	CreateCashback(ctx context.Context, cashback domain.Cashback, credit *domain.Transaction) (bool, error)
+	GetDueCashbacks(ctx context.Context, now time.Time, limit int) ([]domain.Cashback, error)
+	GetCashbacks(ctx context.Context, customerXID string) ([]domain.Cashback, error)
+	// ReleaseCashback credits a held cashback. It returns false when the
+	// cashback is no longer pending.
+	ReleaseCashback(ctx context.Context, cashback domain.Cashback, credit domain.Transaction) (bool, error)
+	// CancelCashback claws back a held cashback and returns its amount to the
+	// campaign budget.
+	CancelCashback(ctx context.Context, cashback domain.Cashback) (bool, error)
+}
@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type CampaignRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCampaignRepository(db *sql.DB) CampaignRepository {
+	return &CampaignRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *CampaignRepositoryImpl) CreateCampaign(ctx context.Context, campaign domain.Campaign) error {
+	_, err := repo.db.ExecContext(ctx, insertCampaignQuery,
+		campaign.ID,
+		campaign.Name,
+		campaign.TransactionType,
+		campaign.MerchantID,
+		campaign.Budget.Currency,
+		campaign.MinAmount,
+		campaign.CashbackRate,
+		campaign.MaxCashback,
+		campaign.CustomerCap,
+		campaign.Budget,
+		campaign.BudgetUsed,
+		campaign.HoldDays,
+		campaign.StartsAt,
+		campaign.EndsAt,
+		campaign.Status,
+		campaign.CreatedAt,
+		campaign.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *CampaignRepositoryImpl) GetCampaign(ctx context.Context, campaignID string) (domain.Campaign, error) {
+	var result domain.Campaign
+	err := scanCampaign(repo.db.QueryRowContext(ctx, getCampaignQuery, campaignID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *CampaignRepositoryImpl) GetCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	return repo.queryCampaigns(ctx, getCampaignsQuery)
+}
+
+func (repo *CampaignRepositoryImpl) GetActiveCampaigns(ctx context.Context, now time.Time) ([]domain.Campaign, error) {
+	return repo.queryCampaigns(ctx, getActiveCampaignsQuery, constants.STATUS_ACTIVE, now)
+}
+
+func (repo *CampaignRepositoryImpl) queryCampaigns(ctx context.Context, query string, args ...interface{}) ([]domain.Campaign, error) {
+	var result []domain.Campaign
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Campaign{}
+		err := scanCampaign(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *CampaignRepositoryImpl) UpdateCampaignStatus(ctx context.Context, campaignID, status string) error {
+	_, err := repo.db.ExecContext(ctx, updateCampaignStatusQuery, status, campaignID)
+	return err
+}
+
+func (repo *CampaignRepositoryImpl) GetEligibleTransactions(ctx context.Context, campaign domain.Campaign, limit int) ([]domain.Transaction, error) {
+	query := getEligibleTransactionsQuery
+	args := []interface{}{
+		campaign.TransactionType,
+		constants.STATUS_SUCCESS,
+		campaign.Budget.Currency,
+		campaign.MinAmount,
+		campaign.StartsAt,
+		campaign.EndsAt,
+		limit,
+	}
+	if campaign.MerchantID != "" {
+		query = getEligibleMerchantTransactionsQuery
+		args = append([]interface{}{campaign.MerchantID}, args...)
+	}
+
+	var result []domain.Transaction
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Transaction{}
+		err := rows.Scan(
+			&data.ID,
+			&data.WalletID,
+			&data.CustomerXID,
+			&data.TransactionType,
+			&data.Amount,
+			&data.Amount.Currency,
+			&data.ReferenceID,
+			&data.Status,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *CampaignRepositoryImpl) GetCustomerCashbackTotal(ctx context.Context, campaignID, customerXID string) (int64, error) {
+	var result int64
+	err := repo.db.QueryRowContext(ctx, getCustomerCashbackTotalQuery, campaignID, customerXID, constants.STATUS_CANCELLED).Scan(&result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *CampaignRepositoryImpl) CreateCashback(ctx context.Context, cashback domain.Cashback, credit *domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if cashback.Amount.Amount > 0 {
+		res, err := tx.ExecContext(ctx, reserveCampaignBudgetQuery, cashback.Amount, cashback.CampaignID, cashback.Amount)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			_ = tx.Rollback()
+			return false, nil
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, insertCashbackQuery,
+		cashback.ID,
+		cashback.CampaignID,
+		cashback.CustomerXID,
+		cashback.WalletID,
+		cashback.SourceTransactionID,
+		cashback.Amount,
+		cashback.Status,
+		cashback.ReleaseAt,
+		cashback.TransactionID,
+		cashback.CreatedAt,
+		cashback.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	// held or declined, nothing to credit yet
+	if credit == nil {
+		err = tx.Commit()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, *credit)
+}
+
+func (repo *CampaignRepositoryImpl) GetDueCashbacks(ctx context.Context, now time.Time, limit int) ([]domain.Cashback, error) {
+	return repo.queryCashbacks(ctx, getDueCashbacksQuery, constants.STATUS_PENDING, now, limit)
+}
+
+func (repo *CampaignRepositoryImpl) GetCashbacks(ctx context.Context, customerXID string) ([]domain.Cashback, error) {
+	return repo.queryCashbacks(ctx, getCashbacksQuery, customerXID)
+}
+
+func (repo *CampaignRepositoryImpl) queryCashbacks(ctx context.Context, query string, args ...interface{}) ([]domain.Cashback, error) {
+	var result []domain.Cashback
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Cashback{}
+		err := rows.Scan(
+			&data.ID,
+			&data.CampaignID,
+			&data.CustomerXID,
+			&data.WalletID,
+			&data.SourceTransactionID,
+			&data.Amount,
+			&data.Amount.Currency,
+			&data.Status,
+			&data.ReleaseAt,
+			&data.TransactionID,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *CampaignRepositoryImpl) ReleaseCashback(ctx context.Context, cashback domain.Cashback, credit domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updateCashbackStatusQuery, constants.STATUS_SUCCESS, credit.ID, cashback.ID, constants.STATUS_PENDING)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, credit)
+}
+
+func (repo *CampaignRepositoryImpl) CancelCashback(ctx context.Context, cashback domain.Cashback) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updateCashbackStatusQuery, constants.STATUS_CANCELLED, "", cashback.ID, constants.STATUS_PENDING)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, releaseCampaignBudgetQuery, cashback.Amount, cashback.CampaignID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func scanCampaign(row rowScanner, campaign *domain.Campaign) error {
+	var currency string
+	err := row.Scan(
+		&campaign.ID,
+		&campaign.Name,
+		&campaign.TransactionType,
+		&campaign.MerchantID,
+		&currency,
+		&campaign.MinAmount,
+		&campaign.CashbackRate,
+		&campaign.MaxCashback,
+		&campaign.CustomerCap,
+		&campaign.Budget,
+		&campaign.BudgetUsed,
+		&campaign.HoldDays,
+		&campaign.StartsAt,
+		&campaign.EndsAt,
+		&campaign.Status,
+		&campaign.CreatedAt,
+		&campaign.UpdatedAt,
+	)
+	campaign.MinAmount.Currency = currency
+	campaign.MaxCashback.Currency = currency
+	campaign.CustomerCap.Currency = currency
+	campaign.Budget.Currency = currency
+	campaign.BudgetUsed.Currency = currency
+	return err
+}
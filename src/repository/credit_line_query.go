@@ -0,0 +1,25 @@
+package repository
+
+const (
+	// a limit can only be lowered down to the credit already used
+	updateCreditLimitQuery = `UPDATE wallets
+		SET
+			credit_limit = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			credit_used <= ?`
+
+	getOverdrawnWalletsQuery = selectWalletColumns + ` WHERE credit_used > 0 AND id > ? order by id LIMIT ?`
+
+	// interest is charged on top of the overdraft even past the credit limit,
+	// as long as the wallet is still overdrawn
+	chargeOverdraftInterestQuery = `UPDATE wallets
+		SET
+			balance = balance - ?,
+			credit_used = GREATEST(-balance, 0),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			credit_used > 0`
+)
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type CreditLineRepository interface {
+	// UpdateCreditLimit returns false when the wallet already uses more
+	// credit than the new limit.
+	UpdateCreditLimit(ctx context.Context, walletID string, creditLimit domain.Money) (bool, error)
+	// GetOverdrawnWallets pages through wallets with a negative balance in ID
+	// order, starting after afterID.
+	GetOverdrawnWallets(ctx context.Context, afterID string, limit int) ([]domain.Wallet, error)
+	// ChargeOverdraftInterest debits the interest and records it in a single
+	// database transaction. It returns false when the wallet is no longer
+	// overdrawn.
+	ChargeOverdraftInterest(ctx context.Context, interest domain.Transaction) (bool, error)
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type CreditLineRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCreditLineRepository(db *sql.DB) CreditLineRepository {
+	return &CreditLineRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *CreditLineRepositoryImpl) UpdateCreditLimit(ctx context.Context, walletID string, creditLimit domain.Money) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updateCreditLimitQuery, creditLimit, walletID, creditLimit)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *CreditLineRepositoryImpl) GetOverdrawnWallets(ctx context.Context, afterID string, limit int) ([]domain.Wallet, error) {
+	var result []domain.Wallet
+	rows, err := repo.db.QueryContext(ctx, getOverdrawnWalletsQuery, afterID, limit)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Wallet{}
+		err := scanWallet(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *CreditLineRepositoryImpl) ChargeOverdraftInterest(ctx context.Context, interest domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, chargeOverdraftInterestQuery, interest.Amount, interest.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	return commitWithTransaction(ctx, tx, interest)
+}
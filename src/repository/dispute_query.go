@@ -0,0 +1,65 @@
+package repository
+
+const (
+	insertDisputeQuery = `INSERT IGNORE INTO disputes
+		(id, transaction_id, wallet_id, customer_xid, transaction_type, amount, currency, reason, evidence, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectDisputeColumns = `SELECT 
+		id, transaction_id, wallet_id, customer_xid, transaction_type, amount, currency, reason, evidence, status, resolution_note, reversal_id, created_at, updated_at, resolved_at
+		FROM disputes`
+
+	getDisputeQuery = selectDisputeColumns + ` WHERE id = ?`
+
+	getDisputesQuery = selectDisputeColumns + ` WHERE customer_xid = ? order by created_at DESC`
+
+	getDisputesByStatusQuery = selectDisputeColumns + ` WHERE status = ? order by created_at`
+
+	updateDisputeStatusQuery = `UPDATE disputes
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	resolveDisputeQuery = `UPDATE disputes
+		SET
+			status = ?,
+			resolution_note = ?,
+			reversal_id = ?,
+			updated_at = ?,
+			resolved_at = ?
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	// locks a disputed transaction, so resolving its dispute and paying it
+	// back some other way wait on each other
+	lockTransactionQuery = `SELECT id FROM transactions WHERE id = ? FOR UPDATE`
+
+	// a locking read, it sees a reversal committed while the lock was awaited
+	isDisputeReversedQuery = `SELECT COUNT(*) FROM transactions WHERE transaction_type = ? AND reference_id = ? FOR UPDATE`
+
+	// a withdrawal whose payout failed, a refunded payment and a split bill
+	// share paid back to its payer already have their money back. Shares are
+	// paid with reference_id payreq-<id> and refunded with payreq-refund-<id>.
+	isTransactionReversedQuery = `SELECT
+		EXISTS (
+			SELECT 1 FROM payouts p
+			WHERE p.transaction_id = t.id AND p.status = ?
+		) OR EXISTS (
+			SELECT 1 FROM payouts p
+			JOIN transactions r ON r.transaction_type = ? AND r.reference_id = p.id
+			WHERE p.transaction_id = t.id
+		) OR EXISTS (
+			SELECT 1 FROM transactions r
+			WHERE t.transaction_type = ? AND r.transaction_type = ? AND r.reference_id = t.reference_id
+		) OR EXISTS (
+			SELECT 1 FROM transactions r
+			WHERE t.transaction_type = ? AND t.reference_id LIKE 'payreq-%' AND
+				r.transaction_type = ? AND r.reference_id = CONCAT('payreq-refund-', SUBSTRING(t.reference_id, 8))
+		)
+		FROM transactions t
+		WHERE t.id = ?`
+)
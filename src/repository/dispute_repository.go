@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type DisputeRepository interface {
+	// CreateDispute returns false when the transaction is already disputed.
+	CreateDispute(ctx context.Context, dispute domain.Dispute) (bool, error)
+	GetDispute(ctx context.Context, disputeID string) (domain.Dispute, error)
+	GetDisputes(ctx context.Context, customerXID string) ([]domain.Dispute, error)
+	GetDisputesByStatus(ctx context.Context, status string) ([]domain.Dispute, error)
+	// UpdateDisputeStatus returns false when the dispute is no longer in
+	// fromStatus.
+	UpdateDisputeStatus(ctx context.Context, disputeID, fromStatus, toStatus string) (bool, error)
+	// IsTransactionReversed reports whether the transaction already got its
+	// money back another way: a failed payout, a payment refund or a refunded
+	// split bill share.
+	IsTransactionReversed(ctx context.Context, transactionID string) (bool, error)
+
+	// ResolveDispute stores the outcome of the dispute and credits reversal
+	// when it is set, in a single database transaction. It returns false when
+	// the dispute is no longer in fromStatus, and domain.ErrTransactionReversed
+	// when reversal is set but the transaction already got its money back.
+	ResolveDispute(ctx context.Context, dispute domain.Dispute, fromStatus string, reversal *domain.Transaction) (bool, error)
+}
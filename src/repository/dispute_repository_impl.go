@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type DisputeRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewDisputeRepository(db *sql.DB) DisputeRepository {
+	return &DisputeRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *DisputeRepositoryImpl) CreateDispute(ctx context.Context, dispute domain.Dispute) (bool, error) {
+	evidence, err := json.Marshal(dispute.Evidence)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := repo.db.ExecContext(ctx, insertDisputeQuery,
+		dispute.ID,
+		dispute.TransactionID,
+		dispute.WalletID,
+		dispute.CustomerXID,
+		dispute.TransactionType,
+		dispute.Amount,
+		dispute.Amount.Currency,
+		dispute.Reason,
+		evidence,
+		dispute.Status,
+		dispute.CreatedAt,
+		dispute.UpdatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (repo *DisputeRepositoryImpl) GetDispute(ctx context.Context, disputeID string) (domain.Dispute, error) {
+	var result domain.Dispute
+	err := scanDispute(repo.db.QueryRowContext(ctx, getDisputeQuery, disputeID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *DisputeRepositoryImpl) GetDisputes(ctx context.Context, customerXID string) ([]domain.Dispute, error) {
+	return repo.queryDisputes(ctx, getDisputesQuery, customerXID)
+}
+
+func (repo *DisputeRepositoryImpl) GetDisputesByStatus(ctx context.Context, status string) ([]domain.Dispute, error) {
+	return repo.queryDisputes(ctx, getDisputesByStatusQuery, status)
+}
+
+func (repo *DisputeRepositoryImpl) queryDisputes(ctx context.Context, query string, args ...interface{}) ([]domain.Dispute, error) {
+	var result []domain.Dispute
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Dispute{}
+		err := scanDispute(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *DisputeRepositoryImpl) UpdateDisputeStatus(ctx context.Context, disputeID, fromStatus, toStatus string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updateDisputeStatusQuery, toStatus, disputeID, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (repo *DisputeRepositoryImpl) IsTransactionReversed(ctx context.Context, transactionID string) (bool, error) {
+	return isTransactionReversed(ctx, repo.db, transactionID)
+}
+
+func (repo *DisputeRepositoryImpl) ResolveDispute(ctx context.Context, dispute domain.Dispute, fromStatus string, reversal *domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, resolveDisputeQuery,
+		dispute.Status,
+		dispute.ResolutionNote,
+		dispute.ReversalID,
+		dispute.UpdatedAt,
+		dispute.ResolvedAt,
+		dispute.ID,
+		fromStatus,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	// resolved against the customer, the transaction stands
+	if reversal == nil {
+		err = tx.Commit()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	// the transaction may have been reversed while the dispute was open
+	err = lockTransaction(ctx, tx, dispute.TransactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	isReversed, err := isTransactionReversed(ctx, tx, dispute.TransactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if isReversed {
+		_ = tx.Rollback()
+		return false, domain.ErrTransactionReversed
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, reversal.Amount, reversal.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, *reversal)
+}
+
+func isTransactionReversed(ctx context.Context, db queryer, transactionID string) (bool, error) {
+	var isReversed bool
+	err := db.QueryRowContext(ctx, isTransactionReversedQuery,
+		constants.STATUS_FAILED,
+		constants.TRANSACTION_TYPE_WITHDRAWAL_REVERSAL,
+		constants.TRANSACTION_TYPE_PAYMENT, constants.TRANSACTION_TYPE_PAYMENT_REFUND,
+		constants.TRANSACTION_TYPE_TRANSFER_OUT, constants.TRANSACTION_TYPE_TRANSFER_OUT,
+		transactionID,
+	).Scan(&isReversed)
+	if err != nil {
+		return false, err
+	}
+	return isReversed, nil
+}
+
+// lockTransaction holds the transaction until tx ends.
+func lockTransaction(ctx context.Context, tx *sql.Tx, transactionID string) error {
+	var id string
+	return tx.QueryRowContext(ctx, lockTransactionQuery, transactionID).Scan(&id)
+}
+
+// isDisputeReversed locks the transaction and reports whether a dispute
+// already paid it back. It runs in the database transaction about to pay
+// the transaction back another way.
+func isDisputeReversed(ctx context.Context, tx *sql.Tx, transactionID string) (bool, error) {
+	err := lockTransaction(ctx, tx, transactionID)
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, isDisputeReversedQuery, constants.TRANSACTION_TYPE_DISPUTE_REVERSAL, transactionID).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func scanDispute(row rowScanner, dispute *domain.Dispute) error {
+	var evidence []byte
+	err := row.Scan(
+		&dispute.ID,
+		&dispute.TransactionID,
+		&dispute.WalletID,
+		&dispute.CustomerXID,
+		&dispute.TransactionType,
+		&dispute.Amount,
+		&dispute.Amount.Currency,
+		&dispute.Reason,
+		&evidence,
+		&dispute.Status,
+		&dispute.ResolutionNote,
+		&dispute.ReversalID,
+		&dispute.CreatedAt,
+		&dispute.UpdatedAt,
+		&dispute.ResolvedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(evidence, &dispute.Evidence)
+}
@@ -0,0 +1,35 @@
+package repository
+
+const (
+	insertEscrowQuery = `INSERT INTO escrows
+		(id, buyer_xid, buyer_wallet_id, seller_xid, seller_wallet_id, amount, currency, description, status, expires_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	insertEscrowEventQuery = `INSERT INTO escrow_events
+		(id, escrow_id, from_status, to_status, actor, note, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?)`
+
+	selectEscrowColumns = `SELECT 
+		id, buyer_xid, buyer_wallet_id, seller_xid, seller_wallet_id, amount, currency, description, status, expires_at, created_at, updated_at
+		FROM escrows`
+
+	getEscrowQuery = selectEscrowColumns + ` WHERE id = ?`
+
+	getEscrowsQuery = selectEscrowColumns + ` WHERE buyer_xid = ? OR seller_xid = ? order by created_at DESC`
+
+	getExpiredEscrowsQuery = selectEscrowColumns + ` WHERE status = ? AND expires_at <= ? order by expires_at LIMIT ?`
+
+	getEscrowEventsQuery = `SELECT 
+		id, escrow_id, from_status, to_status, actor, note, created_at
+		FROM escrow_events
+		WHERE escrow_id = ?
+		order by created_at`
+
+	updateEscrowStatusQuery = `UPDATE escrows
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+)
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type EscrowRepository interface {
+	// CreateEscrow debits the funding from the buyer's wallet and records the
+	// escrow with its first event in a single database transaction. It
+	// returns false when the balance does not cover the escrow.
+	CreateEscrow(ctx context.Context, escrow domain.Escrow, event domain.EscrowEvent, funding domain.Transaction) (bool, error)
+	GetEscrow(ctx context.Context, escrowID string) (domain.Escrow, error)
+	// GetEscrows returns the escrows the customer is the buyer or the seller
+	// of.
+	GetEscrows(ctx context.Context, customerXID string) ([]domain.Escrow, error)
+	GetExpiredEscrows(ctx context.Context, now time.Time, limit int) ([]domain.Escrow, error)
+	GetEscrowEvents(ctx context.Context, escrowID string) ([]domain.EscrowEvent, error)
+
+	// TransitionEscrow moves the escrow as event describes, crediting the
+	// wallet of credit when it is set, in a single database transaction. It
+	// returns false when the escrow already moved on.
+	TransitionEscrow(ctx context.Context, event domain.EscrowEvent, credit *domain.Transaction) (bool, error)
+}
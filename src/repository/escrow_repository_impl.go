@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type EscrowRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewEscrowRepository(db *sql.DB) EscrowRepository {
+	return &EscrowRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *EscrowRepositoryImpl) CreateEscrow(ctx context.Context, escrow domain.Escrow, event domain.EscrowEvent, funding domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, debitWalletBalanceQuery, funding.Amount, funding.WalletID, funding.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertEscrowQuery,
+		escrow.ID,
+		escrow.BuyerXID,
+		escrow.BuyerWalletID,
+		escrow.SellerXID,
+		escrow.SellerWalletID,
+		escrow.Amount,
+		escrow.Amount.Currency,
+		escrow.Description,
+		escrow.Status,
+		escrow.ExpiresAt,
+		escrow.CreatedAt,
+		escrow.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = insertEscrowEvent(ctx, tx, event)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, funding)
+}
+
+func (repo *EscrowRepositoryImpl) GetEscrow(ctx context.Context, escrowID string) (domain.Escrow, error) {
+	var result domain.Escrow
+	err := scanEscrow(repo.db.QueryRowContext(ctx, getEscrowQuery, escrowID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *EscrowRepositoryImpl) GetEscrows(ctx context.Context, customerXID string) ([]domain.Escrow, error) {
+	return repo.queryEscrows(ctx, getEscrowsQuery, customerXID, customerXID)
+}
+
+func (repo *EscrowRepositoryImpl) GetExpiredEscrows(ctx context.Context, now time.Time, limit int) ([]domain.Escrow, error) {
+	return repo.queryEscrows(ctx, getExpiredEscrowsQuery, constants.STATUS_FUNDED, now, limit)
+}
+
+func (repo *EscrowRepositoryImpl) queryEscrows(ctx context.Context, query string, args ...interface{}) ([]domain.Escrow, error) {
+	var result []domain.Escrow
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Escrow{}
+		err := scanEscrow(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *EscrowRepositoryImpl) GetEscrowEvents(ctx context.Context, escrowID string) ([]domain.EscrowEvent, error) {
+	var result []domain.EscrowEvent
+	rows, err := repo.db.QueryContext(ctx, getEscrowEventsQuery, escrowID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.EscrowEvent{}
+		err := rows.Scan(
+			&data.ID,
+			&data.EscrowID,
+			&data.FromStatus,
+			&data.ToStatus,
+			&data.Actor,
+			&data.Note,
+			&data.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *EscrowRepositoryImpl) TransitionEscrow(ctx context.Context, event domain.EscrowEvent, credit *domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updateEscrowStatusQuery, event.ToStatus, event.EscrowID, event.FromStatus)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	err = insertEscrowEvent(ctx, tx, event)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	// a dispute only holds the escrow, nothing to credit yet
+	if credit == nil {
+		err = tx.Commit()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, *credit)
+}
+
+func insertEscrowEvent(ctx context.Context, tx *sql.Tx, event domain.EscrowEvent) error {
+	_, err := tx.ExecContext(ctx, insertEscrowEventQuery,
+		event.ID,
+		event.EscrowID,
+		event.FromStatus,
+		event.ToStatus,
+		event.Actor,
+		event.Note,
+		event.CreatedAt,
+	)
+	return err
+}
+
+func scanEscrow(row rowScanner, escrow *domain.Escrow) error {
+	return row.Scan(
+		&escrow.ID,
+		&escrow.BuyerXID,
+		&escrow.BuyerWalletID,
+		&escrow.SellerXID,
+		&escrow.SellerWalletID,
+		&escrow.Amount,
+		&escrow.Amount.Currency,
+		&escrow.Description,
+		&escrow.Status,
+		&escrow.ExpiresAt,
+		&escrow.CreatedAt,
+		&escrow.UpdatedAt,
+	)
+}
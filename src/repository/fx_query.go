@@ -0,0 +1,36 @@
+package repository
+
+const (
+	upsertFxRateQuery = `INSERT INTO fx_rates
+		(base_currency, quote_currency, bid, ask, updated_at)
+		VALUES(?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON DUPLICATE KEY UPDATE
+			bid = VALUES(bid),
+			ask = VALUES(ask),
+			updated_at = CURRENT_TIMESTAMP`
+
+	getFxRateQuery = `SELECT 
+		base_currency, quote_currency, bid, ask, updated_at FROM fx_rates
+		WHERE base_currency = ? AND quote_currency = ?`
+
+	getFxRatesQuery = `SELECT 
+		base_currency, quote_currency, bid, ask, updated_at FROM fx_rates
+		order by base_currency, quote_currency`
+
+	insertFxQuoteQuery = `INSERT INTO fx_quotes
+		(id, customer_xid, from_currency, to_currency, rate, source_amount, target_amount, status, expires_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	getFxQuoteQuery = `SELECT 
+		id, customer_xid, from_currency, to_currency, rate, source_amount, target_amount, status, expires_at, created_at, updated_at 
+		FROM fx_quotes WHERE id = ?`
+
+	executeFxQuoteQuery = `UPDATE fx_quotes
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			expires_at > ?`
+)
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type FxRepository interface {
+	UpsertRate(ctx context.Context, rate domain.FxRate) error
+	GetRate(ctx context.Context, baseCurrency, quoteCurrency string) (domain.FxRate, error)
+	GetRates(ctx context.Context) ([]domain.FxRate, error)
+
+	CreateQuote(ctx context.Context, quote domain.FxQuote) error
+	GetQuote(ctx context.Context, quoteID string) (domain.FxQuote, error)
+	// ExecuteExchange consumes the quote and posts both legs in a single
+	// database transaction. It returns false when the quote is no longer
+	// executable or the debited wallet cannot cover the source amount.
+	ExecuteExchange(ctx context.Context, quoteID string, debit, credit domain.Transaction, now time.Time) (bool, error)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type FxRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewFxRepository(db *sql.DB) FxRepository {
+	return &FxRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *FxRepositoryImpl) UpsertRate(ctx context.Context, rate domain.FxRate) error {
+	_, err := repo.db.ExecContext(ctx, upsertFxRateQuery,
+		rate.BaseCurrency,
+		rate.QuoteCurrency,
+		rate.Bid,
+		rate.Ask,
+	)
+	return err
+}
+
+func (repo *FxRepositoryImpl) GetRate(ctx context.Context, baseCurrency, quoteCurrency string) (domain.FxRate, error) {
+	var result domain.FxRate
+	err := repo.db.QueryRowContext(ctx, getFxRateQuery, baseCurrency, quoteCurrency).Scan(
+		&result.BaseCurrency,
+		&result.QuoteCurrency,
+		&result.Bid,
+		&result.Ask,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *FxRepositoryImpl) GetRates(ctx context.Context) ([]domain.FxRate, error) {
+	var result []domain.FxRate
+	rows, err := repo.db.QueryContext(ctx, getFxRatesQuery)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.FxRate{}
+		err := rows.Scan(
+			&data.BaseCurrency,
+			&data.QuoteCurrency,
+			&data.Bid,
+			&data.Ask,
+			&data.UpdatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *FxRepositoryImpl) CreateQuote(ctx context.Context, quote domain.FxQuote) error {
+	_, err := repo.db.ExecContext(ctx, insertFxQuoteQuery,
+		quote.ID,
+		quote.CustomerXID,
+		quote.FromCurrency,
+		quote.ToCurrency,
+		quote.Rate,
+		quote.SourceAmount,
+		quote.TargetAmount,
+		quote.Status,
+		quote.ExpiresAt,
+		quote.CreatedAt,
+		quote.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *FxRepositoryImpl) GetQuote(ctx context.Context, quoteID string) (domain.FxQuote, error) {
+	var result domain.FxQuote
+	err := repo.db.QueryRowContext(ctx, getFxQuoteQuery, quoteID).Scan(
+		&result.ID,
+		&result.CustomerXID,
+		&result.FromCurrency,
+		&result.ToCurrency,
+		&result.Rate,
+		&result.SourceAmount,
+		&result.TargetAmount,
+		&result.Status,
+		&result.ExpiresAt,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		return result, err
+	}
+	result.SourceAmount.Currency = result.FromCurrency
+	result.TargetAmount.Currency = result.ToCurrency
+	return result, nil
+}
+
+func (repo *FxRepositoryImpl) ExecuteExchange(ctx context.Context, quoteID string, debit, credit domain.Transaction, now time.Time) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// lock the quote first so a concurrent execute of the same quote stops here
+	res, err := tx.ExecContext(ctx, executeFxQuoteQuery, constants.STATUS_EXECUTED, quoteID, constants.STATUS_PENDING, now)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	res, err = tx.ExecContext(ctx, debitWalletBalanceQuery, debit.Amount, debit.WalletID, debit.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	for _, transaction := range []domain.Transaction{debit, credit} {
+		err = insertTransaction(ctx, tx, transaction)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
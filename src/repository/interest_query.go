@@ -0,0 +1,76 @@
+package repository
+
+import "strings"
+
+var (
+	// the wallet balance comes with every successful transaction made since,
+	// both from the same read. The credit types and the success status come
+	// before the other arguments.
+	getWalletBalanceHistoryQuery = `SELECT 
+		COALESCE(w.balance, 0), w.currency, t.created_at,
+		CASE WHEN t.transaction_type IN (?` + strings.Repeat(", ?", len(creditTransactionTypes)-1) + `) THEN t.amount ELSE -t.amount END
+		FROM wallets w
+		LEFT JOIN transactions t ON t.wallet_id = w.id AND t.status = ? AND t.created_at >= ?
+		WHERE w.id = ?
+		order by t.created_at`
+)
+
+const (
+	updateInterestRateQuery = `UPDATE wallets
+		SET
+			interest_rate = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	// the balance is not filtered on, an empty source may still have days
+	// left to accrue from before it was emptied
+	getInterestBearingWalletsQuery = selectWalletColumns + ` WHERE status = ? AND interest_rate > 0 AND id > ? order by id LIMIT ?`
+
+	getInterestBearingPocketsQuery = `SELECT 
+		p.id, p.wallet_id, p.name, p.target_amount, p.balance, p.status, p.created_at, p.updated_at, w.currency
+		FROM pockets p JOIN wallets w ON w.id = p.wallet_id
+		WHERE p.status = ? AND w.status = ? AND p.id > ? order by p.id LIMIT ?`
+
+	getPocketBalanceHistoryQuery = `SELECT 
+		p.balance, w.currency, t.created_at,
+		CASE WHEN t.transaction_type = ? THEN t.amount ELSE -t.amount END
+		FROM pockets p JOIN wallets w ON w.id = p.wallet_id
+		LEFT JOIN transactions t ON t.pocket_id = p.id AND t.status = ? AND t.created_at >= ?
+		WHERE p.id = ?
+		order by t.created_at`
+
+	// carried remainders are not accruals of their own day
+	getLastInterestAccrualDateQuery = `SELECT 
+		MAX(accrual_date)
+		FROM interest_accruals
+		WHERE wallet_id = ? AND pocket_id = ? AND carried_from = ''`
+
+	// a source accrues once per day, reruns of the job are ignored
+	insertInterestAccrualQuery = `INSERT IGNORE INTO interest_accruals
+		(id, wallet_id, pocket_id, accrual_date, period, balance, rate, amount, transaction_id, carried_from, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	getUnpostedInterestAccrualsQuery = `SELECT 
+		a.id, a.wallet_id, a.pocket_id, a.accrual_date, a.period, a.balance, w.currency, a.rate, a.amount, a.transaction_id, a.carried_from, a.created_at
+		FROM interest_accruals a JOIN wallets w ON w.id = a.wallet_id
+		WHERE a.wallet_id = ? AND a.transaction_id = ''
+		order by a.accrual_date, a.pocket_id`
+
+	// the main balance and every pocket of a wallet are posted apart
+	getUnpostedInterestQuery = `SELECT 
+		a.wallet_id, a.pocket_id, w.customer_xid, w.currency, MAX(a.period), SUM(a.amount)
+		FROM interest_accruals a JOIN wallets w ON w.id = a.wallet_id
+		WHERE a.transaction_id = '' AND a.period < ? AND (a.wallet_id, a.pocket_id) > (?, ?)
+		GROUP BY a.wallet_id, a.pocket_id, w.customer_xid, w.currency
+		order by a.wallet_id, a.pocket_id LIMIT ?`
+
+	postInterestAccrualsQuery = `UPDATE interest_accruals
+		SET
+			transaction_id = ?
+		WHERE 
+			wallet_id = ? AND
+			pocket_id = ? AND
+			period <= ? AND
+			transaction_id = ''`
+)
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type InterestRepository interface {
+	UpdateInterestRate(ctx context.Context, walletID string, interestRate float64) error
+	// GetInterestBearingWallets and GetInterestBearingPockets page through
+	// the wallets paying interest and the open pockets in ID order, starting
+	// after afterID.
+	GetInterestBearingWallets(ctx context.Context, afterID string, limit int) ([]domain.Wallet, error)
+	GetInterestBearingPockets(ctx context.Context, afterID string, limit int) ([]domain.Pocket, error)
+	// GetWalletBalanceHistory and GetPocketBalanceHistory read the current
+	// balance together with the successful transactions that moved it at
+	// or after since.
+	GetWalletBalanceHistory(ctx context.Context, walletID string, since time.Time) (domain.BalanceHistory, error)
+	GetPocketBalanceHistory(ctx context.Context, pocketID string, since time.Time) (domain.BalanceHistory, error)
+	// GetLastInterestAccrualDate is the last day the main balance of the
+	// wallet, or the pocket, accrued interest, nil when it never did.
+	GetLastInterestAccrualDate(ctx context.Context, walletID, pocketID string) (*time.Time, error)
+	// AddInterestAccrual ignores an accrual already stored for the same
+	// source and day.
+	AddInterestAccrual(ctx context.Context, accrual domain.InterestAccrual) error
+	GetUnpostedInterestAccruals(ctx context.Context, walletID string) ([]domain.InterestAccrual, error)
+	// GetUnpostedInterest sums the unposted accruals of every wallet and
+	// pocket from periods before period, paging in wallet and pocket ID order
+	// after afterWalletID and afterPocketID.
+	GetUnpostedInterest(ctx context.Context, period, afterWalletID, afterPocketID string, limit int) ([]domain.InterestPosting, error)
+	// PostInterest marks the accruals of the posting as paid, credits the
+	// interest and stores the carried remainder, unless it is zero, in a
+	// single database transaction. Pocket interest is moved on into the
+	// pocket by allocation, unless the pocket was closed since. It returns
+	// false when the accruals were already posted.
+	PostInterest(ctx context.Context, posting domain.InterestPosting, interest domain.Transaction, allocation *domain.Transaction, carried domain.InterestAccrual) (bool, error)
+}
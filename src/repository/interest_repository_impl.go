@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type InterestRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewInterestRepository(db *sql.DB) InterestRepository {
+	return &InterestRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *InterestRepositoryImpl) UpdateInterestRate(ctx context.Context, walletID string, interestRate float64) error {
+	_, err := repo.db.ExecContext(ctx, updateInterestRateQuery, interestRate, walletID)
+	return err
+}
+
+func (repo *InterestRepositoryImpl) GetInterestBearingWallets(ctx context.Context, afterID string, limit int) ([]domain.Wallet, error) {
+	var result []domain.Wallet
+	rows, err := repo.db.QueryContext(ctx, getInterestBearingWalletsQuery, constants.STATUS_ENABLED, afterID, limit)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Wallet{}
+		err := scanWallet(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *InterestRepositoryImpl) GetInterestBearingPockets(ctx context.Context, afterID string, limit int) ([]domain.Pocket, error) {
+	var result []domain.Pocket
+	rows, err := repo.db.QueryContext(ctx, getInterestBearingPocketsQuery, constants.STATUS_ACTIVE, constants.STATUS_ENABLED, afterID, limit)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Pocket{}
+		err := scanPocket(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *InterestRepositoryImpl) GetWalletBalanceHistory(ctx context.Context, walletID string, since time.Time) (domain.BalanceHistory, error) {
+	return repo.getBalanceHistory(ctx, getWalletBalanceHistoryQuery, balanceCheckArgs(since, walletID)...)
+}
+
+func (repo *InterestRepositoryImpl) GetPocketBalanceHistory(ctx context.Context, pocketID string, since time.Time) (domain.BalanceHistory, error) {
+	return repo.getBalanceHistory(ctx, getPocketBalanceHistoryQuery, constants.TRANSACTION_TYPE_POCKET_ALLOCATION, constants.STATUS_SUCCESS, since, pocketID)
+}
+
+// getBalanceHistory reads a balance repeated on every row of its
+// transactions, a balance nothing moved since comes on a row of its own.
+func (repo *InterestRepositoryImpl) getBalanceHistory(ctx context.Context, query string, args ...interface{}) (domain.BalanceHistory, error) {
+	result := domain.BalanceHistory{}
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	isFound := false
+	for rows.Next() {
+		var (
+			createdAt *time.Time
+			amount    *int64
+		)
+		err := rows.Scan(
+			&result.Balance,
+			&result.Balance.Currency,
+			&createdAt,
+			&amount,
+		)
+		if err != nil {
+			return result, err
+		}
+		isFound = true
+
+		if createdAt != nil && amount != nil {
+			result.Movements = append(result.Movements, domain.BalanceMovement{
+				Amount:    *amount,
+				CreatedAt: *createdAt,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+	if !isFound {
+		return result, sql.ErrNoRows
+	}
+	return result, nil
+}
+
+func (repo *InterestRepositoryImpl) GetLastInterestAccrualDate(ctx context.Context, walletID, pocketID string) (*time.Time, error) {
+	var result *time.Time
+	err := repo.db.QueryRowContext(ctx, getLastInterestAccrualDateQuery, walletID, pocketID).Scan(&result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (repo *InterestRepositoryImpl) AddInterestAccrual(ctx context.Context, accrual domain.InterestAccrual) error {
+	_, err := repo.db.ExecContext(ctx, insertInterestAccrualQuery, interestAccrualArgs(accrual)...)
+	return err
+}
+
+func interestAccrualArgs(accrual domain.InterestAccrual) []interface{} {
+	return []interface{}{
+		accrual.ID,
+		accrual.WalletID,
+		accrual.PocketID,
+		accrual.AccrualDate.Format("2006-01-02"),
+		accrual.Period,
+		accrual.Balance,
+		accrual.Rate,
+		accrual.Amount.FloatString(6),
+		accrual.TransactionID,
+		accrual.CarriedFrom,
+		accrual.CreatedAt,
+	}
+}
+
+func (repo *InterestRepositoryImpl) GetUnpostedInterestAccruals(ctx context.Context, walletID string) ([]domain.InterestAccrual, error) {
+	var result []domain.InterestAccrual
+	rows, err := repo.db.QueryContext(ctx, getUnpostedInterestAccrualsQuery, walletID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.InterestAccrual{}
+		var amount string
+		err := rows.Scan(
+			&data.ID,
+			&data.WalletID,
+			&data.PocketID,
+			&data.AccrualDate,
+			&data.Period,
+			&data.Balance,
+			&data.Balance.Currency,
+			&data.Rate,
+			&amount,
+			&data.TransactionID,
+			&data.CarriedFrom,
+			&data.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		data.Amount, err = parseDecimal(amount)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *InterestRepositoryImpl) GetUnpostedInterest(ctx context.Context, period, afterWalletID, afterPocketID string, limit int) ([]domain.InterestPosting, error) {
+	var result []domain.InterestPosting
+	rows, err := repo.db.QueryContext(ctx, getUnpostedInterestQuery, period, afterWalletID, afterPocketID, limit)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.InterestPosting{}
+		var amount string
+		err := rows.Scan(
+			&data.WalletID,
+			&data.PocketID,
+			&data.CustomerXID,
+			&data.Currency,
+			&data.Period,
+			&amount,
+		)
+		if err != nil {
+			return result, err
+		}
+		data.Amount, err = parseDecimal(amount)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *InterestRepositoryImpl) PostInterest(ctx context.Context, posting domain.InterestPosting, interest domain.Transaction, allocation *domain.Transaction, carried domain.InterestAccrual) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, postInterestAccrualsQuery, interest.ID, posting.WalletID, posting.PocketID, posting.Period)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	if carried.Amount.Sign() != 0 {
+		_, err = tx.ExecContext(ctx, insertInterestAccrualQuery, interestAccrualArgs(carried)...)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	// the interest credited to the main balance is allocated to the pocket
+	// straight away, so only the pocket balance grows
+	if allocation != nil {
+		res, err = tx.ExecContext(ctx, creditPocketBalanceQuery, allocation.Amount, posting.PocketID, constants.STATUS_ACTIVE)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected > 0 {
+			err = insertTransaction(ctx, tx, interest)
+			if err != nil {
+				_ = tx.Rollback()
+				return false, err
+			}
+			return commitWithTransaction(ctx, tx, *allocation)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, interest.Amount, interest.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, interest)
+}
+
+// parseDecimal reads a DECIMAL column without going through float64.
+func parseDecimal(value string) (*big.Rat, error) {
+	result, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, errors.New("invalid decimal " + value)
+	}
+	return result, nil
+}
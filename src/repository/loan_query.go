@@ -0,0 +1,55 @@
+package repository
+
+const (
+	insertLoanQuery = `INSERT INTO loans
+		(id, customer_xid, wallet_id, reference_id, principal, interest_amount, late_fee, currency, installment_count, frequency, status, transaction_id, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectLoanColumns = `SELECT 
+		id, customer_xid, wallet_id, reference_id, principal, interest_amount, late_fee, currency, installment_count, frequency, status, transaction_id, created_at, updated_at
+		FROM loans`
+
+	getLoanQuery = selectLoanColumns + ` WHERE id = ?`
+
+	getLoanByReferenceQuery = selectLoanColumns + ` WHERE reference_id = ?`
+
+	getLoansQuery = selectLoanColumns + ` WHERE customer_xid = ? order by created_at DESC`
+
+	// a loan is completed once its last installment is paid
+	completeLoanQuery = `UPDATE loans
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			NOT EXISTS (SELECT 1 FROM loan_installments WHERE loan_id = ? AND status != ?)`
+
+	insertLoanInstallmentQuery = `INSERT INTO loan_installments
+		(id, loan_id, sequence, due_at, amount, late_fee, paid_amount, status, next_collect_at, paid_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectLoanInstallmentColumns = `SELECT 
+		li.id, li.loan_id, li.sequence, li.due_at, li.amount, li.late_fee, li.paid_amount, l.currency, li.status, li.next_collect_at, li.paid_at, li.created_at, li.updated_at
+		FROM loan_installments li
+		JOIN loans l ON l.id = li.loan_id`
+
+	getLoanInstallmentsQuery = selectLoanInstallmentColumns + ` WHERE li.loan_id = ? order by li.sequence`
+
+	getDueLoanInstallmentsQuery = selectLoanInstallmentColumns + ` WHERE li.status IN (?, ?) AND li.next_collect_at <= ? order by li.next_collect_at LIMIT ?`
+
+	// claims the collection, an installment collected concurrently no longer
+	// matches next_collect_at
+	collectLoanInstallmentQuery = `UPDATE loan_installments
+		SET
+			late_fee = ?,
+			paid_amount = ?,
+			status = ?,
+			next_collect_at = ?,
+			paid_at = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status IN (?, ?) AND
+			next_collect_at = ?`
+)
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type LoanRepository interface {
+	// CreateLoan stores the loan with its installments and credits the
+	// disbursement in a single database transaction.
+	CreateLoan(ctx context.Context, loan domain.Loan, installments []domain.LoanInstallment, disbursement domain.Transaction) error
+	GetLoan(ctx context.Context, loanID string) (domain.Loan, error)
+	GetLoanByReference(ctx context.Context, referenceID string) (domain.Loan, error)
+	GetLoans(ctx context.Context, customerXID string) ([]domain.Loan, error)
+	GetLoanInstallments(ctx context.Context, loanID string) ([]domain.LoanInstallment, error)
+	GetDueLoanInstallments(ctx context.Context, now time.Time, limit int) ([]domain.LoanInstallment, error)
+	// CollectLoanInstallment stores the outcome of the collection due at
+	// collectAt and debits the repayment, if any, in a single database
+	// transaction. It returns false when the collection was already made or
+	// the wallet no longer holds the repayment.
+	CollectLoanInstallment(ctx context.Context, installment domain.LoanInstallment, collectAt time.Time, repayment *domain.Transaction) (bool, error)
+}
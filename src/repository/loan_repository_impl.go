@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type LoanRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewLoanRepository(db *sql.DB) LoanRepository {
+	return &LoanRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *LoanRepositoryImpl) CreateLoan(ctx context.Context, loan domain.Loan, installments []domain.LoanInstallment, disbursement domain.Transaction) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, insertLoanQuery,
+		loan.ID,
+		loan.CustomerXID,
+		loan.WalletID,
+		loan.ReferenceID,
+		loan.Principal,
+		loan.InterestAmount,
+		loan.LateFee,
+		loan.Principal.Currency,
+		loan.InstallmentCount,
+		loan.Frequency,
+		loan.Status,
+		loan.TransactionID,
+		loan.CreatedAt,
+		loan.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, installment := range installments {
+		_, err = tx.ExecContext(ctx, insertLoanInstallmentQuery,
+			installment.ID,
+			installment.LoanID,
+			installment.Sequence,
+			installment.DueAt,
+			installment.Amount,
+			installment.LateFee,
+			installment.PaidAmount,
+			installment.Status,
+			installment.NextCollectAt,
+			installment.PaidAt,
+			installment.CreatedAt,
+			installment.UpdatedAt,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, disbursement.Amount, disbursement.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = commitWithTransaction(ctx, tx, disbursement)
+	return err
+}
+
+func (repo *LoanRepositoryImpl) GetLoan(ctx context.Context, loanID string) (domain.Loan, error) {
+	var result domain.Loan
+	err := scanLoan(repo.db.QueryRowContext(ctx, getLoanQuery, loanID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *LoanRepositoryImpl) GetLoanByReference(ctx context.Context, referenceID string) (domain.Loan, error) {
+	var result domain.Loan
+	err := scanLoan(repo.db.QueryRowContext(ctx, getLoanByReferenceQuery, referenceID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *LoanRepositoryImpl) GetLoans(ctx context.Context, customerXID string) ([]domain.Loan, error) {
+	var result []domain.Loan
+	rows, err := repo.db.QueryContext(ctx, getLoansQuery, customerXID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Loan{}
+		err := scanLoan(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *LoanRepositoryImpl) GetLoanInstallments(ctx context.Context, loanID string) ([]domain.LoanInstallment, error) {
+	return repo.queryLoanInstallments(ctx, getLoanInstallmentsQuery, loanID)
+}
+
+func (repo *LoanRepositoryImpl) GetDueLoanInstallments(ctx context.Context, now time.Time, limit int) ([]domain.LoanInstallment, error) {
+	return repo.queryLoanInstallments(ctx, getDueLoanInstallmentsQuery, constants.STATUS_PENDING, constants.STATUS_PARTIAL, now, limit)
+}
+
+func (repo *LoanRepositoryImpl) queryLoanInstallments(ctx context.Context, query string, args ...interface{}) ([]domain.LoanInstallment, error) {
+	var result []domain.LoanInstallment
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.LoanInstallment{}
+		var currency string
+		err := rows.Scan(
+			&data.ID,
+			&data.LoanID,
+			&data.Sequence,
+			&data.DueAt,
+			&data.Amount,
+			&data.LateFee,
+			&data.PaidAmount,
+			&currency,
+			&data.Status,
+			&data.NextCollectAt,
+			&data.PaidAt,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		data.Amount.Currency = currency
+		data.LateFee.Currency = currency
+		data.PaidAmount.Currency = currency
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *LoanRepositoryImpl) CollectLoanInstallment(ctx context.Context, installment domain.LoanInstallment, collectAt time.Time, repayment *domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, collectLoanInstallmentQuery,
+		installment.LateFee,
+		installment.PaidAmount,
+		installment.Status,
+		installment.NextCollectAt,
+		installment.PaidAt,
+		installment.ID,
+		constants.STATUS_PENDING,
+		constants.STATUS_PARTIAL,
+		collectAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	if installment.Status == constants.STATUS_PAID {
+		_, err = tx.ExecContext(ctx, completeLoanQuery,
+			constants.STATUS_COMPLETED,
+			installment.LoanID,
+			constants.STATUS_ACTIVE,
+			installment.LoanID,
+			constants.STATUS_PAID,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	// nothing could be collected, only the late fee is recorded
+	if repayment == nil {
+		err = tx.Commit()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	res, err = tx.ExecContext(ctx, debitWalletBalanceQuery, repayment.Amount, repayment.WalletID, repayment.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	return commitWithTransaction(ctx, tx, *repayment)
+}
+
+func scanLoan(row rowScanner, loan *domain.Loan) error {
+	var currency string
+	err := row.Scan(
+		&loan.ID,
+		&loan.CustomerXID,
+		&loan.WalletID,
+		&loan.ReferenceID,
+		&loan.Principal,
+		&loan.InterestAmount,
+		&loan.LateFee,
+		&currency,
+		&loan.InstallmentCount,
+		&loan.Frequency,
+		&loan.Status,
+		&loan.TransactionID,
+		&loan.CreatedAt,
+		&loan.UpdatedAt,
+	)
+	loan.Principal.Currency = currency
+	loan.InterestAmount.Currency = currency
+	loan.LateFee.Currency = currency
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+const (
+	// a refunded payment earns no points
+	getPointsEligibleTransactionsQuery = `SELECT 
+		t.id, t.wallet_id, t.customer_xid, t.transaction_type, t.amount, t.currency, t.reference_id, t.status, t.created_at, t.updated_at
+		FROM transactions t
+		LEFT JOIN points_entries p ON p.entry_type = ? AND p.reference_id = t.id
+		LEFT JOIN transactions r ON r.transaction_type = ? AND r.reference_id = t.reference_id
+		WHERE 
+			t.transaction_type = ? AND
+			t.status = ? AND
+			t.currency = ? AND
+			t.amount >= ? AND
+			t.created_at >= ? AND
+			p.id IS NULL AND
+			r.id IS NULL
+		order by t.created_at LIMIT ?`
+
+	// unexpired lots earned on a payment refunded since, not yet reversed
+	getRefundedPointsLotsQuery = `SELECT 
+		e.id, e.customer_xid, e.entry_type, e.points, e.remaining, e.reference_id, e.expires_at, e.transaction_id, e.created_at
+		FROM points_entries e
+		JOIN transactions t ON t.id = e.reference_id
+		JOIN transactions r ON r.transaction_type = ? AND r.reference_id = t.reference_id
+		LEFT JOIN points_entries v ON v.entry_type = ? AND v.reference_id = e.reference_id
+		WHERE 
+			e.entry_type = ? AND
+			e.expires_at > ? AND
+			v.id IS NULL
+		order by e.created_at LIMIT ?`
+
+	getPointsBalanceQuery = `SELECT COALESCE(MAX(balance), 0)
+		FROM loyalty_accounts WHERE customer_xid = ?`
+
+	lockPointsBalanceQuery = `SELECT balance
+		FROM loyalty_accounts WHERE customer_xid = ? FOR UPDATE`
+
+	creditPointsBalanceQuery = `INSERT INTO loyalty_accounts
+		(customer_xid, balance)
+		VALUES(?, ?)
+		ON DUPLICATE KEY UPDATE
+			balance = balance + VALUES(balance),
+			updated_at = CURRENT_TIMESTAMP`
+
+	debitPointsBalanceQuery = `UPDATE loyalty_accounts
+		SET
+			balance = balance - ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			customer_xid = ? AND
+			balance >= ?`
+
+	insertPointsEntryQuery = `INSERT IGNORE INTO points_entries
+		(id, customer_xid, entry_type, points, remaining, reference_id, expires_at, transaction_id, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectPointsEntryColumns = `SELECT 
+		id, customer_xid, entry_type, points, remaining, reference_id, expires_at, transaction_id, created_at
+		FROM points_entries`
+
+	getPointsEntriesQuery = selectPointsEntryColumns + ` WHERE customer_xid = ? order by created_at DESC`
+
+	// lots are spent in the order they expire
+	getAvailablePointsLotsQuery = selectPointsEntryColumns + ` WHERE customer_xid = ? AND entry_type = ? AND remaining > 0 AND expires_at > ? order by expires_at, created_at, id`
+
+	getExpiredPointsLotsQuery = selectPointsEntryColumns + ` WHERE expires_at <= ? AND remaining > 0 order by expires_at LIMIT ?`
+
+	spendPointsLotQuery = `UPDATE points_entries
+		SET
+			remaining = remaining - ?
+		WHERE 
+			id = ? AND
+			remaining >= ?`
+)
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type LoyaltyRepository interface {
+	// GetPointsEligibleTransactions returns payments made since the given
+	// time that are large enough to earn points and did not earn any yet.
+	GetPointsEligibleTransactions(ctx context.Context, currency string, minAmount int64, since time.Time, limit int) ([]domain.Transaction, error)
+	GetPointsBalance(ctx context.Context, customerXID string) (int64, error)
+	GetPointsEntries(ctx context.Context, customerXID string) ([]domain.PointsEntry, error)
+	GetAvailablePointsLots(ctx context.Context, customerXID string, now time.Time) ([]domain.PointsEntry, error)
+	GetExpiredPointsLots(ctx context.Context, now time.Time, limit int) ([]domain.PointsEntry, error)
+	// GetRefundedPointsLots returns the unexpired lots earned on payments
+	// that were refunded afterwards and not reversed yet.
+	GetRefundedPointsLots(ctx context.Context, now time.Time, limit int) ([]domain.PointsEntry, error)
+
+	// EarnPoints records the earned lot and adds it to the balance. It
+	// returns false when the reference already earned points.
+	EarnPoints(ctx context.Context, entry domain.PointsEntry) (bool, error)
+	// RedeemPoints spends the points from the oldest lots and credits the
+	// wallet in a single database transaction. It returns false when the
+	// unexpired lots cannot cover the points.
+	RedeemPoints(ctx context.Context, entry domain.PointsEntry, now time.Time, credit domain.Transaction) (bool, error)
+	// ExpirePoints writes off what is left of an expired lot. It returns
+	// false when the lot was spent meanwhile.
+	ExpirePoints(ctx context.Context, lot domain.PointsEntry, entry domain.PointsEntry) (bool, error)
+	// ReversePoints takes back the points of a refunded lot, first from what
+	// is left of the lot and then from the oldest other lots, and debits the
+	// balance by the same in a single database transaction. Points already
+	// redeemed or expired are not taken back, the entry records what was. It
+	// returns false when the lot was already reversed.
+	ReversePoints(ctx context.Context, lot domain.PointsEntry, entry domain.PointsEntry, now time.Time) (bool, error)
+}
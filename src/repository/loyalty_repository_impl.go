@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type LoyaltyRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewLoyaltyRepository(db *sql.DB) LoyaltyRepository {
+	return &LoyaltyRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *LoyaltyRepositoryImpl) GetPointsEligibleTransactions(ctx context.Context, currency string, minAmount int64, since time.Time, limit int) ([]domain.Transaction, error) {
+	var result []domain.Transaction
+	rows, err := repo.db.QueryContext(ctx, getPointsEligibleTransactionsQuery,
+		constants.POINTS_ENTRY_EARN,
+		constants.TRANSACTION_TYPE_PAYMENT_REFUND,
+		constants.TRANSACTION_TYPE_PAYMENT,
+		constants.STATUS_SUCCESS,
+		currency,
+		minAmount,
+		since,
+		limit,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Transaction{}
+		err := rows.Scan(
+			&data.ID,
+			&data.WalletID,
+			&data.CustomerXID,
+			&data.TransactionType,
+			&data.Amount,
+			&data.Amount.Currency,
+			&data.ReferenceID,
+			&data.Status,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *LoyaltyRepositoryImpl) GetPointsBalance(ctx context.Context, customerXID string) (int64, error) {
+	var result int64
+	err := repo.db.QueryRowContext(ctx, getPointsBalanceQuery, customerXID).Scan(&result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *LoyaltyRepositoryImpl) GetPointsEntries(ctx context.Context, customerXID string) ([]domain.PointsEntry, error) {
+	return queryPointsEntries(ctx, repo.db, getPointsEntriesQuery, customerXID)
+}
+
+func (repo *LoyaltyRepositoryImpl) GetAvailablePointsLots(ctx context.Context, customerXID string, now time.Time) ([]domain.PointsEntry, error) {
+	return queryPointsEntries(ctx, repo.db, getAvailablePointsLotsQuery, customerXID, constants.POINTS_ENTRY_EARN, now)
+}
+
+func (repo *LoyaltyRepositoryImpl) GetExpiredPointsLots(ctx context.Context, now time.Time, limit int) ([]domain.PointsEntry, error) {
+	return queryPointsEntries(ctx, repo.db, getExpiredPointsLotsQuery, now, limit)
+}
+
+func (repo *LoyaltyRepositoryImpl) GetRefundedPointsLots(ctx context.Context, now time.Time, limit int) ([]domain.PointsEntry, error) {
+	return queryPointsEntries(ctx, repo.db, getRefundedPointsLotsQuery,
+		constants.TRANSACTION_TYPE_PAYMENT_REFUND,
+		constants.POINTS_ENTRY_EARN_REVERSAL,
+		constants.POINTS_ENTRY_EARN,
+		now,
+		limit,
+	)
+}
+
+func (repo *LoyaltyRepositoryImpl) EarnPoints(ctx context.Context, entry domain.PointsEntry) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	isInserted, err := insertPointsEntry(ctx, tx, entry)
+	if err != nil || !isInserted {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, creditPointsBalanceQuery, entry.CustomerXID, entry.Points)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (repo *LoyaltyRepositoryImpl) RedeemPoints(ctx context.Context, entry domain.PointsEntry, now time.Time, credit domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// the balance row is locked first, so lots of the customer are only
+	// spent by one redemption or expiry at a time
+	points := -entry.Points
+	res, err := tx.ExecContext(ctx, debitPointsBalanceQuery, points, entry.CustomerXID, points)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	lots, err := queryPointsEntries(ctx, tx, getAvailablePointsLotsQuery, entry.CustomerXID, constants.POINTS_ENTRY_EARN, now)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	for _, lot := range lots {
+		if points == 0 {
+			break
+		}
+		spent := lot.Remaining
+		if spent > points {
+			spent = points
+		}
+
+		res, err = tx.ExecContext(ctx, spendPointsLotQuery, spent, lot.ID, spent)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			_ = tx.Rollback()
+			return false, nil
+		}
+		points -= spent
+	}
+
+	// part of the balance already expired
+	if points > 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	isInserted, err := insertPointsEntry(ctx, tx, entry)
+	if err != nil || !isInserted {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, credit)
+}
+
+func (repo *LoyaltyRepositoryImpl) ExpirePoints(ctx context.Context, lot domain.PointsEntry, entry domain.PointsEntry) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, debitPointsBalanceQuery, lot.Remaining, lot.CustomerXID, lot.Remaining)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	// the lot must still hold what was read, a partly spent one is retried
+	res, err = tx.ExecContext(ctx, spendPointsLotQuery, lot.Remaining, lot.ID, lot.Remaining)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	isInserted, err := insertPointsEntry(ctx, tx, entry)
+	if err != nil || !isInserted {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (repo *LoyaltyRepositoryImpl) ReversePoints(ctx context.Context, lot domain.PointsEntry, entry domain.PointsEntry, now time.Time) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// the balance row is locked first, like a redemption or expiry
+	var balance int64
+	err = tx.QueryRowContext(ctx, lockPointsBalanceQuery, lot.CustomerXID).Scan(&balance)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	lots, err := queryPointsEntries(ctx, tx, getAvailablePointsLotsQuery, lot.CustomerXID, constants.POINTS_ENTRY_EARN, now)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	// the refunded lot is spent before any other
+	for i := range lots {
+		if lots[i].ID == lot.ID {
+			lots[0], lots[i] = lots[i], lots[0]
+			break
+		}
+	}
+
+	points := lot.Points
+	if points > balance {
+		points = balance
+	}
+	taken := int64(0)
+	for _, available := range lots {
+		if taken == points {
+			break
+		}
+		spent := available.Remaining
+		if spent > points-taken {
+			spent = points - taken
+		}
+
+		res, err := tx.ExecContext(ctx, spendPointsLotQuery, spent, available.ID, spent)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			_ = tx.Rollback()
+			return false, nil
+		}
+		taken += spent
+	}
+
+	if taken > 0 {
+		_, err = tx.ExecContext(ctx, debitPointsBalanceQuery, taken, lot.CustomerXID, taken)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	entry.Points = -taken
+	isInserted, err := insertPointsEntry(ctx, tx, entry)
+	if err != nil || !isInserted {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func insertPointsEntry(ctx context.Context, tx *sql.Tx, entry domain.PointsEntry) (bool, error) {
+	res, err := tx.ExecContext(ctx, insertPointsEntryQuery,
+		entry.ID,
+		entry.CustomerXID,
+		entry.EntryType,
+		entry.Points,
+		entry.Remaining,
+		entry.ReferenceID,
+		entry.ExpiresAt,
+		entry.TransactionID,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func queryPointsEntries(ctx context.Context, db queryer, query string, args ...interface{}) ([]domain.PointsEntry, error) {
+	var result []domain.PointsEntry
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.PointsEntry{}
+		err := rows.Scan(
+			&data.ID,
+			&data.CustomerXID,
+			&data.EntryType,
+			&data.Points,
+			&data.Remaining,
+			&data.ReferenceID,
+			&data.ExpiresAt,
+			&data.TransactionID,
+			&data.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
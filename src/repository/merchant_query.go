@@ -0,0 +1,52 @@
+package repository
+
+const (
+	insertMerchantQuery = `INSERT INTO merchants
+		(id, customer_xid, name, city, category_code, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectMerchantColumns = `SELECT 
+		id, customer_xid, name, city, category_code, status, created_at, updated_at
+		FROM merchants`
+
+	getMerchantQuery = selectMerchantColumns + ` WHERE id = ?`
+
+	getMerchantByCustomerXIDQuery = selectMerchantColumns + ` WHERE customer_xid = ?`
+
+	insertPaymentIntentQuery = `INSERT INTO payment_intents
+		(id, merchant_id, merchant_reference, amount, currency, description, status, customer_xid, transaction_id, expires_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectPaymentIntentColumns = `SELECT 
+		id, merchant_id, merchant_reference, amount, currency, description, status, customer_xid, transaction_id, expires_at, created_at, updated_at
+		FROM payment_intents`
+
+	getPaymentIntentQuery = selectPaymentIntentColumns + ` WHERE id = ?`
+
+	getPaymentIntentTransactionIDQuery = `SELECT transaction_id FROM payment_intents WHERE id = ?`
+
+	getPaymentIntentByReferenceQuery = selectPaymentIntentColumns + ` WHERE merchant_id = ? AND merchant_reference = ?`
+
+	getPaymentIntentsQuery = selectPaymentIntentColumns + ` WHERE merchant_id = ? order by created_at DESC`
+
+	getPaymentIntentsByStatusQuery = selectPaymentIntentColumns + ` WHERE merchant_id = ? AND status = ? order by created_at DESC`
+
+	updatePaymentIntentStatusQuery = `UPDATE payment_intents
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	confirmPaymentIntentQuery = `UPDATE payment_intents
+		SET
+			status = ?,
+			customer_xid = ?,
+			transaction_id = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			expires_at > ?`
+)
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type MerchantRepository interface {
+	CreateMerchant(ctx context.Context, merchant domain.Merchant) error
+	GetMerchant(ctx context.Context, merchantID string) (domain.Merchant, error)
+	GetMerchantByCustomerXID(ctx context.Context, customerXID string) (domain.Merchant, error)
+
+	CreatePaymentIntent(ctx context.Context, paymentIntent domain.PaymentIntent) error
+	GetPaymentIntent(ctx context.Context, paymentIntentID string) (domain.PaymentIntent, error)
+	GetPaymentIntentByReference(ctx context.Context, merchantID, merchantReference string) (domain.PaymentIntent, error)
+	// GetPaymentIntents lists the intents of a merchant, an empty status
+	// lists all of them.
+	GetPaymentIntents(ctx context.Context, merchantID, status string) ([]domain.PaymentIntent, error)
+	UpdatePaymentIntentStatus(ctx context.Context, paymentIntentID, fromStatus, toStatus string) (bool, error)
+	// ConfirmPaymentIntent marks the intent paid by the debited customer and
+	// posts the debit in a single database transaction. It returns false when
+	// the intent is no longer payable or the customer cannot cover it.
+	ConfirmPaymentIntent(ctx context.Context, paymentIntentID string, debit domain.Transaction, now time.Time) (bool, error)
+	// RefundPaymentIntent marks a paid intent refunded and credits the
+	// customer in a single database transaction. It returns false when the
+	// intent is not paid, and domain.ErrTransactionReversed when a dispute
+	// already paid the payment back.
+	RefundPaymentIntent(ctx context.Context, paymentIntentID string, credit domain.Transaction) (bool, error)
+}
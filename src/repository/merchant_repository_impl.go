@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type MerchantRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewMerchantRepository(db *sql.DB) MerchantRepository {
+	return &MerchantRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *MerchantRepositoryImpl) CreateMerchant(ctx context.Context, merchant domain.Merchant) error {
+	_, err := repo.db.ExecContext(ctx, insertMerchantQuery,
+		merchant.ID,
+		merchant.CustomerXID,
+		merchant.Name,
+		merchant.City,
+		merchant.CategoryCode,
+		merchant.Status,
+		merchant.CreatedAt,
+		merchant.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *MerchantRepositoryImpl) GetMerchant(ctx context.Context, merchantID string) (domain.Merchant, error) {
+	var result domain.Merchant
+	err := scanMerchant(repo.db.QueryRowContext(ctx, getMerchantQuery, merchantID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *MerchantRepositoryImpl) GetMerchantByCustomerXID(ctx context.Context, customerXID string) (domain.Merchant, error) {
+	var result domain.Merchant
+	err := scanMerchant(repo.db.QueryRowContext(ctx, getMerchantByCustomerXIDQuery, customerXID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *MerchantRepositoryImpl) CreatePaymentIntent(ctx context.Context, paymentIntent domain.PaymentIntent) error {
+	_, err := repo.db.ExecContext(ctx, insertPaymentIntentQuery,
+		paymentIntent.ID,
+		paymentIntent.MerchantID,
+		paymentIntent.MerchantReference,
+		paymentIntent.Amount,
+		paymentIntent.Amount.Currency,
+		paymentIntent.Description,
+		paymentIntent.Status,
+		paymentIntent.CustomerXID,
+		paymentIntent.TransactionID,
+		paymentIntent.ExpiresAt,
+		paymentIntent.CreatedAt,
+		paymentIntent.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *MerchantRepositoryImpl) GetPaymentIntent(ctx context.Context, paymentIntentID string) (domain.PaymentIntent, error) {
+	var result domain.PaymentIntent
+	err := scanPaymentIntent(repo.db.QueryRowContext(ctx, getPaymentIntentQuery, paymentIntentID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *MerchantRepositoryImpl) GetPaymentIntentByReference(ctx context.Context, merchantID, merchantReference string) (domain.PaymentIntent, error) {
+	var result domain.PaymentIntent
+	err := scanPaymentIntent(repo.db.QueryRowContext(ctx, getPaymentIntentByReferenceQuery, merchantID, merchantReference), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *MerchantRepositoryImpl) GetPaymentIntents(ctx context.Context, merchantID, status string) ([]domain.PaymentIntent, error) {
+	var result []domain.PaymentIntent
+	query, args := getPaymentIntentsQuery, []interface{}{merchantID}
+	if status != "" {
+		query, args = getPaymentIntentsByStatusQuery, append(args, status)
+	}
+
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.PaymentIntent{}
+		err := scanPaymentIntent(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *MerchantRepositoryImpl) UpdatePaymentIntentStatus(ctx context.Context, paymentIntentID, fromStatus, toStatus string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updatePaymentIntentStatusQuery, toStatus, paymentIntentID, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *MerchantRepositoryImpl) ConfirmPaymentIntent(ctx context.Context, paymentIntentID string, debit domain.Transaction, now time.Time) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// lock the intent first so a concurrent confirm of the same intent stops here
+	res, err := tx.ExecContext(ctx, confirmPaymentIntentQuery,
+		constants.STATUS_SUCCESS,
+		debit.CustomerXID,
+		debit.ID,
+		paymentIntentID,
+		constants.STATUS_PENDING,
+		now,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	res, err = tx.ExecContext(ctx, debitWalletBalanceQuery, debit.Amount, debit.WalletID, debit.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	return commitWithTransaction(ctx, tx, debit)
+}
+
+func (repo *MerchantRepositoryImpl) RefundPaymentIntent(ctx context.Context, paymentIntentID string, credit domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updatePaymentIntentStatusQuery, constants.STATUS_REFUNDED, paymentIntentID, constants.STATUS_SUCCESS)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	// a payment a dispute paid back is not refunded again
+	var transactionID string
+	err = tx.QueryRowContext(ctx, getPaymentIntentTransactionIDQuery, paymentIntentID).Scan(&transactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	isReversed, err := isDisputeReversed(ctx, tx, transactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if isReversed {
+		_ = tx.Rollback()
+		return false, domain.ErrTransactionReversed
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, credit)
+}
+
+func scanMerchant(row rowScanner, merchant *domain.Merchant) error {
+	return row.Scan(
+		&merchant.ID,
+		&merchant.CustomerXID,
+		&merchant.Name,
+		&merchant.City,
+		&merchant.CategoryCode,
+		&merchant.Status,
+		&merchant.CreatedAt,
+		&merchant.UpdatedAt,
+	)
+}
+
+func scanPaymentIntent(row rowScanner, paymentIntent *domain.PaymentIntent) error {
+	return row.Scan(
+		&paymentIntent.ID,
+		&paymentIntent.MerchantID,
+		&paymentIntent.MerchantReference,
+		&paymentIntent.Amount,
+		&paymentIntent.Amount.Currency,
+		&paymentIntent.Description,
+		&paymentIntent.Status,
+		&paymentIntent.CustomerXID,
+		&paymentIntent.TransactionID,
+		&paymentIntent.ExpiresAt,
+		&paymentIntent.CreatedAt,
+		&paymentIntent.UpdatedAt,
+	)
+}
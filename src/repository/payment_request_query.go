@@ -0,0 +1,40 @@
+package repository
+
+const (
+	insertPaymentRequestQuery = `INSERT INTO payment_requests
+		(id, split_bill_id, requester_xid, payer_xid, amount, currency, note, status, transaction_id, expires_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectPaymentRequestColumns = `SELECT 
+		id, split_bill_id, requester_xid, payer_xid, amount, currency, note, status, transaction_id, expires_at, created_at, updated_at
+		FROM payment_requests`
+
+	getPaymentRequestQuery = selectPaymentRequestColumns + ` WHERE id = ?`
+
+	getIncomingPaymentRequestsQuery = selectPaymentRequestColumns + ` WHERE payer_xid = ? order by created_at DESC`
+
+	getOutgoingPaymentRequestsQuery = selectPaymentRequestColumns + ` WHERE requester_xid = ? order by created_at DESC`
+
+	updatePaymentRequestStatusQuery = `UPDATE payment_requests
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	updatePaymentRequestTransactionQuery = `UPDATE payment_requests
+		SET
+			transaction_id = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	expirePaymentRequestsQuery = `UPDATE payment_requests
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			status = ? AND
+			expires_at <= ?`
+)
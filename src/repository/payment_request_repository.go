@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PaymentRequestRepository interface {
+	CreatePaymentRequest(ctx context.Context, paymentRequest domain.PaymentRequest) error
+	GetPaymentRequest(ctx context.Context, paymentRequestID string) (domain.PaymentRequest, error)
+	GetIncomingPaymentRequests(ctx context.Context, payerXID string) ([]domain.PaymentRequest, error)
+	GetOutgoingPaymentRequests(ctx context.Context, requesterXID string) ([]domain.PaymentRequest, error)
+	// UpdatePaymentRequestStatus moves a request from one status to another.
+	// It returns false when the request is no longer in fromStatus.
+	UpdatePaymentRequestStatus(ctx context.Context, paymentRequestID, fromStatus, toStatus string) (bool, error)
+	UpdatePaymentRequestTransaction(ctx context.Context, paymentRequestID, transactionID string) error
+	ExpirePaymentRequests(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PaymentRequestRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPaymentRequestRepository(db *sql.DB) PaymentRequestRepository {
+	return &PaymentRequestRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *PaymentRequestRepositoryImpl) CreatePaymentRequest(ctx context.Context, paymentRequest domain.PaymentRequest) error {
+	_, err := repo.db.ExecContext(ctx, insertPaymentRequestQuery, paymentRequestArgs(paymentRequest)...)
+	return err
+}
+
+func paymentRequestArgs(paymentRequest domain.PaymentRequest) []interface{} {
+	return []interface{}{
+		paymentRequest.ID,
+		paymentRequest.SplitBillID,
+		paymentRequest.RequesterXID,
+		paymentRequest.PayerXID,
+		paymentRequest.Amount,
+		paymentRequest.Amount.Currency,
+		paymentRequest.Note,
+		paymentRequest.Status,
+		paymentRequest.TransactionID,
+		paymentRequest.ExpiresAt,
+		paymentRequest.CreatedAt,
+		paymentRequest.UpdatedAt,
+	}
+}
+
+func (repo *PaymentRequestRepositoryImpl) GetPaymentRequest(ctx context.Context, paymentRequestID string) (domain.PaymentRequest, error) {
+	var result domain.PaymentRequest
+	err := scanPaymentRequest(repo.db.QueryRowContext(ctx, getPaymentRequestQuery, paymentRequestID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PaymentRequestRepositoryImpl) GetIncomingPaymentRequests(ctx context.Context, payerXID string) ([]domain.PaymentRequest, error) {
+	return repo.queryPaymentRequests(ctx, getIncomingPaymentRequestsQuery, payerXID)
+}
+
+func (repo *PaymentRequestRepositoryImpl) GetOutgoingPaymentRequests(ctx context.Context, requesterXID string) ([]domain.PaymentRequest, error) {
+	return repo.queryPaymentRequests(ctx, getOutgoingPaymentRequestsQuery, requesterXID)
+}
+
+func (repo *PaymentRequestRepositoryImpl) queryPaymentRequests(ctx context.Context, query string, args ...interface{}) ([]domain.PaymentRequest, error) {
+	var result []domain.PaymentRequest
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.PaymentRequest{}
+		err := scanPaymentRequest(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *PaymentRequestRepositoryImpl) UpdatePaymentRequestStatus(ctx context.Context, paymentRequestID, fromStatus, toStatus string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updatePaymentRequestStatusQuery, toStatus, paymentRequestID, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *PaymentRequestRepositoryImpl) UpdatePaymentRequestTransaction(ctx context.Context, paymentRequestID, transactionID string) error {
+	_, err := repo.db.ExecContext(ctx, updatePaymentRequestTransactionQuery, transactionID, paymentRequestID)
+	return err
+}
+
+func (repo *PaymentRequestRepositoryImpl) ExpirePaymentRequests(ctx context.Context, now time.Time) (int64, error) {
+	res, err := repo.db.ExecContext(ctx, expirePaymentRequestsQuery, constants.STATUS_EXPIRED, constants.STATUS_PENDING, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func scanPaymentRequest(row rowScanner, paymentRequest *domain.PaymentRequest) error {
+	return row.Scan(
+		&paymentRequest.ID,
+		&paymentRequest.SplitBillID,
+		&paymentRequest.RequesterXID,
+		&paymentRequest.PayerXID,
+		&paymentRequest.Amount,
+		&paymentRequest.Amount.Currency,
+		&paymentRequest.Note,
+		&paymentRequest.Status,
+		&paymentRequest.TransactionID,
+		&paymentRequest.ExpiresAt,
+		&paymentRequest.CreatedAt,
+		&paymentRequest.UpdatedAt,
+	)
+}
@@ -0,0 +1,96 @@
+package repository
+
+const (
+	insertPayoutBatchQuery = `INSERT IGNORE INTO payout_batches
+		(id, wallet_id, customer_xid, reference, total_amount, currency, row_count, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	insertPayoutBatchItemQuery = `INSERT INTO payout_batch_items
+		(id, batch_id, line_number, bank_code, account_number, account_name, amount, currency, reference_id, payout_id, status, failure_reason, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectPayoutBatchColumns = `SELECT 
+		id, wallet_id, customer_xid, reference, total_amount, currency, row_count, status, created_at, updated_at
+		FROM payout_batches`
+
+	getPayoutBatchQuery = selectPayoutBatchColumns + ` WHERE id = ?`
+
+	getPayoutBatchByReferenceQuery = selectPayoutBatchColumns + ` WHERE customer_xid = ? AND reference = ?`
+
+	getPayoutBatchesQuery = selectPayoutBatchColumns + ` WHERE customer_xid = ? order by created_at DESC`
+
+	// a running batch untouched since staleBefore was left behind by a
+	// runner that stopped, it is picked up again
+	getDuePayoutBatchesQuery = selectPayoutBatchColumns + ` WHERE status = ? OR (status = ? AND updated_at < ?) order by updated_at LIMIT ?`
+
+	claimPayoutBatchQuery = `UPDATE payout_batches
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			(status = ? OR (status = ? AND updated_at < ?))`
+
+	// a row that is not paid yet has no payout to join
+	getPayoutBatchItemsQuery = `SELECT 
+		i.id, i.batch_id, i.line_number, i.bank_code, i.account_number, i.account_name, i.amount, i.currency, i.reference_id, i.payout_id, COALESCE(p.status, ''), i.status, i.failure_reason, i.created_at, i.updated_at
+		FROM payout_batch_items i
+		LEFT JOIN payouts p ON p.id = i.payout_id
+		WHERE i.batch_id = ?
+		order by i.line_number`
+
+	updatePayoutBatchStatusQuery = `UPDATE payout_batches
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	payPayoutBatchItemQuery = `UPDATE payout_batch_items
+		SET
+			status = ?,
+			payout_id = ?,
+			failure_reason = '',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	updatePayoutBatchItemStatusQuery = `UPDATE payout_batch_items
+		SET
+			status = ?,
+			failure_reason = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	// a cancelled row keeps the reason it failed for
+	cancelPayoutBatchItemQuery = `UPDATE payout_batch_items
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	// a row follows its payout once the bank settled it
+	settlePayoutBatchItemQuery = `UPDATE payout_batch_items
+		SET
+			status = ?,
+			failure_reason = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			payout_id = ? AND
+			status = ?`
+
+	retryPayoutBatchItemsQuery = `UPDATE payout_batch_items
+		SET
+			status = ?,
+			failure_reason = '',
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			batch_id = ? AND
+			status = ?`
+)
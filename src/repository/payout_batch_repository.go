@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PayoutBatchRepository interface {
+	// CreatePayoutBatch stores the batch with its rows and debits the
+	// reservation from the wallet in a single database transaction. It
+	// returns false when the customer already used the reference or the
+	// balance does not cover the reservation.
+	CreatePayoutBatch(ctx context.Context, batch domain.PayoutBatch, items []domain.PayoutBatchItem, reservation domain.Transaction) (bool, error)
+	GetPayoutBatch(ctx context.Context, batchID string) (domain.PayoutBatch, error)
+	GetPayoutBatchByReference(ctx context.Context, customerXID, reference string) (domain.PayoutBatch, error)
+	GetPayoutBatches(ctx context.Context, customerXID string) ([]domain.PayoutBatch, error)
+	// GetDuePayoutBatches returns the pending batches, along with the running
+	// ones whose runner stopped before staleBefore, least recently updated
+	// first.
+	GetDuePayoutBatches(ctx context.Context, staleBefore time.Time, limit int) ([]domain.PayoutBatch, error)
+	// ClaimPayoutBatch marks the batch as running. It returns false when the
+	// batch was changed or claimed by another runner.
+	ClaimPayoutBatch(ctx context.Context, batchID string, staleBefore time.Time) (bool, error)
+	GetPayoutBatchItems(ctx context.Context, batchID string) ([]domain.PayoutBatchItem, error)
+	UpdatePayoutBatchStatus(ctx context.Context, batchID, fromStatus, toStatus string) (bool, error)
+
+	// PayPayoutBatchItem marks a pending row submitted and records its
+	// payout, the release of its amount from the reservation and its
+	// withdrawal in a single database transaction. It returns false when the
+	// row already moved on.
+	PayPayoutBatchItem(ctx context.Context, itemID string, payout domain.Payout, release, withdrawal domain.Transaction) (bool, error)
+	FailPayoutBatchItem(ctx context.Context, itemID, reason string) (bool, error)
+
+	// RetryPayoutBatch moves the batch from fromStatus back to pending along
+	// with its failed rows. It returns false when the batch moved on.
+	RetryPayoutBatch(ctx context.Context, batchID, fromStatus string) (bool, error)
+	// CancelPayoutBatch cancels the batch and its failed rows itemIDs and
+	// credits their release back to the wallet in a single database
+	// transaction. It returns false when the batch or any of the rows moved
+	// on.
+	CancelPayoutBatch(ctx context.Context, batchID, fromStatus string, itemIDs []string, release domain.Transaction) (bool, error)
+}
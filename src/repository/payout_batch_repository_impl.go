@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PayoutBatchRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPayoutBatchRepository(db *sql.DB) PayoutBatchRepository {
+	return &PayoutBatchRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *PayoutBatchRepositoryImpl) CreatePayoutBatch(ctx context.Context, batch domain.PayoutBatch, items []domain.PayoutBatchItem, reservation domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, insertPayoutBatchQuery,
+		batch.ID,
+		batch.WalletID,
+		batch.CustomerXID,
+		batch.Reference,
+		batch.TotalAmount,
+		batch.TotalAmount.Currency,
+		batch.RowCount,
+		batch.Status,
+		batch.CreatedAt,
+		batch.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	res, err = tx.ExecContext(ctx, debitWalletBalanceQuery, reservation.Amount, reservation.WalletID, reservation.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, insertPayoutBatchItemQuery,
+			item.ID,
+			item.BatchID,
+			item.LineNumber,
+			item.BankAccount.BankCode,
+			item.BankAccount.AccountNumber,
+			item.BankAccount.AccountName,
+			item.Amount,
+			item.Amount.Currency,
+			item.ReferenceID,
+			item.PayoutID,
+			item.Status,
+			item.FailureReason,
+			item.CreatedAt,
+			item.UpdatedAt,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	return commitWithTransaction(ctx, tx, reservation)
+}
+
+func (repo *PayoutBatchRepositoryImpl) GetPayoutBatch(ctx context.Context, batchID string) (domain.PayoutBatch, error) {
+	var result domain.PayoutBatch
+	err := scanPayoutBatch(repo.db.QueryRowContext(ctx, getPayoutBatchQuery, batchID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) GetPayoutBatchByReference(ctx context.Context, customerXID, reference string) (domain.PayoutBatch, error) {
+	var result domain.PayoutBatch
+	err := scanPayoutBatch(repo.db.QueryRowContext(ctx, getPayoutBatchByReferenceQuery, customerXID, reference), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) GetPayoutBatches(ctx context.Context, customerXID string) ([]domain.PayoutBatch, error) {
+	return repo.queryPayoutBatches(ctx, getPayoutBatchesQuery, customerXID)
+}
+
+func (repo *PayoutBatchRepositoryImpl) GetDuePayoutBatches(ctx context.Context, staleBefore time.Time, limit int) ([]domain.PayoutBatch, error) {
+	return repo.queryPayoutBatches(ctx, getDuePayoutBatchesQuery, constants.STATUS_PENDING, constants.STATUS_RUNNING, staleBefore, limit)
+}
+
+func (repo *PayoutBatchRepositoryImpl) ClaimPayoutBatch(ctx context.Context, batchID string, staleBefore time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, claimPayoutBatchQuery, constants.STATUS_RUNNING, batchID, constants.STATUS_PENDING, constants.STATUS_RUNNING, staleBefore)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) queryPayoutBatches(ctx context.Context, query string, args ...interface{}) ([]domain.PayoutBatch, error) {
+	var result []domain.PayoutBatch
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.PayoutBatch{}
+		err := scanPayoutBatch(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) GetPayoutBatchItems(ctx context.Context, batchID string) ([]domain.PayoutBatchItem, error) {
+	var result []domain.PayoutBatchItem
+	rows, err := repo.db.QueryContext(ctx, getPayoutBatchItemsQuery, batchID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.PayoutBatchItem{}
+		err := rows.Scan(
+			&data.ID,
+			&data.BatchID,
+			&data.LineNumber,
+			&data.BankAccount.BankCode,
+			&data.BankAccount.AccountNumber,
+			&data.BankAccount.AccountName,
+			&data.Amount,
+			&data.Amount.Currency,
+			&data.ReferenceID,
+			&data.PayoutID,
+			&data.PayoutStatus,
+			&data.Status,
+			&data.FailureReason,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) UpdatePayoutBatchStatus(ctx context.Context, batchID, fromStatus, toStatus string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updatePayoutBatchStatusQuery, toStatus, batchID, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) PayPayoutBatchItem(ctx context.Context, itemID string, payout domain.Payout, release, withdrawal domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, payPayoutBatchItemQuery, constants.STATUS_SUBMITTED, payout.ID, itemID, constants.STATUS_PENDING)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertPayoutQuery,
+		payout.ID,
+		payout.WalletID,
+		payout.CustomerXID,
+		payout.BeneficiaryID,
+		payout.TransactionID,
+		payout.BankAccount.BankCode,
+		payout.BankAccount.AccountNumber,
+		payout.BankAccount.AccountName,
+		payout.Amount,
+		payout.Amount.Currency,
+		payout.ProviderReference,
+		payout.Status,
+		payout.FailureReason,
+		payout.CreatedAt,
+		payout.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	// the release and the withdrawal cancel out, the balance was already
+	// taken by the reservation
+	err = insertTransaction(ctx, tx, release)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, withdrawal)
+}
+
+func (repo *PayoutBatchRepositoryImpl) FailPayoutBatchItem(ctx context.Context, itemID, reason string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updatePayoutBatchItemStatusQuery, constants.STATUS_FAILED, reason, itemID, constants.STATUS_PENDING)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *PayoutBatchRepositoryImpl) RetryPayoutBatch(ctx context.Context, batchID, fromStatus string) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updatePayoutBatchStatusQuery, constants.STATUS_PENDING, batchID, fromStatus)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, retryPayoutBatchItemsQuery, constants.STATUS_PENDING, batchID, constants.STATUS_FAILED)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (repo *PayoutBatchRepositoryImpl) CancelPayoutBatch(ctx context.Context, batchID, fromStatus string, itemIDs []string, release domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updatePayoutBatchStatusQuery, constants.STATUS_CANCELLED, batchID, fromStatus)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	// the release was summed from these rows, none of them may have been
+	// retried in the meantime
+	for _, itemID := range itemIDs {
+		res, err = tx.ExecContext(ctx, cancelPayoutBatchItemQuery, constants.STATUS_CANCELLED, itemID, constants.STATUS_FAILED)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+			_ = tx.Rollback()
+			return false, nil
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, release.Amount, release.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, release)
+}
+
+func scanPayoutBatch(row rowScanner, batch *domain.PayoutBatch) error {
+	return row.Scan(
+		&batch.ID,
+		&batch.WalletID,
+		&batch.CustomerXID,
+		&batch.Reference,
+		&batch.TotalAmount,
+		&batch.TotalAmount.Currency,
+		&batch.RowCount,
+		&batch.Status,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+	)
+}
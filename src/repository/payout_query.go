@@ -0,0 +1,75 @@
+package repository
+
+const (
+	// only a name confirmed by an inquiry, given with its verified_at,
+	// refreshes a known account, an unverified one leaves it untouched
+	saveBeneficiaryQuery = `INSERT INTO beneficiaries
+		(id, customer_xid, bank_code, account_number, account_name, verified_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			account_name = IF(VALUES(verified_at) IS NULL, account_name, VALUES(account_name)),
+			updated_at = IF(VALUES(verified_at) IS NULL, updated_at, VALUES(updated_at)),
+			verified_at = IF(VALUES(verified_at) IS NULL, verified_at, VALUES(verified_at))`
+
+	insertBeneficiaryQuery = `INSERT INTO beneficiaries
+		(id, customer_xid, bank_code, account_number, account_name, verified_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+
+	updateBeneficiaryQuery = `UPDATE beneficiaries
+		SET
+			bank_code = ?,
+			account_number = ?,
+			account_name = ?,
+			verified_at = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	deleteBeneficiaryQuery = `DELETE FROM beneficiaries WHERE id = ?`
+
+	selectBeneficiaryColumns = `SELECT 
+		id, customer_xid, bank_code, account_number, account_name, verified_at, created_at, updated_at
+		FROM beneficiaries`
+
+	getBeneficiaryQuery = selectBeneficiaryColumns + ` WHERE id = ?`
+
+	getBeneficiaryByAccountQuery = selectBeneficiaryColumns + ` WHERE customer_xid = ? AND bank_code = ? AND account_number = ?`
+
+	getBeneficiariesQuery = selectBeneficiaryColumns + ` WHERE customer_xid = ? order by updated_at DESC`
+
+	insertPayoutQuery = `INSERT INTO payouts
+		(id, wallet_id, customer_xid, beneficiary_id, transaction_id, bank_code, account_number, account_name, amount, currency, provider_reference, status, failure_reason, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectPayoutColumns = `SELECT 
+		id, wallet_id, customer_xid, beneficiary_id, transaction_id, bank_code, account_number, account_name, amount, currency, provider_reference, status, failure_reason, created_at, updated_at
+		FROM payouts`
+
+	getPayoutQuery = selectPayoutColumns + ` WHERE id = ?`
+
+	getPayoutByTransactionQuery = selectPayoutColumns + ` WHERE transaction_id = ?`
+
+	getPayoutTransactionIDQuery = `SELECT transaction_id FROM payouts WHERE id = ?`
+
+	getPayoutsQuery = selectPayoutColumns + ` WHERE customer_xid = ? order by created_at DESC`
+
+	getPayoutsByStatusQuery = selectPayoutColumns + ` WHERE status = ? order by updated_at LIMIT ?`
+
+	markPayoutSubmittedQuery = `UPDATE payouts
+		SET
+			status = ?,
+			provider_reference = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	updatePayoutStatusQuery = `UPDATE payouts
+		SET
+			status = ?,
+			failure_reason = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+)
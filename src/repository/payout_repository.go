@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PayoutRepository interface {
+	// SaveBeneficiary registers the bank account for the customer and
+	// returns the stored beneficiary. An account already registered only
+	// takes the holder name when the beneficiary is verified.
+	SaveBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error)
+	CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) error
+	UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) error
+	DeleteBeneficiary(ctx context.Context, beneficiaryID string) error
+	GetBeneficiary(ctx context.Context, beneficiaryID string) (domain.Beneficiary, error)
+	GetBeneficiaryByAccount(ctx context.Context, customerXID, bankCode, accountNumber string) (domain.Beneficiary, error)
+	GetBeneficiaries(ctx context.Context, customerXID string) ([]domain.Beneficiary, error)
+
+	// CreatePayout moves the wallet balance from balance to newBalance and
+	// records the withdrawal and the payout in a single database transaction.
+	// It returns false when the balance changed since it was read.
+	CreatePayout(ctx context.Context, payout domain.Payout, withdrawal domain.Transaction, balance, newBalance domain.Money) (bool, error)
+	GetPayout(ctx context.Context, payoutID string) (domain.Payout, error)
+	GetPayoutByTransaction(ctx context.Context, transactionID string) (domain.Payout, error)
+	GetPayouts(ctx context.Context, customerXID string) ([]domain.Payout, error)
+	GetPayoutsByStatus(ctx context.Context, status string, limit int) ([]domain.Payout, error)
+
+	// MarkPayoutSubmitted and MarkPayoutSucceeded move the payout on from
+	// pending and submitted respectively, returning false when it already
+	// moved on. A succeeded payout of a batch row marks the row paid.
+	MarkPayoutSubmitted(ctx context.Context, payoutID, providerReference string) (bool, error)
+	MarkPayoutSucceeded(ctx context.Context, payoutID string) (bool, error)
+
+	// FailPayout marks a submitted payout failed and credits the reversal
+	// back to the wallet in a single database transaction. The payout of a
+	// batch row fails the row instead and takes the reversal back into the
+	// batch reservation. The spending a member withdrew is given back to the
+	// member. It returns false when the payout already moved on,
+	// and domain.ErrTransactionReversed when a dispute already paid the
+	// withdrawal back.
+	FailPayout(ctx context.Context, payoutID, reason string, reversal, reservation domain.Transaction) (bool, error)
+}
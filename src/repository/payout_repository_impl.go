@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PayoutRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPayoutRepository(db *sql.DB) PayoutRepository {
+	return &PayoutRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *PayoutRepositoryImpl) SaveBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+	var result domain.Beneficiary
+	_, err := repo.db.ExecContext(ctx, saveBeneficiaryQuery,
+		beneficiary.ID,
+		beneficiary.CustomerXID,
+		beneficiary.BankAccount.BankCode,
+		beneficiary.BankAccount.AccountNumber,
+		beneficiary.BankAccount.AccountName,
+		beneficiary.VerifiedAt,
+		beneficiary.CreatedAt,
+		beneficiary.UpdatedAt,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	return repo.GetBeneficiaryByAccount(ctx, beneficiary.CustomerXID, beneficiary.BankAccount.BankCode, beneficiary.BankAccount.AccountNumber)
+}
+
+func (repo *PayoutRepositoryImpl) CreateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) error {
+	_, err := repo.db.ExecContext(ctx, insertBeneficiaryQuery,
+		beneficiary.ID,
+		beneficiary.CustomerXID,
+		beneficiary.BankAccount.BankCode,
+		beneficiary.BankAccount.AccountNumber,
+		beneficiary.BankAccount.AccountName,
+		beneficiary.VerifiedAt,
+		beneficiary.CreatedAt,
+		beneficiary.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *PayoutRepositoryImpl) UpdateBeneficiary(ctx context.Context, beneficiary domain.Beneficiary) error {
+	_, err := repo.db.ExecContext(ctx, updateBeneficiaryQuery,
+		beneficiary.BankAccount.BankCode,
+		beneficiary.BankAccount.AccountNumber,
+		beneficiary.BankAccount.AccountName,
+		beneficiary.VerifiedAt,
+		beneficiary.ID,
+	)
+	return err
+}
+
+func (repo *PayoutRepositoryImpl) DeleteBeneficiary(ctx context.Context, beneficiaryID string) error {
+	_, err := repo.db.ExecContext(ctx, deleteBeneficiaryQuery, beneficiaryID)
+	return err
+}
+
+func (repo *PayoutRepositoryImpl) GetBeneficiary(ctx context.Context, beneficiaryID string) (domain.Beneficiary, error) {
+	var result domain.Beneficiary
+	err := scanBeneficiary(repo.db.QueryRowContext(ctx, getBeneficiaryQuery, beneficiaryID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PayoutRepositoryImpl) GetBeneficiaryByAccount(ctx context.Context, customerXID, bankCode, accountNumber string) (domain.Beneficiary, error) {
+	var result domain.Beneficiary
+	err := scanBeneficiary(repo.db.QueryRowContext(ctx, getBeneficiaryByAccountQuery, customerXID, bankCode, accountNumber), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PayoutRepositoryImpl) GetBeneficiaries(ctx context.Context, customerXID string) ([]domain.Beneficiary, error) {
+	var result []domain.Beneficiary
+	rows, err := repo.db.QueryContext(ctx, getBeneficiariesQuery, customerXID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Beneficiary{}
+		err := scanBeneficiary(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *PayoutRepositoryImpl) CreatePayout(ctx context.Context, payout domain.Payout, withdrawal domain.Transaction, balance, newBalance domain.Money) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updateWalletBalanceQuery, newBalance, payout.WalletID, balance, newBalance)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertPayoutQuery,
+		payout.ID,
+		payout.WalletID,
+		payout.CustomerXID,
+		payout.BeneficiaryID,
+		payout.TransactionID,
+		payout.BankAccount.BankCode,
+		payout.BankAccount.AccountNumber,
+		payout.BankAccount.AccountName,
+		payout.Amount,
+		payout.Amount.Currency,
+		payout.ProviderReference,
+		payout.Status,
+		payout.FailureReason,
+		payout.CreatedAt,
+		payout.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, withdrawal)
+}
+
+func (repo *PayoutRepositoryImpl) GetPayout(ctx context.Context, payoutID string) (domain.Payout, error) {
+	var result domain.Payout
+	err := scanPayout(repo.db.QueryRowContext(ctx, getPayoutQuery, payoutID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PayoutRepositoryImpl) GetPayoutByTransaction(ctx context.Context, transactionID string) (domain.Payout, error) {
+	var result domain.Payout
+	err := scanPayout(repo.db.QueryRowContext(ctx, getPayoutByTransactionQuery, transactionID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PayoutRepositoryImpl) GetPayouts(ctx context.Context, customerXID string) ([]domain.Payout, error) {
+	return repo.queryPayouts(ctx, getPayoutsQuery, customerXID)
+}
+
+func (repo *PayoutRepositoryImpl) GetPayoutsByStatus(ctx context.Context, status string, limit int) ([]domain.Payout, error) {
+	return repo.queryPayouts(ctx, getPayoutsByStatusQuery, status, limit)
+}
+
+func (repo *PayoutRepositoryImpl) queryPayouts(ctx context.Context, query string, args ...interface{}) ([]domain.Payout, error) {
+	var result []domain.Payout
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Payout{}
+		err := scanPayout(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *PayoutRepositoryImpl) MarkPayoutSubmitted(ctx context.Context, payoutID, providerReference string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, markPayoutSubmittedQuery, constants.STATUS_SUBMITTED, providerReference, payoutID, constants.STATUS_PENDING)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *PayoutRepositoryImpl) MarkPayoutSucceeded(ctx context.Context, payoutID string) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updatePayoutStatusQuery, constants.STATUS_SUCCEEDED, "", payoutID, constants.STATUS_SUBMITTED)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, settlePayoutBatchItemQuery, constants.STATUS_PAID, "", payoutID, constants.STATUS_SUBMITTED)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (repo *PayoutRepositoryImpl) FailPayout(ctx context.Context, payoutID, reason string, reversal, reservation domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, updatePayoutStatusQuery, constants.STATUS_FAILED, reason, payoutID, constants.STATUS_SUBMITTED)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	// a withdrawal a dispute paid back is not paid back again
+	var transactionID string
+	err = tx.QueryRowContext(ctx, getPayoutTransactionIDQuery, payoutID).Scan(&transactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	isReversed, err := isDisputeReversed(ctx, tx, transactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if isReversed {
+		_ = tx.Rollback()
+		return false, domain.ErrTransactionReversed
+	}
+
+	// the failed withdrawal of a member no longer counts against their
+	// spending limit
+	withdrawal, err := getTransaction(ctx, tx, transactionID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if withdrawal.InitiatedBy != "" {
+		_, err = tx.ExecContext(ctx, releaseMemberSpendByXIDQuery,
+			withdrawal.Amount,
+			withdrawal.WalletID,
+			withdrawal.InitiatedBy,
+			domain.SpendingPeriod(withdrawal.CreatedAt),
+			withdrawal.Amount,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	res, err = tx.ExecContext(ctx, settlePayoutBatchItemQuery, constants.STATUS_FAILED, reason, payoutID, constants.STATUS_SUBMITTED)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	// the failed row keeps its amount reserved until it is retried or
+	// cancelled, the reversal and the reservation cancel out
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected > 0 {
+		err = insertTransaction(ctx, tx, reversal)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		return commitWithTransaction(ctx, tx, reservation)
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, reversal.Amount, reversal.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, reversal)
+}
+
+func scanBeneficiary(row rowScanner, beneficiary *domain.Beneficiary) error {
+	return row.Scan(
+		&beneficiary.ID,
+		&beneficiary.CustomerXID,
+		&beneficiary.BankAccount.BankCode,
+		&beneficiary.BankAccount.AccountNumber,
+		&beneficiary.BankAccount.AccountName,
+		&beneficiary.VerifiedAt,
+		&beneficiary.CreatedAt,
+		&beneficiary.UpdatedAt,
+	)
+}
+
+func scanPayout(row rowScanner, payout *domain.Payout) error {
+	return row.Scan(
+		&payout.ID,
+		&payout.WalletID,
+		&payout.CustomerXID,
+		&payout.BeneficiaryID,
+		&payout.TransactionID,
+		&payout.BankAccount.BankCode,
+		&payout.BankAccount.AccountNumber,
+		&payout.BankAccount.AccountName,
+		&payout.Amount,
+		&payout.Amount.Currency,
+		&payout.ProviderReference,
+		&payout.Status,
+		&payout.FailureReason,
+		&payout.CreatedAt,
+		&payout.UpdatedAt,
+	)
+}
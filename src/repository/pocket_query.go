@@ -0,0 +1,53 @@
+package repository
+
+const (
+	insertPocketQuery = `INSERT INTO pockets
+		(id, wallet_id, name, target_amount, balance, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+
+	getPocketQuery = `SELECT 
+		p.id, p.wallet_id, p.name, p.target_amount, p.balance, p.status, p.created_at, p.updated_at, w.currency
+		FROM pockets p JOIN wallets w ON w.id = p.wallet_id
+		WHERE p.id = ?`
+
+	getPocketsQuery = `SELECT 
+		p.id, p.wallet_id, p.name, p.target_amount, p.balance, p.status, p.created_at, p.updated_at, w.currency
+		FROM pockets p JOIN wallets w ON w.id = p.wallet_id
+		WHERE p.wallet_id = ? AND p.status = ? order by p.created_at`
+
+	updatePocketQuery = `UPDATE pockets
+		SET
+			name = ?,
+			target_amount = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	debitPocketBalanceQuery = `UPDATE pockets
+		SET
+			balance = balance - ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			balance >= ?`
+
+	creditPocketBalanceQuery = `UPDATE pockets
+		SET
+			balance = balance + ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	closePocketQuery = `UPDATE pockets
+		SET
+			balance = 0,
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			balance = ?`
+)
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PocketRepository interface {
+	CreatePocket(ctx context.Context, pocket domain.Pocket) error
+	GetPocket(ctx context.Context, pocketID string) (domain.Pocket, error)
+	GetPockets(ctx context.Context, walletID string) ([]domain.Pocket, error)
+	UpdatePocket(ctx context.Context, pocket domain.Pocket) error
+
+	// AllocateToPocket moves transaction.Amount from the wallet main balance
+	// into the pocket, ReleaseFromPocket moves it back. Both return false when
+	// the source balance cannot cover the amount.
+	AllocateToPocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error)
+	ReleaseFromPocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error)
+	// ClosePocket returns transaction.Amount, which must equal the pocket
+	// balance, to the main balance and closes the pocket.
+	ClosePocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error)
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type PocketRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPocketRepository(db *sql.DB) PocketRepository {
+	return &PocketRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *PocketRepositoryImpl) CreatePocket(ctx context.Context, pocket domain.Pocket) error {
+	_, err := repo.db.ExecContext(ctx, insertPocketQuery,
+		pocket.ID,
+		pocket.WalletID,
+		pocket.Name,
+		pocket.TargetAmount,
+		pocket.Balance,
+		pocket.Status,
+		pocket.CreatedAt,
+		pocket.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *PocketRepositoryImpl) GetPocket(ctx context.Context, pocketID string) (domain.Pocket, error) {
+	var result domain.Pocket
+	err := scanPocket(repo.db.QueryRowContext(ctx, getPocketQuery, pocketID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *PocketRepositoryImpl) GetPockets(ctx context.Context, walletID string) ([]domain.Pocket, error) {
+	var result []domain.Pocket
+	rows, err := repo.db.QueryContext(ctx, getPocketsQuery, walletID, constants.STATUS_ACTIVE)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Pocket{}
+		err := scanPocket(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *PocketRepositoryImpl) UpdatePocket(ctx context.Context, pocket domain.Pocket) error {
+	_, err := repo.db.ExecContext(ctx, updatePocketQuery,
+		pocket.Name,
+		pocket.TargetAmount,
+		pocket.ID,
+		constants.STATUS_ACTIVE,
+	)
+	return err
+}
+
+func (repo *PocketRepositoryImpl) AllocateToPocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, debitWalletBalanceQuery, transaction.Amount, transaction.WalletID, transaction.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	res, err = tx.ExecContext(ctx, creditPocketBalanceQuery, transaction.Amount, pocketID, constants.STATUS_ACTIVE)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	return commitWithTransaction(ctx, tx, transaction)
+}
+
+func (repo *PocketRepositoryImpl) ReleaseFromPocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, debitPocketBalanceQuery, transaction.Amount, pocketID, constants.STATUS_ACTIVE, transaction.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, transaction.Amount, transaction.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, transaction)
+}
+
+func (repo *PocketRepositoryImpl) ClosePocket(ctx context.Context, pocketID string, transaction domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	// the balance guard fails the close if the pocket moved since it was read
+	res, err := tx.ExecContext(ctx, closePocketQuery, constants.STATUS_CLOSED, pocketID, constants.STATUS_ACTIVE, transaction.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	// an empty pocket closes without touching the ledger
+	if transaction.Amount.IsZero() {
+		err = tx.Commit()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, transaction.Amount, transaction.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, transaction)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPocket(row rowScanner, pocket *domain.Pocket) error {
+	var currency string
+	err := row.Scan(
+		&pocket.ID,
+		&pocket.WalletID,
+		&pocket.Name,
+		&pocket.TargetAmount,
+		&pocket.Balance,
+		&pocket.Status,
+		&pocket.CreatedAt,
+		&pocket.UpdatedAt,
+		&currency,
+	)
+	if err != nil {
+		return err
+	}
+	pocket.TargetAmount.Currency = currency
+	pocket.Balance.Currency = currency
+	return nil
+}
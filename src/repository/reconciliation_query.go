@@ -0,0 +1,58 @@
+package repository
+
+const (
+	// a statement imported twice is ignored by the unique file hash
+	insertStatementImportQuery = `INSERT IGNORE INTO statement_imports
+		(id, bank_code, format, file_name, file_hash, currency, period_start, period_end, line_count, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	insertStatementLineQuery = `INSERT INTO statement_lines
+		(id, import_id, line_number, value_date, direction, amount, currency, reference, description, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectStatementImportColumns = `SELECT 
+		id, bank_code, format, file_name, file_hash, currency, period_start, period_end, line_count, created_at
+		FROM statement_imports`
+
+	getStatementImportQuery = selectStatementImportColumns + ` WHERE id = ?`
+
+	getStatementImportsQuery = selectStatementImportColumns + ` order by created_at DESC`
+
+	selectStatementLineColumns = `SELECT 
+		l.id, l.import_id, l.line_number, l.value_date, l.direction, l.amount, l.currency, l.reference, l.description,
+		COALESCE(m.transaction_id, ''), COALESCE(m.match_type, ''), m.created_at, l.created_at
+		FROM statement_lines l
+		LEFT JOIN statement_matches m ON m.line_id = l.id`
+
+	getStatementLineQuery = selectStatementLineColumns + ` WHERE l.id = ?`
+
+	getStatementLinesQuery = selectStatementLineColumns + ` WHERE l.import_id = ? order by l.line_number`
+
+	findMatchableTransactionQuery = `SELECT 
+		t.id, t.wallet_id, t.customer_xid, t.transaction_type, t.amount, t.currency, t.reference_id, t.status, t.created_at, t.updated_at
+		FROM transactions t
+		LEFT JOIN payouts p ON p.transaction_id = t.id
+		LEFT JOIN statement_matches m ON m.transaction_id = t.id
+		WHERE t.transaction_type = ? AND t.status = ? AND t.amount = ? AND t.currency = ? AND m.line_id IS NULL
+		AND (t.reference_id IN (?, ?) OR p.id = ? OR p.provider_reference = ?)
+		order by t.created_at LIMIT 1`
+
+	// a line or a transaction matched twice is ignored by the keys
+	insertStatementMatchQuery = `INSERT IGNORE INTO statement_matches
+		(line_id, transaction_id, match_type, created_at)
+		VALUES(?, ?, ?, ?)`
+
+	deleteStatementMatchQuery = `DELETE FROM statement_matches WHERE line_id = ?`
+
+	getUnmatchedTransactionsQuery = `SELECT 
+		t.id, t.wallet_id, t.customer_xid, t.transaction_type, t.amount, t.currency, t.reference_id, t.status, t.created_at, t.updated_at
+		FROM transactions t
+		LEFT JOIN payouts p ON p.transaction_id = t.id
+		LEFT JOIN virtual_account_payments v ON v.transaction_id = t.id
+		LEFT JOIN statement_matches m ON m.transaction_id = t.id
+		WHERE t.transaction_type IN (?, ?) AND t.status = ? AND t.currency = ?
+		AND (v.bank_code = ? OR (p.id IS NOT NULL AND ?))
+		AND t.created_at >= ? AND t.created_at < ?
+		AND (p.status IS NULL OR p.status <> ?) AND m.line_id IS NULL
+		order by t.created_at`
+)
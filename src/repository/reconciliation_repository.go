@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type ReconciliationRepository interface {
+	// CreateStatementImport stores the import with its lines. It returns
+	// false when the bank already has a statement with the same file hash.
+	CreateStatementImport(ctx context.Context, statementImport domain.StatementImport, lines []domain.StatementLine) (bool, error)
+	GetStatementImport(ctx context.Context, importID string) (domain.StatementImport, error)
+	GetStatementImports(ctx context.Context) ([]domain.StatementImport, error)
+	GetStatementLine(ctx context.Context, lineID string) (domain.StatementLine, error)
+	GetStatementLines(ctx context.Context, importID string) ([]domain.StatementLine, error)
+
+	// FindMatchableTransaction returns the oldest successful transaction of
+	// transactionType for amount that no line is matched to yet, referenced
+	// by reference itself, by depositReferenceID or by the ID or provider
+	// reference of its payout.
+	FindMatchableTransaction(ctx context.Context, transactionType string, amount domain.Money, reference, depositReferenceID string) (domain.Transaction, error)
+
+	// MatchStatementLine returns false when the line or the transaction is
+	// already matched.
+	MatchStatementLine(ctx context.Context, lineID, transactionID, matchType string, matchedAt time.Time) (bool, error)
+	UnmatchStatementLine(ctx context.Context, lineID string) (bool, error)
+
+	// GetUnmatchedTransactions returns successful deposits and withdrawals in
+	// currency made within [from, to) that no line is matched to: deposits by
+	// virtual account of bankCode and, with withPayouts, withdrawals paid out.
+	// Withdrawals whose payout failed never reached the bank and are left out.
+	GetUnmatchedTransactions(ctx context.Context, bankCode, currency string, withPayouts bool, from, to time.Time) ([]domain.Transaction, error)
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type ReconciliationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReconciliationRepository(db *sql.DB) ReconciliationRepository {
+	return &ReconciliationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *ReconciliationRepositoryImpl) CreateStatementImport(ctx context.Context, statementImport domain.StatementImport, lines []domain.StatementLine) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, insertStatementImportQuery,
+		statementImport.ID,
+		statementImport.BankCode,
+		statementImport.Format,
+		statementImport.FileName,
+		statementImport.FileHash,
+		statementImport.Currency,
+		statementImport.PeriodStart,
+		statementImport.PeriodEnd,
+		statementImport.LineCount,
+		statementImport.CreatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	for _, line := range lines {
+		_, err = tx.ExecContext(ctx, insertStatementLineQuery,
+			line.ID,
+			line.ImportID,
+			line.LineNumber,
+			line.ValueDate,
+			line.Direction,
+			line.Amount,
+			line.Amount.Currency,
+			line.Reference,
+			line.Description,
+			line.CreatedAt,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+func (repo *ReconciliationRepositoryImpl) GetStatementImport(ctx context.Context, importID string) (domain.StatementImport, error) {
+	var result domain.StatementImport
+	err := scanStatementImport(repo.db.QueryRowContext(ctx, getStatementImportQuery, importID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) GetStatementImports(ctx context.Context) ([]domain.StatementImport, error) {
+	var result []domain.StatementImport
+	rows, err := repo.db.QueryContext(ctx, getStatementImportsQuery)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.StatementImport{}
+		err := scanStatementImport(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) GetStatementLine(ctx context.Context, lineID string) (domain.StatementLine, error) {
+	var result domain.StatementLine
+	err := scanStatementLine(repo.db.QueryRowContext(ctx, getStatementLineQuery, lineID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) GetStatementLines(ctx context.Context, importID string) ([]domain.StatementLine, error) {
+	var result []domain.StatementLine
+	rows, err := repo.db.QueryContext(ctx, getStatementLinesQuery, importID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.StatementLine{}
+		err := scanStatementLine(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) FindMatchableTransaction(ctx context.Context, transactionType string, amount domain.Money, reference, depositReferenceID string) (domain.Transaction, error) {
+	var result domain.Transaction
+	err := scanTransaction(repo.db.QueryRowContext(ctx, findMatchableTransactionQuery,
+		transactionType,
+		constants.STATUS_SUCCESS,
+		amount,
+		amount.Currency,
+		reference,
+		depositReferenceID,
+		reference,
+		reference,
+	), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) MatchStatementLine(ctx context.Context, lineID, transactionID, matchType string, matchedAt time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, insertStatementMatchQuery, lineID, transactionID, matchType, matchedAt)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) UnmatchStatementLine(ctx context.Context, lineID string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, deleteStatementMatchQuery, lineID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *ReconciliationRepositoryImpl) GetUnmatchedTransactions(ctx context.Context, bankCode, currency string, withPayouts bool, from, to time.Time) ([]domain.Transaction, error) {
+	var result []domain.Transaction
+	rows, err := repo.db.QueryContext(ctx, getUnmatchedTransactionsQuery,
+		constants.TRANSACTION_TYPE_DEPOSIT,
+		constants.TRANSACTION_TYPE_WITHDRAWAL,
+		constants.STATUS_SUCCESS,
+		currency,
+		bankCode,
+		withPayouts,
+		from,
+		to,
+		constants.STATUS_FAILED,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Transaction{}
+		err := scanTransaction(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func scanStatementImport(row rowScanner, statementImport *domain.StatementImport) error {
+	return row.Scan(
+		&statementImport.ID,
+		&statementImport.BankCode,
+		&statementImport.Format,
+		&statementImport.FileName,
+		&statementImport.FileHash,
+		&statementImport.Currency,
+		&statementImport.PeriodStart,
+		&statementImport.PeriodEnd,
+		&statementImport.LineCount,
+		&statementImport.CreatedAt,
+	)
+}
+
+func scanStatementLine(row rowScanner, line *domain.StatementLine) error {
+	return row.Scan(
+		&line.ID,
+		&line.ImportID,
+		&line.LineNumber,
+		&line.ValueDate,
+		&line.Direction,
+		&line.Amount,
+		&line.Amount.Currency,
+		&line.Reference,
+		&line.Description,
+		&line.TransactionID,
+		&line.MatchType,
+		&line.MatchedAt,
+		&line.CreatedAt,
+	)
+}
+
+func scanTransaction(row rowScanner, transaction *domain.Transaction) error {
+	return row.Scan(
+		&transaction.ID,
+		&transaction.WalletID,
+		&transaction.CustomerXID,
+		&transaction.TransactionType,
+		&transaction.Amount,
+		&transaction.Amount.Currency,
+		&transaction.ReferenceID,
+		&transaction.Status,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+	)
+}
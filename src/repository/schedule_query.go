@@ -0,0 +1,56 @@
+package repository
+
+const (
+	insertScheduleQuery = `INSERT INTO schedules
+		(id, customer_xid, schedule_type, recipient_xid, bank_code, account_number, account_name, amount, currency, frequency, day_of_month, next_run_at, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectScheduleColumns = `SELECT 
+		id, customer_xid, schedule_type, recipient_xid, bank_code, account_number, account_name, amount, currency, frequency, day_of_month, next_run_at, status, created_at, updated_at
+		FROM schedules`
+
+	getScheduleQuery = selectScheduleColumns + ` WHERE id = ?`
+
+	getSchedulesQuery = selectScheduleColumns + ` WHERE customer_xid = ? order by created_at`
+
+	// a running schedule untouched since staleBefore was left behind by a
+	// runner that stopped, it is picked up again
+	getDueSchedulesQuery = selectScheduleColumns + ` WHERE next_run_at <= ? AND (status = ? OR (status = ? AND updated_at < ?)) order by next_run_at LIMIT ?`
+
+	updateScheduleStatusQuery = `UPDATE schedules
+		SET
+			status = ?,
+			next_run_at = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			next_run_at = ?`
+
+	claimScheduleQuery = `UPDATE schedules
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			next_run_at = ? AND
+			(status = ? OR (status = ? AND updated_at < ?))`
+
+	advanceScheduleQuery = `UPDATE schedules
+		SET
+			status = ?,
+			next_run_at = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			next_run_at = ?`
+
+	insertScheduleRunQuery = `INSERT IGNORE INTO schedule_runs
+		(id, schedule_id, run_at, reference_id, transaction_id, status, error_message, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?)`
+
+	getScheduleRunsQuery = `SELECT 
+		id, schedule_id, run_at, reference_id, transaction_id, status, error_message, created_at
+		FROM schedule_runs WHERE schedule_id = ? order by run_at DESC`
+)
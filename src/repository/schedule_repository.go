@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type ScheduleRepository interface {
+	CreateSchedule(ctx context.Context, schedule domain.Schedule) error
+	GetSchedule(ctx context.Context, scheduleID string) (domain.Schedule, error)
+	GetSchedules(ctx context.Context, customerXID string) ([]domain.Schedule, error)
+	// GetDueSchedules returns the active schedules due by now, along with the
+	// running ones whose runner stopped before staleBefore.
+	GetDueSchedules(ctx context.Context, now, staleBefore time.Time, limit int) ([]domain.Schedule, error)
+	// UpdateScheduleStatus changes the schedule only if it still has the
+	// status and next run it was read with. It returns false when a runner
+	// picked it up in the meantime.
+	UpdateScheduleStatus(ctx context.Context, schedule domain.Schedule, status string, nextRunAt time.Time) (bool, error)
+	// ClaimSchedule marks the run at runAt as running, so it can no longer be
+	// paused or cancelled while money is moved. It returns false when the
+	// schedule was changed or claimed by another runner.
+	ClaimSchedule(ctx context.Context, scheduleID string, runAt, staleBefore time.Time) (bool, error)
+	// AdvanceSchedule moves a running schedule from runAt to nextRunAt. It
+	// returns false when another runner already advanced it.
+	AdvanceSchedule(ctx context.Context, scheduleID string, runAt, nextRunAt time.Time, status string) (bool, error)
+
+	// AddScheduleRun records the outcome of one occurrence, a run that was
+	// already recorded is left untouched.
+	AddScheduleRun(ctx context.Context, run domain.ScheduleRun) error
+	GetScheduleRuns(ctx context.Context, scheduleID string) ([]domain.ScheduleRun, error)
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type ScheduleRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) ScheduleRepository {
+	return &ScheduleRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *ScheduleRepositoryImpl) CreateSchedule(ctx context.Context, schedule domain.Schedule) error {
+	_, err := repo.db.ExecContext(ctx, insertScheduleQuery,
+		schedule.ID,
+		schedule.CustomerXID,
+		schedule.ScheduleType,
+		schedule.RecipientXID,
+		schedule.BankAccount.BankCode,
+		schedule.BankAccount.AccountNumber,
+		schedule.BankAccount.AccountName,
+		schedule.Amount,
+		schedule.Amount.Currency,
+		schedule.Frequency,
+		schedule.DayOfMonth,
+		schedule.NextRunAt,
+		schedule.Status,
+		schedule.CreatedAt,
+		schedule.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *ScheduleRepositoryImpl) GetSchedule(ctx context.Context, scheduleID string) (domain.Schedule, error) {
+	var result domain.Schedule
+	err := scanSchedule(repo.db.QueryRowContext(ctx, getScheduleQuery, scheduleID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *ScheduleRepositoryImpl) GetSchedules(ctx context.Context, customerXID string) ([]domain.Schedule, error) {
+	return repo.querySchedules(ctx, getSchedulesQuery, customerXID)
+}
+
+func (repo *ScheduleRepositoryImpl) GetDueSchedules(ctx context.Context, now, staleBefore time.Time, limit int) ([]domain.Schedule, error) {
+	return repo.querySchedules(ctx, getDueSchedulesQuery, now, constants.STATUS_ACTIVE, constants.STATUS_RUNNING, staleBefore, limit)
+}
+
+func (repo *ScheduleRepositoryImpl) querySchedules(ctx context.Context, query string, args ...interface{}) ([]domain.Schedule, error) {
+	var result []domain.Schedule
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Schedule{}
+		err := scanSchedule(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *ScheduleRepositoryImpl) UpdateScheduleStatus(ctx context.Context, schedule domain.Schedule, status string, nextRunAt time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updateScheduleStatusQuery, status, nextRunAt, schedule.ID, schedule.Status, schedule.NextRunAt)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *ScheduleRepositoryImpl) ClaimSchedule(ctx context.Context, scheduleID string, runAt, staleBefore time.Time) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, claimScheduleQuery, constants.STATUS_RUNNING, scheduleID, runAt, constants.STATUS_ACTIVE, constants.STATUS_RUNNING, staleBefore)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *ScheduleRepositoryImpl) AdvanceSchedule(ctx context.Context, scheduleID string, runAt, nextRunAt time.Time, status string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, advanceScheduleQuery, status, nextRunAt, scheduleID, constants.STATUS_RUNNING, runAt)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *ScheduleRepositoryImpl) AddScheduleRun(ctx context.Context, run domain.ScheduleRun) error {
+	_, err := repo.db.ExecContext(ctx, insertScheduleRunQuery,
+		run.ID,
+		run.ScheduleID,
+		run.RunAt,
+		run.ReferenceID,
+		run.TransactionID,
+		run.Status,
+		run.ErrorMessage,
+		run.CreatedAt,
+	)
+	return err
+}
+
+func (repo *ScheduleRepositoryImpl) GetScheduleRuns(ctx context.Context, scheduleID string) ([]domain.ScheduleRun, error) {
+	var result []domain.ScheduleRun
+	rows, err := repo.db.QueryContext(ctx, getScheduleRunsQuery, scheduleID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.ScheduleRun{}
+		err := rows.Scan(
+			&data.ID,
+			&data.ScheduleID,
+			&data.RunAt,
+			&data.ReferenceID,
+			&data.TransactionID,
+			&data.Status,
+			&data.ErrorMessage,
+			&data.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func scanSchedule(row rowScanner, schedule *domain.Schedule) error {
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.CustomerXID,
+		&schedule.ScheduleType,
+		&schedule.RecipientXID,
+		&schedule.BankAccount.BankCode,
+		&schedule.BankAccount.AccountNumber,
+		&schedule.BankAccount.AccountName,
+		&schedule.Amount,
+		&schedule.Amount.Currency,
+		&schedule.Frequency,
+		&schedule.DayOfMonth,
+		&schedule.NextRunAt,
+		&schedule.Status,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,61 @@
+package repository
+
+const (
+	// payments and refunds of the merchant up to the cutoff that no
+	// settlement has picked up yet
+	getUnsettledItemsQuery = `SELECT 
+		t.id, t.reference_id, t.transaction_type, t.amount, t.currency, t.created_at
+		FROM transactions t
+		JOIN payment_intents pi ON pi.id = t.reference_id
+		LEFT JOIN settlement_items si ON si.transaction_id = t.id
+		WHERE 
+			pi.merchant_id = ? AND
+			t.transaction_type IN (?, ?) AND
+			t.status = ? AND
+			t.created_at < ? AND
+			si.transaction_id IS NULL
+		order by t.created_at`
+
+	getUnsettledMerchantIDsQuery = `SELECT DISTINCT pi.merchant_id
+		FROM transactions t
+		JOIN payment_intents pi ON pi.id = t.reference_id
+		LEFT JOIN settlement_items si ON si.transaction_id = t.id
+		WHERE 
+			t.transaction_type IN (?, ?) AND
+			t.status = ? AND
+			t.created_at < ? AND
+			si.transaction_id IS NULL`
+
+	insertSettlementQuery = `INSERT INTO settlements
+		(id, merchant_id, currency, cutoff_at, gross_amount, fee_amount, refund_amount, carried_amount, carried_from, net_amount, transaction_id, status, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	// a carried settlement is recovered by exactly one later settlement
+	recoverSettlementQuery = `UPDATE settlements
+		SET
+			status = ?
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	insertSettlementItemQuery = `INSERT INTO settlement_items
+		(transaction_id, settlement_id, payment_intent_id, transaction_type, amount, fee, transacted_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?)`
+
+	selectSettlementColumns = `SELECT 
+		id, merchant_id, currency, cutoff_at, gross_amount, fee_amount, refund_amount, carried_amount, carried_from, net_amount, transaction_id, status, created_at
+		FROM settlements`
+
+	getSettlementQuery = selectSettlementColumns + ` WHERE id = ?`
+
+	getCarriedSettlementQuery = selectSettlementColumns + ` WHERE merchant_id = ? AND currency = ? AND status = ? order by cutoff_at DESC LIMIT 1`
+
+	getSettlementsQuery = selectSettlementColumns + ` WHERE merchant_id = ? order by cutoff_at DESC`
+
+	getSettlementItemsQuery = `SELECT 
+		si.settlement_id, si.transaction_id, si.payment_intent_id, si.transaction_type, si.amount, si.fee, s.currency, si.transacted_at
+		FROM settlement_items si
+		JOIN settlements s ON s.id = si.settlement_id
+		WHERE si.settlement_id = ?
+		order by si.transacted_at, si.transaction_id`
+)
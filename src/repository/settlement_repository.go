@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type SettlementRepository interface {
+	GetUnsettledMerchantIDs(ctx context.Context, cutoffAt time.Time) ([]string, error)
+	GetUnsettledItems(ctx context.Context, merchantID string, cutoffAt time.Time) ([]domain.SettlementItem, error)
+	// CreateSettlement stores the batch with its items, recovers the carried
+	// settlement it takes in and credits the payout, if any, in a single
+	// database transaction. An item can only ever belong to one settlement.
+	// It returns false when the carried settlement was recovered already.
+	CreateSettlement(ctx context.Context, settlement domain.Settlement, items []domain.SettlementItem, payout *domain.Transaction) (bool, error)
+	GetSettlement(ctx context.Context, settlementID string) (domain.Settlement, error)
+	// GetCarriedSettlement returns the merchant's latest settlement in the
+	// currency that is still waiting to be recovered.
+	GetCarriedSettlement(ctx context.Context, merchantID, currency string) (domain.Settlement, error)
+	GetSettlements(ctx context.Context, merchantID string) ([]domain.Settlement, error)
+	GetSettlementItems(ctx context.Context, settlementID string) ([]domain.SettlementItem, error)
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type SettlementRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewSettlementRepository(db *sql.DB) SettlementRepository {
+	return &SettlementRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *SettlementRepositoryImpl) GetUnsettledMerchantIDs(ctx context.Context, cutoffAt time.Time) ([]string, error) {
+	var result []string
+	rows, err := repo.db.QueryContext(ctx, getUnsettledMerchantIDsQuery,
+		constants.TRANSACTION_TYPE_PAYMENT,
+		constants.TRANSACTION_TYPE_PAYMENT_REFUND,
+		constants.STATUS_SUCCESS,
+		cutoffAt,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var merchantID string
+		err := rows.Scan(&merchantID)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, merchantID)
+	}
+	return result, nil
+}
+
+func (repo *SettlementRepositoryImpl) GetUnsettledItems(ctx context.Context, merchantID string, cutoffAt time.Time) ([]domain.SettlementItem, error) {
+	var result []domain.SettlementItem
+	rows, err := repo.db.QueryContext(ctx, getUnsettledItemsQuery,
+		merchantID,
+		constants.TRANSACTION_TYPE_PAYMENT,
+		constants.TRANSACTION_TYPE_PAYMENT_REFUND,
+		constants.STATUS_SUCCESS,
+		cutoffAt,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.SettlementItem{}
+		err := rows.Scan(
+			&data.TransactionID,
+			&data.PaymentIntentID,
+			&data.TransactionType,
+			&data.Amount,
+			&data.Amount.Currency,
+			&data.TransactedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		data.Fee.Currency = data.Amount.Currency
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *SettlementRepositoryImpl) CreateSettlement(ctx context.Context, settlement domain.Settlement, items []domain.SettlementItem, payout *domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if settlement.CarriedFromID != "" {
+		res, err := tx.ExecContext(ctx, recoverSettlementQuery, constants.STATUS_RECOVERED, settlement.CarriedFromID, constants.STATUS_CARRIED)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+
+		rowsAffected, _ := res.RowsAffected()
+		if rowsAffected == 0 {
+			_ = tx.Rollback()
+			return false, nil
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, insertSettlementQuery,
+		settlement.ID,
+		settlement.MerchantID,
+		settlement.NetAmount.Currency,
+		settlement.CutoffAt,
+		settlement.GrossAmount,
+		settlement.FeeAmount,
+		settlement.RefundAmount,
+		settlement.CarriedAmount,
+		settlement.CarriedFromID,
+		settlement.NetAmount,
+		settlement.TransactionID,
+		settlement.Status,
+		settlement.CreatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, insertSettlementItemQuery,
+			item.TransactionID,
+			settlement.ID,
+			item.PaymentIntentID,
+			item.TransactionType,
+			item.Amount,
+			item.Fee,
+			item.TransactedAt,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+	}
+
+	// a carried settlement pays nothing out
+	if payout == nil {
+		err = tx.Commit()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, payout.Amount, payout.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, *payout)
+}
+
+func (repo *SettlementRepositoryImpl) GetSettlement(ctx context.Context, settlementID string) (domain.Settlement, error) {
+	var result domain.Settlement
+	err := scanSettlement(repo.db.QueryRowContext(ctx, getSettlementQuery, settlementID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *SettlementRepositoryImpl) GetCarriedSettlement(ctx context.Context, merchantID, currency string) (domain.Settlement, error) {
+	var result domain.Settlement
+	err := scanSettlement(repo.db.QueryRowContext(ctx, getCarriedSettlementQuery, merchantID, currency, constants.STATUS_CARRIED), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *SettlementRepositoryImpl) GetSettlements(ctx context.Context, merchantID string) ([]domain.Settlement, error) {
+	var result []domain.Settlement
+	rows, err := repo.db.QueryContext(ctx, getSettlementsQuery, merchantID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Settlement{}
+		err := scanSettlement(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *SettlementRepositoryImpl) GetSettlementItems(ctx context.Context, settlementID string) ([]domain.SettlementItem, error) {
+	var result []domain.SettlementItem
+	rows, err := repo.db.QueryContext(ctx, getSettlementItemsQuery, settlementID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.SettlementItem{}
+		err := rows.Scan(
+			&data.SettlementID,
+			&data.TransactionID,
+			&data.PaymentIntentID,
+			&data.TransactionType,
+			&data.Amount,
+			&data.Fee,
+			&data.Amount.Currency,
+			&data.TransactedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		data.Fee.Currency = data.Amount.Currency
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func scanSettlement(row rowScanner, settlement *domain.Settlement) error {
+	var currency string
+	err := row.Scan(
+		&settlement.ID,
+		&settlement.MerchantID,
+		&currency,
+		&settlement.CutoffAt,
+		&settlement.GrossAmount,
+		&settlement.FeeAmount,
+		&settlement.RefundAmount,
+		&settlement.CarriedAmount,
+		&settlement.CarriedFromID,
+		&settlement.NetAmount,
+		&settlement.TransactionID,
+		&settlement.Status,
+		&settlement.CreatedAt,
+	)
+	settlement.GrossAmount.Currency = currency
+	settlement.FeeAmount.Currency = currency
+	settlement.RefundAmount.Currency = currency
+	settlement.CarriedAmount.Currency = currency
+	settlement.NetAmount.Currency = currency
+	return err
+}
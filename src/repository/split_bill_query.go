@@ -0,0 +1,49 @@
+package repository
+
+const (
+	insertSplitBillQuery = `INSERT INTO split_bills
+		(id, creator_xid, title, total_amount, currency, split_type, expiry_policy, status, expires_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectSplitBillColumns = `SELECT 
+		id, creator_xid, title, total_amount, currency, split_type, expiry_policy, status, expires_at, created_at, updated_at
+		FROM split_bills`
+
+	getSplitBillQuery = selectSplitBillColumns + ` WHERE id = ?`
+
+	// locks the bill so it cannot close while one of its shares is paid
+	lockSplitBillStatusQuery = `SELECT status FROM split_bills WHERE id = ? FOR UPDATE`
+
+	getSplitBillsQuery = selectSplitBillColumns + ` WHERE creator_xid = ? order by created_at DESC`
+
+	getExpiredSplitBillsQuery = selectSplitBillColumns + ` WHERE status = ? AND expires_at <= ? order by expires_at LIMIT ?`
+
+	getSplitBillSharesQuery = selectPaymentRequestColumns + ` WHERE split_bill_id = ? order by created_at, id`
+
+	updateSplitBillStatusQuery = `UPDATE split_bills
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ?`
+
+	// a bill completes only while it is pending and none of its shares is
+	// left unpaid
+	completeSplitBillQuery = `UPDATE split_bills
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			NOT EXISTS (SELECT 1 FROM payment_requests WHERE split_bill_id = ? AND status <> ?)`
+
+	updateSplitBillSharesStatusQuery = `UPDATE payment_requests
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			split_bill_id = ? AND
+			status = ?`
+)
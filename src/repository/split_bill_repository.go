@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type SplitBillRepository interface {
+	// CreateSplitBill stores the bill together with the payment request of
+	// every share.
+	CreateSplitBill(ctx context.Context, splitBill domain.SplitBill, shares []domain.PaymentRequest) error
+	GetSplitBill(ctx context.Context, splitBillID string) (domain.SplitBill, error)
+	GetSplitBills(ctx context.Context, creatorXID string) ([]domain.SplitBill, error)
+	GetSplitBillShares(ctx context.Context, splitBillID string) ([]domain.PaymentRequest, error)
+	GetExpiredSplitBills(ctx context.Context, now time.Time, limit int) ([]domain.SplitBill, error)
+	UpdateSplitBillStatus(ctx context.Context, splitBillID, fromStatus, toStatus string) (bool, error)
+	// CompleteSplitBill marks a pending bill completed once every share is
+	// accepted. It returns false while any share is still unpaid.
+	CompleteSplitBill(ctx context.Context, splitBillID string) (bool, error)
+	UpdateSplitBillSharesStatus(ctx context.Context, splitBillID, fromStatus, toStatus string) error
+}
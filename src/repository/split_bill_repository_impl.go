@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type SplitBillRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewSplitBillRepository(db *sql.DB) SplitBillRepository {
+	return &SplitBillRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *SplitBillRepositoryImpl) CreateSplitBill(ctx context.Context, splitBill domain.SplitBill, shares []domain.PaymentRequest) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, insertSplitBillQuery,
+		splitBill.ID,
+		splitBill.CreatorXID,
+		splitBill.Title,
+		splitBill.TotalAmount,
+		splitBill.TotalAmount.Currency,
+		splitBill.SplitType,
+		splitBill.ExpiryPolicy,
+		splitBill.Status,
+		splitBill.ExpiresAt,
+		splitBill.CreatedAt,
+		splitBill.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for i := range shares {
+		_, err = tx.ExecContext(ctx, insertPaymentRequestQuery, paymentRequestArgs(shares[i])...)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *SplitBillRepositoryImpl) GetSplitBill(ctx context.Context, splitBillID string) (domain.SplitBill, error) {
+	var result domain.SplitBill
+	err := scanSplitBill(repo.db.QueryRowContext(ctx, getSplitBillQuery, splitBillID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *SplitBillRepositoryImpl) GetSplitBills(ctx context.Context, creatorXID string) ([]domain.SplitBill, error) {
+	return repo.querySplitBills(ctx, getSplitBillsQuery, creatorXID)
+}
+
+func (repo *SplitBillRepositoryImpl) GetExpiredSplitBills(ctx context.Context, now time.Time, limit int) ([]domain.SplitBill, error) {
+	return repo.querySplitBills(ctx, getExpiredSplitBillsQuery, constants.STATUS_PENDING, now, limit)
+}
+
+func (repo *SplitBillRepositoryImpl) querySplitBills(ctx context.Context, query string, args ...interface{}) ([]domain.SplitBill, error) {
+	var result []domain.SplitBill
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.SplitBill{}
+		err := scanSplitBill(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *SplitBillRepositoryImpl) GetSplitBillShares(ctx context.Context, splitBillID string) ([]domain.PaymentRequest, error) {
+	var result []domain.PaymentRequest
+	rows, err := repo.db.QueryContext(ctx, getSplitBillSharesQuery, splitBillID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.PaymentRequest{}
+		err := scanPaymentRequest(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *SplitBillRepositoryImpl) UpdateSplitBillStatus(ctx context.Context, splitBillID, fromStatus, toStatus string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, updateSplitBillStatusQuery, toStatus, splitBillID, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *SplitBillRepositoryImpl) CompleteSplitBill(ctx context.Context, splitBillID string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, completeSplitBillQuery,
+		constants.STATUS_COMPLETED,
+		splitBillID,
+		constants.STATUS_PENDING,
+		splitBillID,
+		constants.STATUS_ACCEPTED,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func (repo *SplitBillRepositoryImpl) UpdateSplitBillSharesStatus(ctx context.Context, splitBillID, fromStatus, toStatus string) error {
+	_, err := repo.db.ExecContext(ctx, updateSplitBillSharesStatusQuery, toStatus, splitBillID, fromStatus)
+	return err
+}
+
+func scanSplitBill(row rowScanner, splitBill *domain.SplitBill) error {
+	return row.Scan(
+		&splitBill.ID,
+		&splitBill.CreatorXID,
+		&splitBill.Title,
+		&splitBill.TotalAmount,
+		&splitBill.TotalAmount.Currency,
+		&splitBill.SplitType,
+		&splitBill.ExpiryPolicy,
+		&splitBill.Status,
+		&splitBill.ExpiresAt,
+		&splitBill.CreatedAt,
+		&splitBill.UpdatedAt,
+	)
+}
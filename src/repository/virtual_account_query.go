@@ -0,0 +1,26 @@
+package repository
+
+const (
+	insertVirtualAccountQuery = `INSERT INTO virtual_accounts
+		(id, wallet_id, customer_xid, bank_code, number, currency, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?)`
+
+	selectVirtualAccountColumns = `SELECT 
+		id, wallet_id, customer_xid, bank_code, number, currency, created_at
+		FROM virtual_accounts`
+
+	getVirtualAccountByNumberQuery = selectVirtualAccountColumns + ` WHERE number = ?`
+
+	getVirtualAccountByWalletQuery = selectVirtualAccountColumns + ` WHERE wallet_id = ? AND bank_code = ?`
+
+	getVirtualAccountsQuery = selectVirtualAccountColumns + ` WHERE customer_xid = ? order by created_at`
+
+	// a callback delivered twice is ignored by the unique bank reference
+	insertVirtualAccountPaymentQuery = `INSERT IGNORE INTO virtual_account_payments
+		(id, virtual_account_id, wallet_id, bank_code, bank_reference, amount, currency, transaction_id, paid_at, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	getVirtualAccountPaymentQuery = `SELECT 
+		id, virtual_account_id, wallet_id, bank_code, bank_reference, amount, currency, transaction_id, paid_at, created_at
+		FROM virtual_account_payments WHERE bank_code = ? AND bank_reference = ?`
+)
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type VirtualAccountRepository interface {
+	CreateVirtualAccount(ctx context.Context, virtualAccount domain.VirtualAccount) error
+	GetVirtualAccountByNumber(ctx context.Context, number string) (domain.VirtualAccount, error)
+	GetVirtualAccountByWallet(ctx context.Context, walletID, bankCode string) (domain.VirtualAccount, error)
+	GetVirtualAccounts(ctx context.Context, customerXID string) ([]domain.VirtualAccount, error)
+	GetVirtualAccountPayment(ctx context.Context, bankCode, bankReference string) (domain.VirtualAccountPayment, error)
+
+	// CreditVirtualAccountPayment records the payment and credits the wallet
+	// in a single database transaction. It returns false when the bank
+	// reference was already credited.
+	CreditVirtualAccountPayment(ctx context.Context, payment domain.VirtualAccountPayment, credit domain.Transaction) (bool, error)
+}
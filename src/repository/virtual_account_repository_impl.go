@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type VirtualAccountRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewVirtualAccountRepository(db *sql.DB) VirtualAccountRepository {
+	return &VirtualAccountRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *VirtualAccountRepositoryImpl) CreateVirtualAccount(ctx context.Context, virtualAccount domain.VirtualAccount) error {
+	_, err := repo.db.ExecContext(ctx, insertVirtualAccountQuery,
+		virtualAccount.ID,
+		virtualAccount.WalletID,
+		virtualAccount.CustomerXID,
+		virtualAccount.BankCode,
+		virtualAccount.Number,
+		virtualAccount.Currency,
+		virtualAccount.CreatedAt,
+	)
+	return err
+}
+
+func (repo *VirtualAccountRepositoryImpl) GetVirtualAccountByNumber(ctx context.Context, number string) (domain.VirtualAccount, error) {
+	var result domain.VirtualAccount
+	err := scanVirtualAccount(repo.db.QueryRowContext(ctx, getVirtualAccountByNumberQuery, number), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VirtualAccountRepositoryImpl) GetVirtualAccountByWallet(ctx context.Context, walletID, bankCode string) (domain.VirtualAccount, error) {
+	var result domain.VirtualAccount
+	err := scanVirtualAccount(repo.db.QueryRowContext(ctx, getVirtualAccountByWalletQuery, walletID, bankCode), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VirtualAccountRepositoryImpl) GetVirtualAccounts(ctx context.Context, customerXID string) ([]domain.VirtualAccount, error) {
+	var result []domain.VirtualAccount
+	rows, err := repo.db.QueryContext(ctx, getVirtualAccountsQuery, customerXID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.VirtualAccount{}
+		err := scanVirtualAccount(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *VirtualAccountRepositoryImpl) GetVirtualAccountPayment(ctx context.Context, bankCode, bankReference string) (domain.VirtualAccountPayment, error) {
+	var result domain.VirtualAccountPayment
+	err := repo.db.QueryRowContext(ctx, getVirtualAccountPaymentQuery, bankCode, bankReference).Scan(
+		&result.ID,
+		&result.VirtualAccountID,
+		&result.WalletID,
+		&result.BankCode,
+		&result.BankReference,
+		&result.Amount,
+		&result.Amount.Currency,
+		&result.TransactionID,
+		&result.PaidAt,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VirtualAccountRepositoryImpl) CreditVirtualAccountPayment(ctx context.Context, payment domain.VirtualAccountPayment, credit domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, insertVirtualAccountPaymentQuery,
+		payment.ID,
+		payment.VirtualAccountID,
+		payment.WalletID,
+		payment.BankCode,
+		payment.BankReference,
+		payment.Amount,
+		payment.Amount.Currency,
+		payment.TransactionID,
+		payment.PaidAt,
+		payment.CreatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, credit)
+}
+
+func scanVirtualAccount(row rowScanner, virtualAccount *domain.VirtualAccount) error {
+	return row.Scan(
+		&virtualAccount.ID,
+		&virtualAccount.WalletID,
+		&virtualAccount.CustomerXID,
+		&virtualAccount.BankCode,
+		&virtualAccount.Number,
+		&virtualAccount.Currency,
+		&virtualAccount.CreatedAt,
+	)
+}
@@ -0,0 +1,54 @@
+package repository
+
+const (
+	insertVoucherBatchQuery = `INSERT INTO voucher_batches
+		(id, name, value, currency, code_count, max_uses, per_customer_limit, expires_at, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	insertVoucherQuery = `INSERT INTO vouchers
+		(id, batch_id, code, used_count, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?)`
+
+	selectVoucherBatchColumns = `SELECT 
+		id, name, value, currency, code_count, max_uses, per_customer_limit, expires_at, created_at, updated_at
+		FROM voucher_batches`
+
+	getVoucherBatchQuery = selectVoucherBatchColumns + ` WHERE id = ?`
+
+	getVoucherBatchesQuery = selectVoucherBatchColumns + ` order by created_at DESC`
+
+	selectVoucherColumns = `SELECT 
+		id, batch_id, code, used_count, created_at, updated_at
+		FROM vouchers`
+
+	getVoucherByCodeQuery = selectVoucherColumns + ` WHERE code = ?`
+
+	getVouchersQuery = selectVoucherColumns + ` WHERE batch_id = ? order by code`
+
+	getCustomerVoucherUsageQuery = `SELECT COALESCE(MAX(redeemed_count), 0)
+		FROM voucher_customer_usages WHERE batch_id = ? AND customer_xid = ?`
+
+	// the row lock on the code serializes concurrent redemptions of it
+	claimVoucherQuery = `UPDATE vouchers
+		SET
+			used_count = used_count + 1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			used_count < ?`
+
+	// an unchanged row reports no rows affected, which is how a customer at
+	// the limit is told apart from one below it
+	claimCustomerVoucherUsageQuery = `INSERT INTO voucher_customer_usages
+		(batch_id, customer_xid, redeemed_count)
+		VALUES(?, ?, 1)
+		ON DUPLICATE KEY UPDATE
+			redeemed_count = IF(redeemed_count < ?, redeemed_count + 1, redeemed_count)`
+
+	insertVoucherRedemptionQuery = `INSERT INTO voucher_redemptions
+		(id, batch_id, voucher_id, customer_xid, wallet_id, amount, currency, transaction_id, created_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	getVoucherBatchCustomerCountQuery = `SELECT COUNT(DISTINCT customer_xid)
+		FROM voucher_redemptions WHERE batch_id = ?`
+)
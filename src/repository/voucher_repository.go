@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type VoucherRepository interface {
+	CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch, vouchers []domain.Voucher) error
+	GetVoucherBatch(ctx context.Context, batchID string) (domain.VoucherBatch, error)
+	GetVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error)
+	GetVoucherByCode(ctx context.Context, code string) (domain.Voucher, error)
+	GetVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error)
+	GetCustomerVoucherUsage(ctx context.Context, batchID, customerXID string) (int, error)
+	GetVoucherBatchCustomerCount(ctx context.Context, batchID string) (int, error)
+
+	// RedeemVoucher claims a use of the code and of the customer limit,
+	// records the redemption and credits the wallet in a single database
+	// transaction. It returns false when the code is used up or the customer
+	// reached the limit of the batch.
+	RedeemVoucher(ctx context.Context, batch domain.VoucherBatch, redemption domain.VoucherRedemption, credit domain.Transaction) (bool, error)
+}
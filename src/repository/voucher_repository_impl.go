@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type VoucherRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewVoucherRepository(db *sql.DB) VoucherRepository {
+	return &VoucherRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *VoucherRepositoryImpl) CreateVoucherBatch(ctx context.Context, batch domain.VoucherBatch, vouchers []domain.Voucher) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, insertVoucherBatchQuery,
+		batch.ID,
+		batch.Name,
+		batch.Value,
+		batch.Value.Currency,
+		batch.CodeCount,
+		batch.MaxUses,
+		batch.PerCustomerLimit,
+		batch.ExpiresAt,
+		batch.CreatedAt,
+		batch.UpdatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	for _, voucher := range vouchers {
+		_, err = tx.ExecContext(ctx, insertVoucherQuery,
+			voucher.ID,
+			voucher.BatchID,
+			voucher.Code,
+			voucher.UsedCount,
+			voucher.CreatedAt,
+			voucher.UpdatedAt,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *VoucherRepositoryImpl) GetVoucherBatch(ctx context.Context, batchID string) (domain.VoucherBatch, error) {
+	var result domain.VoucherBatch
+	err := scanVoucherBatch(repo.db.QueryRowContext(ctx, getVoucherBatchQuery, batchID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VoucherRepositoryImpl) GetVoucherBatches(ctx context.Context) ([]domain.VoucherBatch, error) {
+	var result []domain.VoucherBatch
+	rows, err := repo.db.QueryContext(ctx, getVoucherBatchesQuery)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.VoucherBatch{}
+		err := scanVoucherBatch(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *VoucherRepositoryImpl) GetVoucherByCode(ctx context.Context, code string) (domain.Voucher, error) {
+	var result domain.Voucher
+	err := scanVoucher(repo.db.QueryRowContext(ctx, getVoucherByCodeQuery, code), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VoucherRepositoryImpl) GetVouchers(ctx context.Context, batchID string) ([]domain.Voucher, error) {
+	var result []domain.Voucher
+	rows, err := repo.db.QueryContext(ctx, getVouchersQuery, batchID)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Voucher{}
+		err := scanVoucher(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *VoucherRepositoryImpl) GetCustomerVoucherUsage(ctx context.Context, batchID, customerXID string) (int, error) {
+	var result int
+	err := repo.db.QueryRowContext(ctx, getCustomerVoucherUsageQuery, batchID, customerXID).Scan(&result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VoucherRepositoryImpl) GetVoucherBatchCustomerCount(ctx context.Context, batchID string) (int, error) {
+	var result int
+	err := repo.db.QueryRowContext(ctx, getVoucherBatchCustomerCountQuery, batchID).Scan(&result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *VoucherRepositoryImpl) RedeemVoucher(ctx context.Context, batch domain.VoucherBatch, redemption domain.VoucherRedemption, credit domain.Transaction) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, claimVoucherQuery, redemption.VoucherID, batch.MaxUses)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	res, err = tx.ExecContext(ctx, claimCustomerVoucherUsageQuery, batch.ID, redemption.CustomerXID, batch.PerCustomerLimit)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, insertVoucherRedemptionQuery,
+		redemption.ID,
+		redemption.BatchID,
+		redemption.VoucherID,
+		redemption.CustomerXID,
+		redemption.WalletID,
+		redemption.Amount,
+		redemption.Amount.Currency,
+		redemption.TransactionID,
+		redemption.CreatedAt,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, credit)
+}
+
+func scanVoucherBatch(row rowScanner, batch *domain.VoucherBatch) error {
+	return row.Scan(
+		&batch.ID,
+		&batch.Name,
+		&batch.Value,
+		&batch.Value.Currency,
+		&batch.CodeCount,
+		&batch.MaxUses,
+		&batch.PerCustomerLimit,
+		&batch.ExpiresAt,
+		&batch.CreatedAt,
+		&batch.UpdatedAt,
+	)
+}
+
+func scanVoucher(row rowScanner, voucher *domain.Voucher) error {
+	return row.Scan(
+		&voucher.ID,
+		&voucher.BatchID,
+		&voucher.Code,
+		&voucher.UsedCount,
+		&voucher.CreatedAt,
+		&voucher.UpdatedAt,
+	)
+}
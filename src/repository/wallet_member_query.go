@@ -0,0 +1,62 @@
+package repository
+
+const (
+	insertWalletMemberQuery = `INSERT IGNORE INTO wallet_members
+		(id, wallet_id, owner_xid, member_xid, spending_limit, currency, allowed_types, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	selectWalletMemberColumns = `SELECT 
+		id, wallet_id, owner_xid, member_xid, spending_limit, currency, allowed_types, status, spent, spent_period, created_at, updated_at
+		FROM wallet_members`
+
+	getWalletMemberQuery = selectWalletMemberColumns + ` WHERE wallet_id = ? AND member_xid = ?`
+
+	getWalletMembersQuery = selectWalletMemberColumns + ` WHERE wallet_id = ? order by created_at`
+
+	getMembershipsQuery = selectWalletMemberColumns + ` WHERE member_xid = ? order by created_at`
+
+	updateWalletMemberControlsQuery = `UPDATE wallet_members
+		SET
+			spending_limit = ?,
+			allowed_types = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	updateWalletMemberStatusQuery = `UPDATE wallet_members
+		SET
+			status = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
+
+	// spending of an earlier period starts over from zero
+	reserveMemberSpendQuery = `UPDATE wallet_members
+		SET
+			spent = IF(spent_period = ?, spent, 0) + ?,
+			spent_period = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			status = ? AND
+			(spending_limit = 0 OR IF(spent_period = ?, spent, 0) + ? <= spending_limit)`
+
+	releaseMemberSpendQuery = `UPDATE wallet_members
+		SET
+			spent = spent - ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			spent_period = ? AND
+			spent >= ?`
+
+	releaseMemberSpendByXIDQuery = `UPDATE wallet_members
+		SET
+			spent = spent - ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			wallet_id = ? AND
+			member_xid = ? AND
+			spent_period = ? AND
+			spent >= ?`
+)
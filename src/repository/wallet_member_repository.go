@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type WalletMemberRepository interface {
+	// CreateWalletMember returns false when the customer already is a member
+	// of the wallet.
+	CreateWalletMember(ctx context.Context, member domain.WalletMember) (bool, error)
+	GetWalletMember(ctx context.Context, walletID, memberXID string) (domain.WalletMember, error)
+	GetWalletMembers(ctx context.Context, walletID string) ([]domain.WalletMember, error)
+	// GetMemberships returns the wallets the customer is a member of.
+	GetMemberships(ctx context.Context, memberXID string) ([]domain.WalletMember, error)
+	UpdateWalletMemberControls(ctx context.Context, member domain.WalletMember) error
+	UpdateWalletMemberStatus(ctx context.Context, memberID, status string) error
+
+	// ReserveMemberSpend counts amount against the member's spending in
+	// period. It returns false when the member is not active or would go
+	// over their spending limit.
+	ReserveMemberSpend(ctx context.Context, memberID string, amount domain.Money, period string) (bool, error)
+	// ReleaseMemberSpend gives back a reservation whose transaction did not
+	// go through.
+	ReleaseMemberSpend(ctx context.Context, memberID string, amount domain.Money, period string) error
+}
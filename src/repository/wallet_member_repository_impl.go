@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+)
+
+type WalletMemberRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewWalletMemberRepository(db *sql.DB) WalletMemberRepository {
+	return &WalletMemberRepositoryImpl{
+		db: db,
+	}
+}
+
+func (repo *WalletMemberRepositoryImpl) CreateWalletMember(ctx context.Context, member domain.WalletMember) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, insertWalletMemberQuery,
+		member.ID,
+		member.WalletID,
+		member.OwnerXID,
+		member.MemberXID,
+		member.SpendingLimit,
+		member.SpendingLimit.Currency,
+		strings.Join(member.AllowedTypes, ","),
+		member.Status,
+		member.CreatedAt,
+		member.UpdatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (repo *WalletMemberRepositoryImpl) GetWalletMember(ctx context.Context, walletID, memberXID string) (domain.WalletMember, error) {
+	var result domain.WalletMember
+	err := scanWalletMember(repo.db.QueryRowContext(ctx, getWalletMemberQuery, walletID, memberXID), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *WalletMemberRepositoryImpl) GetWalletMembers(ctx context.Context, walletID string) ([]domain.WalletMember, error) {
+	return repo.queryWalletMembers(ctx, getWalletMembersQuery, walletID)
+}
+
+func (repo *WalletMemberRepositoryImpl) GetMemberships(ctx context.Context, memberXID string) ([]domain.WalletMember, error) {
+	return repo.queryWalletMembers(ctx, getMembershipsQuery, memberXID)
+}
+
+func (repo *WalletMemberRepositoryImpl) queryWalletMembers(ctx context.Context, query string, args ...interface{}) ([]domain.WalletMember, error) {
+	var result []domain.WalletMember
+	rows, err := repo.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.WalletMember{}
+		err := scanWalletMember(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (repo *WalletMemberRepositoryImpl) UpdateWalletMemberControls(ctx context.Context, member domain.WalletMember) error {
+	_, err := repo.db.ExecContext(ctx, updateWalletMemberControlsQuery,
+		member.SpendingLimit,
+		strings.Join(member.AllowedTypes, ","),
+		member.ID,
+	)
+	return err
+}
+
+func (repo *WalletMemberRepositoryImpl) UpdateWalletMemberStatus(ctx context.Context, memberID, status string) error {
+	_, err := repo.db.ExecContext(ctx, updateWalletMemberStatusQuery, status, memberID)
+	return err
+}
+
+func (repo *WalletMemberRepositoryImpl) ReserveMemberSpend(ctx context.Context, memberID string, amount domain.Money, period string) (bool, error) {
+	res, err := repo.db.ExecContext(ctx, reserveMemberSpendQuery,
+		period,
+		amount,
+		period,
+		memberID,
+		constants.STATUS_ACTIVE,
+		period,
+		amount,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (repo *WalletMemberRepositoryImpl) ReleaseMemberSpend(ctx context.Context, memberID string, amount domain.Money, period string) error {
+	_, err := repo.db.ExecContext(ctx, releaseMemberSpendQuery, amount, memberID, period, amount)
+	return err
+}
+
+func scanWalletMember(row rowScanner, member *domain.WalletMember) error {
+	var allowedTypes string
+	err := row.Scan(
+		&member.ID,
+		&member.WalletID,
+		&member.OwnerXID,
+		&member.MemberXID,
+		&member.SpendingLimit,
+		&member.SpendingLimit.Currency,
+		&allowedTypes,
+		&member.Status,
+		&member.Spent,
+		&member.SpentPeriod,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	member.Spent.Currency = member.SpendingLimit.Currency
+	member.AllowedTypes = []string{}
+	if allowedTypes != "" {
+		member.AllowedTypes = strings.Split(allowedTypes, ",")
+	}
+	return nil
+}
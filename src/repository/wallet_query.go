@@ -1,13 +1,36 @@
 package repository
 
 const (
+	// the new balance must stay within the credit limit, which may have been
+	// lowered since the balance was read. Every balance update also sets
+	// credit_used from the new balance, MySQL assigns the columns of a
+	// single-table UPDATE from left to right.
 	updateWalletBalanceQuery = `UPDATE wallets
 		SET
 			balance = ?,
+			credit_used = GREATEST(-balance, 0),
 			updated_at = CURRENT_TIMESTAMP
 		WHERE 
 			id = ? AND
-			balance = ?`
+			balance = ? AND
+			? + credit_limit >= 0`
+
+	debitWalletBalanceQuery = `UPDATE wallets
+		SET
+			balance = balance - ?,
+			credit_used = GREATEST(-balance, 0),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ? AND
+			balance >= ?`
+
+	creditWalletBalanceQuery = `UPDATE wallets
+		SET
+			balance = balance + ?,
+			credit_used = GREATEST(-balance, 0),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE 
+			id = ?`
 
 	updateTransactionStatusQuery = `UPDATE transactions
 		SET
@@ -17,16 +40,27 @@ const (
 			id = ?`
 
 	insertTransactionQuery = `INSERT INTO transactions
-		(id, wallet_id, customer_xid, transaction_type, amount, reference_id, status, created_at, updated_at)
-		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		(id, wallet_id, customer_xid, initiated_by, pocket_id, transaction_type, amount, currency, reference_id, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	getTransactionsQuery = `SELECT 
-		id, wallet_id, customer_xid, transaction_type, amount, reference_id, status, created_at, updated_at 
+		id, wallet_id, customer_xid, initiated_by, transaction_type, amount, currency, reference_id, status, created_at, updated_at 
 		FROM transactions WHERE wallet_id = ? order by created_at`
 
-	getWalletQuery = `SELECT 	
-		id, customer_xid, status, enabled_at, balance, created_at, updated_at FROM wallets 
-		WHERE customer_xid = ?`
+	getTransactionByReferenceQuery = `SELECT 
+		id, wallet_id, customer_xid, initiated_by, transaction_type, amount, currency, reference_id, status, created_at, updated_at 
+		FROM transactions WHERE transaction_type = ? AND reference_id = ?`
+
+	getTransactionQuery = `SELECT 
+		id, wallet_id, customer_xid, initiated_by, transaction_type, amount, currency, reference_id, status, created_at, updated_at 
+		FROM transactions WHERE id = ?`
+
+	selectWalletColumns = `SELECT 	
+		id, customer_xid, currency, status, enabled_at, balance, credit_limit, credit_used, interest_rate, created_at, updated_at FROM wallets`
+
+	getWalletQuery = selectWalletColumns + ` WHERE customer_xid = ? AND currency = ?`
+
+	getWalletsQuery = selectWalletColumns + ` WHERE customer_xid = ? order by created_at`
 
 	updateWalletStatusQuery = `UPDATE wallets
 		SET
@@ -37,6 +71,6 @@ const (
 			customer_xid = ?`
 
 	insertWalletQuery = `INSERT INTO wallets
-		(id, customer_xid)
-		VALUES(?, ?)`
+		(id, customer_xid, currency, status, enabled_at)
+		VALUES(?, ?, ?, ?, ?)`
 )
@@ -10,10 +10,19 @@ import (
 type WalletRepository interface {
 	CreateWallet(ctx context.Context, wallet domain.Wallet) error
 	GetWallet(ctx context.Context, customerXID string) (domain.Wallet, error)
+	GetWalletByCurrency(ctx context.Context, customerXID, currency string) (domain.Wallet, error)
+	GetWallets(ctx context.Context, customerXID string) ([]domain.Wallet, error)
 	UpdateWalletStatus(ctx context.Context, customerXID string, status string, enabledAt *time.Time) error
-	UpdateWalletBalance(ctx context.Context, walletID string, initialAmount, finalAmount int) (bool, error)
+	UpdateWalletBalance(ctx context.Context, walletID string, initialAmount, finalAmount domain.Money) (bool, error)
 
 	GetWalletTransactions(ctx context.Context, walletID string) ([]domain.Transaction, error)
 	UpdateTransactionStatus(ctx context.Context, transactionID, status string) error
+	GetTransaction(ctx context.Context, transactionID string) (domain.Transaction, error)
+	GetTransactionByReference(ctx context.Context, transactionType, referenceID string) (domain.Transaction, error)
 	AddTransaction(ctx context.Context, transaction domain.Transaction) error
+	// TransferBalance posts both legs of a transfer in a single database
+	// transaction. It returns false when the sender cannot cover the amount
+	// and domain.ErrSplitBillClosed or domain.ErrTransactionReversed when
+	// guard no longer holds.
+	TransferBalance(ctx context.Context, debit, credit domain.Transaction, guard domain.TransferGuard) (bool, error)
 }
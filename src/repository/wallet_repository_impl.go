@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
 	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
 )
 
@@ -24,7 +25,13 @@ func (repo *WalletRepositoryImpl) CreateWallet(ctx context.Context, wallet domai
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, insertWalletQuery, wallet.ID, wallet.CustomerXID)
+	_, err = tx.ExecContext(ctx, insertWalletQuery,
+		wallet.ID,
+		wallet.CustomerXID,
+		wallet.Currency,
+		wallet.Status,
+		wallet.EnabledAt,
+	)
 	if err != nil {
 		return err
 	}
@@ -61,19 +68,35 @@ func (repo *WalletRepositoryImpl) UpdateWalletStatus(ctx context.Context, custom
 }
 
 func (repo *WalletRepositoryImpl) GetWallet(ctx context.Context, customerXID string) (domain.Wallet, error) {
+	return repo.GetWalletByCurrency(ctx, customerXID, constants.DEFAULT_CURRENCY)
+}
+
+func (repo *WalletRepositoryImpl) GetWalletByCurrency(ctx context.Context, customerXID, currency string) (domain.Wallet, error) {
 	var result domain.Wallet
-	err := repo.db.QueryRowContext(ctx, getWalletQuery, customerXID).Scan(
-		&result.ID,
-		&result.CustomerXID,
-		&result.Status,
-		&result.EnabledAt,
-		&result.Balance,
-		&result.CreatedAt,
-		&result.UpdatedAt,
-	)
+	err := scanWallet(repo.db.QueryRowContext(ctx, getWalletQuery, customerXID, currency), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *WalletRepositoryImpl) GetWallets(ctx context.Context, customerXID string) ([]domain.Wallet, error) {
+	var result []domain.Wallet
+	rows, err := repo.db.QueryContext(ctx, getWalletsQuery, customerXID)
 	if err != nil {
 		return result, err
 	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		data := domain.Wallet{}
+		err := scanWallet(rows, &data)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, data)
+	}
 	return result, nil
 }
 
@@ -92,8 +115,10 @@ func (repo *WalletRepositoryImpl) GetWalletTransactions(ctx context.Context, wal
 			&data.ID,
 			&data.WalletID,
 			&data.CustomerXID,
+			&data.InitiatedBy,
 			&data.TransactionType,
 			&data.Amount,
+			&data.Amount.Currency,
 			&data.ReferenceID,
 			&data.Status,
 			&data.CreatedAt,
@@ -107,6 +132,52 @@ func (repo *WalletRepositoryImpl) GetWalletTransactions(ctx context.Context, wal
 	return result, nil
 }
 
+func (repo *WalletRepositoryImpl) GetTransaction(ctx context.Context, transactionID string) (domain.Transaction, error) {
+	return getTransaction(ctx, repo.db, transactionID)
+}
+
+func getTransaction(ctx context.Context, db queryer, transactionID string) (domain.Transaction, error) {
+	var result domain.Transaction
+	err := db.QueryRowContext(ctx, getTransactionQuery, transactionID).Scan(
+		&result.ID,
+		&result.WalletID,
+		&result.CustomerXID,
+		&result.InitiatedBy,
+		&result.TransactionType,
+		&result.Amount,
+		&result.Amount.Currency,
+		&result.ReferenceID,
+		&result.Status,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (repo *WalletRepositoryImpl) GetTransactionByReference(ctx context.Context, transactionType, referenceID string) (domain.Transaction, error) {
+	var result domain.Transaction
+	err := repo.db.QueryRowContext(ctx, getTransactionByReferenceQuery, transactionType, referenceID).Scan(
+		&result.ID,
+		&result.WalletID,
+		&result.CustomerXID,
+		&result.InitiatedBy,
+		&result.TransactionType,
+		&result.Amount,
+		&result.Amount.Currency,
+		&result.ReferenceID,
+		&result.Status,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
 func (repo *WalletRepositoryImpl) AddTransaction(ctx context.Context, transaction domain.Transaction) error {
 	tx, err := repo.db.Begin()
 	if err != nil {
@@ -117,8 +188,11 @@ func (repo *WalletRepositoryImpl) AddTransaction(ctx context.Context, transactio
 		transaction.ID,
 		transaction.WalletID,
 		transaction.CustomerXID,
+		transaction.InitiatedBy,
+		transaction.PocketID,
 		transaction.TransactionType,
 		transaction.Amount,
+		transaction.Amount.Currency,
 		transaction.ReferenceID,
 		transaction.Status,
 		transaction.CreatedAt,
@@ -157,13 +231,13 @@ func (repo *WalletRepositoryImpl) UpdateTransactionStatus(ctx context.Context, t
 	return nil
 }
 
-func (repo *WalletRepositoryImpl) UpdateWalletBalance(ctx context.Context, walletID string, initialAmount, finalAmount int) (bool, error) {
+func (repo *WalletRepositoryImpl) UpdateWalletBalance(ctx context.Context, walletID string, initialAmount, finalAmount domain.Money) (bool, error) {
 	tx, err := repo.db.Begin()
 	if err != nil {
 		return false, err
 	}
 
-	sss, err := tx.ExecContext(ctx, updateWalletBalanceQuery, finalAmount, walletID, initialAmount)
+	sss, err := tx.ExecContext(ctx, updateWalletBalanceQuery, finalAmount, walletID, initialAmount, finalAmount)
 	if err != nil {
 		return false, err
 	}
@@ -176,3 +250,115 @@ func (repo *WalletRepositoryImpl) UpdateWalletBalance(ctx context.Context, walle
 
 	return rowsAffected > 0, nil
 }
+
+func (repo *WalletRepositoryImpl) TransferBalance(ctx context.Context, debit, credit domain.Transaction, guard domain.TransferGuard) (bool, error) {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if guard.SplitBillID != "" {
+		var status string
+		err = tx.QueryRowContext(ctx, lockSplitBillStatusQuery, guard.SplitBillID).Scan(&status)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if status != constants.STATUS_PENDING {
+			_ = tx.Rollback()
+			return false, domain.ErrSplitBillClosed
+		}
+	}
+
+	if guard.RefundedTransactionID != "" {
+		isReversed, err := isDisputeReversed(ctx, tx, guard.RefundedTransactionID)
+		if err != nil {
+			_ = tx.Rollback()
+			return false, err
+		}
+		if isReversed {
+			_ = tx.Rollback()
+			return false, domain.ErrTransactionReversed
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, debitWalletBalanceQuery, debit.Amount, debit.WalletID, debit.Amount)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected == 0 {
+		_ = tx.Rollback()
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(ctx, creditWalletBalanceQuery, credit.Amount, credit.WalletID)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = insertTransaction(ctx, tx, debit)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return commitWithTransaction(ctx, tx, credit)
+}
+
+func scanWallet(row rowScanner, wallet *domain.Wallet) error {
+	err := row.Scan(
+		&wallet.ID,
+		&wallet.CustomerXID,
+		&wallet.Currency,
+		&wallet.Status,
+		&wallet.EnabledAt,
+		&wallet.Balance,
+		&wallet.CreditLimit,
+		&wallet.CreditUsed,
+		&wallet.InterestRate,
+		&wallet.CreatedAt,
+		&wallet.UpdatedAt,
+	)
+	wallet.Balance.Currency = wallet.Currency
+	wallet.CreditLimit.Currency = wallet.Currency
+	wallet.CreditUsed.Currency = wallet.Currency
+	return err
+}
+
+// insertTransaction writes a ledger row as part of a larger database
+// transaction owned by the caller.
+func insertTransaction(ctx context.Context, tx *sql.Tx, transaction domain.Transaction) error {
+	_, err := tx.ExecContext(ctx, insertTransactionQuery,
+		transaction.ID,
+		transaction.WalletID,
+		transaction.CustomerXID,
+		transaction.InitiatedBy,
+		transaction.TransactionType,
+		transaction.Amount,
+		transaction.Amount.Currency,
+		transaction.ReferenceID,
+		transaction.Status,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	)
+	return err
+}
+
+// commitWithTransaction records the ledger row and commits tx, rolling back
+// if either step fails.
+func commitWithTransaction(ctx context.Context, tx *sql.Tx, transaction domain.Transaction) (bool, error) {
+	err := insertTransaction(ctx, tx, transaction)
+	if err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
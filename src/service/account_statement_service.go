@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type AccountStatementServiceItf interface {
+	// GetAccountStatement lists the successful transactions of the period
+	// with the running balance, between the opening and closing balance.
+	GetAccountStatement(ctx context.Context, customerXID string, request web.AccountStatementRequest) (web.AccountStatementResponse, error)
+	// ExportAccountStatement renders the statement as CSV or PDF.
+	ExportAccountStatement(ctx context.Context, customerXID string, request web.AccountStatementRequest) (web.AccountStatementFile, error)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/pdf"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type AccountStatementService struct {
+	WalletRepository repository.WalletRepository
+	Validate         *validator.Validate
+	// Location is the timezone statement dates are printed in
+	Location *time.Location
+}
+
+func NewAccountStatementService(walletRepository repository.WalletRepository, validate *validator.Validate, location *time.Location) AccountStatementServiceItf {
+	return &AccountStatementService{
+		WalletRepository: walletRepository,
+		Validate:         validate,
+		Location:         location,
+	}
+}
+
+func (svc *AccountStatementService) GetAccountStatement(ctx context.Context, customerXID string, request web.AccountStatementRequest) (web.AccountStatementResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.AccountStatementResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+
+	// a disabled wallet keeps its history, so it still gets statements
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.AccountStatementResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.AccountStatementResponse{}, err
+	}
+
+	transactions, err := svc.WalletRepository.GetWalletTransactions(ctx, wallet.ID)
+	if err != nil {
+		return web.AccountStatementResponse{}, err
+	}
+
+	zero := domain.NewMoney(0, wallet.Currency)
+	result := web.AccountStatementResponse{
+		WalletID:       wallet.ID,
+		CustomerXID:    wallet.CustomerXID,
+		Currency:       wallet.Currency,
+		From:           request.From.In(svc.Location),
+		To:             request.To.In(svc.Location),
+		OpeningBalance: zero,
+		TotalCredits:   zero,
+		TotalDebits:    zero,
+		Entries:        []web.AccountStatementEntryResponse{},
+		GeneratedAt:    time.Now().In(svc.Location),
+	}
+
+	// only successful transactions moved the balance
+	balance := zero
+	for i := range transactions {
+		transaction := transactions[i]
+		if transaction.Status != constants.STATUS_SUCCESS || !transaction.CreatedAt.Before(request.To) {
+			continue
+		}
+
+		direction := constants.DIRECTION_DEBIT
+		if constants.CreditTransactionTypes[transaction.TransactionType] {
+			direction = constants.DIRECTION_CREDIT
+			balance, err = balance.Add(transaction.Amount)
+		} else {
+			balance, err = balance.Sub(transaction.Amount)
+		}
+		if err != nil {
+			return web.AccountStatementResponse{}, err
+		}
+
+		if transaction.CreatedAt.Before(request.From) {
+			result.OpeningBalance = balance
+			continue
+		}
+
+		if direction == constants.DIRECTION_CREDIT {
+			result.TotalCredits, err = result.TotalCredits.Add(transaction.Amount)
+		} else {
+			result.TotalDebits, err = result.TotalDebits.Add(transaction.Amount)
+		}
+		if err != nil {
+			return web.AccountStatementResponse{}, err
+		}
+
+		result.Entries = append(result.Entries, web.AccountStatementEntryResponse{
+			ID:           transaction.ID,
+			TransactedAt: transaction.CreatedAt.In(svc.Location),
+			Type:         transaction.TransactionType,
+			ReferenceID:  transaction.ReferenceID,
+			Direction:    direction,
+			Amount:       transaction.Amount,
+			Balance:      balance,
+		})
+	}
+	result.ClosingBalance = balance
+
+	return result, nil
+}
+
+func (svc *AccountStatementService) ExportAccountStatement(ctx context.Context, customerXID string, request web.AccountStatementRequest) (web.AccountStatementFile, error) {
+	statement, err := svc.GetAccountStatement(ctx, customerXID, request)
+	if err != nil {
+		return web.AccountStatementFile{}, err
+	}
+
+	fileName := fmt.Sprintf("statement-%s-%s-%s", statement.Currency, statement.From.Format("20060102"), statement.To.Format("20060102"))
+	switch request.Format {
+	case constants.STATEMENT_FORMAT_CSV:
+		data, err := accountStatementCSV(statement)
+		if err != nil {
+			return web.AccountStatementFile{}, err
+		}
+		return web.AccountStatementFile{ContentType: "text/csv", FileName: fileName + ".csv", Data: data}, nil
+	case constants.STATEMENT_FORMAT_PDF:
+		return web.AccountStatementFile{ContentType: "application/pdf", FileName: fileName + ".pdf", Data: accountStatementPDF(statement)}, nil
+	}
+	return web.AccountStatementFile{}, errors.New("unsupported statement format")
+}
+
+// accountStatementCSV writes one row per transaction, framed by rows for the
+// opening and closing balance. Amounts carry the decimals of the currency.
+func accountStatementCSV(statement web.AccountStatementResponse) ([]byte, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+
+	rows := [][]string{
+		{"date", "transaction_id", "type", "reference_id", "debit", "credit", "balance"},
+		{statement.From.Format(time.RFC3339), "", "opening_balance", "", "", "", statement.OpeningBalance.Decimal()},
+	}
+	for _, entry := range statement.Entries {
+		debit, credit := entry.Amount.Decimal(), ""
+		if entry.Direction == constants.DIRECTION_CREDIT {
+			debit, credit = "", entry.Amount.Decimal()
+		}
+		rows = append(rows, []string{entry.TransactedAt.Format(time.RFC3339), entry.ID, entry.Type, entry.ReferenceID, debit, credit, entry.Balance.Decimal()})
+	}
+	rows = append(rows, []string{statement.To.Format(time.RFC3339), "", "closing_balance", "", statement.TotalDebits.Decimal(), statement.TotalCredits.Decimal(), statement.ClosingBalance.Decimal()})
+
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func accountStatementPDF(statement web.AccountStatementResponse) []byte {
+	const (
+		dateLayout = "2006-01-02 15:04"
+		rowFormat  = "%-16s %-19s %-18s %12s %12s %13s"
+	)
+
+	document := pdf.New("Statement " + statement.Currency + " " + statement.From.Format("2006-01-02"))
+	document.Line("ACCOUNT STATEMENT")
+	document.Line("")
+	document.Line("Customer    : " + statement.CustomerXID)
+	document.Line("Wallet      : " + statement.WalletID + " (" + statement.Currency + ")")
+	document.Line("Period      : " + statement.From.Format(dateLayout) + " to " + statement.To.Format(dateLayout) + " " + statement.From.Format("MST"))
+	document.Line("Generated   : " + statement.GeneratedAt.Format(dateLayout))
+	document.Line("")
+	document.Line(fmt.Sprintf(rowFormat, "Date", "Type", "Reference", "Debit", "Credit", "Balance"))
+	document.Line(strings.Repeat("-", pdf.LineWidth))
+	document.Line(fmt.Sprintf(rowFormat, statement.From.Format(dateLayout), "Opening balance", "", "", "", statement.OpeningBalance.Decimal()))
+	for _, entry := range statement.Entries {
+		debit, credit := entry.Amount.Decimal(), ""
+		if entry.Direction == constants.DIRECTION_CREDIT {
+			debit, credit = "", entry.Amount.Decimal()
+		}
+		document.Line(fmt.Sprintf(rowFormat, entry.TransactedAt.Format(dateLayout), truncate(entry.Type, 19), truncate(entry.ReferenceID, 18), debit, credit, entry.Balance.Decimal()))
+	}
+	document.Line(strings.Repeat("-", pdf.LineWidth))
+	document.Line(fmt.Sprintf(rowFormat, statement.To.Format(dateLayout), "Closing balance", "", statement.TotalDebits.Decimal(), statement.TotalCredits.Decimal(), statement.ClosingBalance.Decimal()))
+	return document.Bytes()
+}
@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	accountStatementSvc service.AccountStatementServiceItf
+
+	mockAccountStatementWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideAccountStatementTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountStatementWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	accountStatementSvc = service.NewAccountStatementService(mockAccountStatementWalletRepository, validator, time.UTC)
+
+	return func() {}
+}
+
+var (
+	statementFrom = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	statementTo   = time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func mockStatementTransactions() {
+	mockAccountStatementWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+		ID:          "mock-wallet",
+		CustomerXID: "1",
+		Currency:    "IDR",
+	}, nil)
+	mockAccountStatementWalletRepository.EXPECT().GetWalletTransactions(gomock.Any(), "mock-wallet").Return([]domain.Transaction{
+		{ID: "t1", TransactionType: "deposit", Amount: domain.NewMoney(1000, "IDR"), Status: "success", CreatedAt: statementFrom.Add(-time.Hour)},
+		{ID: "t2", TransactionType: "withdrawal", Amount: domain.NewMoney(300, "IDR"), ReferenceID: "ref-2", Status: "success", CreatedAt: statementFrom},
+		{ID: "t3", TransactionType: "deposit", Amount: domain.NewMoney(200, "IDR"), Status: "pending", CreatedAt: statementFrom.Add(time.Hour)},
+		{ID: "t4", TransactionType: "transfer_in", Amount: domain.NewMoney(500, "IDR"), ReferenceID: "ref-4", Status: "success", CreatedAt: statementFrom.Add(2 * time.Hour)},
+		{ID: "t5", TransactionType: "deposit", Amount: domain.NewMoney(700, "IDR"), Status: "success", CreatedAt: statementTo},
+	}, nil)
+}
+
+func TestGetAccountStatement(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.AccountStatementRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.AccountStatementResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - running balance of the period",
+			payload: web.AccountStatementRequest{
+				From: statementFrom,
+				To:   statementTo,
+			},
+			mockFunc: mockStatementTransactions,
+			wantErr:  nil,
+			wantResult: web.AccountStatementResponse{
+				WalletID:       "mock-wallet",
+				OpeningBalance: domain.NewMoney(1000, "IDR"),
+				TotalCredits:   domain.NewMoney(500, "IDR"),
+				TotalDebits:    domain.NewMoney(300, "IDR"),
+				ClosingBalance: domain.NewMoney(1200, "IDR"),
+				Entries: []web.AccountStatementEntryResponse{
+					{ID: "t2", TransactedAt: statementFrom, Type: "withdrawal", ReferenceID: "ref-2", Direction: "debit", Amount: domain.NewMoney(300, "IDR"), Balance: domain.NewMoney(700, "IDR")},
+					{ID: "t4", TransactedAt: statementFrom.Add(2 * time.Hour), Type: "transfer_in", ReferenceID: "ref-4", Direction: "credit", Amount: domain.NewMoney(500, "IDR"), Balance: domain.NewMoney(1200, "IDR")},
+				},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - wallet not found",
+			payload: web.AccountStatementRequest{
+				Currency: "USD",
+				From:     statementFrom,
+				To:       statementTo,
+			},
+			mockFunc: func() {
+				mockAccountStatementWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{}, sql.ErrNoRows)
+			},
+			wantErr:    fmt.Errorf("wallet not found"),
+			wantResult: web.AccountStatementResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - period ends before it starts",
+			payload: web.AccountStatementRequest{
+				From: statementTo,
+				To:   statementFrom,
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("Key: 'AccountStatementRequest.To' Error:Field validation for 'To' failed on the 'gtfield' tag"),
+			wantResult: web.AccountStatementResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideAccountStatementTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := accountStatementSvc.GetAccountStatement(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.WalletID, tc.wantResult.WalletID)
+			assert.Equal(t, got.OpeningBalance, tc.wantResult.OpeningBalance)
+			assert.Equal(t, got.TotalCredits, tc.wantResult.TotalCredits)
+			assert.Equal(t, got.TotalDebits, tc.wantResult.TotalDebits)
+			assert.Equal(t, got.ClosingBalance, tc.wantResult.ClosingBalance)
+			assert.Equal(t, got.Entries, tc.wantResult.Entries)
+		})
+	}
+}
+
+func TestExportAccountStatement(t *testing.T) {
+	testDep := provideAccountStatementTest(t)
+	defer testDep()
+
+	mockStatementTransactions()
+	got, err := accountStatementSvc.ExportAccountStatement(context.Background(), "1", web.AccountStatementRequest{
+		From:   statementFrom,
+		To:     statementTo,
+		Format: "csv",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got.ContentType, "text/csv")
+	assert.Equal(t, got.FileName, "statement-IDR-20230101-20230201.csv")
+	assert.Equal(t, string(got.Data), "date,transaction_id,type,reference_id,debit,credit,balance\n"+
+		"2023-01-01T00:00:00Z,,opening_balance,,,,1000\n"+
+		"2023-01-01T00:00:00Z,t2,withdrawal,ref-2,300,,700\n"+
+		"2023-01-01T02:00:00Z,t4,transfer_in,ref-4,,500,1200\n"+
+		"2023-02-01T00:00:00Z,,closing_balance,,300,500,1200\n")
+
+	mockStatementTransactions()
+	got, err = accountStatementSvc.ExportAccountStatement(context.Background(), "1", web.AccountStatementRequest{
+		From:   statementFrom,
+		To:     statementTo,
+		Format: "pdf",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got.ContentType, "application/pdf")
+	assert.True(t, bytes.HasPrefix(got.Data, []byte("%PDF-1.4")))
+	assert.True(t, bytes.Contains(got.Data, []byte("Closing balance")))
+}
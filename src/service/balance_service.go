@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type BalanceServiceItf interface {
+	// CheckBalances recomputes the balance of every wallet from its
+	// successful transactions and calls report for each wallet whose stored
+	// balance differs, opening an incident for it when openIncidents is set.
+	CheckBalances(ctx context.Context, openIncidents bool, report func(web.BalanceDriftResponse)) (web.BalanceCheckResponse, error)
+	RunBalanceCheck(ctx context.Context, now time.Time) error
+	GetBalanceIncidents(ctx context.Context, status string) ([]web.BalanceIncidentResponse, error)
+	ResolveBalanceIncident(ctx context.Context, incidentID string) (web.BalanceIncidentResponse, error)
+}
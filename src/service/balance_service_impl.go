@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// balanceCheckBatchSize is how many wallets a single query recomputes, a
+// check never holds more than one page in memory.
+const balanceCheckBatchSize = 500
+
+type BalanceService struct {
+	BalanceRepository repository.BalanceRepository
+	// OpenIncidents makes the scheduled check record the drifts it finds
+	OpenIncidents bool
+}
+
+func NewBalanceService(balanceRepository repository.BalanceRepository, openIncidents bool) BalanceServiceItf {
+	return &BalanceService{
+		BalanceRepository: balanceRepository,
+		OpenIncidents:     openIncidents,
+	}
+}
+
+func (svc *BalanceService) CheckBalances(ctx context.Context, openIncidents bool, report func(web.BalanceDriftResponse)) (web.BalanceCheckResponse, error) {
+	result := web.BalanceCheckResponse{
+		StartedAt: time.Now(),
+	}
+
+	afterID := ""
+	for {
+		checks, err := svc.BalanceRepository.GetBalanceChecks(ctx, afterID, balanceCheckBatchSize)
+		if err != nil {
+			return result, err
+		}
+
+		for i := range checks {
+			result.WalletCount++
+			if checks[i].Balance == checks[i].ComputedBalance {
+				continue
+			}
+
+			drift, isDrifted, err := svc.confirmDrift(ctx, checks[i], openIncidents)
+			if err != nil {
+				return result, err
+			}
+			if isDrifted {
+				result.DriftCount++
+				report(drift)
+			}
+		}
+
+		if len(checks) < balanceCheckBatchSize {
+			break
+		}
+		afterID = checks[len(checks)-1].WalletID
+	}
+
+	result.FinishedAt = time.Now()
+	return result, nil
+}
+
+func (svc *BalanceService) RunBalanceCheck(ctx context.Context, now time.Time) error {
+	result, err := svc.CheckBalances(ctx, svc.OpenIncidents, func(drift web.BalanceDriftResponse) {
+		log.Println("error balance drift", drift.WalletID+":", drift.Balance.String(), "stored,", drift.ComputedBalance.String(), "computed")
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.DriftCount > 0 {
+		log.Println("error balance check:", result.DriftCount, "of", result.WalletCount, "wallets drifted")
+	}
+	return nil
+}
+
+func (svc *BalanceService) GetBalanceIncidents(ctx context.Context, status string) ([]web.BalanceIncidentResponse, error) {
+	if status == "" {
+		status = constants.STATUS_OPEN
+	}
+
+	incidents, err := svc.BalanceRepository.GetBalanceIncidents(ctx, status)
+	if err != nil {
+		return []web.BalanceIncidentResponse{}, err
+	}
+
+	result := []web.BalanceIncidentResponse{}
+	for i := range incidents {
+		result = append(result, toBalanceIncidentResponse(incidents[i]))
+	}
+	return result, nil
+}
+
+func (svc *BalanceService) ResolveBalanceIncident(ctx context.Context, incidentID string) (web.BalanceIncidentResponse, error) {
+	incident, err := svc.BalanceRepository.GetBalanceIncident(ctx, incidentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.BalanceIncidentResponse{}, errors.New("incident not found")
+	}
+	if err != nil {
+		return web.BalanceIncidentResponse{}, err
+	}
+
+	now := time.Now()
+	isResolved, err := svc.BalanceRepository.ResolveBalanceIncident(ctx, incident.ID, now)
+	if err != nil {
+		return web.BalanceIncidentResponse{}, err
+	}
+	if !isResolved {
+		return web.BalanceIncidentResponse{}, errors.New("incident already resolved")
+	}
+
+	incident.Status = constants.STATUS_RESOLVED
+	incident.ResolvedAt = &now
+	return toBalanceIncidentResponse(incident), nil
+}
+
+// confirmDrift checks a drifted wallet once more before reporting it. A
+// deposit moves the balance before it is marked successful, so a wallet
+// caught in between looks drifted for a moment.
+func (svc *BalanceService) confirmDrift(ctx context.Context, check domain.BalanceCheck, openIncidents bool) (web.BalanceDriftResponse, bool, error) {
+	check, err := svc.BalanceRepository.GetBalanceCheck(ctx, check.WalletID)
+	if err != nil {
+		return web.BalanceDriftResponse{}, false, err
+	}
+	if check.Balance == check.ComputedBalance {
+		return web.BalanceDriftResponse{}, false, nil
+	}
+
+	drift, err := check.Drift()
+	if err != nil {
+		return web.BalanceDriftResponse{}, false, err
+	}
+
+	result := web.BalanceDriftResponse{
+		WalletID:          check.WalletID,
+		CustomerXID:       check.CustomerXID,
+		Currency:          check.Balance.Currency,
+		Balance:           check.Balance,
+		ComputedBalance:   check.ComputedBalance,
+		Drift:             drift,
+		TransactionCount:  check.TransactionCount,
+		LastTransactionAt: check.LastTransactionAt,
+	}
+	if !openIncidents {
+		return result, true, nil
+	}
+
+	incident := domain.BalanceIncident{
+		ID:               uuid.New().String(),
+		WalletID:         check.WalletID,
+		CustomerXID:      check.CustomerXID,
+		Balance:          check.Balance,
+		ComputedBalance:  check.ComputedBalance,
+		Drift:            drift,
+		TransactionCount: check.TransactionCount,
+		Status:           constants.STATUS_OPEN,
+		DetectedAt:       time.Now(),
+	}
+	isCreated, err := svc.BalanceRepository.CreateBalanceIncident(ctx, incident)
+	if err != nil {
+		return web.BalanceDriftResponse{}, false, err
+	}
+	if isCreated {
+		result.IncidentID = incident.ID
+	}
+	return result, true, nil
+}
+
+func toBalanceIncidentResponse(incident domain.BalanceIncident) web.BalanceIncidentResponse {
+	return web.BalanceIncidentResponse{
+		ID:               incident.ID,
+		WalletID:         incident.WalletID,
+		CustomerXID:      incident.CustomerXID,
+		Currency:         incident.Balance.Currency,
+		Balance:          incident.Balance,
+		ComputedBalance:  incident.ComputedBalance,
+		Drift:            incident.Drift,
+		TransactionCount: incident.TransactionCount,
+		Status:           incident.Status,
+		DetectedAt:       incident.DetectedAt,
+		ResolvedAt:       incident.ResolvedAt,
+	}
+}
@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	balanceSvc service.BalanceServiceItf
+
+	mockBalanceRepository *mock_repository.MockBalanceRepository
+)
+
+func provideBalanceTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBalanceRepository = mock_repository.NewMockBalanceRepository(ctrl)
+	balanceSvc = service.NewBalanceService(mockBalanceRepository, false)
+
+	return func() {}
+}
+
+// balanceCheckPage returns size wallets whose balances add up.
+func balanceCheckPage(prefix string, size int) []domain.BalanceCheck {
+	checks := make([]domain.BalanceCheck, size)
+	for i := range checks {
+		checks[i] = domain.BalanceCheck{
+			WalletID:        fmt.Sprintf("%s-%04d", prefix, i),
+			Balance:         domain.NewMoney(1000, "IDR"),
+			ComputedBalance: domain.NewMoney(1000, "IDR"),
+		}
+	}
+	return checks
+}
+
+func TestCheckBalances(t *testing.T) {
+	drifted := domain.BalanceCheck{
+		WalletID:         "a-0007",
+		CustomerXID:      "1",
+		Balance:          domain.NewMoney(1500, "IDR"),
+		ComputedBalance:  domain.NewMoney(1000, "IDR"),
+		TransactionCount: 3,
+	}
+
+	testCases := []struct {
+		testID        int
+		testDesc      string
+		openIncidents bool
+		mockFunc      func()
+		wantErr       error
+		wantResult    web.BalanceCheckResponse
+		wantDrifts    []web.BalanceDriftResponse
+	}{
+		{
+			testID:        1,
+			testDesc:      "Success - drift confirmed and recorded, transient drift ignored",
+			openIncidents: true,
+			mockFunc: func() {
+				first := balanceCheckPage("a", 500)
+				first[7] = drifted
+				mockBalanceRepository.EXPECT().GetBalanceChecks(gomock.Any(), "", 500).Return(first, nil)
+				mockBalanceRepository.EXPECT().GetBalanceCheck(gomock.Any(), "a-0007").Return(drifted, nil)
+				mockBalanceRepository.EXPECT().CreateBalanceIncident(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, incident domain.BalanceIncident) (bool, error) {
+						assert.Equal(t, incident.WalletID, "a-0007")
+						assert.Equal(t, incident.Drift, domain.NewMoney(500, "IDR"))
+						assert.Equal(t, incident.Status, "open")
+						return true, nil
+					})
+
+				second := balanceCheckPage("b", 1)
+				second[0].Balance = domain.NewMoney(2000, "IDR")
+				mockBalanceRepository.EXPECT().GetBalanceChecks(gomock.Any(), "a-0499", 500).Return(second, nil)
+				// the pending deposit was marked successful in the meantime
+				mockBalanceRepository.EXPECT().GetBalanceCheck(gomock.Any(), "b-0000").Return(domain.BalanceCheck{
+					WalletID:        "b-0000",
+					Balance:         domain.NewMoney(2000, "IDR"),
+					ComputedBalance: domain.NewMoney(2000, "IDR"),
+				}, nil)
+			},
+			wantErr: nil,
+			wantResult: web.BalanceCheckResponse{
+				WalletCount: 501,
+				DriftCount:  1,
+			},
+			wantDrifts: []web.BalanceDriftResponse{
+				{
+					WalletID:         "a-0007",
+					CustomerXID:      "1",
+					Currency:         "IDR",
+					Balance:          domain.NewMoney(1500, "IDR"),
+					ComputedBalance:  domain.NewMoney(1000, "IDR"),
+					Drift:            domain.NewMoney(500, "IDR"),
+					TransactionCount: 3,
+				},
+			},
+		},
+		{
+			testID:        2,
+			testDesc:      "Success - drift reported without an incident",
+			openIncidents: false,
+			mockFunc: func() {
+				mockBalanceRepository.EXPECT().GetBalanceChecks(gomock.Any(), "", 500).Return([]domain.BalanceCheck{drifted}, nil)
+				mockBalanceRepository.EXPECT().GetBalanceCheck(gomock.Any(), "a-0007").Return(drifted, nil)
+			},
+			wantErr: nil,
+			wantResult: web.BalanceCheckResponse{
+				WalletCount: 1,
+				DriftCount:  1,
+			},
+			wantDrifts: []web.BalanceDriftResponse{
+				{
+					WalletID:         "a-0007",
+					CustomerXID:      "1",
+					Currency:         "IDR",
+					Balance:          domain.NewMoney(1500, "IDR"),
+					ComputedBalance:  domain.NewMoney(1000, "IDR"),
+					Drift:            domain.NewMoney(500, "IDR"),
+					TransactionCount: 3,
+				},
+			},
+		},
+		{
+			testID:        3,
+			testDesc:      "Failed - recompute fails",
+			openIncidents: false,
+			mockFunc: func() {
+				mockBalanceRepository.EXPECT().GetBalanceChecks(gomock.Any(), "", 500).Return(nil, fmt.Errorf("connection lost"))
+			},
+			wantErr:    fmt.Errorf("connection lost"),
+			wantResult: web.BalanceCheckResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideBalanceTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			var drifts []web.BalanceDriftResponse
+			got, err := balanceSvc.CheckBalances(context.Background(), tc.openIncidents, func(drift web.BalanceDriftResponse) {
+				drifts = append(drifts, drift)
+			})
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, got.WalletCount, tc.wantResult.WalletCount)
+			assert.Equal(t, got.DriftCount, tc.wantResult.DriftCount)
+			assert.Equal(t, len(drifts), len(tc.wantDrifts))
+			for i := range drifts {
+				assert.Equal(t, drifts[i].IncidentID != "", tc.openIncidents)
+				drifts[i].IncidentID = ""
+				assert.Equal(t, drifts[i], tc.wantDrifts[i])
+			}
+		})
+	}
+}
+
+func TestResolveBalanceIncident(t *testing.T) {
+	testDep := provideBalanceTest(t)
+	defer testDep()
+
+	mockBalanceRepository.EXPECT().GetBalanceIncident(gomock.Any(), "mock-incident").Return(domain.BalanceIncident{ID: "mock-incident", Status: "open"}, nil)
+	mockBalanceRepository.EXPECT().ResolveBalanceIncident(gomock.Any(), "mock-incident", gomock.Any()).Return(true, nil)
+
+	got, err := balanceSvc.ResolveBalanceIncident(context.Background(), "mock-incident")
+	assert.Nil(t, err)
+	assert.Equal(t, got.Status, "resolved")
+	assert.WithinDuration(t, time.Now(), *got.ResolvedAt, time.Minute)
+
+	mockBalanceRepository.EXPECT().GetBalanceIncident(gomock.Any(), "mock-incident").Return(domain.BalanceIncident{ID: "mock-incident", Status: "resolved"}, nil)
+	mockBalanceRepository.EXPECT().ResolveBalanceIncident(gomock.Any(), "mock-incident", gomock.Any()).Return(false, nil)
+
+	_, err = balanceSvc.ResolveBalanceIncident(context.Background(), "mock-incident")
+	assert.Equal(t, err.Error(), "incident already resolved")
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type BeneficiaryServiceItf interface {
+	// CreateBeneficiary saves a bank account once a name inquiry confirmed
+	// its holder.
+	CreateBeneficiary(ctx context.Context, customerXID string, request web.BeneficiaryRequest) (web.BeneficiaryResponse, error)
+	GetBeneficiaries(ctx context.Context, customerXID string) ([]web.BeneficiaryResponse, error)
+	GetBeneficiary(ctx context.Context, customerXID, beneficiaryID string) (web.BeneficiaryResponse, error)
+	UpdateBeneficiary(ctx context.Context, customerXID, beneficiaryID string, request web.BeneficiaryRequest) (web.BeneficiaryResponse, error)
+	DeleteBeneficiary(ctx context.Context, customerXID, beneficiaryID string) (web.BeneficiaryResponse, error)
+	// VerifyBeneficiary runs the name inquiry for a beneficiary saved by a
+	// withdrawal, which is not verified until then.
+	VerifyBeneficiary(ctx context.Context, customerXID, beneficiaryID string) (web.BeneficiaryResponse, error)
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type BeneficiaryService struct {
+	PayoutRepository repository.PayoutRepository
+	NameResolver     bank.NameResolver
+	Validate         *validator.Validate
+}
+
+func NewBeneficiaryService(payoutRepository repository.PayoutRepository, nameResolver bank.NameResolver, validate *validator.Validate) BeneficiaryServiceItf {
+	return &BeneficiaryService{
+		PayoutRepository: payoutRepository,
+		NameResolver:     nameResolver,
+		Validate:         validate,
+	}
+}
+
+func (svc *BeneficiaryService) CreateBeneficiary(ctx context.Context, customerXID string, request web.BeneficiaryRequest) (web.BeneficiaryResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	err = svc.checkAccountAvailable(ctx, customerXID, "", request)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	bankAccount, err := svc.inquire(ctx, domain.BankAccount{
+		BankCode:      request.BankCode,
+		AccountNumber: request.AccountNumber,
+		AccountName:   request.AccountName,
+	})
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	now := time.Now()
+	beneficiary := domain.Beneficiary{
+		ID:          uuid.New().String(),
+		CustomerXID: customerXID,
+		BankAccount: bankAccount,
+		VerifiedAt:  &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err = svc.PayoutRepository.CreateBeneficiary(ctx, beneficiary)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	return toBeneficiaryResponse(beneficiary), nil
+}
+
+func (svc *BeneficiaryService) GetBeneficiaries(ctx context.Context, customerXID string) ([]web.BeneficiaryResponse, error) {
+	beneficiaries, err := svc.PayoutRepository.GetBeneficiaries(ctx, customerXID)
+	if err != nil {
+		return []web.BeneficiaryResponse{}, err
+	}
+
+	result := []web.BeneficiaryResponse{}
+	for i := range beneficiaries {
+		result = append(result, toBeneficiaryResponse(beneficiaries[i]))
+	}
+	return result, nil
+}
+
+func (svc *BeneficiaryService) GetBeneficiary(ctx context.Context, customerXID, beneficiaryID string) (web.BeneficiaryResponse, error) {
+	beneficiary, err := getOwnedBeneficiary(ctx, svc.PayoutRepository, customerXID, beneficiaryID)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+	return toBeneficiaryResponse(beneficiary), nil
+}
+
+func (svc *BeneficiaryService) UpdateBeneficiary(ctx context.Context, customerXID, beneficiaryID string, request web.BeneficiaryRequest) (web.BeneficiaryResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	beneficiary, err := getOwnedBeneficiary(ctx, svc.PayoutRepository, customerXID, beneficiaryID)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	err = svc.checkAccountAvailable(ctx, customerXID, beneficiary.ID, request)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	beneficiary.BankAccount, err = svc.inquire(ctx, domain.BankAccount{
+		BankCode:      request.BankCode,
+		AccountNumber: request.AccountNumber,
+		AccountName:   request.AccountName,
+	})
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	now := time.Now()
+	beneficiary.VerifiedAt = &now
+	beneficiary.UpdatedAt = now
+	err = svc.PayoutRepository.UpdateBeneficiary(ctx, beneficiary)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	return toBeneficiaryResponse(beneficiary), nil
+}
+
+func (svc *BeneficiaryService) DeleteBeneficiary(ctx context.Context, customerXID, beneficiaryID string) (web.BeneficiaryResponse, error) {
+	beneficiary, err := getOwnedBeneficiary(ctx, svc.PayoutRepository, customerXID, beneficiaryID)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	// payouts keep their own copy of the bank account
+	err = svc.PayoutRepository.DeleteBeneficiary(ctx, beneficiary.ID)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	return toBeneficiaryResponse(beneficiary), nil
+}
+
+func (svc *BeneficiaryService) VerifyBeneficiary(ctx context.Context, customerXID, beneficiaryID string) (web.BeneficiaryResponse, error) {
+	beneficiary, err := getOwnedBeneficiary(ctx, svc.PayoutRepository, customerXID, beneficiaryID)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	if beneficiary.VerifiedAt != nil {
+		return toBeneficiaryResponse(beneficiary), nil
+	}
+
+	beneficiary.BankAccount, err = svc.inquire(ctx, beneficiary.BankAccount)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	now := time.Now()
+	beneficiary.VerifiedAt = &now
+	beneficiary.UpdatedAt = now
+	err = svc.PayoutRepository.UpdateBeneficiary(ctx, beneficiary)
+	if err != nil {
+		return web.BeneficiaryResponse{}, err
+	}
+
+	return toBeneficiaryResponse(beneficiary), nil
+}
+
+// checkAccountAvailable rejects a bank account the customer already saved
+// as another beneficiary than beneficiaryID.
+func (svc *BeneficiaryService) checkAccountAvailable(ctx context.Context, customerXID, beneficiaryID string, request web.BeneficiaryRequest) error {
+	existing, err := svc.PayoutRepository.GetBeneficiaryByAccount(ctx, customerXID, request.BankCode, request.AccountNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if existing.ID != beneficiaryID {
+		return errors.New("beneficiary already exists")
+	}
+	return nil
+}
+
+// inquire asks the bank who holds the account. A holder name given by the
+// customer must match it, the name the bank knows is the one kept.
+func (svc *BeneficiaryService) inquire(ctx context.Context, bankAccount domain.BankAccount) (domain.BankAccount, error) {
+	accountName, err := svc.NameResolver.ResolveAccountName(ctx, bankAccount.BankCode, bankAccount.AccountNumber)
+	if err != nil {
+		return domain.BankAccount{}, err
+	}
+
+	if bankAccount.AccountName != "" && !sameHolderName(bankAccount.AccountName, accountName) {
+		return domain.BankAccount{}, errors.New("account holder name does not match")
+	}
+
+	bankAccount.AccountName = accountName
+	return bankAccount, nil
+}
+
+// getOwnedBeneficiary is shared with withdrawals, which pay out to a
+// beneficiary by its ID.
+func getOwnedBeneficiary(ctx context.Context, payoutRepository repository.PayoutRepository, customerXID, beneficiaryID string) (domain.Beneficiary, error) {
+	beneficiary, err := payoutRepository.GetBeneficiary(ctx, beneficiaryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Beneficiary{}, errors.New("beneficiary not found")
+	}
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	if beneficiary.CustomerXID != customerXID {
+		return domain.Beneficiary{}, errors.New("beneficiary not found")
+	}
+	return beneficiary, nil
+}
+
+// sameHolderName compares names the way banks print them, ignoring case and
+// repeated spaces.
+func sameHolderName(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
+func toBeneficiaryResponse(beneficiary domain.Beneficiary) web.BeneficiaryResponse {
+	return web.BeneficiaryResponse{
+		ID:            beneficiary.ID,
+		BankCode:      beneficiary.BankAccount.BankCode,
+		AccountNumber: beneficiary.BankAccount.AccountNumber,
+		AccountName:   beneficiary.BankAccount.AccountName,
+		VerifiedAt:    beneficiary.VerifiedAt,
+		CreatedAt:     beneficiary.CreatedAt,
+		UpdatedAt:     beneficiary.UpdatedAt,
+	}
+}
@@ -0,0 +1,177 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	beneficiarySvc service.BeneficiaryServiceItf
+
+	mockBeneficiaryRepository *mock_repository.MockPayoutRepository
+)
+
+func provideBeneficiaryTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBeneficiaryRepository = mock_repository.NewMockPayoutRepository(ctrl)
+	validator := validator.New()
+	beneficiarySvc = service.NewBeneficiaryService(mockBeneficiaryRepository, bank.NewStubNameResolver(), validator)
+
+	return func() {}
+}
+
+func TestCreateBeneficiary(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.BeneficiaryRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.BeneficiaryResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - holder name matches the inquiry",
+			payload: web.BeneficiaryRequest{
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+				AccountName:   "stub  holder 7890",
+			},
+			mockFunc: func() {
+				mockBeneficiaryRepository.EXPECT().GetBeneficiaryByAccount(gomock.Any(), "1", "BCA", "1234567890").Return(domain.Beneficiary{}, sql.ErrNoRows)
+				mockBeneficiaryRepository.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, beneficiary domain.Beneficiary) error {
+						assert.Equal(t, beneficiary.CustomerXID, "1")
+						assert.NotNil(t, beneficiary.VerifiedAt)
+						return nil
+					})
+			},
+			wantErr: nil,
+			wantResult: web.BeneficiaryResponse{
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+				AccountName:   "STUB HOLDER 7890",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - holder name taken from the inquiry",
+			payload: web.BeneficiaryRequest{
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+			},
+			mockFunc: func() {
+				mockBeneficiaryRepository.EXPECT().GetBeneficiaryByAccount(gomock.Any(), "1", "BCA", "1234567890").Return(domain.Beneficiary{}, sql.ErrNoRows)
+				mockBeneficiaryRepository.EXPECT().CreateBeneficiary(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: nil,
+			wantResult: web.BeneficiaryResponse{
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+				AccountName:   "STUB HOLDER 7890",
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - holder name does not match",
+			payload: web.BeneficiaryRequest{
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+				AccountName:   "John Doe",
+			},
+			mockFunc: func() {
+				mockBeneficiaryRepository.EXPECT().GetBeneficiaryByAccount(gomock.Any(), "1", "BCA", "1234567890").Return(domain.Beneficiary{}, sql.ErrNoRows)
+			},
+			wantErr:    fmt.Errorf("account holder name does not match"),
+			wantResult: web.BeneficiaryResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - account unknown to the bank",
+			payload: web.BeneficiaryRequest{
+				BankCode:      "BCA",
+				AccountNumber: "1234560000",
+			},
+			mockFunc: func() {
+				mockBeneficiaryRepository.EXPECT().GetBeneficiaryByAccount(gomock.Any(), "1", "BCA", "1234560000").Return(domain.Beneficiary{}, sql.ErrNoRows)
+			},
+			wantErr:    bank.ErrAccountNotFound,
+			wantResult: web.BeneficiaryResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - account already saved",
+			payload: web.BeneficiaryRequest{
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+			},
+			mockFunc: func() {
+				mockBeneficiaryRepository.EXPECT().GetBeneficiaryByAccount(gomock.Any(), "1", "BCA", "1234567890").Return(domain.Beneficiary{ID: "mock-beneficiary"}, nil)
+			},
+			wantErr:    fmt.Errorf("beneficiary already exists"),
+			wantResult: web.BeneficiaryResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideBeneficiaryTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := beneficiarySvc.CreateBeneficiary(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, got.BankCode, tc.wantResult.BankCode)
+			assert.Equal(t, got.AccountNumber, tc.wantResult.AccountNumber)
+			assert.Equal(t, got.AccountName, tc.wantResult.AccountName)
+		})
+	}
+}
+
+func TestVerifyBeneficiary(t *testing.T) {
+	testDep := provideBeneficiaryTest(t)
+	defer testDep()
+
+	mockBeneficiaryRepository.EXPECT().GetBeneficiary(gomock.Any(), "mock-beneficiary").Return(domain.Beneficiary{
+		ID:          "mock-beneficiary",
+		CustomerXID: "1",
+		BankAccount: domain.BankAccount{
+			BankCode:      "BCA",
+			AccountNumber: "1234567890",
+			AccountName:   "Stub Holder 7890",
+		},
+	}, nil)
+	mockBeneficiaryRepository.EXPECT().UpdateBeneficiary(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, beneficiary domain.Beneficiary) error {
+			assert.Equal(t, beneficiary.BankAccount.AccountName, "STUB HOLDER 7890")
+			assert.WithinDuration(t, time.Now(), *beneficiary.VerifiedAt, time.Minute)
+			return nil
+		})
+
+	got, err := beneficiarySvc.VerifyBeneficiary(context.Background(), "1", "mock-beneficiary")
+	assert.Nil(t, err)
+	assert.NotNil(t, got.VerifiedAt)
+
+	mockBeneficiaryRepository.EXPECT().GetBeneficiary(gomock.Any(), "mock-beneficiary").Return(domain.Beneficiary{ID: "mock-beneficiary", CustomerXID: "2"}, nil)
+	_, err = beneficiarySvc.VerifyBeneficiary(context.Background(), "1", "mock-beneficiary")
+	assert.Equal(t, err.Error(), "beneficiary not found")
+}
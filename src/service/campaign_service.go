@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type CampaignServiceItf interface {
+	CreateCampaign(ctx context.Context, request web.CampaignCreateRequest) (web.CampaignResponse, error)
+	GetCampaigns(ctx context.Context) ([]web.CampaignResponse, error)
+	EndCampaign(ctx context.Context, campaignID string) (web.CampaignResponse, error)
+	GetCashbacks(ctx context.Context, customerXID string) ([]web.CashbackResponse, error)
+	// RunCampaigns awards cashback on qualifying transactions of every active
+	// campaign and releases held cashback whose clawback window has passed.
+	RunCampaigns(ctx context.Context, now time.Time) error
+}
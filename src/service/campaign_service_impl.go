@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type CampaignService struct {
+	CampaignRepository repository.CampaignRepository
+	WalletRepository   repository.WalletRepository
+	Validate           *validator.Validate
+}
+
+func NewCampaignService(campaignRepository repository.CampaignRepository, walletRepository repository.WalletRepository, validate *validator.Validate) CampaignServiceItf {
+	return &CampaignService{
+		CampaignRepository: campaignRepository,
+		WalletRepository:   walletRepository,
+		Validate:           validate,
+	}
+}
+
+func (svc *CampaignService) CreateCampaign(ctx context.Context, request web.CampaignCreateRequest) (web.CampaignResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.CampaignResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+	if !isSupportedCurrency(currency) {
+		return web.CampaignResponse{}, errors.New("unsupported currency")
+	}
+
+	now := time.Now()
+	if !request.EndsAt.After(now) {
+		return web.CampaignResponse{}, errors.New("ends_at must be in the future")
+	}
+
+	campaign := domain.Campaign{
+		ID:              uuid.New().String(),
+		Name:            request.Name,
+		TransactionType: request.TransactionType,
+		MerchantID:      request.MerchantID,
+		MinAmount:       domain.NewMoney(request.MinAmount, currency),
+		CashbackRate:    request.CashbackRate,
+		MaxCashback:     domain.NewMoney(request.MaxCashback, currency),
+		CustomerCap:     domain.NewMoney(request.CustomerCap, currency),
+		Budget:          domain.NewMoney(request.Budget, currency),
+		BudgetUsed:      domain.NewMoney(0, currency),
+		HoldDays:        request.HoldDays,
+		StartsAt:        request.StartsAt,
+		EndsAt:          request.EndsAt,
+		Status:          constants.STATUS_ACTIVE,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	err = svc.CampaignRepository.CreateCampaign(ctx, campaign)
+	if err != nil {
+		return web.CampaignResponse{}, err
+	}
+	return toCampaignResponse(campaign), nil
+}
+
+func (svc *CampaignService) GetCampaigns(ctx context.Context) ([]web.CampaignResponse, error) {
+	campaigns, err := svc.CampaignRepository.GetCampaigns(ctx)
+	if err != nil {
+		return []web.CampaignResponse{}, err
+	}
+
+	result := []web.CampaignResponse{}
+	for _, campaign := range campaigns {
+		result = append(result, toCampaignResponse(campaign))
+	}
+	return result, nil
+}
+
+func (svc *CampaignService) EndCampaign(ctx context.Context, campaignID string) (web.CampaignResponse, error) {
+	campaign, err := svc.CampaignRepository.GetCampaign(ctx, campaignID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.CampaignResponse{}, errors.New("campaign not found")
+	}
+	if err != nil {
+		return web.CampaignResponse{}, err
+	}
+
+	// cashback already awarded, held or not, is still paid out
+	if campaign.Status == constants.STATUS_ACTIVE {
+		err = svc.CampaignRepository.UpdateCampaignStatus(ctx, campaign.ID, constants.STATUS_CLOSED)
+		if err != nil {
+			return web.CampaignResponse{}, err
+		}
+		campaign.Status = constants.STATUS_CLOSED
+	}
+	return toCampaignResponse(campaign), nil
+}
+
+func (svc *CampaignService) GetCashbacks(ctx context.Context, customerXID string) ([]web.CashbackResponse, error) {
+	cashbacks, err := svc.CampaignRepository.GetCashbacks(ctx, customerXID)
+	if err != nil {
+		return []web.CashbackResponse{}, err
+	}
+
+	result := []web.CashbackResponse{}
+	for _, cashback := range cashbacks {
+		result = append(result, web.CashbackResponse{
+			ID:                  cashback.ID,
+			CampaignID:          cashback.CampaignID,
+			SourceTransactionID: cashback.SourceTransactionID,
+			Amount:              cashback.Amount,
+			Status:              cashback.Status,
+			ReleaseAt:           cashback.ReleaseAt,
+			TransactionID:       cashback.TransactionID,
+			CreatedAt:           cashback.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (svc *CampaignService) RunCampaigns(ctx context.Context, now time.Time) error {
+	campaigns, err := svc.CampaignRepository.GetActiveCampaigns(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range campaigns {
+		err = svc.awardCashbacks(ctx, campaigns[i], now)
+		if err != nil {
+			log.Println("error run campaign", campaigns[i].ID+":", err.Error())
+		}
+	}
+
+	cashbacks, err := svc.CampaignRepository.GetDueCashbacks(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range cashbacks {
+		err = svc.releaseCashback(ctx, cashbacks[i], now)
+		if err != nil {
+			log.Println("error release cashback", cashbacks[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *CampaignService) awardCashbacks(ctx context.Context, campaign domain.Campaign, now time.Time) error {
+	transactions, err := svc.CampaignRepository.GetEligibleTransactions(ctx, campaign, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	// the campaign is over once its window closed and every transaction in it
+	// was looked at, or once the budget is spent
+	remaining, err := campaign.Budget.Sub(campaign.BudgetUsed)
+	if err != nil {
+		return err
+	}
+	if (len(transactions) == 0 && !now.Before(campaign.EndsAt)) || !remaining.IsPositive() {
+		return svc.CampaignRepository.UpdateCampaignStatus(ctx, campaign.ID, constants.STATUS_COMPLETED)
+	}
+
+	for i := range transactions {
+		amount, err := svc.cashbackAmount(ctx, campaign, transactions[i], remaining)
+		if err != nil {
+			log.Println("error award cashback for transaction", transactions[i].ID+":", err.Error())
+			continue
+		}
+
+		isCreated, err := svc.createCashback(ctx, campaign, transactions[i], amount, now)
+		if err != nil {
+			log.Println("error award cashback for transaction", transactions[i].ID+":", err.Error())
+			continue
+		}
+		if !isCreated {
+			return errors.New("campaign budget exhausted")
+		}
+
+		remaining, err = remaining.Sub(amount)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cashbackAmount applies the campaign rate to the transaction and caps it by
+// the single cashback limit, what is left of the customer cap and of the
+// budget. A reversed transaction earns nothing.
+func (svc *CampaignService) cashbackAmount(ctx context.Context, campaign domain.Campaign, source domain.Transaction, remaining domain.Money) (domain.Money, error) {
+	zero := domain.NewMoney(0, source.Amount.Currency)
+
+	isReversed, err := svc.isReversed(ctx, source)
+	if err != nil {
+		return zero, err
+	}
+	if isReversed {
+		return zero, nil
+	}
+
+	limits := []domain.Money{remaining}
+	if campaign.MaxCashback.IsPositive() {
+		limits = append(limits, campaign.MaxCashback)
+	}
+	if campaign.CustomerCap.IsPositive() {
+		earned, err := svc.CampaignRepository.GetCustomerCashbackTotal(ctx, campaign.ID, source.CustomerXID)
+		if err != nil {
+			return zero, err
+		}
+		customerRemaining, err := campaign.CustomerCap.Sub(domain.NewMoney(earned, campaign.CustomerCap.Currency))
+		if err != nil {
+			return zero, err
+		}
+		limits = append(limits, customerRemaining)
+	}
+
+	amount := applyRate(source.Amount, floatToRat(campaign.CashbackRate))
+	for _, limit := range limits {
+		cmp, err := amount.Cmp(limit)
+		if err != nil {
+			return zero, err
+		}
+		if cmp > 0 {
+			amount = limit
+		}
+	}
+	if !amount.IsPositive() {
+		return zero, nil
+	}
+	return amount, nil
+}
+
+// createCashback records the cashback earned by source. A zero amount is
+// stored as declined so the transaction is not looked at again, a held
+// cashback stays pending until its release.
+func (svc *CampaignService) createCashback(ctx context.Context, campaign domain.Campaign, source domain.Transaction, amount domain.Money, now time.Time) (bool, error) {
+	cashback := domain.Cashback{
+		ID:                  uuid.New().String(),
+		CampaignID:          campaign.ID,
+		CustomerXID:         source.CustomerXID,
+		WalletID:            source.WalletID,
+		SourceTransactionID: source.ID,
+		Amount:              amount,
+		Status:              constants.STATUS_DECLINED,
+		ReleaseAt:           now,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+
+	var credit *domain.Transaction
+	switch {
+	case !amount.IsPositive():
+	case campaign.HoldDays > 0:
+		cashback.Status = constants.STATUS_PENDING
+		cashback.ReleaseAt = now.AddDate(0, 0, campaign.HoldDays)
+	default:
+		credit = newCashbackCredit(cashback, now)
+		cashback.Status = constants.STATUS_SUCCESS
+		cashback.TransactionID = credit.ID
+	}
+
+	return svc.CampaignRepository.CreateCashback(ctx, cashback, credit)
+}
+
+func (svc *CampaignService) releaseCashback(ctx context.Context, cashback domain.Cashback, now time.Time) error {
+	source, err := svc.WalletRepository.GetTransaction(ctx, cashback.SourceTransactionID)
+	if err != nil {
+		return err
+	}
+
+	// a transaction reversed within the clawback window loses its cashback
+	isReversed, err := svc.isReversed(ctx, source)
+	if err != nil {
+		return err
+	}
+	if isReversed {
+		_, err = svc.CampaignRepository.CancelCashback(ctx, cashback)
+		return err
+	}
+
+	isReleased, err := svc.CampaignRepository.ReleaseCashback(ctx, cashback, *newCashbackCredit(cashback, now))
+	if err != nil {
+		return err
+	}
+	if !isReleased {
+		return errors.New("cashback already released")
+	}
+	return nil
+}
+
+// isReversed reports whether the payment behind source was refunded.
+func (svc *CampaignService) isReversed(ctx context.Context, source domain.Transaction) (bool, error) {
+	if source.TransactionType != constants.TRANSACTION_TYPE_PAYMENT {
+		return false, nil
+	}
+
+	_, err := svc.WalletRepository.GetTransactionByReference(ctx, constants.TRANSACTION_TYPE_PAYMENT_REFUND, source.ReferenceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func newCashbackCredit(cashback domain.Cashback, now time.Time) *domain.Transaction {
+	return &domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        cashback.WalletID,
+		CustomerXID:     cashback.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_CASHBACK,
+		Amount:          cashback.Amount,
+		ReferenceID:     cashback.SourceTransactionID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+func toCampaignResponse(campaign domain.Campaign) web.CampaignResponse {
+	return web.CampaignResponse{
+		ID:              campaign.ID,
+		Name:            campaign.Name,
+		TransactionType: campaign.TransactionType,
+		MerchantID:      campaign.MerchantID,
+		Currency:        campaign.Budget.Currency,
+		MinAmount:       campaign.MinAmount,
+		CashbackRate:    campaign.CashbackRate,
+		MaxCashback:     campaign.MaxCashback,
+		CustomerCap:     campaign.CustomerCap,
+		Budget:          campaign.Budget,
+		BudgetUsed:      campaign.BudgetUsed,
+		HoldDays:        campaign.HoldDays,
+		StartsAt:        campaign.StartsAt,
+		EndsAt:          campaign.EndsAt,
+		Status:          campaign.Status,
+		CreatedAt:       campaign.CreatedAt,
+	}
+}
@@ -0,0 +1,333 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	campaignSvc service.CampaignServiceItf
+
+	mockCampaignRepository       *mock_repository.MockCampaignRepository
+	mockCampaignWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideCampaignTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCampaignRepository = mock_repository.NewMockCampaignRepository(ctrl)
+	mockCampaignWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	campaignSvc = service.NewCampaignService(mockCampaignRepository, mockCampaignWalletRepository, validator)
+
+	return func() {}
+}
+
+func TestCreateCampaign(t *testing.T) {
+	startsAt := time.Now()
+	endsAt := startsAt.AddDate(0, 1, 0)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.CampaignCreateRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.CampaignResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			payload: web.CampaignCreateRequest{
+				Name:            "Payday",
+				TransactionType: "payment",
+				MerchantID:      "mock-merchant",
+				CashbackRate:    0.1,
+				Budget:          100000,
+				HoldDays:        7,
+				StartsAt:        startsAt,
+				EndsAt:          endsAt,
+			},
+			mockFunc: func() {
+				mockCampaignRepository.EXPECT().CreateCampaign(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.CampaignResponse{
+				Name:            "Payday",
+				TransactionType: "payment",
+				MerchantID:      "mock-merchant",
+				Currency:        "IDR",
+				MinAmount:       domain.Money{Amount: 0, Currency: "IDR"},
+				CashbackRate:    0.1,
+				MaxCashback:     domain.Money{Amount: 0, Currency: "IDR"},
+				CustomerCap:     domain.Money{Amount: 0, Currency: "IDR"},
+				Budget:          domain.Money{Amount: 100000, Currency: "IDR"},
+				BudgetUsed:      domain.Money{Amount: 0, Currency: "IDR"},
+				HoldDays:        7,
+				StartsAt:        startsAt,
+				EndsAt:          endsAt,
+				Status:          "active",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - merchant on transfer campaign",
+			payload: web.CampaignCreateRequest{
+				Name:            "Payday",
+				TransactionType: "transfer_out",
+				MerchantID:      "mock-merchant",
+				CashbackRate:    0.1,
+				Budget:          100000,
+				StartsAt:        startsAt,
+				EndsAt:          endsAt,
+			},
+			mockFunc: func() {},
+			wantErr:  true,
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - ends before it starts",
+			payload: web.CampaignCreateRequest{
+				Name:            "Payday",
+				TransactionType: "payment",
+				CashbackRate:    0.1,
+				Budget:          100000,
+				StartsAt:        endsAt,
+				EndsAt:          startsAt,
+			},
+			mockFunc: func() {},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideCampaignTest(t)
+			defer testDep()
+
+			tc.mockFunc()
+			got, err := campaignSvc.CreateCampaign(context.Background(), tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			if !tc.wantErr {
+				tc.wantResult.ID = got.ID
+				tc.wantResult.CreatedAt = got.CreatedAt
+			}
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
+
+func TestRunCampaignsAward(t *testing.T) {
+	now := time.Date(2023, 3, 15, 1, 0, 0, 0, time.UTC)
+	campaign := domain.Campaign{
+		ID:              "mock-campaign",
+		TransactionType: "payment",
+		CashbackRate:    0.1,
+		MaxCashback:     domain.Money{Amount: 5000, Currency: "IDR"},
+		CustomerCap:     domain.Money{Amount: 8000, Currency: "IDR"},
+		Budget:          domain.Money{Amount: 100000, Currency: "IDR"},
+		BudgetUsed:      domain.Money{Amount: 0, Currency: "IDR"},
+		StartsAt:        now.AddDate(0, 0, -1),
+		EndsAt:          now.AddDate(0, 0, 30),
+		Status:          "active",
+	}
+	source := domain.Transaction{
+		ID:              "mock-transaction",
+		WalletID:        "mock-id",
+		CustomerXID:     "1",
+		TransactionType: "payment",
+		Amount:          domain.Money{Amount: 30000, Currency: "IDR"},
+		ReferenceID:     "mock-intent",
+		Status:          "success",
+	}
+
+	testCases := []struct {
+		testID        int
+		testDesc      string
+		holdDays      int
+		budgetUsed    int64
+		earned        int64
+		isRefunded    bool
+		wantAmount    int64
+		wantStatus    string
+		wantReleaseAt time.Time
+		wantCredit    bool
+	}{
+		{
+			testID:        1,
+			testDesc:      "Success - credited at once",
+			wantAmount:    3000,
+			wantStatus:    "success",
+			wantReleaseAt: now,
+			wantCredit:    true,
+		},
+		{
+			testID:        2,
+			testDesc:      "Success - held for the clawback window",
+			holdDays:      7,
+			wantAmount:    3000,
+			wantStatus:    "pending",
+			wantReleaseAt: now.AddDate(0, 0, 7),
+		},
+		{
+			testID:        3,
+			testDesc:      "Success - capped by customer cap",
+			earned:        6000,
+			wantAmount:    2000,
+			wantStatus:    "success",
+			wantReleaseAt: now,
+			wantCredit:    true,
+		},
+		{
+			testID:        4,
+			testDesc:      "Success - capped by remaining budget",
+			budgetUsed:    99000,
+			wantAmount:    1000,
+			wantStatus:    "success",
+			wantReleaseAt: now,
+			wantCredit:    true,
+		},
+		{
+			testID:        5,
+			testDesc:      "Success - declined once customer cap is reached",
+			earned:        8000,
+			wantAmount:    0,
+			wantStatus:    "declined",
+			wantReleaseAt: now,
+		},
+		{
+			testID:        6,
+			testDesc:      "Success - refunded payment earns nothing",
+			isRefunded:    true,
+			wantAmount:    0,
+			wantStatus:    "declined",
+			wantReleaseAt: now,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideCampaignTest(t)
+			defer testDep()
+
+			active := campaign
+			active.HoldDays = tc.holdDays
+			active.BudgetUsed.Amount = tc.budgetUsed
+
+			refundErr := sql.ErrNoRows
+			if tc.isRefunded {
+				refundErr = nil
+			}
+
+			mockCampaignRepository.EXPECT().GetActiveCampaigns(gomock.Any(), now).Return([]domain.Campaign{active}, nil)
+			mockCampaignRepository.EXPECT().GetEligibleTransactions(gomock.Any(), active, gomock.Any()).Return([]domain.Transaction{source}, nil)
+			mockCampaignWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "payment_refund", "mock-intent").Return(domain.Transaction{}, refundErr)
+			if !tc.isRefunded {
+				mockCampaignRepository.EXPECT().GetCustomerCashbackTotal(gomock.Any(), "mock-campaign", "1").Return(tc.earned, nil)
+			}
+
+			var got domain.Cashback
+			var credit *domain.Transaction
+			mockCampaignRepository.EXPECT().CreateCashback(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, cashback domain.Cashback, transaction *domain.Transaction) (bool, error) {
+					got = cashback
+					credit = transaction
+					return true, nil
+				})
+			mockCampaignRepository.EXPECT().GetDueCashbacks(gomock.Any(), now, gomock.Any()).Return([]domain.Cashback{}, nil)
+
+			err := campaignSvc.RunCampaigns(context.Background(), now)
+			assert.Nil(t, err)
+			assert.Equal(t, got.Amount, domain.Money{Amount: tc.wantAmount, Currency: "IDR"})
+			assert.Equal(t, got.Status, tc.wantStatus)
+			assert.Equal(t, got.ReleaseAt, tc.wantReleaseAt)
+			assert.Equal(t, got.SourceTransactionID, "mock-transaction")
+			assert.Equal(t, credit != nil, tc.wantCredit)
+			if tc.wantCredit {
+				assert.Equal(t, credit.TransactionType, "cashback")
+				assert.Equal(t, credit.ReferenceID, "mock-transaction")
+				assert.Equal(t, credit.Amount, got.Amount)
+				assert.Equal(t, got.TransactionID, credit.ID)
+			}
+		})
+	}
+}
+
+func TestRunCampaignsRelease(t *testing.T) {
+	now := time.Date(2023, 3, 22, 1, 0, 0, 0, time.UTC)
+	cashback := domain.Cashback{
+		ID:                  "mock-cashback",
+		CampaignID:          "mock-campaign",
+		CustomerXID:         "1",
+		WalletID:            "mock-id",
+		SourceTransactionID: "mock-transaction",
+		Amount:              domain.Money{Amount: 3000, Currency: "IDR"},
+		Status:              "pending",
+		ReleaseAt:           now.AddDate(0, 0, -1),
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		isRefunded bool
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - released after the window",
+		},
+		{
+			testID:     2,
+			testDesc:   "Success - clawed back after refund",
+			isRefunded: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideCampaignTest(t)
+			defer testDep()
+
+			refundErr := sql.ErrNoRows
+			if tc.isRefunded {
+				refundErr = nil
+			}
+
+			mockCampaignRepository.EXPECT().GetActiveCampaigns(gomock.Any(), now).Return([]domain.Campaign{}, nil)
+			mockCampaignRepository.EXPECT().GetDueCashbacks(gomock.Any(), now, gomock.Any()).Return([]domain.Cashback{cashback}, nil)
+			mockCampaignWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(domain.Transaction{
+				ID:              "mock-transaction",
+				TransactionType: "payment",
+				ReferenceID:     "mock-intent",
+			}, nil)
+			mockCampaignWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "payment_refund", "mock-intent").Return(domain.Transaction{}, refundErr)
+
+			if tc.isRefunded {
+				mockCampaignRepository.EXPECT().CancelCashback(gomock.Any(), cashback).Return(true, nil)
+			} else {
+				mockCampaignRepository.EXPECT().ReleaseCashback(gomock.Any(), cashback, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cashback domain.Cashback, credit domain.Transaction) (bool, error) {
+						assert.Equal(t, credit.TransactionType, "cashback")
+						assert.Equal(t, credit.ReferenceID, "mock-transaction")
+						assert.Equal(t, credit.WalletID, "mock-id")
+						assert.Equal(t, credit.Amount, domain.Money{Amount: 3000, Currency: "IDR"})
+						return true, nil
+					})
+			}
+
+			err := campaignSvc.RunCampaigns(context.Background(), now)
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type CreditLineServiceItf interface {
+	SetCreditLimit(ctx context.Context, request web.CreditLimitRequest) (web.WalletResponse, error)
+	// AccrueOverdraftInterest charges one day of interest to every overdrawn
+	// wallet. Each wallet is charged at most once per day, however often it
+	// runs.
+	AccrueOverdraftInterest(ctx context.Context, now time.Time) error
+}
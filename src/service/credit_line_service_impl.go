@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// daysPerYear converts the annual overdraft rate into a daily one.
+const daysPerYear = 365
+
+type CreditLineService struct {
+	CreditLineRepository repository.CreditLineRepository
+	WalletRepository     repository.WalletRepository
+	Validate             *validator.Validate
+	// InterestRate is the annual rate charged on overdrawn balances, 0.2 is 20%
+	InterestRate float64
+	// Location decides where an interest day starts and ends
+	Location *time.Location
+}
+
+func NewCreditLineService(creditLineRepository repository.CreditLineRepository, walletRepository repository.WalletRepository, validate *validator.Validate, interestRate float64, location *time.Location) CreditLineServiceItf {
+	return &CreditLineService{
+		CreditLineRepository: creditLineRepository,
+		WalletRepository:     walletRepository,
+		Validate:             validate,
+		InterestRate:         interestRate,
+		Location:             location,
+	}
+}
+
+func (svc *CreditLineService) SetCreditLimit(ctx context.Context, request web.CreditLimitRequest) (web.WalletResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, request.CustomerXID, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.WalletResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+
+	wallet.CreditLimit = domain.NewMoney(request.CreditLimit, wallet.Currency)
+	isUpdated, err := svc.CreditLineRepository.UpdateCreditLimit(ctx, wallet.ID, wallet.CreditLimit)
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+	if !isUpdated {
+		return web.WalletResponse{}, errors.New("credit limit below credit used")
+	}
+
+	result := web.WalletResponse{
+		ID:        wallet.ID,
+		OwnedBy:   wallet.CustomerXID,
+		Currency:  wallet.Currency,
+		Status:    wallet.Status,
+		EnabledAt: wallet.EnabledAt,
+		Balance:   wallet.Balance,
+	}
+	setCreditLine(&result, wallet)
+	return result, nil
+}
+
+func (svc *CreditLineService) AccrueOverdraftInterest(ctx context.Context, now time.Time) error {
+	if svc.InterestRate <= 0 {
+		return nil
+	}
+
+	dailyRate := new(big.Rat).Quo(floatToRat(svc.InterestRate), big.NewRat(daysPerYear, 1))
+	day := now.In(svc.Location).Format("20060102")
+
+	afterID := ""
+	for {
+		wallets, err := svc.CreditLineRepository.GetOverdrawnWallets(ctx, afterID, scheduleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range wallets {
+			err = svc.chargeInterest(ctx, wallets[i], dailyRate, day, now)
+			if err != nil {
+				log.Println("error charge overdraft interest", wallets[i].ID+":", err.Error())
+			}
+		}
+
+		if len(wallets) < scheduleBatchSize {
+			return nil
+		}
+		afterID = wallets[len(wallets)-1].ID
+	}
+}
+
+func (svc *CreditLineService) chargeInterest(ctx context.Context, wallet domain.Wallet, dailyRate *big.Rat, day string, now time.Time) error {
+	interest := applyRate(wallet.CreditUsed, dailyRate)
+	if !interest.IsPositive() {
+		return nil
+	}
+
+	// the wallet was already charged for the day
+	referenceID := fmt.Sprintf("odint-%s-%s", wallet.ID, day)
+	_, err := svc.WalletRepository.GetTransactionByReference(ctx, constants.TRANSACTION_TYPE_OVERDRAFT_INTEREST, referenceID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	_, err = svc.CreditLineRepository.ChargeOverdraftInterest(ctx, domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_OVERDRAFT_INTEREST,
+		Amount:          interest,
+		ReferenceID:     referenceID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	})
+	return err
+}
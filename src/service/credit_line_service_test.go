@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	creditLineSvc service.CreditLineServiceItf
+
+	mockCreditLineRepository       *mock_repository.MockCreditLineRepository
+	mockCreditLineWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideCreditLineTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCreditLineRepository = mock_repository.NewMockCreditLineRepository(ctrl)
+	mockCreditLineWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	creditLineSvc = service.NewCreditLineService(mockCreditLineRepository, mockCreditLineWalletRepository, validator, 0.2, time.UTC)
+
+	return func() {}
+}
+
+func TestSetCreditLimit(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.Money{Amount: -3000, Currency: "IDR"},
+		CreditLimit: domain.Money{Amount: 5000, Currency: "IDR"},
+		CreditUsed:  domain.Money{Amount: 3000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.CreditLimitRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.WalletResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			payload: web.CreditLimitRequest{
+				CustomerXID: "1",
+				CreditLimit: 10000,
+			},
+			mockFunc: func() {
+				mockCreditLineWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockCreditLineRepository.EXPECT().UpdateCreditLimit(gomock.Any(), "mock-id", domain.Money{Amount: 10000, Currency: "IDR"}).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.WalletResponse{
+				ID:          "mock-id",
+				Balance:     domain.Money{Amount: -3000, Currency: "IDR"},
+				CreditLimit: &domain.Money{Amount: 10000, Currency: "IDR"},
+				CreditUsed:  &domain.Money{Amount: 3000, Currency: "IDR"},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - below credit used",
+			payload: web.CreditLimitRequest{
+				CustomerXID: "1",
+				CreditLimit: 1000,
+			},
+			mockFunc: func() {
+				mockCreditLineWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockCreditLineRepository.EXPECT().UpdateCreditLimit(gomock.Any(), "mock-id", domain.Money{Amount: 1000, Currency: "IDR"}).Return(false, nil)
+			},
+			wantErr:    true,
+			wantResult: web.WalletResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - wallet not found",
+			payload: web.CreditLimitRequest{
+				CustomerXID: "1",
+				Currency:    "USD",
+				CreditLimit: 1000,
+			},
+			mockFunc: func() {
+				mockCreditLineWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{}, sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: web.WalletResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideCreditLineTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := creditLineSvc.SetCreditLimit(context.Background(), tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.ID, tc.wantResult.ID)
+			assert.Equal(t, got.Balance, tc.wantResult.Balance)
+			assert.Equal(t, got.CreditLimit, tc.wantResult.CreditLimit)
+			assert.Equal(t, got.CreditUsed, tc.wantResult.CreditUsed)
+		})
+	}
+}
+
+func TestAccrueOverdraftInterest(t *testing.T) {
+	now := time.Date(2023, 3, 1, 1, 0, 0, 0, time.UTC)
+	wallet := domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Balance:     domain.Money{Amount: -1000000, Currency: "IDR"},
+		CreditLimit: domain.Money{Amount: 2000000, Currency: "IDR"},
+		CreditUsed:  domain.Money{Amount: 1000000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		mockFunc   func()
+		wantErr    bool
+		wantResult domain.Money
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - one day of interest",
+			mockFunc: func() {
+				mockCreditLineWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "overdraft_interest", "odint-mock-id-20230301").Return(domain.Transaction{}, sql.ErrNoRows)
+			},
+			wantErr:    false,
+			wantResult: domain.Money{Amount: 548, Currency: "IDR"},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - already charged today",
+			mockFunc: func() {
+				mockCreditLineWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "overdraft_interest", "odint-mock-id-20230301").Return(domain.Transaction{ID: "mock-trx"}, nil)
+			},
+			wantErr:    false,
+			wantResult: domain.Money{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideCreditLineTest(t)
+			defer testDep()
+
+			mockCreditLineRepository.EXPECT().GetOverdrawnWallets(gomock.Any(), "", gomock.Any()).Return([]domain.Wallet{wallet}, nil)
+			tc.mockFunc()
+
+			var got domain.Transaction
+			if tc.wantResult.IsPositive() {
+				mockCreditLineRepository.EXPECT().ChargeOverdraftInterest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, interest domain.Transaction) (bool, error) {
+						got = interest
+						return true, nil
+					})
+			}
+
+			err := creditLineSvc.AccrueOverdraftInterest(context.Background(), now)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.Amount, tc.wantResult)
+		})
+	}
+}
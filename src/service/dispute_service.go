@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type DisputeServiceItf interface {
+	// OpenDispute disputes a successful debit of the customer, a transaction
+	// can be disputed once.
+	OpenDispute(ctx context.Context, customerXID string, request web.DisputeCreateRequest) (web.DisputeResponse, error)
+	GetDisputes(ctx context.Context, customerXID string) ([]web.DisputeResponse, error)
+	GetDispute(ctx context.Context, customerXID, disputeID string) (web.DisputeResponse, error)
+
+	// GetDisputesByStatus lists the disputes in status for admins, open ones
+	// when status is empty.
+	GetDisputesByStatus(ctx context.Context, status string) ([]web.DisputeResponse, error)
+	InvestigateDispute(ctx context.Context, disputeID string) (web.DisputeResponse, error)
+	// ResolveDispute closes a dispute under investigation. Resolved in the
+	// customer's favor, the disputed transaction is reversed.
+	ResolveDispute(ctx context.Context, disputeID string, request web.DisputeResolveRequest) (web.DisputeResponse, error)
+}
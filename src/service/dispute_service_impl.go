@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// disputeWindow is how long after a transaction it can still be disputed.
+const disputeWindow = 120 * 24 * time.Hour
+
+// disputableTransactionTypes are the debits a customer can dispute. Moves
+// between the customer's own balances and holds like escrows have their own
+// way back and are left out.
+var disputableTransactionTypes = map[string]bool{
+	constants.TRANSACTION_TYPE_WITHDRAWAL:         true,
+	constants.TRANSACTION_TYPE_TRANSFER_OUT:       true,
+	constants.TRANSACTION_TYPE_PAYMENT:            true,
+	constants.TRANSACTION_TYPE_LOAN_REPAYMENT:     true,
+	constants.TRANSACTION_TYPE_OVERDRAFT_INTEREST: true,
+}
+
+type DisputeService struct {
+	DisputeRepository repository.DisputeRepository
+	WalletRepository  repository.WalletRepository
+	PayoutRepository  repository.PayoutRepository
+	Validate          *validator.Validate
+}
+
+func NewDisputeService(disputeRepository repository.DisputeRepository, walletRepository repository.WalletRepository, payoutRepository repository.PayoutRepository, validate *validator.Validate) DisputeServiceItf {
+	return &DisputeService{
+		DisputeRepository: disputeRepository,
+		WalletRepository:  walletRepository,
+		PayoutRepository:  payoutRepository,
+		Validate:          validate,
+	}
+}
+
+func (svc *DisputeService) OpenDispute(ctx context.Context, customerXID string, request web.DisputeCreateRequest) (web.DisputeResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+
+	transaction, err := svc.WalletRepository.GetTransaction(ctx, request.TransactionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.DisputeResponse{}, errors.New("transaction not found")
+	}
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+
+	if transaction.CustomerXID != customerXID {
+		return web.DisputeResponse{}, errors.New("transaction not found")
+	}
+	if !disputableTransactionTypes[transaction.TransactionType] {
+		return web.DisputeResponse{}, errors.New("transaction cannot be disputed")
+	}
+	if transaction.Status != constants.STATUS_SUCCESS {
+		return web.DisputeResponse{}, errors.New("only successful transactions can be disputed")
+	}
+
+	now := time.Now()
+	if now.Sub(transaction.CreatedAt) > disputeWindow {
+		return web.DisputeResponse{}, errors.New("transaction is too old to dispute")
+	}
+
+	// a payout still on its way may yet fail and be reversed
+	if transaction.TransactionType == constants.TRANSACTION_TYPE_WITHDRAWAL {
+		payout, err := svc.PayoutRepository.GetPayoutByTransaction(ctx, transaction.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return web.DisputeResponse{}, err
+		}
+		if payout.Status != constants.STATUS_SUCCEEDED {
+			return web.DisputeResponse{}, errors.New("only withdrawals paid out can be disputed")
+		}
+	}
+
+	isReversed, err := svc.DisputeRepository.IsTransactionReversed(ctx, transaction.ID)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+	if isReversed {
+		return web.DisputeResponse{}, errors.New("transaction is already reversed")
+	}
+
+	evidence := request.Evidence
+	if evidence == nil {
+		evidence = map[string]string{}
+	}
+	dispute := domain.Dispute{
+		ID:              uuid.New().String(),
+		TransactionID:   transaction.ID,
+		WalletID:        transaction.WalletID,
+		CustomerXID:     transaction.CustomerXID,
+		TransactionType: transaction.TransactionType,
+		Amount:          transaction.Amount,
+		Reason:          request.Reason,
+		Evidence:        evidence,
+		Status:          constants.STATUS_OPEN,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isCreated, err := svc.DisputeRepository.CreateDispute(ctx, dispute)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+	if !isCreated {
+		return web.DisputeResponse{}, errors.New("transaction already disputed")
+	}
+
+	return toDisputeResponse(dispute), nil
+}
+
+func (svc *DisputeService) GetDisputes(ctx context.Context, customerXID string) ([]web.DisputeResponse, error) {
+	disputes, err := svc.DisputeRepository.GetDisputes(ctx, customerXID)
+	if err != nil {
+		return []web.DisputeResponse{}, err
+	}
+
+	result := []web.DisputeResponse{}
+	for i := range disputes {
+		result = append(result, toDisputeResponse(disputes[i]))
+	}
+	return result, nil
+}
+
+func (svc *DisputeService) GetDispute(ctx context.Context, customerXID, disputeID string) (web.DisputeResponse, error) {
+	dispute, err := svc.getDispute(ctx, disputeID)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+
+	if dispute.CustomerXID != customerXID {
+		return web.DisputeResponse{}, errors.New("dispute not found")
+	}
+	return toDisputeResponse(dispute), nil
+}
+
+func (svc *DisputeService) GetDisputesByStatus(ctx context.Context, status string) ([]web.DisputeResponse, error) {
+	if status == "" {
+		status = constants.STATUS_OPEN
+	}
+
+	disputes, err := svc.DisputeRepository.GetDisputesByStatus(ctx, status)
+	if err != nil {
+		return []web.DisputeResponse{}, err
+	}
+
+	result := []web.DisputeResponse{}
+	for i := range disputes {
+		result = append(result, toDisputeResponse(disputes[i]))
+	}
+	return result, nil
+}
+
+func (svc *DisputeService) InvestigateDispute(ctx context.Context, disputeID string) (web.DisputeResponse, error) {
+	dispute, err := svc.getDispute(ctx, disputeID)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+
+	if dispute.Status != constants.STATUS_OPEN {
+		return web.DisputeResponse{}, errors.New("dispute is not open")
+	}
+
+	isUpdated, err := svc.DisputeRepository.UpdateDisputeStatus(ctx, dispute.ID, constants.STATUS_OPEN, constants.STATUS_INVESTIGATING)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+	if !isUpdated {
+		return web.DisputeResponse{}, errors.New("dispute changed, please retry")
+	}
+
+	dispute.Status = constants.STATUS_INVESTIGATING
+	dispute.UpdatedAt = time.Now()
+	return toDisputeResponse(dispute), nil
+}
+
+func (svc *DisputeService) ResolveDispute(ctx context.Context, disputeID string, request web.DisputeResolveRequest) (web.DisputeResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+
+	dispute, err := svc.getDispute(ctx, disputeID)
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+
+	if dispute.Status != constants.STATUS_INVESTIGATING {
+		return web.DisputeResponse{}, errors.New("dispute is not under investigation")
+	}
+
+	now := time.Now()
+	dispute.Status = constants.STATUS_RESOLVED_UNFAVORABLE
+	dispute.ResolutionNote = request.Note
+	dispute.UpdatedAt = now
+	dispute.ResolvedAt = &now
+
+	// the reversal uses the disputed transaction as reference_id, so it can
+	// only ever be credited once
+	var reversal *domain.Transaction
+	if request.Outcome == "favorable" {
+		reversal = &domain.Transaction{
+			ID:              uuid.New().String(),
+			WalletID:        dispute.WalletID,
+			CustomerXID:     dispute.CustomerXID,
+			TransactionType: constants.TRANSACTION_TYPE_DISPUTE_REVERSAL,
+			Amount:          dispute.Amount,
+			ReferenceID:     dispute.TransactionID,
+			Status:          constants.STATUS_SUCCESS,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		dispute.Status = constants.STATUS_RESOLVED_FAVORABLE
+		dispute.ReversalID = reversal.ID
+	}
+
+	isResolved, err := svc.DisputeRepository.ResolveDispute(ctx, dispute, constants.STATUS_INVESTIGATING, reversal)
+	if errors.Is(err, domain.ErrTransactionReversed) {
+		return web.DisputeResponse{}, errors.New("transaction is already reversed")
+	}
+	if err != nil {
+		return web.DisputeResponse{}, err
+	}
+	if !isResolved {
+		return web.DisputeResponse{}, errors.New("dispute changed, please retry")
+	}
+
+	return toDisputeResponse(dispute), nil
+}
+
+func (svc *DisputeService) getDispute(ctx context.Context, disputeID string) (domain.Dispute, error) {
+	dispute, err := svc.DisputeRepository.GetDispute(ctx, disputeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Dispute{}, errors.New("dispute not found")
+	}
+	if err != nil {
+		return domain.Dispute{}, err
+	}
+	return dispute, nil
+}
+
+func toDisputeResponse(dispute domain.Dispute) web.DisputeResponse {
+	return web.DisputeResponse{
+		ID:              dispute.ID,
+		TransactionID:   dispute.TransactionID,
+		CustomerXID:     dispute.CustomerXID,
+		TransactionType: dispute.TransactionType,
+		Amount:          dispute.Amount,
+		Reason:          dispute.Reason,
+		Evidence:        dispute.Evidence,
+		Status:          dispute.Status,
+		ResolutionNote:  dispute.ResolutionNote,
+		ReversalID:      dispute.ReversalID,
+		CreatedAt:       dispute.CreatedAt,
+		UpdatedAt:       dispute.UpdatedAt,
+		ResolvedAt:      dispute.ResolvedAt,
+	}
+}
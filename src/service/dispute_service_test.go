@@ -0,0 +1,363 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	disputeSvc service.DisputeServiceItf
+
+	mockDisputeRepository       *mock_repository.MockDisputeRepository
+	mockDisputeWalletRepository *mock_repository.MockWalletRepository
+	mockDisputePayoutRepository *mock_repository.MockPayoutRepository
+)
+
+func provideDisputeTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDisputeRepository = mock_repository.NewMockDisputeRepository(ctrl)
+	mockDisputeWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockDisputePayoutRepository = mock_repository.NewMockPayoutRepository(ctrl)
+	validator := validator.New()
+	disputeSvc = service.NewDisputeService(mockDisputeRepository, mockDisputeWalletRepository, mockDisputePayoutRepository, validator)
+
+	return func() {}
+}
+
+func TestOpenDispute(t *testing.T) {
+	transaction := domain.Transaction{
+		ID:              "mock-transaction",
+		WalletID:        "mock-wallet",
+		CustomerXID:     "1",
+		TransactionType: "payment",
+		Amount:          domain.NewMoney(2500, "IDR"),
+		ReferenceID:     "mock-intent",
+		Status:          "success",
+		CreatedAt:       time.Now().Add(-24 * time.Hour),
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.DisputeCreateRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.DisputeResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - dispute opened",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "goods never arrived",
+				Evidence:      map[string]string{"order_id": "INV-42"},
+			},
+			mockFunc: func() {
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(transaction, nil)
+				mockDisputeRepository.EXPECT().IsTransactionReversed(gomock.Any(), "mock-transaction").Return(false, nil)
+				mockDisputeRepository.EXPECT().CreateDispute(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: nil,
+			wantResult: web.DisputeResponse{
+				TransactionID:   "mock-transaction",
+				CustomerXID:     "1",
+				TransactionType: "payment",
+				Amount:          domain.NewMoney(2500, "IDR"),
+				Reason:          "goods never arrived",
+				Evidence:        map[string]string{"order_id": "INV-42"},
+				Status:          "open",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - transaction of other customers",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "goods never arrived",
+			},
+			mockFunc: func() {
+				other := transaction
+				other.CustomerXID = "2"
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(other, nil)
+			},
+			wantErr:    fmt.Errorf("transaction not found"),
+			wantResult: web.DisputeResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - credits cannot be disputed",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "unknown deposit",
+			},
+			mockFunc: func() {
+				deposit := transaction
+				deposit.TransactionType = "deposit"
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(deposit, nil)
+			},
+			wantErr:    fmt.Errorf("transaction cannot be disputed"),
+			wantResult: web.DisputeResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - transaction too old",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "goods never arrived",
+			},
+			mockFunc: func() {
+				old := transaction
+				old.CreatedAt = time.Now().Add(-121 * 24 * time.Hour)
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(old, nil)
+			},
+			wantErr:    fmt.Errorf("transaction is too old to dispute"),
+			wantResult: web.DisputeResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - transaction already disputed",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "goods never arrived",
+			},
+			mockFunc: func() {
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(transaction, nil)
+				mockDisputeRepository.EXPECT().IsTransactionReversed(gomock.Any(), "mock-transaction").Return(false, nil)
+				mockDisputeRepository.EXPECT().CreateDispute(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr:    fmt.Errorf("transaction already disputed"),
+			wantResult: web.DisputeResponse{},
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - transaction not found",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "missing-transaction",
+				Reason:        "goods never arrived",
+			},
+			mockFunc: func() {
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "missing-transaction").Return(domain.Transaction{}, sql.ErrNoRows)
+			},
+			wantErr:    fmt.Errorf("transaction not found"),
+			wantResult: web.DisputeResponse{},
+		},
+		{
+			testID:   7,
+			testDesc: "Failed - transaction already refunded",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "goods never arrived",
+			},
+			mockFunc: func() {
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(transaction, nil)
+				mockDisputeRepository.EXPECT().IsTransactionReversed(gomock.Any(), "mock-transaction").Return(true, nil)
+			},
+			wantErr:    fmt.Errorf("transaction is already reversed"),
+			wantResult: web.DisputeResponse{},
+		},
+		{
+			testID:   8,
+			testDesc: "Success - withdrawal paid out",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "money never arrived",
+			},
+			mockFunc: func() {
+				withdrawal := transaction
+				withdrawal.TransactionType = "withdrawal"
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(withdrawal, nil)
+				mockDisputePayoutRepository.EXPECT().GetPayoutByTransaction(gomock.Any(), "mock-transaction").Return(domain.Payout{ID: "mock-payout", Status: "succeeded"}, nil)
+				mockDisputeRepository.EXPECT().IsTransactionReversed(gomock.Any(), "mock-transaction").Return(false, nil)
+				mockDisputeRepository.EXPECT().CreateDispute(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: nil,
+			wantResult: web.DisputeResponse{
+				TransactionID:   "mock-transaction",
+				CustomerXID:     "1",
+				TransactionType: "withdrawal",
+				Amount:          domain.NewMoney(2500, "IDR"),
+				Reason:          "money never arrived",
+				Evidence:        map[string]string{},
+				Status:          "open",
+			},
+		},
+		{
+			testID:   9,
+			testDesc: "Failed - withdrawal payout still submitted",
+			payload: web.DisputeCreateRequest{
+				TransactionID: "mock-transaction",
+				Reason:        "money never arrived",
+			},
+			mockFunc: func() {
+				withdrawal := transaction
+				withdrawal.TransactionType = "withdrawal"
+				mockDisputeWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(withdrawal, nil)
+				mockDisputePayoutRepository.EXPECT().GetPayoutByTransaction(gomock.Any(), "mock-transaction").Return(domain.Payout{ID: "mock-payout", Status: "submitted"}, nil)
+			},
+			wantErr:    fmt.Errorf("only withdrawals paid out can be disputed"),
+			wantResult: web.DisputeResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideDisputeTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := disputeSvc.OpenDispute(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.TransactionID, tc.wantResult.TransactionID)
+			assert.Equal(t, got.CustomerXID, tc.wantResult.CustomerXID)
+			assert.Equal(t, got.TransactionType, tc.wantResult.TransactionType)
+			assert.Equal(t, got.Amount, tc.wantResult.Amount)
+			assert.Equal(t, got.Reason, tc.wantResult.Reason)
+			assert.Equal(t, got.Evidence, tc.wantResult.Evidence)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+		})
+	}
+}
+
+func TestResolveDispute(t *testing.T) {
+	dispute := domain.Dispute{
+		ID:              "mock-dispute",
+		TransactionID:   "mock-transaction",
+		WalletID:        "mock-wallet",
+		CustomerXID:     "1",
+		TransactionType: "payment",
+		Amount:          domain.NewMoney(2500, "IDR"),
+		Status:          "investigating",
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.DisputeResolveRequest
+		mockFunc   func()
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - resolved in favor reverses the transaction",
+			payload: web.DisputeResolveRequest{
+				Outcome: "favorable",
+				Note:    "merchant did not respond",
+			},
+			mockFunc: func() {
+				mockDisputeRepository.EXPECT().GetDispute(gomock.Any(), "mock-dispute").Return(dispute, nil)
+				mockDisputeRepository.EXPECT().ResolveDispute(gomock.Any(), gomock.Any(), "investigating", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, resolved domain.Dispute, fromStatus string, reversal *domain.Transaction) (bool, error) {
+						assert.Equal(t, resolved.Status, "resolved_favorable")
+						assert.Equal(t, resolved.ResolutionNote, "merchant did not respond")
+						assert.Equal(t, resolved.ReversalID, reversal.ID)
+						assert.Equal(t, reversal.TransactionType, "dispute_reversal")
+						assert.Equal(t, reversal.WalletID, "mock-wallet")
+						assert.Equal(t, reversal.Amount, domain.NewMoney(2500, "IDR"))
+						assert.Equal(t, reversal.ReferenceID, "mock-transaction")
+						return true, nil
+					})
+			},
+			wantErr:    nil,
+			wantStatus: "resolved_favorable",
+		},
+		{
+			testID:   2,
+			testDesc: "Success - resolved against the customer",
+			payload: web.DisputeResolveRequest{
+				Outcome: "unfavorable",
+			},
+			mockFunc: func() {
+				mockDisputeRepository.EXPECT().GetDispute(gomock.Any(), "mock-dispute").Return(dispute, nil)
+				mockDisputeRepository.EXPECT().ResolveDispute(gomock.Any(), gomock.Any(), "investigating", nil).Return(true, nil)
+			},
+			wantErr:    nil,
+			wantStatus: "resolved_unfavorable",
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - dispute not investigated yet",
+			payload: web.DisputeResolveRequest{
+				Outcome: "favorable",
+			},
+			mockFunc: func() {
+				open := dispute
+				open.Status = "open"
+				mockDisputeRepository.EXPECT().GetDispute(gomock.Any(), "mock-dispute").Return(open, nil)
+			},
+			wantErr:    fmt.Errorf("dispute is not under investigation"),
+			wantStatus: "",
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - dispute resolved in the meantime",
+			payload: web.DisputeResolveRequest{
+				Outcome: "favorable",
+			},
+			mockFunc: func() {
+				mockDisputeRepository.EXPECT().GetDispute(gomock.Any(), "mock-dispute").Return(dispute, nil)
+				mockDisputeRepository.EXPECT().ResolveDispute(gomock.Any(), gomock.Any(), "investigating", gomock.Any()).Return(false, nil)
+			},
+			wantErr:    fmt.Errorf("dispute changed, please retry"),
+			wantStatus: "",
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - unknown outcome",
+			payload: web.DisputeResolveRequest{
+				Outcome: "refund",
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("Key: 'DisputeResolveRequest.Outcome' Error:Field validation for 'Outcome' failed on the 'oneof' tag"),
+			wantStatus: "",
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - transaction reversed while under investigation",
+			payload: web.DisputeResolveRequest{
+				Outcome: "favorable",
+			},
+			mockFunc: func() {
+				mockDisputeRepository.EXPECT().GetDispute(gomock.Any(), "mock-dispute").Return(dispute, nil)
+				mockDisputeRepository.EXPECT().ResolveDispute(gomock.Any(), gomock.Any(), "investigating", gomock.Any()).Return(false, domain.ErrTransactionReversed)
+			},
+			wantErr:    fmt.Errorf("transaction is already reversed"),
+			wantStatus: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideDisputeTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := disputeSvc.ResolveDispute(context.Background(), "mock-dispute", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.Status, tc.wantStatus)
+			assert.NotNil(t, got.ResolvedAt)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type EscrowServiceItf interface {
+	// CreateEscrow takes the amount from the buyer's balance and holds it
+	// for the seller.
+	CreateEscrow(ctx context.Context, customerXID string, request web.EscrowCreateRequest) (web.EscrowResponse, error)
+	GetEscrows(ctx context.Context, customerXID string) ([]web.EscrowResponse, error)
+	// GetEscrow shows the escrow with its history to its buyer and seller.
+	GetEscrow(ctx context.Context, customerXID, escrowID string) (web.EscrowResponse, error)
+	// ReleaseEscrow pays the escrow to the seller, only the buyer can.
+	ReleaseEscrow(ctx context.Context, customerXID, escrowID string) (web.EscrowResponse, error)
+	// RefundEscrow gives the escrow back to the buyer, only the seller can.
+	RefundEscrow(ctx context.Context, customerXID, escrowID string) (web.EscrowResponse, error)
+	// DisputeEscrow holds a funded escrow for an admin to resolve, either
+	// party can open a dispute.
+	DisputeEscrow(ctx context.Context, customerXID, escrowID string, request web.EscrowDisputeRequest) (web.EscrowResponse, error)
+	ResolveEscrow(ctx context.Context, escrowID string, request web.EscrowResolveRequest) (web.EscrowResponse, error)
+	// ExpireEscrows refunds funded escrows past their expiry.
+	ExpireEscrows(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+const defaultEscrowTTL = 14 * 24 * time.Hour
+
+type EscrowService struct {
+	EscrowRepository repository.EscrowRepository
+	WalletRepository repository.WalletRepository
+	Validate         *validator.Validate
+}
+
+func NewEscrowService(escrowRepository repository.EscrowRepository, walletRepository repository.WalletRepository, validate *validator.Validate) EscrowServiceItf {
+	return &EscrowService{
+		EscrowRepository: escrowRepository,
+		WalletRepository: walletRepository,
+		Validate:         validate,
+	}
+}
+
+func (svc *EscrowService) CreateEscrow(ctx context.Context, customerXID string, request web.EscrowCreateRequest) (web.EscrowResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	if request.SellerXID == customerXID {
+		return web.EscrowResponse{}, errors.New("cannot open an escrow with yourself")
+	}
+
+	now := time.Now()
+	expiresAt := request.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(defaultEscrowTTL)
+	}
+	if !expiresAt.After(now) {
+		return web.EscrowResponse{}, errors.New("expires_at must be in the future")
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	seller, err := svc.WalletRepository.GetWallet(ctx, request.SellerXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.EscrowResponse{}, errors.New("seller wallet not found")
+	}
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.EscrowResponse{}, errors.New("wallet disabled")
+	}
+	if seller.Status == constants.STATUS_DISABLED {
+		return web.EscrowResponse{}, errors.New("seller wallet disabled")
+	}
+	if seller.Currency != wallet.Currency {
+		return web.EscrowResponse{}, errors.New("seller wallet currency does not match")
+	}
+
+	amount := domain.NewMoney(request.Amount, wallet.Currency)
+	finalBalance, err := wallet.Balance.Sub(amount)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+	if finalBalance.IsNegative() {
+		return web.EscrowResponse{}, errors.New("insufficient balance")
+	}
+
+	// the seller must be able to hold the amount once it is released
+	_, err = seller.Balance.Add(amount)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	escrow := domain.Escrow{
+		ID:             uuid.New().String(),
+		BuyerXID:       wallet.CustomerXID,
+		BuyerWalletID:  wallet.ID,
+		SellerXID:      seller.CustomerXID,
+		SellerWalletID: seller.ID,
+		Amount:         amount,
+		Description:    request.Description,
+		Status:         constants.STATUS_FUNDED,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	event := domain.EscrowEvent{
+		ID:        uuid.New().String(),
+		EscrowID:  escrow.ID,
+		ToStatus:  constants.STATUS_FUNDED,
+		Actor:     customerXID,
+		CreatedAt: now,
+	}
+	funding := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_ESCROW_FUNDING,
+		Amount:          amount,
+		ReferenceID:     escrow.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isCreated, err := svc.EscrowRepository.CreateEscrow(ctx, escrow, event, funding)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+	if !isCreated {
+		return web.EscrowResponse{}, errors.New("insufficient balance")
+	}
+
+	return toEscrowResponse(escrow, []domain.EscrowEvent{event}), nil
+}
+
+func (svc *EscrowService) GetEscrows(ctx context.Context, customerXID string) ([]web.EscrowResponse, error) {
+	escrows, err := svc.EscrowRepository.GetEscrows(ctx, customerXID)
+	if err != nil {
+		return []web.EscrowResponse{}, err
+	}
+
+	result := []web.EscrowResponse{}
+	for i := range escrows {
+		result = append(result, toEscrowResponse(escrows[i], nil))
+	}
+	return result, nil
+}
+
+func (svc *EscrowService) GetEscrow(ctx context.Context, customerXID, escrowID string) (web.EscrowResponse, error) {
+	escrow, err := svc.getPartyEscrow(ctx, customerXID, escrowID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	events, err := svc.EscrowRepository.GetEscrowEvents(ctx, escrow.ID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+	return toEscrowResponse(escrow, events), nil
+}
+
+func (svc *EscrowService) ReleaseEscrow(ctx context.Context, customerXID, escrowID string) (web.EscrowResponse, error) {
+	escrow, err := svc.getPartyEscrow(ctx, customerXID, escrowID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	if escrow.BuyerXID != customerXID {
+		return web.EscrowResponse{}, errors.New("only the buyer can release the escrow")
+	}
+	if escrow.Status != constants.STATUS_FUNDED {
+		return web.EscrowResponse{}, errors.New("escrow is not funded")
+	}
+
+	return svc.transitionEscrow(ctx, escrow, constants.STATUS_RELEASED, customerXID, "")
+}
+
+func (svc *EscrowService) RefundEscrow(ctx context.Context, customerXID, escrowID string) (web.EscrowResponse, error) {
+	escrow, err := svc.getPartyEscrow(ctx, customerXID, escrowID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	if escrow.SellerXID != customerXID {
+		return web.EscrowResponse{}, errors.New("only the seller can refund the escrow")
+	}
+	// the seller may give in to a dispute without waiting for an admin
+	if escrow.Status != constants.STATUS_FUNDED && escrow.Status != constants.STATUS_DISPUTED {
+		return web.EscrowResponse{}, errors.New("escrow is already settled")
+	}
+
+	return svc.transitionEscrow(ctx, escrow, constants.STATUS_REFUNDED, customerXID, "")
+}
+
+func (svc *EscrowService) DisputeEscrow(ctx context.Context, customerXID, escrowID string, request web.EscrowDisputeRequest) (web.EscrowResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	escrow, err := svc.getPartyEscrow(ctx, customerXID, escrowID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	if escrow.Status != constants.STATUS_FUNDED {
+		return web.EscrowResponse{}, errors.New("escrow is not funded")
+	}
+
+	return svc.transitionEscrow(ctx, escrow, constants.STATUS_DISPUTED, customerXID, request.Reason)
+}
+
+func (svc *EscrowService) ResolveEscrow(ctx context.Context, escrowID string, request web.EscrowResolveRequest) (web.EscrowResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	escrow, err := svc.EscrowRepository.GetEscrow(ctx, escrowID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.EscrowResponse{}, errors.New("escrow not found")
+	}
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+
+	if escrow.Status != constants.STATUS_DISPUTED {
+		return web.EscrowResponse{}, errors.New("escrow is not disputed")
+	}
+
+	status := constants.STATUS_RELEASED
+	if request.Outcome == "refund" {
+		status = constants.STATUS_REFUNDED
+	}
+	return svc.transitionEscrow(ctx, escrow, status, constants.ESCROW_ACTOR_ADMIN, request.Note)
+}
+
+func (svc *EscrowService) ExpireEscrows(ctx context.Context, now time.Time) error {
+	escrows, err := svc.EscrowRepository.GetExpiredEscrows(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range escrows {
+		_, err = svc.transitionEscrow(ctx, escrows[i], constants.STATUS_REFUNDED, constants.ESCROW_ACTOR_SYSTEM, "expired")
+		if err != nil {
+			log.Println("error expire escrow", escrows[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+// getPartyEscrow returns the escrow when the customer is its buyer or seller.
+func (svc *EscrowService) getPartyEscrow(ctx context.Context, customerXID, escrowID string) (domain.Escrow, error) {
+	escrow, err := svc.EscrowRepository.GetEscrow(ctx, escrowID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Escrow{}, errors.New("escrow not found")
+	}
+	if err != nil {
+		return domain.Escrow{}, err
+	}
+
+	if escrow.BuyerXID != customerXID && escrow.SellerXID != customerXID {
+		return domain.Escrow{}, errors.New("escrow not found")
+	}
+	return escrow, nil
+}
+
+// transitionEscrow moves the escrow to status and records who did it. A
+// release credits the seller, a refund the buyer, with the escrow ID as
+// reference_id, so the money can only move once.
+func (svc *EscrowService) transitionEscrow(ctx context.Context, escrow domain.Escrow, status, actor, note string) (web.EscrowResponse, error) {
+	now := time.Now()
+	event := domain.EscrowEvent{
+		ID:         uuid.New().String(),
+		EscrowID:   escrow.ID,
+		FromStatus: escrow.Status,
+		ToStatus:   status,
+		Actor:      actor,
+		Note:       note,
+		CreatedAt:  now,
+	}
+
+	var credit *domain.Transaction
+	switch status {
+	case constants.STATUS_RELEASED:
+		credit = &domain.Transaction{
+			WalletID:        escrow.SellerWalletID,
+			CustomerXID:     escrow.SellerXID,
+			TransactionType: constants.TRANSACTION_TYPE_ESCROW_RELEASE,
+		}
+	case constants.STATUS_REFUNDED:
+		credit = &domain.Transaction{
+			WalletID:        escrow.BuyerWalletID,
+			CustomerXID:     escrow.BuyerXID,
+			TransactionType: constants.TRANSACTION_TYPE_ESCROW_REFUND,
+		}
+	}
+	if credit != nil {
+		credit.ID = uuid.New().String()
+		credit.Amount = escrow.Amount
+		credit.ReferenceID = escrow.ID
+		credit.Status = constants.STATUS_SUCCESS
+		credit.CreatedAt = now
+		credit.UpdatedAt = now
+	}
+
+	isMoved, err := svc.EscrowRepository.TransitionEscrow(ctx, event, credit)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+	if !isMoved {
+		return web.EscrowResponse{}, errors.New("escrow changed, please retry")
+	}
+
+	escrow.Status = status
+	escrow.UpdatedAt = now
+	events, err := svc.EscrowRepository.GetEscrowEvents(ctx, escrow.ID)
+	if err != nil {
+		return web.EscrowResponse{}, err
+	}
+	return toEscrowResponse(escrow, events), nil
+}
+
+func toEscrowResponse(escrow domain.Escrow, events []domain.EscrowEvent) web.EscrowResponse {
+	result := web.EscrowResponse{
+		ID:          escrow.ID,
+		BuyerXID:    escrow.BuyerXID,
+		SellerXID:   escrow.SellerXID,
+		Amount:      escrow.Amount,
+		Description: escrow.Description,
+		Status:      escrow.Status,
+		ExpiresAt:   escrow.ExpiresAt,
+		CreatedAt:   escrow.CreatedAt,
+		UpdatedAt:   escrow.UpdatedAt,
+	}
+	for i := range events {
+		result.Events = append(result.Events, web.EscrowEventResponse{
+			FromStatus: events[i].FromStatus,
+			ToStatus:   events[i].ToStatus,
+			Actor:      events[i].Actor,
+			Note:       events[i].Note,
+			CreatedAt:  events[i].CreatedAt,
+		})
+	}
+	return result
+}
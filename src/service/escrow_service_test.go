@@ -0,0 +1,286 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	escrowSvc service.EscrowServiceItf
+
+	mockEscrowRepository       *mock_repository.MockEscrowRepository
+	mockEscrowWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideEscrowTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEscrowRepository = mock_repository.NewMockEscrowRepository(ctrl)
+	mockEscrowWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	escrowSvc = service.NewEscrowService(mockEscrowRepository, mockEscrowWalletRepository, validator)
+
+	return func() {}
+}
+
+func TestCreateEscrow(t *testing.T) {
+	buyer := domain.Wallet{
+		ID:          "buyer-wallet",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.NewMoney(5000, "IDR"),
+	}
+	seller := domain.Wallet{
+		ID:          "seller-wallet",
+		CustomerXID: "2",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.NewMoney(0, "IDR"),
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.EscrowCreateRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.EscrowResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - escrow funded from the buyer",
+			payload: web.EscrowCreateRequest{
+				SellerXID:   "2",
+				Amount:      3000,
+				Description: "Used bicycle",
+			},
+			mockFunc: func() {
+				mockEscrowWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(buyer, nil)
+				mockEscrowWalletRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(seller, nil)
+				mockEscrowRepository.EXPECT().CreateEscrow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, escrow domain.Escrow, event domain.EscrowEvent, funding domain.Transaction) (bool, error) {
+						assert.Equal(t, escrow.SellerWalletID, "seller-wallet")
+						assert.WithinDuration(t, escrow.ExpiresAt, time.Now().Add(14*24*time.Hour), time.Minute)
+						assert.Equal(t, event.ToStatus, "funded")
+						assert.Equal(t, event.Actor, "1")
+						assert.Equal(t, funding.TransactionType, "escrow_funding")
+						assert.Equal(t, funding.WalletID, "buyer-wallet")
+						assert.Equal(t, funding.ReferenceID, escrow.ID)
+						return true, nil
+					})
+			},
+			wantErr: nil,
+			wantResult: web.EscrowResponse{
+				BuyerXID:    "1",
+				SellerXID:   "2",
+				Amount:      domain.NewMoney(3000, "IDR"),
+				Description: "Used bicycle",
+				Status:      "funded",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - escrow with yourself",
+			payload: web.EscrowCreateRequest{
+				SellerXID: "1",
+				Amount:    3000,
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("cannot open an escrow with yourself"),
+			wantResult: web.EscrowResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - seller wallet not found",
+			payload: web.EscrowCreateRequest{
+				SellerXID: "3",
+				Amount:    3000,
+			},
+			mockFunc: func() {
+				mockEscrowWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(buyer, nil)
+				mockEscrowWalletRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(domain.Wallet{}, sql.ErrNoRows)
+			},
+			wantErr:    fmt.Errorf("seller wallet not found"),
+			wantResult: web.EscrowResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - insufficient balance",
+			payload: web.EscrowCreateRequest{
+				SellerXID: "2",
+				Amount:    6000,
+			},
+			mockFunc: func() {
+				mockEscrowWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(buyer, nil)
+				mockEscrowWalletRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(seller, nil)
+			},
+			wantErr:    fmt.Errorf("insufficient balance"),
+			wantResult: web.EscrowResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideEscrowTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := escrowSvc.CreateEscrow(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.BuyerXID, tc.wantResult.BuyerXID)
+			assert.Equal(t, got.SellerXID, tc.wantResult.SellerXID)
+			assert.Equal(t, got.Amount, tc.wantResult.Amount)
+			assert.Equal(t, got.Description, tc.wantResult.Description)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, len(got.Events), 1)
+		})
+	}
+}
+
+func TestReleaseEscrow(t *testing.T) {
+	escrow := domain.Escrow{
+		ID:             "mock-escrow",
+		BuyerXID:       "1",
+		BuyerWalletID:  "buyer-wallet",
+		SellerXID:      "2",
+		SellerWalletID: "seller-wallet",
+		Amount:         domain.NewMoney(3000, "IDR"),
+		Status:         "funded",
+	}
+
+	testCases := []struct {
+		testID      int
+		testDesc    string
+		customerXID string
+		mockFunc    func()
+		wantErr     error
+	}{
+		{
+			testID:      1,
+			testDesc:    "Success - buyer releases to the seller",
+			customerXID: "1",
+			mockFunc: func() {
+				mockEscrowRepository.EXPECT().GetEscrow(gomock.Any(), "mock-escrow").Return(escrow, nil)
+				mockEscrowRepository.EXPECT().TransitionEscrow(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, event domain.EscrowEvent, credit *domain.Transaction) (bool, error) {
+						assert.Equal(t, event.FromStatus, "funded")
+						assert.Equal(t, event.ToStatus, "released")
+						assert.Equal(t, event.Actor, "1")
+						assert.Equal(t, credit.TransactionType, "escrow_release")
+						assert.Equal(t, credit.WalletID, "seller-wallet")
+						assert.Equal(t, credit.Amount, domain.NewMoney(3000, "IDR"))
+						assert.Equal(t, credit.ReferenceID, "mock-escrow")
+						return true, nil
+					})
+				mockEscrowRepository.EXPECT().GetEscrowEvents(gomock.Any(), "mock-escrow").Return([]domain.EscrowEvent{
+					{ToStatus: "funded", Actor: "1"},
+					{FromStatus: "funded", ToStatus: "released", Actor: "1"},
+				}, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			testID:      2,
+			testDesc:    "Failed - seller cannot release",
+			customerXID: "2",
+			mockFunc: func() {
+				mockEscrowRepository.EXPECT().GetEscrow(gomock.Any(), "mock-escrow").Return(escrow, nil)
+			},
+			wantErr: fmt.Errorf("only the buyer can release the escrow"),
+		},
+		{
+			testID:      3,
+			testDesc:    "Failed - escrow of other customers",
+			customerXID: "3",
+			mockFunc: func() {
+				mockEscrowRepository.EXPECT().GetEscrow(gomock.Any(), "mock-escrow").Return(escrow, nil)
+			},
+			wantErr: fmt.Errorf("escrow not found"),
+		},
+		{
+			testID:      4,
+			testDesc:    "Failed - escrow refunded in the meantime",
+			customerXID: "1",
+			mockFunc: func() {
+				mockEscrowRepository.EXPECT().GetEscrow(gomock.Any(), "mock-escrow").Return(escrow, nil)
+				mockEscrowRepository.EXPECT().TransitionEscrow(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: fmt.Errorf("escrow changed, please retry"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideEscrowTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := escrowSvc.ReleaseEscrow(context.Background(), tc.customerXID, "mock-escrow")
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.Status, "released")
+			assert.Equal(t, len(got.Events), 2)
+		})
+	}
+}
+
+func TestResolveEscrow(t *testing.T) {
+	testDep := provideEscrowTest(t)
+	defer testDep()
+
+	escrow := domain.Escrow{
+		ID:             "mock-escrow",
+		BuyerXID:       "1",
+		BuyerWalletID:  "buyer-wallet",
+		SellerXID:      "2",
+		SellerWalletID: "seller-wallet",
+		Amount:         domain.NewMoney(3000, "IDR"),
+		Status:         "funded",
+	}
+
+	// a funded escrow has to be disputed first
+	mockEscrowRepository.EXPECT().GetEscrow(gomock.Any(), "mock-escrow").Return(escrow, nil)
+	_, err := escrowSvc.ResolveEscrow(context.Background(), "mock-escrow", web.EscrowResolveRequest{Outcome: "refund"})
+	assert.Equal(t, err.Error(), "escrow is not disputed")
+
+	escrow.Status = "disputed"
+	mockEscrowRepository.EXPECT().GetEscrow(gomock.Any(), "mock-escrow").Return(escrow, nil)
+	mockEscrowRepository.EXPECT().TransitionEscrow(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, event domain.EscrowEvent, credit *domain.Transaction) (bool, error) {
+			assert.Equal(t, event.ToStatus, "refunded")
+			assert.Equal(t, event.Actor, "admin")
+			assert.Equal(t, event.Note, "item never shipped")
+			assert.Equal(t, credit.TransactionType, "escrow_refund")
+			assert.Equal(t, credit.WalletID, "buyer-wallet")
+			return true, nil
+		})
+	mockEscrowRepository.EXPECT().GetEscrowEvents(gomock.Any(), "mock-escrow").Return(nil, nil)
+
+	got, err := escrowSvc.ResolveEscrow(context.Background(), "mock-escrow", web.EscrowResolveRequest{
+		Outcome: "refund",
+		Note:    "item never shipped",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got.Status, "refunded")
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type FxServiceItf interface {
+	SetRate(ctx context.Context, request web.FxRateRequest) (web.FxRateResponse, error)
+	LoadRates(ctx context.Context, path string) error
+	GetRates(ctx context.Context) ([]web.FxRateResponse, error)
+	CreateQuote(ctx context.Context, customerXID string, request web.FxQuoteRequest) (web.FxQuoteResponse, error)
+	ExecuteExchange(ctx context.Context, customerXID string, request web.FxExchangeRequest) (web.FxExchangeResponse, error)
+}
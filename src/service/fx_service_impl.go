@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type FxService struct {
+	WalletRepository repository.WalletRepository
+	FxRepository     repository.FxRepository
+	Validate         *validator.Validate
+	QuoteTTL         time.Duration
+}
+
+func NewFxService(walletRepository repository.WalletRepository, fxRepository repository.FxRepository, validate *validator.Validate, quoteTTL time.Duration) FxServiceItf {
+	return &FxService{
+		WalletRepository: walletRepository,
+		FxRepository:     fxRepository,
+		Validate:         validate,
+		QuoteTTL:         quoteTTL,
+	}
+}
+
+func (svc *FxService) SetRate(ctx context.Context, request web.FxRateRequest) (web.FxRateResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.FxRateResponse{}, err
+	}
+
+	if !isSupportedCurrency(request.BaseCurrency) || !isSupportedCurrency(request.QuoteCurrency) {
+		return web.FxRateResponse{}, errors.New("unsupported currency")
+	}
+
+	rate := domain.FxRate{
+		BaseCurrency:  request.BaseCurrency,
+		QuoteCurrency: request.QuoteCurrency,
+		Bid:           request.Bid,
+		Ask:           request.Ask,
+		UpdatedAt:     time.Now(),
+	}
+	err = svc.FxRepository.UpsertRate(ctx, rate)
+	if err != nil {
+		return web.FxRateResponse{}, err
+	}
+
+	return web.FxRateResponse{
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Bid:           rate.Bid,
+		Ask:           rate.Ask,
+		UpdatedAt:     rate.UpdatedAt,
+	}, nil
+}
+
+// LoadRates reads a JSON array of rates from path and stores every entry.
+func (svc *FxService) LoadRates(ctx context.Context, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var requests []web.FxRateRequest
+	err = json.Unmarshal(raw, &requests)
+	if err != nil {
+		return err
+	}
+
+	for i := range requests {
+		_, err = svc.SetRate(ctx, requests[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (svc *FxService) GetRates(ctx context.Context) ([]web.FxRateResponse, error) {
+	rates, err := svc.FxRepository.GetRates(ctx)
+	if err != nil {
+		return []web.FxRateResponse{}, err
+	}
+
+	result := []web.FxRateResponse{}
+	for i := range rates {
+		result = append(result, web.FxRateResponse{
+			BaseCurrency:  rates[i].BaseCurrency,
+			QuoteCurrency: rates[i].QuoteCurrency,
+			Bid:           rates[i].Bid,
+			Ask:           rates[i].Ask,
+			UpdatedAt:     rates[i].UpdatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (svc *FxService) CreateQuote(ctx context.Context, customerXID string, request web.FxQuoteRequest) (web.FxQuoteResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.FxQuoteResponse{}, err
+	}
+
+	if !isSupportedCurrency(request.FromCurrency) || !isSupportedCurrency(request.ToCurrency) {
+		return web.FxQuoteResponse{}, errors.New("unsupported currency")
+	}
+
+	// both wallets must exist before a rate is locked for the customer
+	_, err = svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, request.FromCurrency)
+	if err != nil {
+		return web.FxQuoteResponse{}, err
+	}
+	_, err = svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, request.ToCurrency)
+	if err != nil {
+		return web.FxQuoteResponse{}, err
+	}
+
+	rate, err := svc.customerRate(ctx, request.FromCurrency, request.ToCurrency)
+	if err != nil {
+		return web.FxQuoteResponse{}, err
+	}
+
+	sourceAmount := domain.NewMoney(request.Amount, request.FromCurrency)
+	targetAmount, err := convertAmount(sourceAmount, request.ToCurrency, rate)
+	if err != nil {
+		return web.FxQuoteResponse{}, err
+	}
+	if !targetAmount.IsPositive() {
+		return web.FxQuoteResponse{}, errors.New("amount too small to exchange")
+	}
+
+	rateFloat, _ := rate.Float64()
+	now := time.Now()
+	quote := domain.FxQuote{
+		ID:           uuid.New().String(),
+		CustomerXID:  customerXID,
+		FromCurrency: request.FromCurrency,
+		ToCurrency:   request.ToCurrency,
+		Rate:         rateFloat,
+		SourceAmount: sourceAmount,
+		TargetAmount: targetAmount,
+		Status:       constants.STATUS_PENDING,
+		ExpiresAt:    now.Add(svc.QuoteTTL),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err = svc.FxRepository.CreateQuote(ctx, quote)
+	if err != nil {
+		return web.FxQuoteResponse{}, err
+	}
+
+	return web.FxQuoteResponse{
+		ID:           quote.ID,
+		FromCurrency: quote.FromCurrency,
+		ToCurrency:   quote.ToCurrency,
+		Rate:         quote.Rate,
+		SourceAmount: quote.SourceAmount,
+		TargetAmount: quote.TargetAmount,
+		Status:       quote.Status,
+		ExpiresAt:    quote.ExpiresAt,
+	}, nil
+}
+
+func (svc *FxService) ExecuteExchange(ctx context.Context, customerXID string, request web.FxExchangeRequest) (web.FxExchangeResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.FxExchangeResponse{}, err
+	}
+
+	quote, err := svc.FxRepository.GetQuote(ctx, request.QuoteID)
+	if err != nil {
+		return web.FxExchangeResponse{}, err
+	}
+
+	if quote.CustomerXID != customerXID {
+		return web.FxExchangeResponse{}, errors.New("quote not found")
+	}
+
+	now := time.Now()
+	if quote.Status != constants.STATUS_PENDING {
+		return web.FxExchangeResponse{}, errors.New("quote already executed")
+	}
+	if !now.Before(quote.ExpiresAt) {
+		return web.FxExchangeResponse{}, errors.New("quote expired")
+	}
+
+	fromWallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, quote.FromCurrency)
+	if err != nil {
+		return web.FxExchangeResponse{}, err
+	}
+	toWallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, quote.ToCurrency)
+	if err != nil {
+		return web.FxExchangeResponse{}, err
+	}
+
+	// check wallet status
+	if fromWallet.Status == constants.STATUS_DISABLED || toWallet.Status == constants.STATUS_DISABLED {
+		return web.FxExchangeResponse{}, errors.New("wallet disabled")
+	}
+
+	// compare amount with balance
+	cmp, err := quote.SourceAmount.Cmp(fromWallet.Balance)
+	if err != nil {
+		return web.FxExchangeResponse{}, err
+	}
+	if cmp > 0 {
+		return web.FxExchangeResponse{}, errors.New("insufficient balance")
+	}
+
+	debit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        fromWallet.ID,
+		CustomerXID:     customerXID,
+		TransactionType: constants.TRANSACTION_TYPE_EXCHANGE_DEBIT,
+		Amount:          quote.SourceAmount,
+		ReferenceID:     quote.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	credit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        toWallet.ID,
+		CustomerXID:     customerXID,
+		TransactionType: constants.TRANSACTION_TYPE_EXCHANGE_CREDIT,
+		Amount:          quote.TargetAmount,
+		ReferenceID:     quote.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isExecuted, err := svc.FxRepository.ExecuteExchange(ctx, quote.ID, debit, credit, now)
+	if err != nil {
+		return web.FxExchangeResponse{}, err
+	}
+	if !isExecuted {
+		return web.FxExchangeResponse{}, errors.New("quote expired or insufficient balance")
+	}
+
+	return web.FxExchangeResponse{
+		QuoteID: quote.ID,
+		Debit: web.TransactionResponse{
+			ID:           debit.ID,
+			Status:       debit.Status,
+			TransactedAt: debit.CreatedAt,
+			Type:         debit.TransactionType,
+			Amount:       debit.Amount,
+			ReferenceID:  debit.ReferenceID,
+		},
+		Credit: web.TransactionResponse{
+			ID:           credit.ID,
+			Status:       credit.Status,
+			TransactedAt: credit.CreatedAt,
+			Type:         credit.TransactionType,
+			Amount:       credit.Amount,
+			ReferenceID:  credit.ReferenceID,
+		},
+	}, nil
+}
+
+// customerRate returns how many units of toCurrency the customer receives
+// for one unit of fromCurrency. A rate stored in the opposite direction is
+// inverted using its ask side.
+func (svc *FxService) customerRate(ctx context.Context, fromCurrency, toCurrency string) (*big.Rat, error) {
+	rate, err := svc.FxRepository.GetRate(ctx, fromCurrency, toCurrency)
+	if err == nil {
+		return floatToRat(rate.Bid), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	rate, err = svc.FxRepository.GetRate(ctx, toCurrency, fromCurrency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("exchange rate not available")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Inv(floatToRat(rate.Ask)), nil
+}
+
+func isSupportedCurrency(currency string) bool {
+	_, ok := constants.CurrencyMinorUnits[currency]
+	return ok
+}
+
+// floatToRat keeps the decimal the rate was entered with, so 0.29 stays
+// 29/100 instead of its nearest binary approximation.
+func floatToRat(value float64) *big.Rat {
+	result, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+	return result
+}
+
+// convertAmount converts amount into toCurrency minor units, rounding down so
+// we never credit more than the rate allows.
+func convertAmount(amount domain.Money, toCurrency string, rate *big.Rat) (domain.Money, error) {
+	result := new(big.Rat).Mul(big.NewRat(amount.Amount, 1), rate)
+
+	scale := constants.CurrencyMinorUnits[toCurrency] - constants.CurrencyMinorUnits[amount.Currency]
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(scale))), nil)
+	if scale >= 0 {
+		result.Mul(result, new(big.Rat).SetInt(factor))
+	} else {
+		result.Quo(result, new(big.Rat).SetInt(factor))
+	}
+
+	converted := new(big.Int).Quo(result.Num(), result.Denom())
+	if !converted.IsInt64() {
+		return domain.Money{}, domain.ErrAmountOverflow
+	}
+	return domain.NewMoney(converted.Int64(), toCurrency), nil
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
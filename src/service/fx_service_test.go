@@ -0,0 +1,353 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	fxSvc service.FxServiceItf
+
+	mockFxWalletRepository *mock_repository.MockWalletRepository
+	mockFxRepository       *mock_repository.MockFxRepository
+)
+
+func provideFxTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFxWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockFxRepository = mock_repository.NewMockFxRepository(ctrl)
+	validator := validator.New()
+	fxSvc = service.NewFxService(mockFxWalletRepository, mockFxRepository, validator, time.Minute)
+
+	return func() {}
+}
+
+func TestCreateQuote(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.FxQuoteRequest
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.FxQuoteResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - direct rate uses bid",
+			args: args{
+				customerXID: "1",
+				payload: web.FxQuoteRequest{
+					FromCurrency: "USD",
+					ToCurrency:   "IDR",
+					Amount:       1050,
+				},
+			},
+			mockFunc: func() {
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{ID: "usd"}, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{ID: "idr"}, nil)
+				mockFxRepository.EXPECT().GetRate(gomock.Any(), "USD", "IDR").Return(domain.FxRate{
+					BaseCurrency:  "USD",
+					QuoteCurrency: "IDR",
+					Bid:           15450,
+					Ask:           15650,
+				}, nil)
+				mockFxRepository.EXPECT().CreateQuote(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.FxQuoteResponse{
+				FromCurrency: "USD",
+				ToCurrency:   "IDR",
+				SourceAmount: domain.Money{Amount: 1050, Currency: "USD"},
+				TargetAmount: domain.Money{Amount: 162225, Currency: "IDR"},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - inverse rate uses ask",
+			args: args{
+				customerXID: "1",
+				payload: web.FxQuoteRequest{
+					FromCurrency: "IDR",
+					ToCurrency:   "USD",
+					Amount:       156520,
+				},
+			},
+			mockFunc: func() {
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{ID: "idr"}, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{ID: "usd"}, nil)
+				mockFxRepository.EXPECT().GetRate(gomock.Any(), "IDR", "USD").Return(domain.FxRate{}, sql.ErrNoRows)
+				mockFxRepository.EXPECT().GetRate(gomock.Any(), "USD", "IDR").Return(domain.FxRate{
+					BaseCurrency:  "USD",
+					QuoteCurrency: "IDR",
+					Bid:           15450,
+					Ask:           15650,
+				}, nil)
+				mockFxRepository.EXPECT().CreateQuote(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.FxQuoteResponse{
+				FromCurrency: "IDR",
+				ToCurrency:   "USD",
+				SourceAmount: domain.Money{Amount: 156520, Currency: "IDR"},
+				TargetAmount: domain.Money{Amount: 1000, Currency: "USD"},
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - error validate",
+			args: args{
+				customerXID: "1",
+				payload: web.FxQuoteRequest{
+					FromCurrency: "USD",
+					ToCurrency:   "USD",
+					Amount:       1000,
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.FxQuoteResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - currency wallet not opened",
+			args: args{
+				customerXID: "1",
+				payload: web.FxQuoteRequest{
+					FromCurrency: "USD",
+					ToCurrency:   "IDR",
+					Amount:       1000,
+				},
+			},
+			mockFunc: func() {
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{}, sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: web.FxQuoteResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - rate not available",
+			args: args{
+				customerXID: "1",
+				payload: web.FxQuoteRequest{
+					FromCurrency: "USD",
+					ToCurrency:   "IDR",
+					Amount:       1000,
+				},
+			},
+			mockFunc: func() {
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{ID: "usd"}, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{ID: "idr"}, nil)
+				mockFxRepository.EXPECT().GetRate(gomock.Any(), "USD", "IDR").Return(domain.FxRate{}, sql.ErrNoRows)
+				mockFxRepository.EXPECT().GetRate(gomock.Any(), "IDR", "USD").Return(domain.FxRate{}, sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: web.FxQuoteResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideFxTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := fxSvc.CreateQuote(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.FromCurrency, tc.wantResult.FromCurrency)
+			assert.Equal(t, got.ToCurrency, tc.wantResult.ToCurrency)
+			assert.Equal(t, got.SourceAmount, tc.wantResult.SourceAmount)
+			assert.Equal(t, got.TargetAmount, tc.wantResult.TargetAmount)
+		})
+	}
+}
+
+func TestExecuteExchange(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.FxExchangeRequest
+		}
+	)
+
+	pendingQuote := domain.FxQuote{
+		ID:           "mock-quote",
+		CustomerXID:  "1",
+		FromCurrency: "IDR",
+		ToCurrency:   "USD",
+		SourceAmount: domain.Money{Amount: 156520, Currency: "IDR"},
+		TargetAmount: domain.Money{Amount: 1000, Currency: "USD"},
+		Status:       "pending",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.FxExchangeResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			args: args{
+				customerXID: "1",
+				payload:     web.FxExchangeRequest{QuoteID: "mock-quote"},
+			},
+			mockFunc: func() {
+				mockFxRepository.EXPECT().GetQuote(gomock.Any(), "mock-quote").Return(pendingQuote, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+					ID:      "idr",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 200000, Currency: "IDR"},
+				}, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{
+					ID:     "usd",
+					Status: "enabled",
+				}, nil)
+				mockFxRepository.EXPECT().ExecuteExchange(gomock.Any(), "mock-quote", gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.FxExchangeResponse{
+				QuoteID: "mock-quote",
+				Debit: web.TransactionResponse{
+					Type:        "exchange_debit",
+					Amount:      domain.Money{Amount: 156520, Currency: "IDR"},
+					ReferenceID: "mock-quote",
+				},
+				Credit: web.TransactionResponse{
+					Type:        "exchange_credit",
+					Amount:      domain.Money{Amount: 1000, Currency: "USD"},
+					ReferenceID: "mock-quote",
+				},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - quote owned by other customer",
+			args: args{
+				customerXID: "2",
+				payload:     web.FxExchangeRequest{QuoteID: "mock-quote"},
+			},
+			mockFunc: func() {
+				mockFxRepository.EXPECT().GetQuote(gomock.Any(), "mock-quote").Return(pendingQuote, nil)
+			},
+			wantErr:    true,
+			wantResult: web.FxExchangeResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - quote expired",
+			args: args{
+				customerXID: "1",
+				payload:     web.FxExchangeRequest{QuoteID: "mock-quote"},
+			},
+			mockFunc: func() {
+				expiredQuote := pendingQuote
+				expiredQuote.ExpiresAt = time.Now().Add(-time.Second)
+				mockFxRepository.EXPECT().GetQuote(gomock.Any(), "mock-quote").Return(expiredQuote, nil)
+			},
+			wantErr:    true,
+			wantResult: web.FxExchangeResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - insufficient balance",
+			args: args{
+				customerXID: "1",
+				payload:     web.FxExchangeRequest{QuoteID: "mock-quote"},
+			},
+			mockFunc: func() {
+				mockFxRepository.EXPECT().GetQuote(gomock.Any(), "mock-quote").Return(pendingQuote, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+					ID:      "idr",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 100, Currency: "IDR"},
+				}, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{
+					ID:     "usd",
+					Status: "enabled",
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.FxExchangeResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - quote consumed concurrently",
+			args: args{
+				customerXID: "1",
+				payload:     web.FxExchangeRequest{QuoteID: "mock-quote"},
+			},
+			mockFunc: func() {
+				mockFxRepository.EXPECT().GetQuote(gomock.Any(), "mock-quote").Return(pendingQuote, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+					ID:      "idr",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 200000, Currency: "IDR"},
+				}, nil)
+				mockFxWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{
+					ID:     "usd",
+					Status: "enabled",
+				}, nil)
+				mockFxRepository.EXPECT().ExecuteExchange(gomock.Any(), "mock-quote", gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr:    true,
+			wantResult: web.FxExchangeResponse{},
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - error GetQuote",
+			args: args{
+				customerXID: "1",
+				payload:     web.FxExchangeRequest{QuoteID: "mock-quote"},
+			},
+			mockFunc: func() {
+				mockFxRepository.EXPECT().GetQuote(gomock.Any(), "mock-quote").Return(domain.FxQuote{}, fmt.Errorf("error"))
+			},
+			wantErr:    true,
+			wantResult: web.FxExchangeResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideFxTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := fxSvc.ExecuteExchange(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.QuoteID, tc.wantResult.QuoteID)
+			assert.Equal(t, got.Debit.Type, tc.wantResult.Debit.Type)
+			assert.Equal(t, got.Debit.Amount, tc.wantResult.Debit.Amount)
+			assert.Equal(t, got.Credit.Type, tc.wantResult.Credit.Type)
+			assert.Equal(t, got.Credit.Amount, tc.wantResult.Credit.Amount)
+			assert.Equal(t, got.Credit.ReferenceID, tc.wantResult.Credit.ReferenceID)
+		})
+	}
+}
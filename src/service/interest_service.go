@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type InterestServiceItf interface {
+	SetInterestRate(ctx context.Context, request web.InterestRateRequest) (web.InterestRateResponse, error)
+	// RunInterest accrues interest for the day that just closed and posts the
+	// accruals of every finished month.
+	RunInterest(ctx context.Context, now time.Time) error
+	GetInterestPreview(ctx context.Context, customerXID string) (web.InterestPreviewResponse, error)
+}
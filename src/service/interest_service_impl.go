@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+const (
+	// accrualScale is the number of decimal places of a minor unit kept on
+	// every daily accrual.
+	accrualScale = 6
+	// interestBatchSize bounds how many wallets, pockets or postings are read
+	// at a time.
+	interestBatchSize = 100
+)
+
+type InterestService struct {
+	InterestRepository repository.InterestRepository
+	WalletRepository   repository.WalletRepository
+	Validate           *validator.Validate
+	// PocketRate is the annual rate paid on every pocket balance
+	PocketRate float64
+	// Location decides where an interest day starts and ends
+	Location *time.Location
+}
+
+func NewInterestService(interestRepository repository.InterestRepository, walletRepository repository.WalletRepository, validate *validator.Validate, pocketRate float64, location *time.Location) InterestServiceItf {
+	return &InterestService{
+		InterestRepository: interestRepository,
+		WalletRepository:   walletRepository,
+		Validate:           validate,
+		PocketRate:         pocketRate,
+		Location:           location,
+	}
+}
+
+func (svc *InterestService) SetInterestRate(ctx context.Context, request web.InterestRateRequest) (web.InterestRateResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.InterestRateResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, request.CustomerXID, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.InterestRateResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.InterestRateResponse{}, err
+	}
+
+	err = svc.InterestRepository.UpdateInterestRate(ctx, wallet.ID, request.InterestRate)
+	if err != nil {
+		return web.InterestRateResponse{}, err
+	}
+
+	return web.InterestRateResponse{
+		WalletID:     wallet.ID,
+		Currency:     wallet.Currency,
+		InterestRate: request.InterestRate,
+	}, nil
+}
+
+func (svc *InterestService) RunInterest(ctx context.Context, now time.Time) error {
+	local := now.In(svc.Location)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	// every day up to yesterday is accrued, including the days missed by
+	// earlier runs
+	err := svc.accrueWallets(ctx, today, now)
+	if err != nil {
+		return err
+	}
+
+	err = svc.accruePockets(ctx, today, now)
+	if err != nil {
+		return err
+	}
+
+	// accruals are posted once their month is over
+	return svc.postInterest(ctx, today, now)
+}
+
+func (svc *InterestService) accrueWallets(ctx context.Context, today, now time.Time) error {
+	afterID := ""
+	for {
+		wallets, err := svc.InterestRepository.GetInterestBearingWallets(ctx, afterID, interestBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range wallets {
+			err = svc.accrueWallet(ctx, wallets[i], today, now)
+			if err != nil {
+				log.Println("error accrue interest for wallet", wallets[i].ID+":", err.Error())
+			}
+		}
+
+		if len(wallets) < interestBatchSize {
+			return nil
+		}
+		afterID = wallets[len(wallets)-1].ID
+	}
+}
+
+func (svc *InterestService) accruePockets(ctx context.Context, today, now time.Time) error {
+	if svc.PocketRate <= 0 {
+		return nil
+	}
+
+	afterID := ""
+	for {
+		pockets, err := svc.InterestRepository.GetInterestBearingPockets(ctx, afterID, interestBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range pockets {
+			err = svc.accruePocket(ctx, pockets[i], today, now)
+			if err != nil {
+				log.Println("error accrue interest for pocket", pockets[i].ID+":", err.Error())
+			}
+		}
+
+		if len(pockets) < interestBatchSize {
+			return nil
+		}
+		afterID = pockets[len(pockets)-1].ID
+	}
+}
+
+func (svc *InterestService) accrueWallet(ctx context.Context, wallet domain.Wallet, today, now time.Time) error {
+	from, err := svc.firstUnaccruedDate(ctx, wallet.ID, "", today)
+	if err != nil || !from.Before(today) {
+		return err
+	}
+
+	history, err := svc.InterestRepository.GetWalletBalanceHistory(ctx, wallet.ID, svc.endOfDay(from))
+	if err != nil {
+		return err
+	}
+
+	return svc.accrueDays(ctx, wallet.ID, "", history, wallet.InterestRate, from, today, now)
+}
+
+func (svc *InterestService) accruePocket(ctx context.Context, pocket domain.Pocket, today, now time.Time) error {
+	from, err := svc.firstUnaccruedDate(ctx, pocket.WalletID, pocket.ID, today)
+	if err != nil || !from.Before(today) {
+		return err
+	}
+
+	history, err := svc.InterestRepository.GetPocketBalanceHistory(ctx, pocket.ID, svc.endOfDay(from))
+	if err != nil {
+		return err
+	}
+
+	return svc.accrueDays(ctx, pocket.WalletID, pocket.ID, history, svc.PocketRate, from, today, now)
+}
+
+// firstUnaccruedDate is the day after the last accrual of the main balance
+// or the pocket. A source that never accrued starts with yesterday.
+func (svc *InterestService) firstUnaccruedDate(ctx context.Context, walletID, pocketID string, today time.Time) (time.Time, error) {
+	lastAccrualDate, err := svc.InterestRepository.GetLastInterestAccrualDate(ctx, walletID, pocketID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if lastAccrualDate == nil {
+		return today.AddDate(0, 0, -1), nil
+	}
+	return lastAccrualDate.AddDate(0, 0, 1), nil
+}
+
+// accrueDays accrues every day from from up to today on the balance the day
+// closed with. A day that closed empty or overdrawn earns nothing.
+func (svc *InterestService) accrueDays(ctx context.Context, walletID, pocketID string, history domain.BalanceHistory, rate float64, from, today, now time.Time) error {
+	for accrualDate := from; accrualDate.Before(today); accrualDate = accrualDate.AddDate(0, 0, 1) {
+		balance, err := history.BalanceAt(svc.endOfDay(accrualDate))
+		if err != nil {
+			return err
+		}
+		if !balance.IsPositive() {
+			continue
+		}
+
+		err = svc.accrue(ctx, walletID, pocketID, balance, rate, accrualDate, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endOfDay is the moment the interest day of date closes, midnight at the
+// start of the next day in Location.
+func (svc *InterestService) endOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, svc.Location)
+}
+
+func (svc *InterestService) accrue(ctx context.Context, walletID, pocketID string, balance domain.Money, rate float64, accrualDate, now time.Time) error {
+	amount := new(big.Rat).Mul(big.NewRat(balance.Amount, 1), floatToRat(rate))
+	amount.Quo(amount, big.NewRat(daysPerYear, 1))
+
+	return svc.InterestRepository.AddInterestAccrual(ctx, domain.InterestAccrual{
+		ID:          uuid.New().String(),
+		WalletID:    walletID,
+		PocketID:    pocketID,
+		AccrualDate: accrualDate,
+		Period:      accrualDate.Format("2006-01"),
+		Balance:     balance,
+		Rate:        rate,
+		Amount:      roundHalfEven(amount, accrualScale),
+		CreatedAt:   now,
+	})
+}
+
+func (svc *InterestService) postInterest(ctx context.Context, today, now time.Time) error {
+	afterWalletID, afterPocketID := "", ""
+	for {
+		postings, err := svc.InterestRepository.GetUnpostedInterest(ctx, today.Format("2006-01"), afterWalletID, afterPocketID, interestBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range postings {
+			err = svc.post(ctx, postings[i], today, now)
+			if err != nil {
+				log.Println("error post interest for wallet", postings[i].WalletID+":", err.Error())
+			}
+		}
+
+		if len(postings) < interestBatchSize {
+			return nil
+		}
+		afterWalletID = postings[len(postings)-1].WalletID
+		afterPocketID = postings[len(postings)-1].PocketID
+	}
+}
+
+func (svc *InterestService) post(ctx context.Context, posting domain.InterestPosting, today, now time.Time) error {
+	// a total that rounds to zero stays unposted and is carried into the
+	// next posting
+	amount := roundHalfEven(posting.Amount, 0)
+	if amount.Sign() <= 0 {
+		return nil
+	}
+	if !amount.Num().IsInt64() {
+		return domain.ErrAmountOverflow
+	}
+
+	// the fraction lost to rounding, either way, is carried into the current
+	// period so it is paid with the next posting
+	transactionID := uuid.New().String()
+	carried := domain.InterestAccrual{
+		ID:          uuid.New().String(),
+		WalletID:    posting.WalletID,
+		PocketID:    posting.PocketID,
+		AccrualDate: today,
+		Period:      today.Format("2006-01"),
+		Balance:     domain.NewMoney(0, posting.Currency),
+		Amount:      new(big.Rat).Sub(posting.Amount, amount),
+		CarriedFrom: transactionID,
+		CreatedAt:   now,
+	}
+
+	sourceID := posting.WalletID
+	if posting.PocketID != "" {
+		sourceID = posting.PocketID
+	}
+	interest := domain.Transaction{
+		ID:              transactionID,
+		WalletID:        posting.WalletID,
+		CustomerXID:     posting.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_INTEREST,
+		Amount:          domain.NewMoney(amount.Num().Int64(), posting.Currency),
+		ReferenceID:     fmt.Sprintf("interest-%s-%s", sourceID, strings.ReplaceAll(posting.Period, "-", "")),
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	// pocket interest is moved from the main balance into the pocket
+	var allocation *domain.Transaction
+	if posting.PocketID != "" {
+		allocation = &domain.Transaction{
+			ID:              uuid.New().String(),
+			WalletID:        posting.WalletID,
+			CustomerXID:     posting.CustomerXID,
+			PocketID:        posting.PocketID,
+			TransactionType: constants.TRANSACTION_TYPE_POCKET_ALLOCATION,
+			Amount:          interest.Amount,
+			ReferenceID:     interest.ReferenceID,
+			Status:          constants.STATUS_SUCCESS,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+	}
+
+	_, err := svc.InterestRepository.PostInterest(ctx, posting, interest, allocation, carried)
+	return err
+}
+
+func (svc *InterestService) GetInterestPreview(ctx context.Context, customerXID string) (web.InterestPreviewResponse, error) {
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.InterestPreviewResponse{}, err
+	}
+
+	accruals, err := svc.InterestRepository.GetUnpostedInterestAccruals(ctx, wallet.ID)
+	if err != nil {
+		return web.InterestPreviewResponse{}, err
+	}
+
+	result := web.InterestPreviewResponse{
+		WalletID: wallet.ID,
+		Period:   time.Now().In(svc.Location).Format("2006-01"),
+		Currency: wallet.Currency,
+		Accruals: []web.InterestAccrualResponse{},
+	}
+
+	// remainders of earlier postings and earlier periods too small to post
+	// are shown apart from what accrued in the current period
+	total := new(big.Rat)
+	carried := new(big.Rat)
+	for _, accrual := range accruals {
+		if accrual.CarriedFrom != "" || accrual.Period != result.Period {
+			carried.Add(carried, accrual.Amount)
+			continue
+		}
+
+		total.Add(total, accrual.Amount)
+		result.Accruals = append(result.Accruals, web.InterestAccrualResponse{
+			Date:     accrual.AccrualDate.Format("2006-01-02"),
+			PocketID: accrual.PocketID,
+			Balance:  accrual.Balance,
+			Rate:     accrual.Rate,
+			Amount:   accrual.Amount.FloatString(accrualScale),
+		})
+	}
+
+	accrued := roundHalfEven(total, 0)
+	if !accrued.Num().IsInt64() {
+		return web.InterestPreviewResponse{}, domain.ErrAmountOverflow
+	}
+	result.AccruedAmount = domain.NewMoney(accrued.Num().Int64(), wallet.Currency)
+	result.ExactAmount = total.FloatString(accrualScale)
+	result.CarriedAmount = carried.FloatString(accrualScale)
+	return result, nil
+}
+
+// roundHalfEven rounds value to scale decimal places, ties going to the even
+// neighbour so rounding errors do not pile up in one direction.
+func roundHalfEven(value *big.Rat, scale int) *big.Rat {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(factor))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	twice := new(big.Int).Abs(remainder)
+	twice.Lsh(twice, 1)
+	if cmp := twice.Cmp(scaled.Denom()); cmp > 0 || (cmp == 0 && quotient.Bit(0) == 1) {
+		quotient.Add(quotient, big.NewInt(int64(scaled.Sign())))
+	}
+
+	return new(big.Rat).SetFrac(quotient, factor)
+}
@@ -0,0 +1,331 @@
+package service_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	interestSvc service.InterestServiceItf
+
+	mockInterestRepository       *mock_repository.MockInterestRepository
+	mockInterestWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideInterestTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockInterestRepository = mock_repository.NewMockInterestRepository(ctrl)
+	mockInterestWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	interestSvc = service.NewInterestService(mockInterestRepository, mockInterestWalletRepository, validator, 0.03, time.UTC)
+
+	return func() {}
+}
+
+func decimal(value string) *big.Rat {
+	result, _ := new(big.Rat).SetString(value)
+	return result
+}
+
+func TestRunInterest(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:           "mock-id",
+		CustomerXID:  "1",
+		Currency:     "IDR",
+		Balance:      domain.Money{Amount: 1000000, Currency: "IDR"},
+		InterestRate: 0.05,
+	}
+	pocket := domain.Pocket{
+		ID:       "mock-pocket",
+		WalletID: "mock-id",
+		Balance:  domain.Money{Amount: 500000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID       int
+		testDesc     string
+		now          time.Time
+		postings     []domain.InterestPosting
+		wantAccruals map[string]string
+		wantPosted   int64
+		wantCarried  string
+		wantRef      string
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - accrue previous day",
+			now:      time.Date(2023, 3, 15, 1, 0, 0, 0, time.UTC),
+			postings: []domain.InterestPosting{},
+			wantAccruals: map[string]string{
+				"":            "136.986301",
+				"mock-pocket": "41.095890",
+			},
+			wantPosted:  0,
+			wantCarried: "",
+		},
+		{
+			testID:   2,
+			testDesc: "Success - post finished month rounding half to even",
+			now:      time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC),
+			postings: []domain.InterestPosting{
+				{WalletID: "mock-id", CustomerXID: "1", Currency: "IDR", Period: "2023-03", Amount: decimal("5518.500000")},
+			},
+			wantAccruals: map[string]string{
+				"":            "136.986301",
+				"mock-pocket": "41.095890",
+			},
+			wantPosted:  5518,
+			wantCarried: "0.500000",
+			wantRef:     "interest-mock-id-202303",
+		},
+		{
+			testID:   3,
+			testDesc: "Success - post rounding up carries a negative remainder",
+			now:      time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC),
+			postings: []domain.InterestPosting{
+				{WalletID: "mock-id", CustomerXID: "1", Currency: "IDR", Period: "2023-03", Amount: decimal("5518.700000")},
+			},
+			wantAccruals: map[string]string{
+				"":            "136.986301",
+				"mock-pocket": "41.095890",
+			},
+			wantPosted:  5519,
+			wantCarried: "-0.300000",
+			wantRef:     "interest-mock-id-202303",
+		},
+		{
+			testID:   4,
+			testDesc: "Success - less than a minor unit is carried",
+			now:      time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC),
+			postings: []domain.InterestPosting{
+				{WalletID: "mock-id", CustomerXID: "1", Currency: "IDR", Period: "2023-03", Amount: decimal("0.400000")},
+			},
+			wantAccruals: map[string]string{
+				"":            "136.986301",
+				"mock-pocket": "41.095890",
+			},
+			wantPosted:  0,
+			wantCarried: "",
+		},
+		{
+			testID:   5,
+			testDesc: "Success - pocket interest is allocated to the pocket",
+			now:      time.Date(2023, 4, 1, 1, 0, 0, 0, time.UTC),
+			postings: []domain.InterestPosting{
+				{WalletID: "mock-id", PocketID: "mock-pocket", CustomerXID: "1", Currency: "IDR", Period: "2023-03", Amount: decimal("1273.972590")},
+			},
+			wantAccruals: map[string]string{
+				"":            "136.986301",
+				"mock-pocket": "41.095890",
+			},
+			wantPosted:  1274,
+			wantCarried: "-0.027410",
+			wantRef:     "interest-mock-pocket-202303",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideInterestTest(t)
+			defer testDep()
+
+			wantPeriod := tc.now.Format("2006-01")
+			wantDate := time.Date(tc.now.Year(), tc.now.Month(), tc.now.Day()-1, 0, 0, 0, 0, time.UTC)
+
+			closedAt := wantDate.AddDate(0, 0, 1)
+
+			got := map[string]string{}
+			mockInterestRepository.EXPECT().GetInterestBearingWallets(gomock.Any(), "", gomock.Any()).Return([]domain.Wallet{wallet}, nil)
+			mockInterestRepository.EXPECT().GetLastInterestAccrualDate(gomock.Any(), "mock-id", "").Return(nil, nil)
+			mockInterestRepository.EXPECT().GetWalletBalanceHistory(gomock.Any(), "mock-id", closedAt).Return(domain.BalanceHistory{Balance: wallet.Balance}, nil)
+			mockInterestRepository.EXPECT().GetInterestBearingPockets(gomock.Any(), "", gomock.Any()).Return([]domain.Pocket{pocket}, nil)
+			mockInterestRepository.EXPECT().GetLastInterestAccrualDate(gomock.Any(), "mock-id", "mock-pocket").Return(nil, nil)
+			mockInterestRepository.EXPECT().GetPocketBalanceHistory(gomock.Any(), "mock-pocket", closedAt).Return(domain.BalanceHistory{Balance: pocket.Balance}, nil)
+			mockInterestRepository.EXPECT().AddInterestAccrual(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, accrual domain.InterestAccrual) error {
+					assert.Equal(t, accrual.AccrualDate, wantDate)
+					assert.Equal(t, accrual.Period, wantDate.Format("2006-01"))
+					got[accrual.PocketID] = accrual.Amount.FloatString(6)
+					return nil
+				}).Times(2)
+			mockInterestRepository.EXPECT().GetUnpostedInterest(gomock.Any(), wantPeriod, "", "", gomock.Any()).Return(tc.postings, nil)
+
+			var posted domain.Transaction
+			if tc.wantPosted > 0 {
+				mockInterestRepository.EXPECT().PostInterest(gomock.Any(), tc.postings[0], gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, posting domain.InterestPosting, interest domain.Transaction, allocation *domain.Transaction, carried domain.InterestAccrual) (bool, error) {
+						posted = interest
+						if posting.PocketID == "" {
+							assert.Nil(t, allocation)
+						} else {
+							assert.Equal(t, allocation.TransactionType, "pocket_allocation")
+							assert.Equal(t, allocation.Amount, interest.Amount)
+							assert.Equal(t, allocation.ReferenceID, interest.ReferenceID)
+						}
+						assert.Equal(t, carried.PocketID, posting.PocketID)
+						assert.Equal(t, carried.CarriedFrom, interest.ID)
+						assert.Equal(t, carried.Period, wantPeriod)
+						assert.Equal(t, carried.Amount.FloatString(6), tc.wantCarried)
+						return true, nil
+					})
+			}
+
+			err := interestSvc.RunInterest(context.Background(), tc.now)
+			assert.Nil(t, err)
+			assert.Equal(t, got, tc.wantAccruals)
+			assert.Equal(t, posted.Amount.Amount, tc.wantPosted)
+			if tc.wantPosted > 0 {
+				assert.Equal(t, posted.TransactionType, "interest")
+				assert.Equal(t, posted.ReferenceID, tc.wantRef)
+			}
+		})
+	}
+}
+
+func TestRunInterestCatchUp(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:           "mock-id",
+		CustomerXID:  "1",
+		Currency:     "IDR",
+		Balance:      domain.Money{Amount: 730000, Currency: "IDR"},
+		InterestRate: 0.05,
+	}
+	// closes the 11th overdrawn, the 12th at 1460000, the 13th at 1825000
+	// and the 14th at 730000
+	history := domain.BalanceHistory{
+		Balance: wallet.Balance,
+		Movements: []domain.BalanceMovement{
+			{Amount: 1500000, CreatedAt: time.Date(2023, 3, 12, 8, 0, 0, 0, time.UTC)},
+			{Amount: 365000, CreatedAt: time.Date(2023, 3, 13, 10, 0, 0, 0, time.UTC)},
+			{Amount: -1095000, CreatedAt: time.Date(2023, 3, 14, 9, 0, 0, 0, time.UTC)},
+		},
+	}
+	now := time.Date(2023, 3, 15, 1, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testID          int
+		testDesc        string
+		lastAccrualDate time.Time
+		wantAccruals    map[string]string
+	}{
+		{
+			testID:          1,
+			testDesc:        "Success - accrue missed days on their closing balance",
+			lastAccrualDate: time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC),
+			wantAccruals: map[string]string{
+				"2023-03-12": "200.000000",
+				"2023-03-13": "250.000000",
+				"2023-03-14": "100.000000",
+			},
+		},
+		{
+			testID:          2,
+			testDesc:        "Success - nothing left to accrue",
+			lastAccrualDate: time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC),
+			wantAccruals:    map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideInterestTest(t)
+			defer testDep()
+
+			got := map[string]string{}
+			lastAccrualDate := tc.lastAccrualDate
+			mockInterestRepository.EXPECT().GetInterestBearingWallets(gomock.Any(), "", gomock.Any()).Return([]domain.Wallet{wallet}, nil)
+			mockInterestRepository.EXPECT().GetLastInterestAccrualDate(gomock.Any(), "mock-id", "").Return(&lastAccrualDate, nil)
+			if len(tc.wantAccruals) > 0 {
+				mockInterestRepository.EXPECT().GetWalletBalanceHistory(gomock.Any(), "mock-id", lastAccrualDate.AddDate(0, 0, 2)).Return(history, nil)
+				mockInterestRepository.EXPECT().AddInterestAccrual(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, accrual domain.InterestAccrual) error {
+						assert.Equal(t, accrual.Period, "2023-03")
+						got[accrual.AccrualDate.Format("2006-01-02")] = accrual.Amount.FloatString(6)
+						return nil
+					}).Times(len(tc.wantAccruals))
+			}
+			mockInterestRepository.EXPECT().GetInterestBearingPockets(gomock.Any(), "", gomock.Any()).Return([]domain.Pocket{}, nil)
+			mockInterestRepository.EXPECT().GetUnpostedInterest(gomock.Any(), "2023-03", "", "", gomock.Any()).Return([]domain.InterestPosting{}, nil)
+
+			err := interestSvc.RunInterest(context.Background(), now)
+			assert.Nil(t, err)
+			assert.Equal(t, got, tc.wantAccruals)
+		})
+	}
+}
+
+func TestGetInterestPreview(t *testing.T) {
+	testDep := provideInterestTest(t)
+	defer testDep()
+
+	mockInterestWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+		ID:       "mock-id",
+		Currency: "IDR",
+	}, nil)
+	now := time.Now().UTC()
+	period := now.Format("2006-01")
+	firstDay := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	mockInterestRepository.EXPECT().GetUnpostedInterestAccruals(gomock.Any(), "mock-id").Return([]domain.InterestAccrual{
+		{
+			WalletID:    "mock-id",
+			AccrualDate: firstDay.AddDate(0, -1, 0),
+			Period:      firstDay.AddDate(0, -1, 0).Format("2006-01"),
+			Balance:     domain.Money{Amount: 1000, Currency: "IDR"},
+			Rate:        0.05,
+			Amount:      decimal("0.136986"),
+		},
+		{
+			WalletID:    "mock-id",
+			AccrualDate: firstDay,
+			Period:      period,
+			Balance:     domain.Money{Amount: 0, Currency: "IDR"},
+			Amount:      decimal("-0.300000"),
+			CarriedFrom: "mock-interest",
+		},
+		{
+			WalletID:    "mock-id",
+			AccrualDate: firstDay,
+			Period:      period,
+			Balance:     domain.Money{Amount: 1000000, Currency: "IDR"},
+			Rate:        0.05,
+			Amount:      decimal("136.986301"),
+		},
+		{
+			WalletID:    "mock-id",
+			PocketID:    "mock-pocket",
+			AccrualDate: firstDay,
+			Period:      period,
+			Balance:     domain.Money{Amount: 500000, Currency: "IDR"},
+			Rate:        0.03,
+			Amount:      decimal("41.095890"),
+		},
+	}, nil)
+
+	got, err := interestSvc.GetInterestPreview(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, got.Period, period)
+	assert.Equal(t, got.AccruedAmount, domain.Money{Amount: 178, Currency: "IDR"})
+	assert.Equal(t, got.ExactAmount, "178.082191")
+	assert.Equal(t, got.CarriedAmount, "-0.163014")
+	assert.Equal(t, len(got.Accruals), 2)
+	assert.Equal(t, got.Accruals[1], web.InterestAccrualResponse{
+		Date:     firstDay.Format("2006-01-02"),
+		PocketID: "mock-pocket",
+		Balance:  domain.Money{Amount: 500000, Currency: "IDR"},
+		Rate:     0.03,
+		Amount:   "41.095890",
+	})
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type LoanServiceItf interface {
+	DisburseLoan(ctx context.Context, request web.LoanCreateRequest) (web.LoanResponse, error)
+	GetLoans(ctx context.Context, customerXID string) ([]web.LoanResponse, error)
+	GetLoan(ctx context.Context, customerXID, loanID string) (web.LoanResponse, error)
+	// CollectDueInstallments auto-debits every installment due by now, taking
+	// whatever the wallet can cover and charging the late fee on the rest.
+	CollectDueInstallments(ctx context.Context, now time.Time) error
+}
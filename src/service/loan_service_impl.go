@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type LoanService struct {
+	LoanRepository   repository.LoanRepository
+	WalletRepository repository.WalletRepository
+	Validate         *validator.Validate
+}
+
+func NewLoanService(loanRepository repository.LoanRepository, walletRepository repository.WalletRepository, validate *validator.Validate) LoanServiceItf {
+	return &LoanService{
+		LoanRepository:   loanRepository,
+		WalletRepository: walletRepository,
+		Validate:         validate,
+	}
+}
+
+func (svc *LoanService) DisburseLoan(ctx context.Context, request web.LoanCreateRequest) (web.LoanResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+
+	// a repeated reference returns the loan it already disbursed
+	existing, err := svc.LoanRepository.GetLoanByReference(ctx, request.ReferenceID)
+	if err == nil {
+		if existing.CustomerXID != request.CustomerXID || existing.Principal.Amount != request.Principal {
+			return web.LoanResponse{}, errors.New("reference_id already used for another loan")
+		}
+		return svc.toLoanResponse(ctx, existing)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.LoanResponse{}, err
+	}
+
+	now := time.Now()
+	if !request.FirstDueAt.After(now) {
+		return web.LoanResponse{}, errors.New("first_due_at must be in the future")
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, request.CustomerXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.LoanResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.LoanResponse{}, errors.New("wallet disabled")
+	}
+
+	principal := domain.NewMoney(request.Principal, wallet.Currency)
+
+	// the wallet must be able to hold the disbursement
+	_, err = wallet.Balance.Add(principal)
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+
+	loan := domain.Loan{
+		ID:               uuid.New().String(),
+		CustomerXID:      wallet.CustomerXID,
+		WalletID:         wallet.ID,
+		ReferenceID:      request.ReferenceID,
+		Principal:        principal,
+		InterestAmount:   applyRate(principal, floatToRat(request.InterestRate)),
+		LateFee:          domain.NewMoney(request.LateFee, wallet.Currency),
+		InstallmentCount: request.InstallmentCount,
+		Frequency:        request.Frequency,
+		Status:           constants.STATUS_ACTIVE,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	installments, err := loan.Installments(request.FirstDueAt)
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+	if !installments[len(installments)-1].Amount.IsPositive() {
+		return web.LoanResponse{}, errors.New("principal too small for the installment count")
+	}
+	for i := range installments {
+		installments[i].ID = uuid.New().String()
+		installments[i].CreatedAt = now
+		installments[i].UpdatedAt = now
+	}
+
+	disbursement := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_LOAN_DISBURSEMENT,
+		Amount:          principal,
+		ReferenceID:     loan.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	loan.TransactionID = disbursement.ID
+
+	err = svc.LoanRepository.CreateLoan(ctx, loan, installments, disbursement)
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+
+	return toLoanResponse(loan, installments)
+}
+
+func (svc *LoanService) GetLoans(ctx context.Context, customerXID string) ([]web.LoanResponse, error) {
+	loans, err := svc.LoanRepository.GetLoans(ctx, customerXID)
+	if err != nil {
+		return []web.LoanResponse{}, err
+	}
+
+	result := []web.LoanResponse{}
+	for i := range loans {
+		loan, err := svc.toLoanResponse(ctx, loans[i])
+		if err != nil {
+			return []web.LoanResponse{}, err
+		}
+		result = append(result, loan)
+	}
+	return result, nil
+}
+
+func (svc *LoanService) GetLoan(ctx context.Context, customerXID, loanID string) (web.LoanResponse, error) {
+	loan, err := svc.LoanRepository.GetLoan(ctx, loanID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && loan.CustomerXID != customerXID) {
+		return web.LoanResponse{}, errors.New("loan not found")
+	}
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+
+	return svc.toLoanResponse(ctx, loan)
+}
+
+func (svc *LoanService) CollectDueInstallments(ctx context.Context, now time.Time) error {
+	installments, err := svc.LoanRepository.GetDueLoanInstallments(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range installments {
+		err = svc.collectInstallment(ctx, installments[i], now)
+		if err != nil {
+			log.Println("error collect loan installment", installments[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *LoanService) collectInstallment(ctx context.Context, installment domain.LoanInstallment, now time.Time) error {
+	loan, err := svc.LoanRepository.GetLoan(ctx, installment.LoanID)
+	if err != nil {
+		return err
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, loan.CustomerXID, loan.Principal.Currency)
+	if err != nil {
+		return err
+	}
+
+	outstanding, err := installment.Outstanding()
+	if err != nil {
+		return err
+	}
+
+	// take whatever the wallet can cover, a disabled wallet pays nothing
+	payment := outstanding
+	cmp, err := outstanding.Cmp(wallet.Balance)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		payment = wallet.Balance
+	}
+	if wallet.Status == constants.STATUS_DISABLED || !payment.IsPositive() {
+		payment = domain.NewMoney(0, outstanding.Currency)
+	}
+
+	collectAt := installment.NextCollectAt
+	var repayment *domain.Transaction
+	if payment.IsPositive() {
+		repayment = &domain.Transaction{
+			ID:              uuid.New().String(),
+			WalletID:        wallet.ID,
+			CustomerXID:     wallet.CustomerXID,
+			TransactionType: constants.TRANSACTION_TYPE_LOAN_REPAYMENT,
+			Amount:          payment,
+			ReferenceID:     installment.CollectionReferenceID(),
+			Status:          constants.STATUS_SUCCESS,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		installment.PaidAmount, err = installment.PaidAmount.Add(payment)
+		if err != nil {
+			return err
+		}
+	}
+
+	if payment == outstanding {
+		installment.Status = constants.STATUS_PAID
+		installment.PaidAt = &now
+	} else {
+		installment.Status = constants.STATUS_PENDING
+		if installment.PaidAmount.IsPositive() {
+			installment.Status = constants.STATUS_PARTIAL
+		}
+
+		// the shortfall is charged the late fee and retried a day later, a
+		// collection that fell behind is charged once and not per missed day
+		installment.LateFee, err = installment.LateFee.Add(loan.LateFee)
+		if err != nil {
+			return err
+		}
+		nextCollectAt := collectAt.AddDate(0, 0, 1)
+		for !nextCollectAt.After(now) {
+			nextCollectAt = nextCollectAt.AddDate(0, 0, 1)
+		}
+		installment.NextCollectAt = nextCollectAt
+	}
+
+	isCollected, err := svc.LoanRepository.CollectLoanInstallment(ctx, installment, collectAt, repayment)
+	if err != nil {
+		return err
+	}
+	if !isCollected {
+		return errors.New("installment already collected or insufficient balance")
+	}
+	return nil
+}
+
+func (svc *LoanService) toLoanResponse(ctx context.Context, loan domain.Loan) (web.LoanResponse, error) {
+	installments, err := svc.LoanRepository.GetLoanInstallments(ctx, loan.ID)
+	if err != nil {
+		return web.LoanResponse{}, err
+	}
+	return toLoanResponse(loan, installments)
+}
+
+func toLoanResponse(loan domain.Loan, installments []domain.LoanInstallment) (web.LoanResponse, error) {
+	result := web.LoanResponse{
+		ID:                loan.ID,
+		CustomerXID:       loan.CustomerXID,
+		ReferenceID:       loan.ReferenceID,
+		Principal:         loan.Principal,
+		InterestAmount:    loan.InterestAmount,
+		LateFee:           loan.LateFee,
+		Currency:          loan.Principal.Currency,
+		InstallmentCount:  loan.InstallmentCount,
+		Frequency:         loan.Frequency,
+		Status:            loan.Status,
+		TransactionID:     loan.TransactionID,
+		OutstandingAmount: domain.NewMoney(0, loan.Principal.Currency),
+		Installments:      []web.LoanInstallmentResponse{},
+		CreatedAt:         loan.CreatedAt,
+	}
+
+	for _, installment := range installments {
+		response := web.LoanInstallmentResponse{
+			ID:         installment.ID,
+			Sequence:   installment.Sequence,
+			DueAt:      installment.DueAt,
+			Amount:     installment.Amount,
+			LateFee:    installment.LateFee,
+			PaidAmount: installment.PaidAmount,
+			Status:     installment.Status,
+			PaidAt:     installment.PaidAt,
+		}
+		if installment.Status != constants.STATUS_PAID {
+			nextCollectAt := installment.NextCollectAt
+			response.NextCollectAt = &nextCollectAt
+
+			outstanding, err := installment.Outstanding()
+			if err != nil {
+				return web.LoanResponse{}, err
+			}
+			result.OutstandingAmount, err = result.OutstandingAmount.Add(outstanding)
+			if err != nil {
+				return web.LoanResponse{}, err
+			}
+		}
+		result.Installments = append(result.Installments, response)
+	}
+	return result, nil
+}
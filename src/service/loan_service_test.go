@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	loanSvc service.LoanServiceItf
+
+	mockLoanRepository       *mock_repository.MockLoanRepository
+	mockLoanWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideLoanTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoanRepository = mock_repository.NewMockLoanRepository(ctrl)
+	mockLoanWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	loanSvc = service.NewLoanService(mockLoanRepository, mockLoanWalletRepository, validator)
+
+	return func() {}
+}
+
+func TestDisburseLoan(t *testing.T) {
+	firstDueAt := time.Now().Add(24 * time.Hour)
+	existing := domain.Loan{
+		ID:          "mock-loan",
+		CustomerXID: "1",
+		ReferenceID: "loan-1",
+		Principal:   domain.Money{Amount: 1000000, Currency: "IDR"},
+		Status:      "active",
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.LoanCreateRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.LoanResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - interest spread over installments",
+			payload: web.LoanCreateRequest{
+				CustomerXID:      "1",
+				ReferenceID:      "loan-2",
+				Principal:        1000000,
+				InterestRate:     0.1,
+				LateFee:          5000,
+				InstallmentCount: 3,
+				Frequency:        "monthly",
+				FirstDueAt:       firstDueAt,
+			},
+			mockFunc: func() {
+				mockLoanRepository.EXPECT().GetLoanByReference(gomock.Any(), "loan-2").Return(domain.Loan{}, sql.ErrNoRows)
+				mockLoanWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:          "mock-id",
+					CustomerXID: "1",
+					Currency:    "IDR",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: 0, Currency: "IDR"},
+				}, nil)
+				mockLoanRepository.EXPECT().CreateLoan(gomock.Any(), gomock.Any(), gomock.Len(3), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.LoanResponse{
+				CustomerXID:       "1",
+				ReferenceID:       "loan-2",
+				Principal:         domain.Money{Amount: 1000000, Currency: "IDR"},
+				InterestAmount:    domain.Money{Amount: 100000, Currency: "IDR"},
+				Status:            "active",
+				OutstandingAmount: domain.Money{Amount: 1100000, Currency: "IDR"},
+				Installments: []web.LoanInstallmentResponse{
+					{Sequence: 1, Amount: domain.Money{Amount: 366667, Currency: "IDR"}},
+					{Sequence: 2, Amount: domain.Money{Amount: 366667, Currency: "IDR"}},
+					{Sequence: 3, Amount: domain.Money{Amount: 366666, Currency: "IDR"}},
+				},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - repeated reference returns the same loan",
+			payload: web.LoanCreateRequest{
+				CustomerXID:      "1",
+				ReferenceID:      "loan-1",
+				Principal:        1000000,
+				InstallmentCount: 3,
+				Frequency:        "monthly",
+				FirstDueAt:       firstDueAt,
+			},
+			mockFunc: func() {
+				mockLoanRepository.EXPECT().GetLoanByReference(gomock.Any(), "loan-1").Return(existing, nil)
+				mockLoanRepository.EXPECT().GetLoanInstallments(gomock.Any(), "mock-loan").Return([]domain.LoanInstallment{}, nil)
+			},
+			wantErr: false,
+			wantResult: web.LoanResponse{
+				ID:                "mock-loan",
+				CustomerXID:       "1",
+				ReferenceID:       "loan-1",
+				Principal:         domain.Money{Amount: 1000000, Currency: "IDR"},
+				Status:            "active",
+				OutstandingAmount: domain.Money{Amount: 0, Currency: "IDR"},
+				Installments:      []web.LoanInstallmentResponse{},
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - wallet disabled",
+			payload: web.LoanCreateRequest{
+				CustomerXID:      "1",
+				ReferenceID:      "loan-2",
+				Principal:        1000000,
+				InstallmentCount: 3,
+				Frequency:        "monthly",
+				FirstDueAt:       firstDueAt,
+			},
+			mockFunc: func() {
+				mockLoanRepository.EXPECT().GetLoanByReference(gomock.Any(), "loan-2").Return(domain.Loan{}, sql.ErrNoRows)
+				mockLoanWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "disabled",
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.LoanResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - unsupported frequency",
+			payload: web.LoanCreateRequest{
+				CustomerXID:      "1",
+				ReferenceID:      "loan-2",
+				Principal:        1000000,
+				InstallmentCount: 3,
+				Frequency:        "daily",
+				FirstDueAt:       firstDueAt,
+			},
+			mockFunc:   func() {},
+			wantErr:    true,
+			wantResult: web.LoanResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideLoanTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := loanSvc.DisburseLoan(context.Background(), tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			if tc.wantResult.ID != "" {
+				assert.Equal(t, got.ID, tc.wantResult.ID)
+			}
+			assert.Equal(t, got.CustomerXID, tc.wantResult.CustomerXID)
+			assert.Equal(t, got.ReferenceID, tc.wantResult.ReferenceID)
+			assert.Equal(t, got.Principal, tc.wantResult.Principal)
+			assert.Equal(t, got.InterestAmount, tc.wantResult.InterestAmount)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, got.OutstandingAmount, tc.wantResult.OutstandingAmount)
+			assert.Equal(t, len(got.Installments), len(tc.wantResult.Installments))
+			for i := range tc.wantResult.Installments {
+				assert.Equal(t, got.Installments[i].Sequence, tc.wantResult.Installments[i].Sequence)
+				assert.Equal(t, got.Installments[i].Amount, tc.wantResult.Installments[i].Amount)
+			}
+		})
+	}
+}
+
+func TestCollectDueInstallments(t *testing.T) {
+	now := time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC)
+	loan := domain.Loan{
+		ID:          "mock-loan",
+		CustomerXID: "1",
+		Principal:   domain.Money{Amount: 300000, Currency: "IDR"},
+		LateFee:     domain.Money{Amount: 5000, Currency: "IDR"},
+		Status:      "active",
+	}
+	installment := domain.LoanInstallment{
+		ID:            "mock-installment",
+		LoanID:        "mock-loan",
+		Sequence:      1,
+		DueAt:         now,
+		Amount:        domain.Money{Amount: 100000, Currency: "IDR"},
+		LateFee:       domain.Money{Amount: 0, Currency: "IDR"},
+		PaidAmount:    domain.Money{Amount: 0, Currency: "IDR"},
+		Status:        "pending",
+		NextCollectAt: now,
+	}
+
+	testCases := []struct {
+		testID          int
+		testDesc        string
+		balance         int64
+		wantErr         bool
+		wantStatus      string
+		wantPaidAmount  int64
+		wantLateFee     int64
+		wantRepayment   int64
+		wantNextCollect time.Time
+	}{
+		{
+			testID:          1,
+			testDesc:        "Success - paid in full",
+			balance:         250000,
+			wantErr:         false,
+			wantStatus:      "paid",
+			wantPaidAmount:  100000,
+			wantLateFee:     0,
+			wantRepayment:   100000,
+			wantNextCollect: now,
+		},
+		{
+			testID:          2,
+			testDesc:        "Success - partial payment charges late fee",
+			balance:         40000,
+			wantErr:         false,
+			wantStatus:      "partial",
+			wantPaidAmount:  40000,
+			wantLateFee:     5000,
+			wantRepayment:   40000,
+			wantNextCollect: now.AddDate(0, 0, 1),
+		},
+		{
+			testID:          3,
+			testDesc:        "Success - empty wallet only charges late fee",
+			balance:         0,
+			wantErr:         false,
+			wantStatus:      "pending",
+			wantPaidAmount:  0,
+			wantLateFee:     5000,
+			wantRepayment:   0,
+			wantNextCollect: now.AddDate(0, 0, 1),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideLoanTest(t)
+			defer testDep()
+
+			mockLoanRepository.EXPECT().GetDueLoanInstallments(gomock.Any(), now, gomock.Any()).Return([]domain.LoanInstallment{installment}, nil)
+			mockLoanRepository.EXPECT().GetLoan(gomock.Any(), "mock-loan").Return(loan, nil)
+			mockLoanWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+				ID:          "mock-id",
+				CustomerXID: "1",
+				Status:      "enabled",
+				Balance:     domain.Money{Amount: tc.balance, Currency: "IDR"},
+			}, nil)
+
+			var got domain.LoanInstallment
+			var repayment *domain.Transaction
+			mockLoanRepository.EXPECT().CollectLoanInstallment(gomock.Any(), gomock.Any(), now, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, data domain.LoanInstallment, collectAt time.Time, transaction *domain.Transaction) (bool, error) {
+					got = data
+					repayment = transaction
+					return true, nil
+				})
+
+			err := loanSvc.CollectDueInstallments(context.Background(), now)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.Status, tc.wantStatus)
+			assert.Equal(t, got.PaidAmount.Amount, tc.wantPaidAmount)
+			assert.Equal(t, got.LateFee.Amount, tc.wantLateFee)
+			assert.Equal(t, got.NextCollectAt, tc.wantNextCollect)
+			if tc.wantRepayment > 0 {
+				assert.Equal(t, repayment.Amount.Amount, tc.wantRepayment)
+				assert.Equal(t, repayment.ReferenceID, "loan-mock-installment-20230201T090000")
+			} else {
+				assert.Nil(t, repayment)
+			}
+		})
+	}
+}
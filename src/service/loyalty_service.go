@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type LoyaltyServiceItf interface {
+	GetPoints(ctx context.Context, customerXID string) (web.PointsResponse, error)
+	GetPointsHistory(ctx context.Context, customerXID string) ([]web.PointsEntryResponse, error)
+	// RedeemPoints converts points into wallet balance, spending the points
+	// that expire first.
+	RedeemPoints(ctx context.Context, customerXID string, request web.PointsRedeemRequest) (web.PointsRedemptionResponse, error)
+	// RunLoyaltyPoints awards points on recent payments and writes off
+	// expired points.
+	RunLoyaltyPoints(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// pointsLookback bounds how far back payments are looked at for points, so
+// turning the program on does not reward the whole history.
+const pointsLookback = 7 * 24 * time.Hour
+
+type LoyaltyService struct {
+	LoyaltyRepository repository.LoyaltyRepository
+	WalletRepository  repository.WalletRepository
+	Validate          *validator.Validate
+	// EarnRate is the points earned per rupiah paid, RedeemRate the rupiah
+	// credited per point redeemed
+	EarnRate     float64
+	RedeemRate   float64
+	ExpiryMonths int
+}
+
+func NewLoyaltyService(loyaltyRepository repository.LoyaltyRepository, walletRepository repository.WalletRepository, validate *validator.Validate, earnRate, redeemRate float64, expiryMonths int) LoyaltyServiceItf {
+	return &LoyaltyService{
+		LoyaltyRepository: loyaltyRepository,
+		WalletRepository:  walletRepository,
+		Validate:          validate,
+		EarnRate:          earnRate,
+		RedeemRate:        redeemRate,
+		ExpiryMonths:      expiryMonths,
+	}
+}
+
+func (svc *LoyaltyService) GetPoints(ctx context.Context, customerXID string) (web.PointsResponse, error) {
+	balance, err := svc.LoyaltyRepository.GetPointsBalance(ctx, customerXID)
+	if err != nil {
+		return web.PointsResponse{}, err
+	}
+
+	lots, err := svc.LoyaltyRepository.GetAvailablePointsLots(ctx, customerXID, time.Now())
+	if err != nil {
+		return web.PointsResponse{}, err
+	}
+
+	result := web.PointsResponse{
+		Balance:     balance,
+		RedeemValue: svc.redeemValue(balance),
+	}
+
+	// lots sharing the earliest expiry day expire together
+	for _, lot := range lots {
+		if result.NextExpiryAt != nil && !sameDay(*lot.ExpiresAt, *result.NextExpiryAt) {
+			break
+		}
+		if result.NextExpiryAt == nil {
+			result.NextExpiryAt = lot.ExpiresAt
+		}
+		result.ExpiringPoints += lot.Remaining
+	}
+	return result, nil
+}
+
+func (svc *LoyaltyService) GetPointsHistory(ctx context.Context, customerXID string) ([]web.PointsEntryResponse, error) {
+	entries, err := svc.LoyaltyRepository.GetPointsEntries(ctx, customerXID)
+	if err != nil {
+		return []web.PointsEntryResponse{}, err
+	}
+
+	result := []web.PointsEntryResponse{}
+	for _, entry := range entries {
+		result = append(result, web.PointsEntryResponse{
+			ID:            entry.ID,
+			Type:          entry.EntryType,
+			Points:        entry.Points,
+			ReferenceID:   entry.ReferenceID,
+			ExpiresAt:     entry.ExpiresAt,
+			TransactionID: entry.TransactionID,
+			CreatedAt:     entry.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (svc *LoyaltyService) RedeemPoints(ctx context.Context, customerXID string, request web.PointsRedeemRequest) (web.PointsRedemptionResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PointsRedemptionResponse{}, err
+	}
+
+	if svc.RedeemRate <= 0 {
+		return web.PointsRedemptionResponse{}, errors.New("points redemption unavailable")
+	}
+
+	amount := svc.redeemValue(request.Points)
+	if !amount.IsPositive() {
+		return web.PointsRedemptionResponse{}, errors.New("points too few to redeem")
+	}
+
+	balance, err := svc.LoyaltyRepository.GetPointsBalance(ctx, customerXID)
+	if err != nil {
+		return web.PointsRedemptionResponse{}, err
+	}
+	if balance < request.Points {
+		return web.PointsRedemptionResponse{}, errors.New("insufficient points")
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, amount.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PointsRedemptionResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.PointsRedemptionResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.PointsRedemptionResponse{}, errors.New("wallet disabled")
+	}
+
+	// the wallet must be able to hold the credit
+	_, err = wallet.Balance.Add(amount)
+	if err != nil {
+		return web.PointsRedemptionResponse{}, err
+	}
+
+	now := time.Now()
+	entry := domain.PointsEntry{
+		ID:          uuid.New().String(),
+		CustomerXID: wallet.CustomerXID,
+		EntryType:   constants.POINTS_ENTRY_REDEEM,
+		Points:      -request.Points,
+		CreatedAt:   now,
+	}
+	entry.ReferenceID = entry.ID
+	credit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_POINTS_REDEMPTION,
+		Amount:          amount,
+		ReferenceID:     entry.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	entry.TransactionID = credit.ID
+
+	isRedeemed, err := svc.LoyaltyRepository.RedeemPoints(ctx, entry, now, credit)
+	if err != nil {
+		return web.PointsRedemptionResponse{}, err
+	}
+	if !isRedeemed {
+		return web.PointsRedemptionResponse{}, errors.New("insufficient points")
+	}
+
+	return web.PointsRedemptionResponse{
+		ID:            entry.ID,
+		Points:        request.Points,
+		Amount:        amount,
+		TransactionID: credit.ID,
+		Balance:       balance - request.Points,
+		RedeemedAt:    now,
+	}, nil
+}
+
+func (svc *LoyaltyService) RunLoyaltyPoints(ctx context.Context, now time.Time) error {
+	err := svc.earnPoints(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	err = svc.reversePoints(ctx, now)
+	if err != nil {
+		return err
+	}
+	return svc.expirePoints(ctx, now)
+}
+
+func (svc *LoyaltyService) earnPoints(ctx context.Context, now time.Time) error {
+	if svc.EarnRate <= 0 {
+		return nil
+	}
+
+	// smaller payments would earn zero points and are not looked at
+	rate := floatToRat(svc.EarnRate)
+	perPoint := new(big.Rat).Inv(rate)
+	minAmount := new(big.Int).Quo(perPoint.Num(), perPoint.Denom())
+	if !perPoint.IsInt() {
+		minAmount.Add(minAmount, big.NewInt(1))
+	}
+
+	transactions, err := svc.LoyaltyRepository.GetPointsEligibleTransactions(ctx, constants.CURRENCY_IDR, minAmount.Int64(), now.Add(-pointsLookback), scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range transactions {
+		points := new(big.Rat).Mul(big.NewRat(transactions[i].Amount.Amount, 1), rate)
+		expiresAt := transactions[i].CreatedAt.AddDate(0, svc.ExpiryMonths, 0)
+		entry := domain.PointsEntry{
+			ID:          uuid.New().String(),
+			CustomerXID: transactions[i].CustomerXID,
+			EntryType:   constants.POINTS_ENTRY_EARN,
+			Points:      new(big.Int).Quo(points.Num(), points.Denom()).Int64(),
+			ReferenceID: transactions[i].ID,
+			ExpiresAt:   &expiresAt,
+			CreatedAt:   now,
+		}
+		entry.Remaining = entry.Points
+
+		_, err = svc.LoyaltyRepository.EarnPoints(ctx, entry)
+		if err != nil {
+			log.Println("error earn points for transaction", transactions[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *LoyaltyService) reversePoints(ctx context.Context, now time.Time) error {
+	lots, err := svc.LoyaltyRepository.GetRefundedPointsLots(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range lots {
+		entry := domain.PointsEntry{
+			ID:          uuid.New().String(),
+			CustomerXID: lots[i].CustomerXID,
+			EntryType:   constants.POINTS_ENTRY_EARN_REVERSAL,
+			Points:      -lots[i].Points,
+			ReferenceID: lots[i].ReferenceID,
+			CreatedAt:   now,
+		}
+		_, err = svc.LoyaltyRepository.ReversePoints(ctx, lots[i], entry, now)
+		if err != nil {
+			log.Println("error reverse points lot", lots[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *LoyaltyService) expirePoints(ctx context.Context, now time.Time) error {
+	lots, err := svc.LoyaltyRepository.GetExpiredPointsLots(ctx, now, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range lots {
+		entry := domain.PointsEntry{
+			ID:          uuid.New().String(),
+			CustomerXID: lots[i].CustomerXID,
+			EntryType:   constants.POINTS_ENTRY_EXPIRE,
+			Points:      -lots[i].Remaining,
+			ReferenceID: lots[i].ID,
+			CreatedAt:   now,
+		}
+		_, err = svc.LoyaltyRepository.ExpirePoints(ctx, lots[i], entry)
+		if err != nil {
+			log.Println("error expire points lot", lots[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+// redeemValue is what points are worth in the wallet, rounded down.
+func (svc *LoyaltyService) redeemValue(points int64) domain.Money {
+	value := new(big.Rat).Mul(big.NewRat(points, 1), floatToRat(svc.RedeemRate))
+	return domain.NewMoney(new(big.Int).Quo(value.Num(), value.Denom()).Int64(), constants.CURRENCY_IDR)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
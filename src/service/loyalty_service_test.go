@@ -0,0 +1,211 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	loyaltySvc service.LoyaltyServiceItf
+
+	mockLoyaltyRepository       *mock_repository.MockLoyaltyRepository
+	mockLoyaltyWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideLoyaltyTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoyaltyRepository = mock_repository.NewMockLoyaltyRepository(ctrl)
+	mockLoyaltyWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	loyaltySvc = service.NewLoyaltyService(mockLoyaltyRepository, mockLoyaltyWalletRepository, validator, 0.001, 0.5, 12)
+
+	return func() {}
+}
+
+func TestRunLoyaltyPoints(t *testing.T) {
+	testDep := provideLoyaltyTest(t)
+	defer testDep()
+
+	now := time.Date(2023, 3, 15, 1, 0, 0, 0, time.UTC)
+	paidAt := time.Date(2023, 3, 14, 10, 0, 0, 0, time.UTC)
+	expiredAt := now.AddDate(0, 0, -1)
+
+	mockLoyaltyRepository.EXPECT().GetPointsEligibleTransactions(gomock.Any(), "IDR", int64(1000), now.Add(-7*24*time.Hour), gomock.Any()).Return([]domain.Transaction{
+		{
+			ID:          "mock-transaction",
+			CustomerXID: "1",
+			Amount:      domain.Money{Amount: 25999, Currency: "IDR"},
+			CreatedAt:   paidAt,
+		},
+	}, nil)
+	mockLoyaltyRepository.EXPECT().EarnPoints(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entry domain.PointsEntry) (bool, error) {
+			assert.Equal(t, entry.EntryType, "earn")
+			assert.Equal(t, entry.Points, int64(25))
+			assert.Equal(t, entry.Remaining, int64(25))
+			assert.Equal(t, entry.ReferenceID, "mock-transaction")
+			assert.Equal(t, *entry.ExpiresAt, paidAt.AddDate(1, 0, 0))
+			return true, nil
+		})
+
+	refundedLot := domain.PointsEntry{
+		ID:          "mock-refunded-lot",
+		CustomerXID: "1",
+		EntryType:   "earn",
+		Points:      30,
+		Remaining:   10,
+		ReferenceID: "mock-refunded-transaction",
+	}
+	mockLoyaltyRepository.EXPECT().GetRefundedPointsLots(gomock.Any(), now, gomock.Any()).Return([]domain.PointsEntry{refundedLot}, nil)
+	mockLoyaltyRepository.EXPECT().ReversePoints(gomock.Any(), refundedLot, gomock.Any(), now).
+		DoAndReturn(func(ctx context.Context, lot domain.PointsEntry, entry domain.PointsEntry, now time.Time) (bool, error) {
+			assert.Equal(t, entry.EntryType, "earn_reversal")
+			assert.Equal(t, entry.Points, int64(-30))
+			assert.Equal(t, entry.ReferenceID, "mock-refunded-transaction")
+			return true, nil
+		})
+
+	lot := domain.PointsEntry{
+		ID:          "mock-lot",
+		CustomerXID: "1",
+		EntryType:   "earn",
+		Points:      40,
+		Remaining:   15,
+		ExpiresAt:   &expiredAt,
+	}
+	mockLoyaltyRepository.EXPECT().GetExpiredPointsLots(gomock.Any(), now, gomock.Any()).Return([]domain.PointsEntry{lot}, nil)
+	mockLoyaltyRepository.EXPECT().ExpirePoints(gomock.Any(), lot, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, lot domain.PointsEntry, entry domain.PointsEntry) (bool, error) {
+			assert.Equal(t, entry.EntryType, "expire")
+			assert.Equal(t, entry.Points, int64(-15))
+			assert.Equal(t, entry.ReferenceID, "mock-lot")
+			return true, nil
+		})
+
+	err := loyaltySvc.RunLoyaltyPoints(context.Background(), now)
+	assert.Nil(t, err)
+}
+
+func TestRedeemPoints(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.Money{Amount: 1000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.PointsRedeemRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.PointsRedemptionResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			payload:  web.PointsRedeemRequest{Points: 101},
+			mockFunc: func() {
+				mockLoyaltyRepository.EXPECT().GetPointsBalance(gomock.Any(), "1").Return(int64(150), nil)
+				mockLoyaltyWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockLoyaltyRepository.EXPECT().RedeemPoints(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, entry domain.PointsEntry, now time.Time, credit domain.Transaction) (bool, error) {
+						assert.Equal(t, entry.EntryType, "redeem")
+						assert.Equal(t, entry.Points, int64(-101))
+						assert.Equal(t, entry.TransactionID, credit.ID)
+						assert.Equal(t, credit.TransactionType, "points_redemption")
+						assert.Equal(t, credit.ReferenceID, entry.ID)
+						return true, nil
+					})
+			},
+			wantErr: false,
+			wantResult: web.PointsRedemptionResponse{
+				Points:  101,
+				Amount:  domain.Money{Amount: 50, Currency: "IDR"},
+				Balance: 49,
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - worth less than a rupiah",
+			payload:  web.PointsRedeemRequest{Points: 1},
+			mockFunc: func() {},
+			wantErr:  true,
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - insufficient points",
+			payload:  web.PointsRedeemRequest{Points: 200},
+			mockFunc: func() {
+				mockLoyaltyRepository.EXPECT().GetPointsBalance(gomock.Any(), "1").Return(int64(150), nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - points expired meanwhile",
+			payload:  web.PointsRedeemRequest{Points: 100},
+			mockFunc: func() {
+				mockLoyaltyRepository.EXPECT().GetPointsBalance(gomock.Any(), "1").Return(int64(150), nil)
+				mockLoyaltyWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockLoyaltyRepository.EXPECT().RedeemPoints(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideLoyaltyTest(t)
+			defer testDep()
+
+			tc.mockFunc()
+			got, err := loyaltySvc.RedeemPoints(context.Background(), "1", tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			if !tc.wantErr {
+				tc.wantResult.ID = got.ID
+				tc.wantResult.TransactionID = got.TransactionID
+				tc.wantResult.RedeemedAt = got.RedeemedAt
+			}
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
+
+func TestGetPoints(t *testing.T) {
+	testDep := provideLoyaltyTest(t)
+	defer testDep()
+
+	first := time.Date(2023, 4, 1, 10, 0, 0, 0, time.UTC)
+	sameDay := time.Date(2023, 4, 1, 18, 0, 0, 0, time.UTC)
+	later := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	mockLoyaltyRepository.EXPECT().GetPointsBalance(gomock.Any(), "1").Return(int64(70), nil)
+	mockLoyaltyRepository.EXPECT().GetAvailablePointsLots(gomock.Any(), "1", gomock.Any()).Return([]domain.PointsEntry{
+		{ID: "a", Remaining: 10, ExpiresAt: &first},
+		{ID: "b", Remaining: 20, ExpiresAt: &sameDay},
+		{ID: "c", Remaining: 40, ExpiresAt: &later},
+	}, nil)
+
+	got, err := loyaltySvc.GetPoints(context.Background(), "1")
+	assert.Nil(t, err)
+	assert.Equal(t, got, web.PointsResponse{
+		Balance:        70,
+		RedeemValue:    domain.Money{Amount: 35, Currency: "IDR"},
+		ExpiringPoints: 30,
+		NextExpiryAt:   &first,
+	})
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type MerchantServiceItf interface {
+	RegisterMerchant(ctx context.Context, customerXID string, request web.MerchantRequest) (web.MerchantResponse, error)
+	GetMerchant(ctx context.Context, customerXID string) (web.MerchantResponse, error)
+
+	CreatePaymentIntent(ctx context.Context, customerXID string, request web.PaymentIntentCreateRequest) (web.PaymentIntentResponse, error)
+	GetPaymentIntents(ctx context.Context, customerXID, status string) ([]web.PaymentIntentResponse, error)
+	CancelPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error)
+	RefundPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error)
+
+	GetPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error)
+	ConfirmPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error)
+}
@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type MerchantService struct {
+	MerchantRepository repository.MerchantRepository
+	WalletRepository   repository.WalletRepository
+	Validate           *validator.Validate
+	PaymentIntentTTL   time.Duration
+}
+
+func NewMerchantService(merchantRepository repository.MerchantRepository, walletRepository repository.WalletRepository, validate *validator.Validate, paymentIntentTTL time.Duration) MerchantServiceItf {
+	return &MerchantService{
+		MerchantRepository: merchantRepository,
+		WalletRepository:   walletRepository,
+		Validate:           validate,
+		PaymentIntentTTL:   paymentIntentTTL,
+	}
+}
+
+func (svc *MerchantService) RegisterMerchant(ctx context.Context, customerXID string, request web.MerchantRequest) (web.MerchantResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.MerchantResponse{}, err
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.MerchantResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.MerchantResponse{}, errors.New("wallet disabled")
+	}
+
+	_, err = svc.MerchantRepository.GetMerchantByCustomerXID(ctx, customerXID)
+	if err == nil {
+		return web.MerchantResponse{}, errors.New("merchant already registered")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.MerchantResponse{}, err
+	}
+
+	categoryCode := request.CategoryCode
+	if categoryCode == "" {
+		categoryCode = constants.DEFAULT_MERCHANT_CATEGORY_CODE
+	}
+
+	now := time.Now()
+	merchant := domain.Merchant{
+		ID:           uuid.New().String(),
+		CustomerXID:  customerXID,
+		Name:         request.Name,
+		City:         request.City,
+		CategoryCode: categoryCode,
+		Status:       constants.STATUS_ACTIVE,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err = svc.MerchantRepository.CreateMerchant(ctx, merchant)
+	if err != nil {
+		return web.MerchantResponse{}, err
+	}
+
+	return toMerchantResponse(merchant), nil
+}
+
+func (svc *MerchantService) GetMerchant(ctx context.Context, customerXID string) (web.MerchantResponse, error) {
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return web.MerchantResponse{}, err
+	}
+
+	return toMerchantResponse(merchant), nil
+}
+
+// CreatePaymentIntent is idempotent on the merchant reference: repeating a
+// request returns the intent created the first time.
+func (svc *MerchantService) CreatePaymentIntent(ctx context.Context, customerXID string, request web.PaymentIntentCreateRequest) (web.PaymentIntentResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	if merchant.Status != constants.STATUS_ACTIVE {
+		return web.PaymentIntentResponse{}, errors.New("merchant is not active")
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+	if !isSupportedCurrency(currency) {
+		return web.PaymentIntentResponse{}, errors.New("unsupported currency")
+	}
+	amount := domain.NewMoney(request.Amount, currency)
+
+	existing, err := svc.MerchantRepository.GetPaymentIntentByReference(ctx, merchant.ID, request.MerchantReference)
+	if err == nil {
+		if existing.Amount != amount {
+			return web.PaymentIntentResponse{}, errors.New("merchant_reference already used")
+		}
+		return toPaymentIntentResponse(existing, merchant, time.Now()), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// payments settle into the merchant wallet of the same currency
+	_, err = svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PaymentIntentResponse{}, errors.New("merchant has no " + currency + " wallet")
+	}
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	now := time.Now()
+	paymentIntent := domain.PaymentIntent{
+		ID:                uuid.New().String(),
+		MerchantID:        merchant.ID,
+		MerchantReference: request.MerchantReference,
+		Amount:            amount,
+		Description:       request.Description,
+		Status:            constants.STATUS_PENDING,
+		ExpiresAt:         now.Add(svc.PaymentIntentTTL),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	err = svc.MerchantRepository.CreatePaymentIntent(ctx, paymentIntent)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	return toPaymentIntentResponse(paymentIntent, merchant, now), nil
+}
+
+func (svc *MerchantService) GetPaymentIntents(ctx context.Context, customerXID, status string) ([]web.PaymentIntentResponse, error) {
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return []web.PaymentIntentResponse{}, err
+	}
+
+	paymentIntents, err := svc.MerchantRepository.GetPaymentIntents(ctx, merchant.ID, status)
+	if err != nil {
+		return []web.PaymentIntentResponse{}, err
+	}
+
+	now := time.Now()
+	result := []web.PaymentIntentResponse{}
+	for i := range paymentIntents {
+		result = append(result, toPaymentIntentResponse(paymentIntents[i], merchant, now))
+	}
+	return result, nil
+}
+
+func (svc *MerchantService) CancelPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	paymentIntent, err := svc.getPaymentIntent(ctx, paymentIntentID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	if paymentIntent.MerchantID != merchant.ID {
+		return web.PaymentIntentResponse{}, errors.New("payment intent not found")
+	}
+
+	isUpdated, err := svc.MerchantRepository.UpdatePaymentIntentStatus(ctx, paymentIntent.ID, constants.STATUS_PENDING, constants.STATUS_CANCELLED)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+	if !isUpdated {
+		return web.PaymentIntentResponse{}, errors.New("payment intent is no longer pending")
+	}
+
+	paymentIntent.Status = constants.STATUS_CANCELLED
+	return toPaymentIntentResponse(paymentIntent, merchant, time.Now()), nil
+}
+
+// GetPaymentIntent shows a checkout to the customer about to pay it.
+func (svc *MerchantService) GetPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	paymentIntent, err := svc.getPaymentIntent(ctx, paymentIntentID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// once paid, only the payer gets to see it
+	if paymentIntent.CustomerXID != "" && paymentIntent.CustomerXID != customerXID {
+		return web.PaymentIntentResponse{}, errors.New("payment intent not found")
+	}
+
+	merchant, err := svc.MerchantRepository.GetMerchant(ctx, paymentIntent.MerchantID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	return toPaymentIntentResponse(paymentIntent, merchant, time.Now()), nil
+}
+
+func (svc *MerchantService) ConfirmPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	paymentIntent, err := svc.getPaymentIntent(ctx, paymentIntentID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	now := time.Now()
+	if paymentIntent.Status != constants.STATUS_PENDING {
+		return web.PaymentIntentResponse{}, errors.New("payment intent is no longer pending")
+	}
+	if !now.Before(paymentIntent.ExpiresAt) {
+		return web.PaymentIntentResponse{}, errors.New("payment intent expired")
+	}
+
+	merchant, err := svc.MerchantRepository.GetMerchant(ctx, paymentIntent.MerchantID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	if merchant.Status != constants.STATUS_ACTIVE {
+		return web.PaymentIntentResponse{}, errors.New("merchant is not active")
+	}
+	if merchant.CustomerXID == customerXID {
+		return web.PaymentIntentResponse{}, errors.New("cannot pay own payment intent")
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, paymentIntent.Amount.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PaymentIntentResponse{}, errors.New("no " + paymentIntent.Amount.Currency + " wallet")
+	}
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// the merchant is paid out into this wallet when the payment settles
+	merchantWallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, merchant.CustomerXID, paymentIntent.Amount.Currency)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.PaymentIntentResponse{}, errors.New("wallet disabled")
+	}
+	if merchantWallet.Status == constants.STATUS_DISABLED {
+		return web.PaymentIntentResponse{}, errors.New("merchant wallet disabled")
+	}
+
+	// compare amount with balance
+	finalBalance, err := wallet.Balance.Sub(paymentIntent.Amount)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+	if finalBalance.IsNegative() {
+		return web.PaymentIntentResponse{}, errors.New("insufficient balance")
+	}
+
+	debit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_PAYMENT,
+		Amount:          paymentIntent.Amount,
+		ReferenceID:     paymentIntent.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isConfirmed, err := svc.MerchantRepository.ConfirmPaymentIntent(ctx, paymentIntent.ID, debit, now)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+	if !isConfirmed {
+		return web.PaymentIntentResponse{}, errors.New("payment intent expired or insufficient balance")
+	}
+
+	paymentIntent.Status = constants.STATUS_SUCCESS
+	paymentIntent.CustomerXID = customerXID
+	paymentIntent.TransactionID = debit.ID
+	return toPaymentIntentResponse(paymentIntent, merchant, now), nil
+}
+
+// RefundPaymentIntent pays a confirmed payment back to the customer in full.
+// The refund is netted out of the merchant's next settlement.
+func (svc *MerchantService) RefundPaymentIntent(ctx context.Context, customerXID, paymentIntentID string) (web.PaymentIntentResponse, error) {
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	paymentIntent, err := svc.getPaymentIntent(ctx, paymentIntentID)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	if paymentIntent.MerchantID != merchant.ID {
+		return web.PaymentIntentResponse{}, errors.New("payment intent not found")
+	}
+
+	if paymentIntent.Status != constants.STATUS_SUCCESS {
+		return web.PaymentIntentResponse{}, errors.New("payment intent is not paid")
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, paymentIntent.CustomerXID, paymentIntent.Amount.Currency)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// the customer wallet must be able to hold the amount
+	_, err = wallet.Balance.Add(paymentIntent.Amount)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	now := time.Now()
+	credit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_PAYMENT_REFUND,
+		Amount:          paymentIntent.Amount,
+		ReferenceID:     paymentIntent.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isRefunded, err := svc.MerchantRepository.RefundPaymentIntent(ctx, paymentIntent.ID, credit)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+	if !isRefunded {
+		return web.PaymentIntentResponse{}, errors.New("payment intent is not paid")
+	}
+
+	paymentIntent.Status = constants.STATUS_REFUNDED
+	return toPaymentIntentResponse(paymentIntent, merchant, now), nil
+}
+
+func (svc *MerchantService) getMerchant(ctx context.Context, customerXID string) (domain.Merchant, error) {
+	merchant, err := svc.MerchantRepository.GetMerchantByCustomerXID(ctx, customerXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Merchant{}, errors.New("merchant not found")
+	}
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+	return merchant, nil
+}
+
+func (svc *MerchantService) getPaymentIntent(ctx context.Context, paymentIntentID string) (domain.PaymentIntent, error) {
+	paymentIntent, err := svc.MerchantRepository.GetPaymentIntent(ctx, paymentIntentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PaymentIntent{}, errors.New("payment intent not found")
+	}
+	if err != nil {
+		return domain.PaymentIntent{}, err
+	}
+	return paymentIntent, nil
+}
+
+func toMerchantResponse(merchant domain.Merchant) web.MerchantResponse {
+	return web.MerchantResponse{
+		ID:           merchant.ID,
+		CustomerXID:  merchant.CustomerXID,
+		Name:         merchant.Name,
+		City:         merchant.City,
+		CategoryCode: merchant.CategoryCode,
+		Status:       merchant.Status,
+		CreatedAt:    merchant.CreatedAt,
+	}
+}
+
+// toPaymentIntentResponse reports a pending intent past its expiry as
+// expired, confirming it is refused either way.
+func toPaymentIntentResponse(paymentIntent domain.PaymentIntent, merchant domain.Merchant, now time.Time) web.PaymentIntentResponse {
+	status := paymentIntent.Status
+	if status == constants.STATUS_PENDING && !now.Before(paymentIntent.ExpiresAt) {
+		status = constants.STATUS_EXPIRED
+	}
+
+	return web.PaymentIntentResponse{
+		ID:                paymentIntent.ID,
+		MerchantID:        paymentIntent.MerchantID,
+		MerchantName:      merchant.Name,
+		MerchantReference: paymentIntent.MerchantReference,
+		Amount:            paymentIntent.Amount,
+		Description:       paymentIntent.Description,
+		Status:            status,
+		CustomerXID:       paymentIntent.CustomerXID,
+		TransactionID:     paymentIntent.TransactionID,
+		ExpiresAt:         paymentIntent.ExpiresAt,
+		CreatedAt:         paymentIntent.CreatedAt,
+	}
+}
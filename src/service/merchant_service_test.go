@@ -0,0 +1,403 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	merchantSvc service.MerchantServiceItf
+
+	mockMerchantRepository       *mock_repository.MockMerchantRepository
+	mockMerchantWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideMerchantTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMerchantRepository = mock_repository.NewMockMerchantRepository(ctrl)
+	mockMerchantWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	merchantSvc = service.NewMerchantService(mockMerchantRepository, mockMerchantWalletRepository, validator, 15*time.Minute)
+
+	return func() {}
+}
+
+func TestRegisterMerchant(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.MerchantRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.MerchantResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - default category code",
+			payload: web.MerchantRequest{
+				Name: "Kopi Kita",
+				City: "Jakarta",
+			},
+			mockFunc: func() {
+				mockMerchantWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(domain.Merchant{}, sql.ErrNoRows)
+				mockMerchantRepository.EXPECT().CreateMerchant(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.MerchantResponse{
+				CustomerXID:  "1",
+				Name:         "Kopi Kita",
+				City:         "Jakarta",
+				CategoryCode: "5999",
+				Status:       "active",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - already registered",
+			payload: web.MerchantRequest{
+				Name: "Kopi Kita",
+				City: "Jakarta",
+			},
+			mockFunc: func() {
+				mockMerchantWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(domain.Merchant{ID: "mock-merchant"}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.MerchantResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideMerchantTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := merchantSvc.RegisterMerchant(context.Background(), "1", tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.CustomerXID, tc.wantResult.CustomerXID)
+			assert.Equal(t, got.Name, tc.wantResult.Name)
+			assert.Equal(t, got.City, tc.wantResult.City)
+			assert.Equal(t, got.CategoryCode, tc.wantResult.CategoryCode)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+		})
+	}
+}
+
+func TestCreatePaymentIntent(t *testing.T) {
+	merchant := domain.Merchant{
+		ID:          "mock-merchant",
+		CustomerXID: "1",
+		Name:        "Kopi Kita",
+		Status:      "active",
+	}
+	existing := domain.PaymentIntent{
+		ID:                "mock-intent",
+		MerchantID:        "mock-merchant",
+		MerchantReference: "order-1",
+		Amount:            domain.Money{Amount: 25000, Currency: "IDR"},
+		Status:            "pending",
+		ExpiresAt:         time.Now().Add(time.Minute),
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.PaymentIntentCreateRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.PaymentIntentResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - new intent",
+			payload: web.PaymentIntentCreateRequest{
+				MerchantReference: "order-2",
+				Amount:            25000,
+			},
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockMerchantRepository.EXPECT().GetPaymentIntentByReference(gomock.Any(), "mock-merchant", "order-2").Return(domain.PaymentIntent{}, sql.ErrNoRows)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{ID: "mock-id"}, nil)
+				mockMerchantRepository.EXPECT().CreatePaymentIntent(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.PaymentIntentResponse{
+				MerchantID:        "mock-merchant",
+				MerchantReference: "order-2",
+				Amount:            domain.Money{Amount: 25000, Currency: "IDR"},
+				Status:            "pending",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - repeated reference returns the same intent",
+			payload: web.PaymentIntentCreateRequest{
+				MerchantReference: "order-1",
+				Amount:            25000,
+			},
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockMerchantRepository.EXPECT().GetPaymentIntentByReference(gomock.Any(), "mock-merchant", "order-1").Return(existing, nil)
+			},
+			wantErr: false,
+			wantResult: web.PaymentIntentResponse{
+				ID:                "mock-intent",
+				MerchantID:        "mock-merchant",
+				MerchantReference: "order-1",
+				Amount:            domain.Money{Amount: 25000, Currency: "IDR"},
+				Status:            "pending",
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - reference reused with another amount",
+			payload: web.PaymentIntentCreateRequest{
+				MerchantReference: "order-1",
+				Amount:            30000,
+			},
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockMerchantRepository.EXPECT().GetPaymentIntentByReference(gomock.Any(), "mock-merchant", "order-1").Return(existing, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentIntentResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideMerchantTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := merchantSvc.CreatePaymentIntent(context.Background(), "1", tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			if tc.wantResult.ID != "" {
+				assert.Equal(t, got.ID, tc.wantResult.ID)
+			}
+			assert.Equal(t, got.MerchantID, tc.wantResult.MerchantID)
+			assert.Equal(t, got.MerchantReference, tc.wantResult.MerchantReference)
+			assert.Equal(t, got.Amount, tc.wantResult.Amount)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+		})
+	}
+}
+
+func TestConfirmPaymentIntent(t *testing.T) {
+	merchant := domain.Merchant{
+		ID:          "mock-merchant",
+		CustomerXID: "1",
+		Name:        "Kopi Kita",
+		Status:      "active",
+	}
+	pending := domain.PaymentIntent{
+		ID:         "mock-intent",
+		MerchantID: "mock-merchant",
+		Amount:     domain.Money{Amount: 25000, Currency: "IDR"},
+		Status:     "pending",
+		ExpiresAt:  time.Now().Add(time.Minute),
+	}
+	expired := pending
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		testID      int
+		testDesc    string
+		customerXID string
+		mockFunc    func()
+		wantErr     bool
+		wantResult  web.PaymentIntentResponse
+	}{
+		{
+			testID:      1,
+			testDesc:    "Success - debits the customer",
+			customerXID: "2",
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(pending, nil)
+				mockMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(merchant, nil)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "2", "IDR").Return(domain.Wallet{
+					ID:          "mock-customer-wallet",
+					CustomerXID: "2",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: 50000, Currency: "IDR"},
+				}, nil)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+					ID:          "mock-merchant-wallet",
+					CustomerXID: "1",
+					Status:      "enabled",
+					Balance:     domain.Money{Currency: "IDR"},
+				}, nil)
+				mockMerchantRepository.EXPECT().ConfirmPaymentIntent(gomock.Any(), "mock-intent", gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, debit domain.Transaction, _ time.Time) (bool, error) {
+						assert.Equal(t, "payment", debit.TransactionType)
+						assert.Equal(t, "mock-customer-wallet", debit.WalletID)
+						assert.Equal(t, "mock-intent", debit.ReferenceID)
+						return true, nil
+					})
+			},
+			wantErr: false,
+			wantResult: web.PaymentIntentResponse{
+				MerchantName: "Kopi Kita",
+				Status:       "success",
+				CustomerXID:  "2",
+			},
+		},
+		{
+			testID:      2,
+			testDesc:    "Failed - insufficient balance",
+			customerXID: "2",
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(pending, nil)
+				mockMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(merchant, nil)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "2", "IDR").Return(domain.Wallet{
+					ID:      "mock-customer-wallet",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 1000, Currency: "IDR"},
+				}, nil)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+					ID:     "mock-merchant-wallet",
+					Status: "enabled",
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentIntentResponse{},
+		},
+		{
+			testID:      3,
+			testDesc:    "Failed - merchant paying itself",
+			customerXID: "1",
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(pending, nil)
+				mockMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(merchant, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentIntentResponse{},
+		},
+		{
+			testID:      4,
+			testDesc:    "Failed - expired",
+			customerXID: "2",
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(expired, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentIntentResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideMerchantTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := merchantSvc.ConfirmPaymentIntent(context.Background(), tc.customerXID, "mock-intent")
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.MerchantName, tc.wantResult.MerchantName)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, got.CustomerXID, tc.wantResult.CustomerXID)
+		})
+	}
+}
+
+func TestRefundPaymentIntent(t *testing.T) {
+	merchant := domain.Merchant{
+		ID:          "mock-merchant",
+		CustomerXID: "1",
+		Name:        "Kopi Kita",
+		Status:      "active",
+	}
+	paid := domain.PaymentIntent{
+		ID:            "mock-intent",
+		MerchantID:    "mock-merchant",
+		Amount:        domain.Money{Amount: 25000, Currency: "IDR"},
+		Status:        "success",
+		CustomerXID:   "2",
+		TransactionID: "mock-payment",
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	wallet := domain.Wallet{
+		ID:          "mock-customer-wallet",
+		CustomerXID: "2",
+		Status:      "enabled",
+		Balance:     domain.Money{Amount: 1000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		mockFunc   func()
+		wantErr    error
+		wantResult web.PaymentIntentResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - credits the customer",
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(paid, nil)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "2", "IDR").Return(wallet, nil)
+				mockMerchantRepository.EXPECT().RefundPaymentIntent(gomock.Any(), "mock-intent", gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, credit domain.Transaction) (bool, error) {
+						assert.Equal(t, "payment_refund", credit.TransactionType)
+						assert.Equal(t, "mock-customer-wallet", credit.WalletID)
+						assert.Equal(t, "mock-intent", credit.ReferenceID)
+						return true, nil
+					})
+			},
+			wantErr: nil,
+			wantResult: web.PaymentIntentResponse{
+				MerchantName: "Kopi Kita",
+				Status:       "refunded",
+				CustomerXID:  "2",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - payment already paid back by a dispute",
+			mockFunc: func() {
+				mockMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(paid, nil)
+				mockMerchantWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "2", "IDR").Return(wallet, nil)
+				mockMerchantRepository.EXPECT().RefundPaymentIntent(gomock.Any(), "mock-intent", gomock.Any()).Return(false, domain.ErrTransactionReversed)
+			},
+			wantErr:    domain.ErrTransactionReversed,
+			wantResult: web.PaymentIntentResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideMerchantTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := merchantSvc.RefundPaymentIntent(context.Background(), "1", "mock-intent")
+			assert.Equal(t, err, tc.wantErr)
+			assert.Equal(t, got.MerchantName, tc.wantResult.MerchantName)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, got.CustomerXID, tc.wantResult.CustomerXID)
+		})
+	}
+}
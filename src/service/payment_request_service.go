@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type PaymentRequestServiceItf interface {
+	CreatePaymentRequest(ctx context.Context, customerXID string, request web.PaymentRequestCreateRequest) (web.PaymentRequestResponse, error)
+	GetIncomingPaymentRequests(ctx context.Context, customerXID string) ([]web.PaymentRequestResponse, error)
+	GetOutgoingPaymentRequests(ctx context.Context, customerXID string) ([]web.PaymentRequestResponse, error)
+	AcceptPaymentRequest(ctx context.Context, customerXID, paymentRequestID string) (web.PaymentRequestResponse, error)
+	DeclinePaymentRequest(ctx context.Context, customerXID, paymentRequestID string) (web.PaymentRequestResponse, error)
+	CancelPaymentRequest(ctx context.Context, customerXID, paymentRequestID string) (web.PaymentRequestResponse, error)
+	ExpirePaymentRequests(ctx context.Context, now time.Time) error
+}
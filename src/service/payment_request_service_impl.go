@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// defaultPaymentRequestTTL applies when a request is created without expiry.
+const defaultPaymentRequestTTL = 7 * 24 * time.Hour
+
+type PaymentRequestService struct {
+	PaymentRequestRepository repository.PaymentRequestRepository
+	SplitBillRepository      repository.SplitBillRepository
+	WalletRepository         repository.WalletRepository
+	WalletService            WalletServiceItf
+	Validate                 *validator.Validate
+}
+
+func NewPaymentRequestService(paymentRequestRepository repository.PaymentRequestRepository, splitBillRepository repository.SplitBillRepository, walletRepository repository.WalletRepository, walletService WalletServiceItf, validate *validator.Validate) PaymentRequestServiceItf {
+	return &PaymentRequestService{
+		PaymentRequestRepository: paymentRequestRepository,
+		SplitBillRepository:      splitBillRepository,
+		WalletRepository:         walletRepository,
+		WalletService:            walletService,
+		Validate:                 validate,
+	}
+}
+
+func (svc *PaymentRequestService) CreatePaymentRequest(ctx context.Context, customerXID string, request web.PaymentRequestCreateRequest) (web.PaymentRequestResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	if request.PayerXID == customerXID {
+		return web.PaymentRequestResponse{}, errors.New("cannot request money from own wallet")
+	}
+
+	now := time.Now()
+	expiresAt := request.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(defaultPaymentRequestTTL)
+	}
+	if !expiresAt.After(now) {
+		return web.PaymentRequestResponse{}, errors.New("expires_at must be in the future")
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.PaymentRequestResponse{}, errors.New("wallet disabled")
+	}
+
+	_, err = svc.WalletRepository.GetWallet(ctx, request.PayerXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PaymentRequestResponse{}, errors.New("payer wallet not found")
+	}
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	paymentRequest := domain.PaymentRequest{
+		ID:           uuid.New().String(),
+		RequesterXID: customerXID,
+		PayerXID:     request.PayerXID,
+		Amount:       domain.NewMoney(request.Amount, wallet.Currency),
+		Note:         request.Note,
+		Status:       constants.STATUS_PENDING,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err = svc.PaymentRequestRepository.CreatePaymentRequest(ctx, paymentRequest)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	return toPaymentRequestResponse(paymentRequest, now), nil
+}
+
+func (svc *PaymentRequestService) GetIncomingPaymentRequests(ctx context.Context, customerXID string) ([]web.PaymentRequestResponse, error) {
+	paymentRequests, err := svc.PaymentRequestRepository.GetIncomingPaymentRequests(ctx, customerXID)
+	if err != nil {
+		return []web.PaymentRequestResponse{}, err
+	}
+
+	return toPaymentRequestResponses(paymentRequests, time.Now()), nil
+}
+
+func (svc *PaymentRequestService) GetOutgoingPaymentRequests(ctx context.Context, customerXID string) ([]web.PaymentRequestResponse, error) {
+	paymentRequests, err := svc.PaymentRequestRepository.GetOutgoingPaymentRequests(ctx, customerXID)
+	if err != nil {
+		return []web.PaymentRequestResponse{}, err
+	}
+
+	return toPaymentRequestResponses(paymentRequests, time.Now()), nil
+}
+
+func (svc *PaymentRequestService) AcceptPaymentRequest(ctx context.Context, customerXID, paymentRequestID string) (web.PaymentRequestResponse, error) {
+	paymentRequest, err := svc.getPendingPaymentRequest(ctx, paymentRequestID)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	if paymentRequest.PayerXID != customerXID {
+		return web.PaymentRequestResponse{}, errors.New("payment request not found")
+	}
+
+	// a share cannot be paid once its split bill is closed, the transfer
+	// checks again while it holds the bill
+	if paymentRequest.SplitBillID != "" {
+		splitBill, err := svc.SplitBillRepository.GetSplitBill(ctx, paymentRequest.SplitBillID)
+		if err != nil {
+			return web.PaymentRequestResponse{}, err
+		}
+		if splitBill.Status != constants.STATUS_PENDING {
+			return web.PaymentRequestResponse{}, errors.New("split bill is no longer pending")
+		}
+	}
+
+	// claim the request before moving money so a concurrent cancel or accept
+	// cannot race the transfer
+	isClaimed, err := svc.PaymentRequestRepository.UpdatePaymentRequestStatus(ctx, paymentRequest.ID, constants.STATUS_PENDING, constants.STATUS_ACCEPTED)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+	if !isClaimed {
+		return web.PaymentRequestResponse{}, errors.New("payment request is no longer pending")
+	}
+
+	transfer, err := svc.WalletService.TransferBalance(ctx, customerXID, web.TransferRequest{
+		RecipientXID: paymentRequest.RequesterXID,
+		Amount:       paymentRequest.Amount.Amount,
+		ReferenceID:  paymentRequest.ReferenceID(),
+		SplitBillID:  paymentRequest.SplitBillID,
+	})
+	if err != nil {
+		// release the claim so the payer can retry once the problem is fixed
+		_, errRevert := svc.PaymentRequestRepository.UpdatePaymentRequestStatus(ctx, paymentRequest.ID, constants.STATUS_ACCEPTED, constants.STATUS_PENDING)
+		if errRevert != nil {
+			log.Println("error revert payment request status:", errRevert.Error())
+		}
+		return web.PaymentRequestResponse{}, err
+	}
+
+	paymentRequest.Status = constants.STATUS_ACCEPTED
+	paymentRequest.TransactionID = transfer.ID
+	err = svc.PaymentRequestRepository.UpdatePaymentRequestTransaction(ctx, paymentRequest.ID, transfer.ID)
+	if err != nil {
+		log.Println("error update payment request transaction:", err.Error())
+	}
+
+	// the last paid share settles the split bill
+	if paymentRequest.SplitBillID != "" {
+		_, err = svc.SplitBillRepository.CompleteSplitBill(ctx, paymentRequest.SplitBillID)
+		if err != nil {
+			log.Println("error complete split bill:", err.Error())
+		}
+	}
+
+	return toPaymentRequestResponse(paymentRequest, time.Now()), nil
+}
+
+func (svc *PaymentRequestService) DeclinePaymentRequest(ctx context.Context, customerXID, paymentRequestID string) (web.PaymentRequestResponse, error) {
+	paymentRequest, err := svc.getPendingPaymentRequest(ctx, paymentRequestID)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	if paymentRequest.PayerXID != customerXID {
+		return web.PaymentRequestResponse{}, errors.New("payment request not found")
+	}
+
+	return svc.closePaymentRequest(ctx, paymentRequest, constants.STATUS_DECLINED)
+}
+
+func (svc *PaymentRequestService) CancelPaymentRequest(ctx context.Context, customerXID, paymentRequestID string) (web.PaymentRequestResponse, error) {
+	paymentRequest, err := svc.getPendingPaymentRequest(ctx, paymentRequestID)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+
+	if paymentRequest.RequesterXID != customerXID {
+		return web.PaymentRequestResponse{}, errors.New("payment request not found")
+	}
+
+	if paymentRequest.SplitBillID != "" {
+		return web.PaymentRequestResponse{}, errors.New("cancel the split bill instead")
+	}
+
+	return svc.closePaymentRequest(ctx, paymentRequest, constants.STATUS_CANCELLED)
+}
+
+func (svc *PaymentRequestService) ExpirePaymentRequests(ctx context.Context, now time.Time) error {
+	_, err := svc.PaymentRequestRepository.ExpirePaymentRequests(ctx, now)
+	return err
+}
+
+func (svc *PaymentRequestService) closePaymentRequest(ctx context.Context, paymentRequest domain.PaymentRequest, status string) (web.PaymentRequestResponse, error) {
+	isUpdated, err := svc.PaymentRequestRepository.UpdatePaymentRequestStatus(ctx, paymentRequest.ID, constants.STATUS_PENDING, status)
+	if err != nil {
+		return web.PaymentRequestResponse{}, err
+	}
+	if !isUpdated {
+		return web.PaymentRequestResponse{}, errors.New("payment request is no longer pending")
+	}
+
+	paymentRequest.Status = status
+	return toPaymentRequestResponse(paymentRequest, time.Now()), nil
+}
+
+// getPendingPaymentRequest loads a request that can still be acted on. A
+// request found past its expiry is expired on the spot instead of waiting
+// for the background job.
+func (svc *PaymentRequestService) getPendingPaymentRequest(ctx context.Context, paymentRequestID string) (domain.PaymentRequest, error) {
+	paymentRequest, err := svc.PaymentRequestRepository.GetPaymentRequest(ctx, paymentRequestID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PaymentRequest{}, errors.New("payment request not found")
+	}
+	if err != nil {
+		return domain.PaymentRequest{}, err
+	}
+
+	if paymentRequest.Status != constants.STATUS_PENDING {
+		return domain.PaymentRequest{}, errors.New("payment request is no longer pending")
+	}
+
+	if !time.Now().Before(paymentRequest.ExpiresAt) {
+		_, err = svc.PaymentRequestRepository.UpdatePaymentRequestStatus(ctx, paymentRequest.ID, constants.STATUS_PENDING, constants.STATUS_EXPIRED)
+		if err != nil {
+			return domain.PaymentRequest{}, err
+		}
+		return domain.PaymentRequest{}, errors.New("payment request expired")
+	}
+
+	return paymentRequest, nil
+}
+
+func toPaymentRequestResponses(paymentRequests []domain.PaymentRequest, now time.Time) []web.PaymentRequestResponse {
+	result := []web.PaymentRequestResponse{}
+	for i := range paymentRequests {
+		result = append(result, toPaymentRequestResponse(paymentRequests[i], now))
+	}
+	return result
+}
+
+// toPaymentRequestResponse reports a pending request past its expiry as
+// expired even if the background job has not caught up with it yet.
+func toPaymentRequestResponse(paymentRequest domain.PaymentRequest, now time.Time) web.PaymentRequestResponse {
+	status := paymentRequest.Status
+	if status == constants.STATUS_PENDING && !now.Before(paymentRequest.ExpiresAt) {
+		status = constants.STATUS_EXPIRED
+	}
+
+	return web.PaymentRequestResponse{
+		ID:            paymentRequest.ID,
+		SplitBillID:   paymentRequest.SplitBillID,
+		RequesterXID:  paymentRequest.RequesterXID,
+		PayerXID:      paymentRequest.PayerXID,
+		Amount:        paymentRequest.Amount,
+		Note:          paymentRequest.Note,
+		Status:        status,
+		TransactionID: paymentRequest.TransactionID,
+		ExpiresAt:     paymentRequest.ExpiresAt,
+		CreatedAt:     paymentRequest.CreatedAt,
+	}
+}
@@ -0,0 +1,261 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	mock_service "github.com/mozartmuhammad/julo-be-test/src/mock/service"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	paymentRequestSvc service.PaymentRequestServiceItf
+
+	mockPaymentRequestRepository       *mock_repository.MockPaymentRequestRepository
+	mockPaymentRequestSplitBillRepo    *mock_repository.MockSplitBillRepository
+	mockPaymentRequestWalletRepository *mock_repository.MockWalletRepository
+	mockPaymentRequestWalletService    *mock_service.MockWalletServiceItf
+)
+
+func providePaymentRequestTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPaymentRequestRepository = mock_repository.NewMockPaymentRequestRepository(ctrl)
+	mockPaymentRequestSplitBillRepo = mock_repository.NewMockSplitBillRepository(ctrl)
+	mockPaymentRequestWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockPaymentRequestWalletService = mock_service.NewMockWalletServiceItf(ctrl)
+	validator := validator.New()
+	paymentRequestSvc = service.NewPaymentRequestService(mockPaymentRequestRepository, mockPaymentRequestSplitBillRepo, mockPaymentRequestWalletRepository, mockPaymentRequestWalletService, validator)
+
+	return func() {}
+}
+
+func TestCreatePaymentRequest(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.PaymentRequestCreateRequest
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.PaymentRequestResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			args: args{
+				customerXID: "1",
+				payload: web.PaymentRequestCreateRequest{
+					PayerXID: "2",
+					Amount:   1000,
+					Note:     "dinner",
+				},
+			},
+			mockFunc: func() {
+				mockPaymentRequestWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:       "mock-id",
+					Status:   "enabled",
+					Currency: "IDR",
+				}, nil)
+				mockPaymentRequestWalletRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{
+					ID:     "mock-id-2",
+					Status: "enabled",
+				}, nil)
+				mockPaymentRequestRepository.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.PaymentRequestResponse{
+				RequesterXID: "1",
+				PayerXID:     "2",
+				Amount:       domain.Money{Amount: 1000, Currency: "IDR"},
+				Note:         "dinner",
+				Status:       "pending",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - request to self",
+			args: args{
+				customerXID: "1",
+				payload: web.PaymentRequestCreateRequest{
+					PayerXID: "1",
+					Amount:   1000,
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - expiry in the past",
+			args: args{
+				customerXID: "1",
+				payload: web.PaymentRequestCreateRequest{
+					PayerXID:  "2",
+					Amount:    1000,
+					ExpiresAt: time.Now().Add(-time.Hour),
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePaymentRequestTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := paymentRequestSvc.CreatePaymentRequest(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.RequesterXID, tc.wantResult.RequesterXID)
+			assert.Equal(t, got.PayerXID, tc.wantResult.PayerXID)
+			assert.Equal(t, got.Amount, tc.wantResult.Amount)
+			assert.Equal(t, got.Note, tc.wantResult.Note)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+		})
+	}
+}
+
+func TestAcceptPaymentRequest(t *testing.T) {
+	pending := domain.PaymentRequest{
+		ID:           "mock-request",
+		RequesterXID: "1",
+		PayerXID:     "2",
+		Amount:       domain.Money{Amount: 1000, Currency: "IDR"},
+		Status:       "pending",
+		ExpiresAt:    time.Now().Add(time.Hour),
+	}
+	expired := pending
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	share := pending
+	share.SplitBillID = "mock-bill"
+
+	testCases := []struct {
+		testID      int
+		testDesc    string
+		customerXID string
+		mockFunc    func()
+		wantErr     bool
+		wantResult  web.PaymentRequestResponse
+	}{
+		{
+			testID:      1,
+			testDesc:    "Success - transfers to requester",
+			customerXID: "2",
+			mockFunc: func() {
+				mockPaymentRequestRepository.EXPECT().GetPaymentRequest(gomock.Any(), "mock-request").Return(pending, nil)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "pending", "accepted").Return(true, nil)
+				mockPaymentRequestWalletService.EXPECT().TransferBalance(gomock.Any(), "2", web.TransferRequest{
+					RecipientXID: "1",
+					Amount:       1000,
+					ReferenceID:  "payreq-mock-request",
+				}).Return(web.TransferResponse{ID: "mock-transaction"}, nil)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestTransaction(gomock.Any(), "mock-request", "mock-transaction").Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.PaymentRequestResponse{
+				Status:        "accepted",
+				TransactionID: "mock-transaction",
+			},
+		},
+		{
+			testID:      2,
+			testDesc:    "Failed - transfer error reverts to pending",
+			customerXID: "2",
+			mockFunc: func() {
+				mockPaymentRequestRepository.EXPECT().GetPaymentRequest(gomock.Any(), "mock-request").Return(pending, nil)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "pending", "accepted").Return(true, nil)
+				mockPaymentRequestWalletService.EXPECT().TransferBalance(gomock.Any(), "2", gomock.Any()).Return(web.TransferResponse{}, errors.New("balance is not enough"))
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "accepted", "pending").Return(true, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+		{
+			testID:      3,
+			testDesc:    "Failed - not the payer",
+			customerXID: "1",
+			mockFunc: func() {
+				mockPaymentRequestRepository.EXPECT().GetPaymentRequest(gomock.Any(), "mock-request").Return(pending, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+		{
+			testID:      4,
+			testDesc:    "Failed - expired",
+			customerXID: "2",
+			mockFunc: func() {
+				mockPaymentRequestRepository.EXPECT().GetPaymentRequest(gomock.Any(), "mock-request").Return(expired, nil)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "pending", "expired").Return(true, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+		{
+			testID:      5,
+			testDesc:    "Failed - claimed concurrently",
+			customerXID: "2",
+			mockFunc: func() {
+				mockPaymentRequestRepository.EXPECT().GetPaymentRequest(gomock.Any(), "mock-request").Return(pending, nil)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "pending", "accepted").Return(false, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+		{
+			testID:      6,
+			testDesc:    "Failed - split bill closed while the share was paid",
+			customerXID: "2",
+			mockFunc: func() {
+				mockPaymentRequestRepository.EXPECT().GetPaymentRequest(gomock.Any(), "mock-request").Return(share, nil)
+				mockPaymentRequestSplitBillRepo.EXPECT().GetSplitBill(gomock.Any(), "mock-bill").Return(domain.SplitBill{ID: "mock-bill", Status: "pending"}, nil)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "pending", "accepted").Return(true, nil)
+				mockPaymentRequestWalletService.EXPECT().TransferBalance(gomock.Any(), "2", web.TransferRequest{
+					RecipientXID: "1",
+					Amount:       1000,
+					ReferenceID:  "payreq-mock-request",
+					SplitBillID:  "mock-bill",
+				}).Return(web.TransferResponse{}, domain.ErrSplitBillClosed)
+				mockPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-request", "accepted", "pending").Return(true, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PaymentRequestResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePaymentRequestTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := paymentRequestSvc.AcceptPaymentRequest(context.Background(), tc.customerXID, "mock-request")
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, got.TransactionID, tc.wantResult.TransactionID)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type PayoutBatchServiceItf interface {
+	// CreatePayoutBatch validates every row of the batch, reserves its total
+	// from the wallet and pays the rows out. A reference used before returns
+	// the batch already created.
+	CreatePayoutBatch(ctx context.Context, customerXID string, request web.PayoutBatchRequest) (web.PayoutBatchResponse, error)
+	GetPayoutBatches(ctx context.Context, customerXID string) ([]web.PayoutBatchResponse, error)
+	GetPayoutBatch(ctx context.Context, customerXID, batchID string) (web.PayoutBatchResponse, error)
+	// RetryPayoutBatch pays the failed rows of a batch out again, their
+	// amounts are still reserved.
+	RetryPayoutBatch(ctx context.Context, customerXID, batchID string) (web.PayoutBatchResponse, error)
+	// CancelPayoutBatch gives up on the failed rows of a batch and releases
+	// their amounts back to the wallet.
+	CancelPayoutBatch(ctx context.Context, customerXID, batchID string) (web.PayoutBatchResponse, error)
+	// RunPayoutBatches finishes batches whose rows were left pending, when the
+	// request paying them out did not complete.
+	RunPayoutBatches(ctx context.Context, now time.Time) error
+}
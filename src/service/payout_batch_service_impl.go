@@ -0,0 +1,587 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+const (
+	maxPayoutBatchRows = 1000
+	// payoutBatchConcurrency bounds the rows of a batch paid out at once, it
+	// is also how many name inquiries a batch keeps open with the bank
+	payoutBatchConcurrency = 8
+	// a running batch untouched for this long is no longer being paid out by
+	// the job that claimed it
+	payoutBatchStaleAfter = 10 * time.Minute
+)
+
+type PayoutBatchService struct {
+	PayoutBatchRepository repository.PayoutBatchRepository
+	PayoutRepository      repository.PayoutRepository
+	WalletRepository      repository.WalletRepository
+	PayoutProvider        bank.PayoutProvider
+	NameResolver          bank.NameResolver
+	Validate              *validator.Validate
+}
+
+func NewPayoutBatchService(payoutBatchRepository repository.PayoutBatchRepository, payoutRepository repository.PayoutRepository, walletRepository repository.WalletRepository, payoutProvider bank.PayoutProvider, nameResolver bank.NameResolver, validate *validator.Validate) PayoutBatchServiceItf {
+	return &PayoutBatchService{
+		PayoutBatchRepository: payoutBatchRepository,
+		PayoutRepository:      payoutRepository,
+		WalletRepository:      walletRepository,
+		PayoutProvider:        payoutProvider,
+		NameResolver:          nameResolver,
+		Validate:              validate,
+	}
+}
+
+func (svc *PayoutBatchService) CreatePayoutBatch(ctx context.Context, customerXID string, request web.PayoutBatchRequest) (web.PayoutBatchResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	rows, err := svc.parsePayoutBatchRows(ctx, request)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	total := domain.NewMoney(0, wallet.Currency)
+	for _, row := range rows {
+		total, err = total.Add(domain.NewMoney(row.Amount, wallet.Currency))
+		if err != nil {
+			return web.PayoutBatchResponse{}, err
+		}
+	}
+
+	// uploading a batch again returns it as it stands
+	existing, err := svc.PayoutBatchRepository.GetPayoutBatchByReference(ctx, customerXID, request.Reference)
+	if err == nil {
+		return svc.existingPayoutBatch(ctx, existing, total, len(rows))
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.PayoutBatchResponse{}, errors.New("wallet disabled")
+	}
+
+	finalBalance, err := wallet.Balance.Sub(total)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+	if finalBalance.IsNegative() {
+		return web.PayoutBatchResponse{}, errors.New("insufficient balance")
+	}
+
+	now := time.Now()
+	batch := domain.PayoutBatch{
+		ID:          uuid.New().String(),
+		WalletID:    wallet.ID,
+		CustomerXID: wallet.CustomerXID,
+		Reference:   request.Reference,
+		TotalAmount: total,
+		RowCount:    len(rows),
+		Status:      constants.STATUS_PENDING,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	items := make([]domain.PayoutBatchItem, len(rows))
+	for i, row := range rows {
+		items[i] = domain.PayoutBatchItem{
+			ID:         uuid.New().String(),
+			BatchID:    batch.ID,
+			LineNumber: i + 1,
+			BankAccount: domain.BankAccount{
+				BankCode:      row.BankCode,
+				AccountNumber: row.AccountNumber,
+				AccountName:   row.AccountName,
+			},
+			Amount:      domain.NewMoney(row.Amount, wallet.Currency),
+			ReferenceID: row.ReferenceID,
+			Status:      constants.STATUS_PENDING,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	}
+	reservation := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_PAYOUT_BATCH_RESERVATION,
+		Amount:          total,
+		ReferenceID:     batch.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isCreated, err := svc.PayoutBatchRepository.CreatePayoutBatch(ctx, batch, items, reservation)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+	if !isCreated {
+		// the same batch may have been uploaded twice at once
+		existing, err = svc.PayoutBatchRepository.GetPayoutBatchByReference(ctx, customerXID, request.Reference)
+		if err == nil {
+			return svc.existingPayoutBatch(ctx, existing, total, len(rows))
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return web.PayoutBatchResponse{}, err
+		}
+		return web.PayoutBatchResponse{}, errors.New("insufficient balance")
+	}
+
+	// the rows are paid out by the payout batches job
+	return toPayoutBatchResponse(batch, items), nil
+}
+
+// existingPayoutBatch answers an upload whose reference is already taken. The
+// batch is only the same one when its total and row count agree.
+func (svc *PayoutBatchService) existingPayoutBatch(ctx context.Context, batch domain.PayoutBatch, total domain.Money, rowCount int) (web.PayoutBatchResponse, error) {
+	if batch.TotalAmount != total || batch.RowCount != rowCount {
+		return web.PayoutBatchResponse{}, errors.New("reference already used by another batch")
+	}
+
+	items, err := svc.PayoutBatchRepository.GetPayoutBatchItems(ctx, batch.ID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+	return toPayoutBatchResponse(batch, items), nil
+}
+
+// parsePayoutBatchRows reads every row of the uploaded file and validates
+// them all before anything is reserved. Rows are numbered from 1, a csv
+// header does not count.
+func (svc *PayoutBatchService) parsePayoutBatchRows(ctx context.Context, request web.PayoutBatchRequest) ([]web.PayoutBatchRow, error) {
+	var rows []web.PayoutBatchRow
+	var err error
+	switch request.Format {
+	case constants.BATCH_FORMAT_CSV:
+		rows, err = parsePayoutBatchCSV(request.Data)
+	case constants.BATCH_FORMAT_JSON:
+		err = json.Unmarshal(request.Data, &rows)
+		if err != nil {
+			return nil, errors.New("batch is not a json array of rows")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("batch has no rows")
+	}
+	if len(rows) > maxPayoutBatchRows {
+		return nil, fmt.Errorf("batch has more than %d rows", maxPayoutBatchRows)
+	}
+
+	references := map[string]int{}
+	for i := range rows {
+		err = svc.Validate.StructCtx(ctx, rows[i])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %s", i+1, err.Error())
+		}
+
+		if first, ok := references[rows[i].ReferenceID]; ok {
+			return nil, fmt.Errorf("row %d: reference_id already used by row %d", i+1, first)
+		}
+		references[rows[i].ReferenceID] = i + 1
+	}
+	return rows, nil
+}
+
+// parsePayoutBatchCSV reads a csv file with a header row naming its columns.
+// bank_code, account_number, amount and reference_id are required,
+// account_name is optional.
+func parsePayoutBatchCSV(data []byte) ([]web.PayoutBatchRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("batch has no rows")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"bank_code", "account_number", "amount", "reference_id"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New("batch is missing the " + name + " column")
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []web.PayoutBatchRow
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := strconv.ParseInt(field(record, "amount"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid amount", row)
+		}
+
+		rows = append(rows, web.PayoutBatchRow{
+			BankCode:      field(record, "bank_code"),
+			AccountNumber: field(record, "account_number"),
+			AccountName:   field(record, "account_name"),
+			Amount:        amount,
+			ReferenceID:   field(record, "reference_id"),
+		})
+	}
+	return rows, nil
+}
+
+func (svc *PayoutBatchService) GetPayoutBatches(ctx context.Context, customerXID string) ([]web.PayoutBatchResponse, error) {
+	batches, err := svc.PayoutBatchRepository.GetPayoutBatches(ctx, customerXID)
+	if err != nil {
+		return []web.PayoutBatchResponse{}, err
+	}
+
+	result := []web.PayoutBatchResponse{}
+	for i := range batches {
+		result = append(result, toPayoutBatchResponse(batches[i], nil))
+	}
+	return result, nil
+}
+
+func (svc *PayoutBatchService) GetPayoutBatch(ctx context.Context, customerXID, batchID string) (web.PayoutBatchResponse, error) {
+	batch, err := svc.getOwnedPayoutBatch(ctx, customerXID, batchID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	items, err := svc.PayoutBatchRepository.GetPayoutBatchItems(ctx, batch.ID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+	return toPayoutBatchResponse(batch, items), nil
+}
+
+func (svc *PayoutBatchService) RetryPayoutBatch(ctx context.Context, customerXID, batchID string) (web.PayoutBatchResponse, error) {
+	batch, err := svc.getOwnedPayoutBatch(ctx, customerXID, batchID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	if batch.Status != constants.STATUS_PARTIAL && batch.Status != constants.STATUS_FAILED {
+		return web.PayoutBatchResponse{}, errors.New("payout batch has no failed rows")
+	}
+
+	isRetried, err := svc.PayoutBatchRepository.RetryPayoutBatch(ctx, batch.ID, batch.Status)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+	if !isRetried {
+		return web.PayoutBatchResponse{}, errors.New("payout batch changed, please retry")
+	}
+
+	// the failed rows are paid out again by the payout batches job
+	items, err := svc.PayoutBatchRepository.GetPayoutBatchItems(ctx, batch.ID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	batch.Status = constants.STATUS_PENDING
+	batch.UpdatedAt = time.Now()
+	return toPayoutBatchResponse(batch, items), nil
+}
+
+func (svc *PayoutBatchService) CancelPayoutBatch(ctx context.Context, customerXID, batchID string) (web.PayoutBatchResponse, error) {
+	batch, err := svc.getOwnedPayoutBatch(ctx, customerXID, batchID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	if batch.Status != constants.STATUS_PARTIAL && batch.Status != constants.STATUS_FAILED {
+		return web.PayoutBatchResponse{}, errors.New("payout batch has no failed rows")
+	}
+
+	items, err := svc.PayoutBatchRepository.GetPayoutBatchItems(ctx, batch.ID)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+
+	released := domain.NewMoney(0, batch.TotalAmount.Currency)
+	var itemIDs []string
+	for i := range items {
+		if items[i].Status != constants.STATUS_FAILED {
+			continue
+		}
+		released, err = released.Add(items[i].Amount)
+		if err != nil {
+			return web.PayoutBatchResponse{}, err
+		}
+		itemIDs = append(itemIDs, items[i].ID)
+	}
+
+	now := time.Now()
+	release := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        batch.WalletID,
+		CustomerXID:     batch.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_PAYOUT_BATCH_RELEASE,
+		Amount:          released,
+		ReferenceID:     batch.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	isCancelled, err := svc.PayoutBatchRepository.CancelPayoutBatch(ctx, batch.ID, batch.Status, itemIDs, release)
+	if err != nil {
+		return web.PayoutBatchResponse{}, err
+	}
+	if !isCancelled {
+		return web.PayoutBatchResponse{}, errors.New("payout batch changed, please retry")
+	}
+
+	batch.Status = constants.STATUS_CANCELLED
+	batch.UpdatedAt = now
+	for i := range items {
+		if items[i].Status == constants.STATUS_FAILED {
+			items[i].Status = constants.STATUS_CANCELLED
+		}
+	}
+	return toPayoutBatchResponse(batch, items), nil
+}
+
+func (svc *PayoutBatchService) RunPayoutBatches(ctx context.Context, now time.Time) error {
+	staleBefore := now.Add(-payoutBatchStaleAfter)
+	batches, err := svc.PayoutBatchRepository.GetDuePayoutBatches(ctx, staleBefore, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range batches {
+		// another runner may have claimed the batch meanwhile
+		isClaimed, err := svc.PayoutBatchRepository.ClaimPayoutBatch(ctx, batches[i].ID, staleBefore)
+		if err != nil {
+			log.Println("error claim payout batch", batches[i].ID+":", err.Error())
+			continue
+		}
+		if !isClaimed {
+			continue
+		}
+
+		batches[i].Status = constants.STATUS_RUNNING
+		err = svc.executePayoutBatch(ctx, batches[i])
+		if err != nil {
+			log.Println("error execute payout batch", batches[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *PayoutBatchService) getOwnedPayoutBatch(ctx context.Context, customerXID, batchID string) (domain.PayoutBatch, error) {
+	batch, err := svc.PayoutBatchRepository.GetPayoutBatch(ctx, batchID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PayoutBatch{}, errors.New("payout batch not found")
+	}
+	if err != nil {
+		return domain.PayoutBatch{}, err
+	}
+
+	if batch.CustomerXID != customerXID {
+		return domain.PayoutBatch{}, errors.New("payout batch not found")
+	}
+	return batch, nil
+}
+
+// executePayoutBatch pays out the pending rows of a running batch, a few at
+// a time. A row is paid or failed once the bank settled its payout, until
+// then the batch goes back to pending and is looked at again by the next
+// run. Once every row is settled the batch is completed, partial or failed,
+// depending on how many rows were paid.
+func (svc *PayoutBatchService) executePayoutBatch(ctx context.Context, batch domain.PayoutBatch) error {
+	items, err := svc.PayoutBatchRepository.GetPayoutBatchItems(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, payoutBatchConcurrency)
+	for i := range items {
+		if items[i].Status != constants.STATUS_PENDING {
+			continue
+		}
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(item domain.PayoutBatchItem) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := svc.payPayoutBatchItem(ctx, batch, item)
+			if err != nil {
+				log.Println("error pay payout batch item", item.ID+":", err.Error())
+			}
+		}(items[i])
+	}
+	wg.Wait()
+
+	items, err = svc.PayoutBatchRepository.GetPayoutBatchItems(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	var paid, failed int
+	for i := range items {
+		switch items[i].Status {
+		case constants.STATUS_PAID:
+			paid++
+		case constants.STATUS_FAILED:
+			failed++
+		}
+	}
+	status := constants.STATUS_PARTIAL
+	switch {
+	case paid+failed < len(items):
+		status = constants.STATUS_PENDING
+	case failed == 0:
+		status = constants.STATUS_COMPLETED
+	case paid == 0:
+		status = constants.STATUS_FAILED
+	}
+	_, err = svc.PayoutBatchRepository.UpdatePayoutBatchStatus(ctx, batch.ID, batch.Status, status)
+	return err
+}
+
+// payPayoutBatchItem confirms the holder of the row's bank account and turns
+// the row into a payout. A row whose inquiry fails is failed with the
+// reason, it stays reserved until it is retried or cancelled.
+func (svc *PayoutBatchService) payPayoutBatchItem(ctx context.Context, batch domain.PayoutBatch, item domain.PayoutBatchItem) error {
+	accountName, err := svc.NameResolver.ResolveAccountName(ctx, item.BankAccount.BankCode, item.BankAccount.AccountNumber)
+	if err == nil && item.BankAccount.AccountName != "" && !sameHolderName(item.BankAccount.AccountName, accountName) {
+		err = errors.New("account holder name does not match")
+	}
+	if err != nil {
+		_, err = svc.PayoutBatchRepository.FailPayoutBatchItem(ctx, item.ID, truncate(err.Error(), 255))
+		return err
+	}
+
+	now := time.Now()
+	bankAccount := item.BankAccount
+	bankAccount.AccountName = accountName
+	beneficiary, err := svc.PayoutRepository.SaveBeneficiary(ctx, domain.Beneficiary{
+		ID:          uuid.New().String(),
+		CustomerXID: batch.CustomerXID,
+		BankAccount: bankAccount,
+		VerifiedAt:  &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return err
+	}
+
+	// a row retried after its payout failed is paid out again with a
+	// payout of its own, so the transactions refer to the payout
+	payoutID := uuid.New().String()
+	release := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        batch.WalletID,
+		CustomerXID:     batch.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_PAYOUT_BATCH_RELEASE,
+		Amount:          item.Amount,
+		ReferenceID:     payoutID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	withdrawal := release
+	withdrawal.ID = uuid.New().String()
+	withdrawal.TransactionType = constants.TRANSACTION_TYPE_WITHDRAWAL
+	payout := domain.Payout{
+		ID:            payoutID,
+		WalletID:      batch.WalletID,
+		CustomerXID:   batch.CustomerXID,
+		BeneficiaryID: beneficiary.ID,
+		TransactionID: withdrawal.ID,
+		BankAccount:   bankAccount,
+		Amount:        item.Amount,
+		Status:        constants.STATUS_PENDING,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	isPaid, err := svc.PayoutBatchRepository.PayPayoutBatchItem(ctx, item.ID, payout, release, withdrawal)
+	if err != nil || !isPaid {
+		return err
+	}
+
+	// a payout the provider did not take stays pending and is submitted
+	// again by the payouts job
+	_, err = submitPayout(ctx, svc.PayoutRepository, svc.PayoutProvider, payout)
+	return err
+}
+
+// toPayoutBatchResponse leaves the rows out when items is nil.
+func toPayoutBatchResponse(batch domain.PayoutBatch, items []domain.PayoutBatchItem) web.PayoutBatchResponse {
+	result := web.PayoutBatchResponse{
+		ID:          batch.ID,
+		Reference:   batch.Reference,
+		TotalAmount: batch.TotalAmount,
+		RowCount:    batch.RowCount,
+		Status:      batch.Status,
+		CreatedAt:   batch.CreatedAt,
+		UpdatedAt:   batch.UpdatedAt,
+	}
+	for i := range items {
+		result.Items = append(result.Items, web.PayoutBatchItemResponse{
+			ID:            items[i].ID,
+			LineNumber:    items[i].LineNumber,
+			BankCode:      items[i].BankAccount.BankCode,
+			AccountNumber: items[i].BankAccount.AccountNumber,
+			AccountName:   items[i].BankAccount.AccountName,
+			Amount:        items[i].Amount,
+			ReferenceID:   items[i].ReferenceID,
+			Status:        items[i].Status,
+			FailureReason: items[i].FailureReason,
+			PayoutID:      items[i].PayoutID,
+			PayoutStatus:  items[i].PayoutStatus,
+		})
+	}
+	return result
+}
@@ -0,0 +1,382 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	payoutBatchSvc service.PayoutBatchServiceItf
+
+	mockPayoutBatchRepository       *mock_repository.MockPayoutBatchRepository
+	mockPayoutBatchPayoutRepository *mock_repository.MockPayoutRepository
+	mockPayoutBatchWalletRepository *mock_repository.MockWalletRepository
+)
+
+func providePayoutBatchTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPayoutBatchRepository = mock_repository.NewMockPayoutBatchRepository(ctrl)
+	mockPayoutBatchPayoutRepository = mock_repository.NewMockPayoutRepository(ctrl)
+	mockPayoutBatchWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	payoutBatchSvc = service.NewPayoutBatchService(mockPayoutBatchRepository, mockPayoutBatchPayoutRepository, mockPayoutBatchWalletRepository,
+		bank.NewStubPayoutProvider(0), bank.NewStubNameResolver(), validator)
+
+	return func() {}
+}
+
+func TestCreatePayoutBatch(t *testing.T) {
+	wallet := domain.Wallet{
+		ID:          "mock-wallet",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.NewMoney(5000, "IDR"),
+	}
+	existing := domain.PayoutBatch{
+		ID:          "mock-batch",
+		CustomerXID: "1",
+		Reference:   "PAYROLL-01",
+		TotalAmount: domain.NewMoney(1500, "IDR"),
+		RowCount:    2,
+		Status:      "completed",
+	}
+	csvBatch := []byte("bank_code,account_number,account_name,amount,reference_id\n" +
+		"BCA,1234567890,,1000,R1\n" +
+		"BNI,9876540000,Jane Doe,500,R2\n")
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.PayoutBatchRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.PayoutBatchResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - total reserved, rows left to the job",
+			payload: web.PayoutBatchRequest{
+				Reference: "PAYROLL-01",
+				Format:    "csv",
+				Data:      csvBatch,
+			},
+			mockFunc: func() {
+				mockPayoutBatchWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(wallet, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchByReference(gomock.Any(), "1", "PAYROLL-01").Return(domain.PayoutBatch{}, sql.ErrNoRows)
+				mockPayoutBatchRepository.EXPECT().CreatePayoutBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, batch domain.PayoutBatch, batchItems []domain.PayoutBatchItem, reservation domain.Transaction) (bool, error) {
+						assert.Equal(t, batch.TotalAmount, domain.NewMoney(1500, "IDR"))
+						assert.Equal(t, batch.RowCount, 2)
+						assert.Equal(t, reservation.TransactionType, "payout_batch_reservation")
+						assert.Equal(t, reservation.Amount, domain.NewMoney(1500, "IDR"))
+						assert.Equal(t, reservation.ReferenceID, batch.ID)
+						assert.Equal(t, batchItems[1].LineNumber, 2)
+						assert.Equal(t, batchItems[1].Amount, domain.NewMoney(500, "IDR"))
+						assert.Equal(t, batchItems[1].Status, "pending")
+						return true, nil
+					})
+			},
+			wantErr: nil,
+			wantResult: web.PayoutBatchResponse{
+				Reference:   "PAYROLL-01",
+				TotalAmount: domain.NewMoney(1500, "IDR"),
+				RowCount:    2,
+				Status:      "pending",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - batch uploaded again",
+			payload: web.PayoutBatchRequest{
+				Reference: "PAYROLL-01",
+				Format:    "csv",
+				Data:      csvBatch,
+			},
+			mockFunc: func() {
+				mockPayoutBatchWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(wallet, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchByReference(gomock.Any(), "1", "PAYROLL-01").Return(existing, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchItems(gomock.Any(), "mock-batch").Return([]domain.PayoutBatchItem{
+					{ID: "item-1", Status: "paid"},
+					{ID: "item-2", Status: "paid"},
+				}, nil)
+			},
+			wantErr: nil,
+			wantResult: web.PayoutBatchResponse{
+				ID:          "mock-batch",
+				Reference:   "PAYROLL-01",
+				TotalAmount: domain.NewMoney(1500, "IDR"),
+				RowCount:    2,
+				Status:      "completed",
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - reference used by another batch",
+			payload: web.PayoutBatchRequest{
+				Reference: "PAYROLL-01",
+				Format:    "json",
+				Data:      []byte(`[{"bank_code":"BCA","account_number":"1234567890","amount":1500,"reference_id":"R1"}]`),
+			},
+			mockFunc: func() {
+				mockPayoutBatchWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(wallet, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchByReference(gomock.Any(), "1", "PAYROLL-01").Return(existing, nil)
+			},
+			wantErr:    fmt.Errorf("reference already used by another batch"),
+			wantResult: web.PayoutBatchResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - invalid account number",
+			payload: web.PayoutBatchRequest{
+				Reference: "PAYROLL-02",
+				Format:    "json",
+				Data: []byte(`[{"bank_code":"BCA","account_number":"1234567890","amount":1000,"reference_id":"R1"},
+					{"bank_code":"BCA","account_number":"12-34","amount":1000,"reference_id":"R2"}]`),
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("row 2: Key: 'PayoutBatchRow.AccountNumber' Error:Field validation for 'AccountNumber' failed on the 'numeric' tag"),
+			wantResult: web.PayoutBatchResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - duplicate reference_id",
+			payload: web.PayoutBatchRequest{
+				Reference: "PAYROLL-02",
+				Format:    "csv",
+				Data: []byte("bank_code,account_number,amount,reference_id\n" +
+					"BCA,1234567890,1000,R1\n" +
+					"BCA,1234567891,1000,R1\n"),
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("row 2: reference_id already used by row 1"),
+			wantResult: web.PayoutBatchResponse{},
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - total exceeds the balance",
+			payload: web.PayoutBatchRequest{
+				Reference: "PAYROLL-02",
+				Format:    "csv",
+				Data: []byte("bank_code,account_number,amount,reference_id\n" +
+					"BCA,1234567890,3000,R1\n" +
+					"BCA,1234567891,3000,R2\n"),
+			},
+			mockFunc: func() {
+				mockPayoutBatchWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(wallet, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchByReference(gomock.Any(), "1", "PAYROLL-02").Return(domain.PayoutBatch{}, sql.ErrNoRows)
+			},
+			wantErr:    fmt.Errorf("insufficient balance"),
+			wantResult: web.PayoutBatchResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePayoutBatchTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := payoutBatchSvc.CreatePayoutBatch(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			if tc.wantResult.ID != "" {
+				assert.Equal(t, got.ID, tc.wantResult.ID)
+			}
+			assert.Equal(t, got.Reference, tc.wantResult.Reference)
+			assert.Equal(t, got.TotalAmount, tc.wantResult.TotalAmount)
+			assert.Equal(t, got.RowCount, tc.wantResult.RowCount)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, len(got.Items), 2)
+		})
+	}
+}
+
+func TestRunPayoutBatches(t *testing.T) {
+	now := time.Date(2023, 3, 15, 1, 0, 0, 0, time.UTC)
+	batch := domain.PayoutBatch{
+		ID:          "mock-batch",
+		WalletID:    "mock-wallet",
+		CustomerXID: "1",
+		TotalAmount: domain.NewMoney(1500, "IDR"),
+		RowCount:    2,
+		Status:      "pending",
+	}
+	items := []domain.PayoutBatchItem{
+		{
+			ID:          "item-1",
+			BatchID:     "mock-batch",
+			LineNumber:  1,
+			BankAccount: domain.BankAccount{BankCode: "BCA", AccountNumber: "1234567890"},
+			Amount:      domain.NewMoney(1000, "IDR"),
+			Status:      "pending",
+		},
+		{
+			ID:          "item-2",
+			BatchID:     "mock-batch",
+			LineNumber:  2,
+			BankAccount: domain.BankAccount{BankCode: "BNI", AccountNumber: "9876540000", AccountName: "Jane Doe"},
+			Amount:      domain.NewMoney(500, "IDR"),
+			Status:      "pending",
+		},
+	}
+	withStatus := func(item domain.PayoutBatchItem, status string) domain.PayoutBatchItem {
+		item.Status = status
+		return item
+	}
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		mockFunc func()
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - rows submitted, closed account failed, batch waits for the bank",
+			mockFunc: func() {
+				mockPayoutBatchRepository.EXPECT().ClaimPayoutBatch(gomock.Any(), "mock-batch", now.Add(-10*time.Minute)).Return(true, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchItems(gomock.Any(), "mock-batch").Return(items, nil)
+				mockPayoutBatchPayoutRepository.EXPECT().SaveBeneficiary(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, beneficiary domain.Beneficiary) (domain.Beneficiary, error) {
+						assert.Equal(t, beneficiary.BankAccount.AccountName, "STUB HOLDER 7890")
+						assert.NotNil(t, beneficiary.VerifiedAt)
+						return domain.Beneficiary{ID: "mock-beneficiary"}, nil
+					})
+				mockPayoutBatchRepository.EXPECT().PayPayoutBatchItem(gomock.Any(), "item-1", gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, itemID string, payout domain.Payout, release, withdrawal domain.Transaction) (bool, error) {
+						assert.Equal(t, payout.TransactionID, withdrawal.ID)
+						assert.Equal(t, payout.Amount, domain.NewMoney(1000, "IDR"))
+						assert.Equal(t, release.TransactionType, "payout_batch_release")
+						assert.Equal(t, release.ReferenceID, payout.ID)
+						assert.Equal(t, withdrawal.TransactionType, "withdrawal")
+						assert.Equal(t, withdrawal.ReferenceID, payout.ID)
+						return true, nil
+					})
+				mockPayoutBatchPayoutRepository.EXPECT().MarkPayoutSubmitted(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockPayoutBatchRepository.EXPECT().FailPayoutBatchItem(gomock.Any(), "item-2", "bank account not found").Return(true, nil)
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchItems(gomock.Any(), "mock-batch").Return([]domain.PayoutBatchItem{
+					withStatus(items[0], "submitted"),
+					withStatus(items[1], "failed"),
+				}, nil)
+				mockPayoutBatchRepository.EXPECT().UpdatePayoutBatchStatus(gomock.Any(), "mock-batch", "running", "pending").Return(true, nil)
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - settled rows finish the batch",
+			mockFunc: func() {
+				mockPayoutBatchRepository.EXPECT().ClaimPayoutBatch(gomock.Any(), "mock-batch", now.Add(-10*time.Minute)).Return(true, nil)
+				settled := []domain.PayoutBatchItem{
+					withStatus(items[0], "paid"),
+					withStatus(items[1], "failed"),
+				}
+				mockPayoutBatchRepository.EXPECT().GetPayoutBatchItems(gomock.Any(), "mock-batch").Return(settled, nil).Times(2)
+				mockPayoutBatchRepository.EXPECT().UpdatePayoutBatchStatus(gomock.Any(), "mock-batch", "running", "partial").Return(true, nil)
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Success - batch claimed by another runner is skipped",
+			mockFunc: func() {
+				mockPayoutBatchRepository.EXPECT().ClaimPayoutBatch(gomock.Any(), "mock-batch", now.Add(-10*time.Minute)).Return(false, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePayoutBatchTest(t)
+			defer testDep()
+
+			mockPayoutBatchRepository.EXPECT().GetDuePayoutBatches(gomock.Any(), now.Add(-10*time.Minute), gomock.Any()).Return([]domain.PayoutBatch{batch}, nil)
+			tc.mockFunc()
+
+			err := payoutBatchSvc.RunPayoutBatches(context.Background(), now)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestCancelPayoutBatch(t *testing.T) {
+	testDep := providePayoutBatchTest(t)
+	defer testDep()
+
+	batch := domain.PayoutBatch{
+		ID:          "mock-batch",
+		WalletID:    "mock-wallet",
+		CustomerXID: "1",
+		TotalAmount: domain.NewMoney(1500, "IDR"),
+		RowCount:    2,
+		Status:      "partial",
+	}
+	mockPayoutBatchRepository.EXPECT().GetPayoutBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+	mockPayoutBatchRepository.EXPECT().GetPayoutBatchItems(gomock.Any(), "mock-batch").Return([]domain.PayoutBatchItem{
+		{ID: "item-1", Amount: domain.NewMoney(1000, "IDR"), Status: "paid"},
+		{ID: "item-2", Amount: domain.NewMoney(500, "IDR"), Status: "failed"},
+	}, nil)
+	mockPayoutBatchRepository.EXPECT().CancelPayoutBatch(gomock.Any(), "mock-batch", "partial", []string{"item-2"}, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, batchID, fromStatus string, itemIDs []string, release domain.Transaction) (bool, error) {
+			assert.Equal(t, release.TransactionType, "payout_batch_release")
+			assert.Equal(t, release.Amount, domain.NewMoney(500, "IDR"))
+			assert.Equal(t, release.ReferenceID, "mock-batch")
+			return true, nil
+		})
+
+	got, err := payoutBatchSvc.CancelPayoutBatch(context.Background(), "1", "mock-batch")
+	assert.Nil(t, err)
+	assert.Equal(t, got.Status, "cancelled")
+	assert.Equal(t, got.Items[1].Status, "cancelled")
+
+	// a batch of another customer is not found
+	mockPayoutBatchRepository.EXPECT().GetPayoutBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+	_, err = payoutBatchSvc.CancelPayoutBatch(context.Background(), "2", "mock-batch")
+	assert.Equal(t, err.Error(), "payout batch not found")
+}
+
+func TestRetryPayoutBatch(t *testing.T) {
+	testDep := providePayoutBatchTest(t)
+	defer testDep()
+
+	batch := domain.PayoutBatch{
+		ID:          "mock-batch",
+		CustomerXID: "1",
+		TotalAmount: domain.NewMoney(1500, "IDR"),
+		RowCount:    2,
+		Status:      "partial",
+	}
+	mockPayoutBatchRepository.EXPECT().GetPayoutBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+	mockPayoutBatchRepository.EXPECT().RetryPayoutBatch(gomock.Any(), "mock-batch", "partial").Return(true, nil)
+	mockPayoutBatchRepository.EXPECT().GetPayoutBatchItems(gomock.Any(), "mock-batch").Return([]domain.PayoutBatchItem{
+		{ID: "item-1", Amount: domain.NewMoney(1000, "IDR"), Status: "paid"},
+		{ID: "item-2", Amount: domain.NewMoney(500, "IDR"), Status: "pending"},
+	}, nil)
+
+	// the rows are left to the payout batches job
+	got, err := payoutBatchSvc.RetryPayoutBatch(context.Background(), "1", "mock-batch")
+	assert.Nil(t, err)
+	assert.Equal(t, got.Status, "pending")
+	assert.Equal(t, got.Items[1].Status, "pending")
+
+	batch.Status = "completed"
+	mockPayoutBatchRepository.EXPECT().GetPayoutBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+	_, err = payoutBatchSvc.RetryPayoutBatch(context.Background(), "1", "mock-batch")
+	assert.Equal(t, err.Error(), "payout batch has no failed rows")
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type PayoutServiceItf interface {
+	GetPayouts(ctx context.Context, customerXID string) ([]web.PayoutResponse, error)
+	GetPayout(ctx context.Context, customerXID, payoutID string) (web.PayoutResponse, error)
+	// RunPayouts submits payouts the provider has not taken yet and settles
+	// submitted ones, crediting failed payouts back to the wallet.
+	RunPayouts(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type PayoutService struct {
+	PayoutRepository repository.PayoutRepository
+	PayoutProvider   bank.PayoutProvider
+}
+
+func NewPayoutService(payoutRepository repository.PayoutRepository, payoutProvider bank.PayoutProvider) PayoutServiceItf {
+	return &PayoutService{
+		PayoutRepository: payoutRepository,
+		PayoutProvider:   payoutProvider,
+	}
+}
+
+func (svc *PayoutService) GetPayouts(ctx context.Context, customerXID string) ([]web.PayoutResponse, error) {
+	payouts, err := svc.PayoutRepository.GetPayouts(ctx, customerXID)
+	if err != nil {
+		return []web.PayoutResponse{}, err
+	}
+
+	result := []web.PayoutResponse{}
+	for i := range payouts {
+		result = append(result, toPayoutResponse(payouts[i]))
+	}
+	return result, nil
+}
+
+func (svc *PayoutService) GetPayout(ctx context.Context, customerXID, payoutID string) (web.PayoutResponse, error) {
+	payout, err := svc.PayoutRepository.GetPayout(ctx, payoutID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PayoutResponse{}, errors.New("payout not found")
+	}
+	if err != nil {
+		return web.PayoutResponse{}, err
+	}
+
+	if payout.CustomerXID != customerXID {
+		return web.PayoutResponse{}, errors.New("payout not found")
+	}
+	return toPayoutResponse(payout), nil
+}
+
+func (svc *PayoutService) RunPayouts(ctx context.Context, now time.Time) error {
+	pending, err := svc.PayoutRepository.GetPayoutsByStatus(ctx, constants.STATUS_PENDING, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range pending {
+		_, err = submitPayout(ctx, svc.PayoutRepository, svc.PayoutProvider, pending[i])
+		if err != nil {
+			log.Println("error submit payout", pending[i].ID+":", err.Error())
+		}
+	}
+
+	submitted, err := svc.PayoutRepository.GetPayoutsByStatus(ctx, constants.STATUS_SUBMITTED, scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range submitted {
+		err = svc.settlePayout(ctx, submitted[i], now)
+		if err != nil {
+			log.Println("error settle payout", submitted[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *PayoutService) settlePayout(ctx context.Context, payout domain.Payout, now time.Time) error {
+	status, err := svc.PayoutProvider.GetPayoutStatus(ctx, payout.ProviderReference)
+	if err != nil {
+		return err
+	}
+
+	switch status.Status {
+	case constants.STATUS_SUCCEEDED:
+		_, err = svc.PayoutRepository.MarkPayoutSucceeded(ctx, payout.ID)
+		return err
+	case constants.STATUS_FAILED:
+		// the reversal is unique per payout, failing it twice credits once
+		reversal := domain.Transaction{
+			ID:              uuid.New().String(),
+			WalletID:        payout.WalletID,
+			CustomerXID:     payout.CustomerXID,
+			TransactionType: constants.TRANSACTION_TYPE_WITHDRAWAL_REVERSAL,
+			Amount:          payout.Amount,
+			ReferenceID:     payout.ID,
+			Status:          constants.STATUS_SUCCESS,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		// a failed batch row takes the amount back into its reservation
+		reservation := reversal
+		reservation.ID = uuid.New().String()
+		reservation.TransactionType = constants.TRANSACTION_TYPE_PAYOUT_BATCH_RESERVATION
+		_, err = svc.PayoutRepository.FailPayout(ctx, payout.ID, truncate(status.FailureReason, 255), reversal, reservation)
+		return err
+	}
+	return nil
+}
+
+// submitPayout hands a pending payout to the provider and records the
+// provider's reference. The payout is returned as it now stands.
+func submitPayout(ctx context.Context, payoutRepository repository.PayoutRepository, payoutProvider bank.PayoutProvider, payout domain.Payout) (domain.Payout, error) {
+	reference, err := payoutProvider.SubmitPayout(ctx, bank.PayoutRequest{
+		ID:          payout.ID,
+		BankAccount: payout.BankAccount,
+		Amount:      payout.Amount,
+	})
+	if err != nil {
+		return payout, err
+	}
+
+	isSubmitted, err := payoutRepository.MarkPayoutSubmitted(ctx, payout.ID, reference)
+	if err != nil {
+		return payout, err
+	}
+	if isSubmitted {
+		payout.ProviderReference = reference
+		payout.Status = constants.STATUS_SUBMITTED
+	}
+	return payout, nil
+}
+
+func toPayoutResponse(payout domain.Payout) web.PayoutResponse {
+	return web.PayoutResponse{
+		ID:            payout.ID,
+		BeneficiaryID: payout.BeneficiaryID,
+		BankCode:      payout.BankAccount.BankCode,
+		AccountNumber: payout.BankAccount.AccountNumber,
+		AccountName:   payout.BankAccount.AccountName,
+		Amount:        payout.Amount,
+		TransactionID: payout.TransactionID,
+		Status:        payout.Status,
+		FailureReason: payout.FailureReason,
+		CreatedAt:     payout.CreatedAt,
+		UpdatedAt:     payout.UpdatedAt,
+	}
+}
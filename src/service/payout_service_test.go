@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	payoutSvc service.PayoutServiceItf
+
+	mockPayoutServiceRepository *mock_repository.MockPayoutRepository
+	stubPayoutProvider          *bank.StubPayoutProvider
+)
+
+func providePayoutTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPayoutServiceRepository = mock_repository.NewMockPayoutRepository(ctrl)
+	stubPayoutProvider = bank.NewStubPayoutProvider(0)
+	payoutSvc = service.NewPayoutService(mockPayoutServiceRepository, stubPayoutProvider)
+
+	return func() {}
+}
+
+func TestRunPayouts(t *testing.T) {
+	now := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	payout := domain.Payout{
+		ID:          "mock-payout",
+		WalletID:    "mock-id",
+		CustomerXID: "1",
+		BankAccount: domain.BankAccount{
+			BankCode:      "BCA",
+			AccountNumber: "1234567890",
+			AccountName:   "John Doe",
+		},
+		Amount: domain.Money{Amount: 1000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID        int
+		testDesc      string
+		accountNumber string
+		mockFunc      func(submitted domain.Payout)
+	}{
+		{
+			testID:        1,
+			testDesc:      "Success - pending payout is submitted again",
+			accountNumber: "1234567890",
+			mockFunc: func(submitted domain.Payout) {
+				pending := payout
+				pending.Status = "pending"
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "pending", gomock.Any()).Return([]domain.Payout{pending}, nil)
+				mockPayoutServiceRepository.EXPECT().MarkPayoutSubmitted(gomock.Any(), "mock-payout", gomock.Any()).Return(true, nil)
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "submitted", gomock.Any()).Return([]domain.Payout{}, nil)
+			},
+		},
+		{
+			testID:        2,
+			testDesc:      "Success - succeeded payout is completed",
+			accountNumber: "1234567890",
+			mockFunc: func(submitted domain.Payout) {
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "pending", gomock.Any()).Return([]domain.Payout{}, nil)
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "submitted", gomock.Any()).Return([]domain.Payout{submitted}, nil)
+				mockPayoutServiceRepository.EXPECT().MarkPayoutSucceeded(gomock.Any(), "mock-payout").Return(true, nil)
+			},
+		},
+		{
+			testID:        3,
+			testDesc:      "Success - failed payout is reversed",
+			accountNumber: "1234560000",
+			mockFunc: func(submitted domain.Payout) {
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "pending", gomock.Any()).Return([]domain.Payout{}, nil)
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "submitted", gomock.Any()).Return([]domain.Payout{submitted}, nil)
+				mockPayoutServiceRepository.EXPECT().FailPayout(gomock.Any(), "mock-payout", "account closed", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payoutID, reason string, reversal, reservation domain.Transaction) (bool, error) {
+						assert.Equal(t, reversal.TransactionType, "withdrawal_reversal")
+						assert.Equal(t, reversal.ReferenceID, "mock-payout")
+						assert.Equal(t, reversal.WalletID, "mock-id")
+						assert.Equal(t, reversal.Amount, domain.Money{Amount: 1000, Currency: "IDR"})
+						assert.Equal(t, reversal.Status, "success")
+						assert.Equal(t, reservation.TransactionType, "payout_batch_reservation")
+						assert.Equal(t, reservation.ReferenceID, "mock-payout")
+						assert.Equal(t, reservation.Amount, reversal.Amount)
+						return true, nil
+					})
+			},
+		},
+		{
+			testID:        4,
+			testDesc:      "Success - failed payout of a withdrawal a dispute paid back is not reversed",
+			accountNumber: "1234560000",
+			mockFunc: func(submitted domain.Payout) {
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "pending", gomock.Any()).Return([]domain.Payout{}, nil)
+				mockPayoutServiceRepository.EXPECT().GetPayoutsByStatus(gomock.Any(), "submitted", gomock.Any()).Return([]domain.Payout{submitted}, nil)
+				mockPayoutServiceRepository.EXPECT().FailPayout(gomock.Any(), "mock-payout", "account closed", gomock.Any(), gomock.Any()).Return(false, domain.ErrTransactionReversed)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePayoutTest(t)
+			defer testDep()
+
+			submitted := payout
+			submitted.BankAccount.AccountNumber = tc.accountNumber
+			submitted.Status = "submitted"
+			reference, err := stubPayoutProvider.SubmitPayout(context.Background(), bank.PayoutRequest{
+				ID:          submitted.ID,
+				BankAccount: submitted.BankAccount,
+				Amount:      submitted.Amount,
+			})
+			assert.Nil(t, err)
+			submitted.ProviderReference = reference
+			tc.mockFunc(submitted)
+
+			err = payoutSvc.RunPayouts(context.Background(), now)
+			assert.Nil(t, err)
+		})
+	}
+}
+
+func TestGetPayout(t *testing.T) {
+	testDep := providePayoutTest(t)
+	defer testDep()
+
+	mockPayoutServiceRepository.EXPECT().GetPayout(gomock.Any(), "mock-payout").Return(domain.Payout{ID: "mock-payout", CustomerXID: "2"}, nil)
+	_, err := payoutSvc.GetPayout(context.Background(), "1", "mock-payout")
+	assert.Equal(t, err.Error(), "payout not found")
+
+	mockPayoutServiceRepository.EXPECT().GetPayout(gomock.Any(), "missing").Return(domain.Payout{}, sql.ErrNoRows)
+	_, err = payoutSvc.GetPayout(context.Background(), "1", "missing")
+	assert.Equal(t, err.Error(), "payout not found")
+}
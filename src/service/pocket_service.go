@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type PocketServiceItf interface {
+	CreatePocket(ctx context.Context, customerXID string, request web.PocketCreateRequest) (web.PocketResponse, error)
+	GetPockets(ctx context.Context, customerXID string) ([]web.PocketResponse, error)
+	UpdatePocket(ctx context.Context, customerXID, pocketID string, request web.PocketUpdateRequest) (web.PocketResponse, error)
+	AllocateToPocket(ctx context.Context, customerXID, pocketID string, request web.TransactionRequest) (web.PocketTransferResponse, error)
+	ReleaseFromPocket(ctx context.Context, customerXID, pocketID string, request web.TransactionRequest) (web.PocketTransferResponse, error)
+	ClosePocket(ctx context.Context, customerXID, pocketID string) (web.PocketTransferResponse, error)
+}
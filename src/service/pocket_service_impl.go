@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type PocketService struct {
+	WalletRepository repository.WalletRepository
+	PocketRepository repository.PocketRepository
+	Validate         *validator.Validate
+}
+
+func NewPocketService(walletRepository repository.WalletRepository, pocketRepository repository.PocketRepository, validate *validator.Validate) PocketServiceItf {
+	return &PocketService{
+		WalletRepository: walletRepository,
+		PocketRepository: pocketRepository,
+		Validate:         validate,
+	}
+}
+
+func (svc *PocketService) CreatePocket(ctx context.Context, customerXID string, request web.PocketCreateRequest) (web.PocketResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PocketResponse{}, err
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.PocketResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.PocketResponse{}, errors.New("wallet disabled")
+	}
+
+	now := time.Now()
+	pocket := domain.Pocket{
+		ID:           uuid.New().String(),
+		WalletID:     wallet.ID,
+		Name:         request.Name,
+		TargetAmount: domain.NewMoney(request.TargetAmount, wallet.Currency),
+		Balance:      domain.NewMoney(0, wallet.Currency),
+		Status:       constants.STATUS_ACTIVE,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err = svc.PocketRepository.CreatePocket(ctx, pocket)
+	if err != nil {
+		return web.PocketResponse{}, err
+	}
+
+	return toPocketResponse(pocket), nil
+}
+
+func (svc *PocketService) GetPockets(ctx context.Context, customerXID string) ([]web.PocketResponse, error) {
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return []web.PocketResponse{}, err
+	}
+
+	pockets, err := svc.PocketRepository.GetPockets(ctx, wallet.ID)
+	if err != nil {
+		return []web.PocketResponse{}, err
+	}
+
+	result := []web.PocketResponse{}
+	for i := range pockets {
+		result = append(result, toPocketResponse(pockets[i]))
+	}
+	return result, nil
+}
+
+func (svc *PocketService) UpdatePocket(ctx context.Context, customerXID, pocketID string, request web.PocketUpdateRequest) (web.PocketResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PocketResponse{}, err
+	}
+
+	_, pocket, err := svc.getOwnedPocket(ctx, customerXID, pocketID)
+	if err != nil {
+		return web.PocketResponse{}, err
+	}
+
+	pocket.Name = request.Name
+	pocket.TargetAmount = domain.NewMoney(request.TargetAmount, pocket.Balance.Currency)
+	err = svc.PocketRepository.UpdatePocket(ctx, pocket)
+	if err != nil {
+		return web.PocketResponse{}, err
+	}
+
+	return toPocketResponse(pocket), nil
+}
+
+func (svc *PocketService) AllocateToPocket(ctx context.Context, customerXID, pocketID string, request web.TransactionRequest) (web.PocketTransferResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	wallet, pocket, err := svc.getOwnedPocket(ctx, customerXID, pocketID)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	amount := domain.NewMoney(request.Amount, wallet.Currency)
+	finalBalance, err := wallet.Balance.Sub(amount)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	// compare amount with balance
+	if finalBalance.IsNegative() {
+		return web.PocketTransferResponse{}, errors.New("insufficient balance")
+	}
+
+	pocket.Balance, err = pocket.Balance.Add(amount)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	transaction := newPocketTransaction(wallet, pocket.ID, constants.TRANSACTION_TYPE_POCKET_ALLOCATION, amount, request.ReferenceID)
+	isMoved, err := svc.PocketRepository.AllocateToPocket(ctx, pocket.ID, transaction)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+	if !isMoved {
+		return web.PocketTransferResponse{}, errors.New("insufficient balance")
+	}
+
+	return toPocketTransferResponse(pocket, transaction), nil
+}
+
+func (svc *PocketService) ReleaseFromPocket(ctx context.Context, customerXID, pocketID string, request web.TransactionRequest) (web.PocketTransferResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	wallet, pocket, err := svc.getOwnedPocket(ctx, customerXID, pocketID)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	amount := domain.NewMoney(request.Amount, wallet.Currency)
+	pocket.Balance, err = pocket.Balance.Sub(amount)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	// compare amount with pocket balance
+	if pocket.Balance.IsNegative() {
+		return web.PocketTransferResponse{}, errors.New("insufficient pocket balance")
+	}
+
+	transaction := newPocketTransaction(wallet, pocket.ID, constants.TRANSACTION_TYPE_POCKET_RELEASE, amount, request.ReferenceID)
+	isMoved, err := svc.PocketRepository.ReleaseFromPocket(ctx, pocket.ID, transaction)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+	if !isMoved {
+		return web.PocketTransferResponse{}, errors.New("insufficient pocket balance")
+	}
+
+	return toPocketTransferResponse(pocket, transaction), nil
+}
+
+func (svc *PocketService) ClosePocket(ctx context.Context, customerXID, pocketID string) (web.PocketTransferResponse, error) {
+	wallet, pocket, err := svc.getOwnedPocket(ctx, customerXID, pocketID)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+
+	// the pocket ID makes the release idempotent, a pocket can only close once
+	transaction := newPocketTransaction(wallet, pocket.ID, constants.TRANSACTION_TYPE_POCKET_RELEASE, pocket.Balance, "close-"+pocket.ID)
+	isClosed, err := svc.PocketRepository.ClosePocket(ctx, pocket.ID, transaction)
+	if err != nil {
+		return web.PocketTransferResponse{}, err
+	}
+	if !isClosed {
+		return web.PocketTransferResponse{}, errors.New("pocket changed while closing, please retry")
+	}
+
+	pocket.Balance = domain.NewMoney(0, pocket.Balance.Currency)
+	pocket.Status = constants.STATUS_CLOSED
+	return toPocketTransferResponse(pocket, transaction), nil
+}
+
+// getOwnedPocket loads the customer's wallet together with one of its active
+// pockets, hiding pockets that belong to someone else.
+func (svc *PocketService) getOwnedPocket(ctx context.Context, customerXID, pocketID string) (domain.Wallet, domain.Pocket, error) {
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return domain.Wallet{}, domain.Pocket{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return domain.Wallet{}, domain.Pocket{}, errors.New("wallet disabled")
+	}
+
+	pocket, err := svc.PocketRepository.GetPocket(ctx, pocketID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Wallet{}, domain.Pocket{}, errors.New("pocket not found")
+	}
+	if err != nil {
+		return domain.Wallet{}, domain.Pocket{}, err
+	}
+
+	if pocket.WalletID != wallet.ID || pocket.Status != constants.STATUS_ACTIVE {
+		return domain.Wallet{}, domain.Pocket{}, errors.New("pocket not found")
+	}
+
+	return wallet, pocket, nil
+}
+
+func newPocketTransaction(wallet domain.Wallet, pocketID, transactionType string, amount domain.Money, referenceID string) domain.Transaction {
+	return domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		PocketID:        pocketID,
+		TransactionType: transactionType,
+		Amount:          amount,
+		ReferenceID:     referenceID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+func toPocketResponse(pocket domain.Pocket) web.PocketResponse {
+	return web.PocketResponse{
+		ID:           pocket.ID,
+		Name:         pocket.Name,
+		TargetAmount: pocket.TargetAmount,
+		Balance:      pocket.Balance,
+		Status:       pocket.Status,
+		CreatedAt:    pocket.CreatedAt,
+	}
+}
+
+func toPocketTransferResponse(pocket domain.Pocket, transaction domain.Transaction) web.PocketTransferResponse {
+	return web.PocketTransferResponse{
+		Pocket: toPocketResponse(pocket),
+		Transaction: web.TransactionResponse{
+			ID:           transaction.ID,
+			Status:       transaction.Status,
+			TransactedAt: transaction.CreatedAt,
+			Type:         transaction.TransactionType,
+			Amount:       transaction.Amount,
+			ReferenceID:  transaction.ReferenceID,
+		},
+	}
+}
@@ -0,0 +1,271 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	pocketSvc service.PocketServiceItf
+
+	mockPocketWalletRepository *mock_repository.MockWalletRepository
+	mockPocketPocketRepository *mock_repository.MockPocketRepository
+)
+
+func providePocketTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPocketWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockPocketPocketRepository = mock_repository.NewMockPocketRepository(ctrl)
+	validator := validator.New()
+	pocketSvc = service.NewPocketService(mockPocketWalletRepository, mockPocketPocketRepository, validator)
+
+	return func() {}
+}
+
+func TestAllocateToPocket(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			pocketID    string
+			payload     web.TransactionRequest
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.PocketTransferResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			args: args{
+				customerXID: "1",
+				pocketID:    "mock-pocket",
+				payload: web.TransactionRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockPocketWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 5000},
+				}, nil)
+				mockPocketPocketRepository.EXPECT().GetPocket(gomock.Any(), "mock-pocket").Return(domain.Pocket{
+					ID:       "mock-pocket",
+					WalletID: "mock-id",
+					Balance:  domain.Money{Amount: 200},
+					Status:   "active",
+				}, nil)
+				mockPocketPocketRepository.EXPECT().AllocateToPocket(gomock.Any(), "mock-pocket", gomock.Any()).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.PocketTransferResponse{
+				Pocket: web.PocketResponse{
+					ID:      "mock-pocket",
+					Balance: domain.Money{Amount: 1200},
+				},
+				Transaction: web.TransactionResponse{
+					Type:   "pocket_allocation",
+					Amount: domain.Money{Amount: 1000},
+				},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - insufficient main balance",
+			args: args{
+				customerXID: "1",
+				pocketID:    "mock-pocket",
+				payload: web.TransactionRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockPocketWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 500},
+				}, nil)
+				mockPocketPocketRepository.EXPECT().GetPocket(gomock.Any(), "mock-pocket").Return(domain.Pocket{
+					ID:       "mock-pocket",
+					WalletID: "mock-id",
+					Status:   "active",
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PocketTransferResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - pocket of another wallet",
+			args: args{
+				customerXID: "1",
+				pocketID:    "mock-pocket",
+				payload: web.TransactionRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockPocketWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 5000},
+				}, nil)
+				mockPocketPocketRepository.EXPECT().GetPocket(gomock.Any(), "mock-pocket").Return(domain.Pocket{
+					ID:       "mock-pocket",
+					WalletID: "other-wallet",
+					Status:   "active",
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PocketTransferResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - pocket not found",
+			args: args{
+				customerXID: "1",
+				pocketID:    "mock-pocket",
+				payload: web.TransactionRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockPocketWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockPocketPocketRepository.EXPECT().GetPocket(gomock.Any(), "mock-pocket").Return(domain.Pocket{}, sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: web.PocketTransferResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePocketTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := pocketSvc.AllocateToPocket(context.Background(), tc.args.customerXID, tc.args.pocketID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.Pocket.ID, tc.wantResult.Pocket.ID)
+			assert.Equal(t, got.Pocket.Balance, tc.wantResult.Pocket.Balance)
+			assert.Equal(t, got.Transaction.Type, tc.wantResult.Transaction.Type)
+			assert.Equal(t, got.Transaction.Amount, tc.wantResult.Transaction.Amount)
+		})
+	}
+}
+
+func TestClosePocket(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			pocketID    string
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.PocketTransferResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - funds return to main balance",
+			args: args{
+				customerXID: "1",
+				pocketID:    "mock-pocket",
+			},
+			mockFunc: func() {
+				mockPocketWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockPocketPocketRepository.EXPECT().GetPocket(gomock.Any(), "mock-pocket").Return(domain.Pocket{
+					ID:       "mock-pocket",
+					WalletID: "mock-id",
+					Balance:  domain.Money{Amount: 7000},
+					Status:   "active",
+				}, nil)
+				mockPocketPocketRepository.EXPECT().ClosePocket(gomock.Any(), "mock-pocket", gomock.Any()).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.PocketTransferResponse{
+				Pocket: web.PocketResponse{
+					ID:     "mock-pocket",
+					Status: "closed",
+				},
+				Transaction: web.TransactionResponse{
+					Type:        "pocket_release",
+					Amount:      domain.Money{Amount: 7000},
+					ReferenceID: "close-mock-pocket",
+				},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - pocket changed concurrently",
+			args: args{
+				customerXID: "1",
+				pocketID:    "mock-pocket",
+			},
+			mockFunc: func() {
+				mockPocketWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockPocketPocketRepository.EXPECT().GetPocket(gomock.Any(), "mock-pocket").Return(domain.Pocket{
+					ID:       "mock-pocket",
+					WalletID: "mock-id",
+					Balance:  domain.Money{Amount: 7000},
+					Status:   "active",
+				}, nil)
+				mockPocketPocketRepository.EXPECT().ClosePocket(gomock.Any(), "mock-pocket", gomock.Any()).Return(false, nil)
+			},
+			wantErr:    true,
+			wantResult: web.PocketTransferResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := providePocketTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := pocketSvc.ClosePocket(context.Background(), tc.args.customerXID, tc.args.pocketID)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.Pocket.ID, tc.wantResult.Pocket.ID)
+			assert.Equal(t, got.Pocket.Status, tc.wantResult.Pocket.Status)
+			assert.Equal(t, got.Transaction.Type, tc.wantResult.Transaction.Type)
+			assert.Equal(t, got.Transaction.Amount, tc.wantResult.Transaction.Amount)
+			assert.Equal(t, got.Transaction.ReferenceID, tc.wantResult.Transaction.ReferenceID)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type QRServiceItf interface {
+	GetMerchantQR(ctx context.Context, customerXID string) (web.QRResponse, error)
+	GetPaymentIntentQR(ctx context.Context, customerXID, paymentIntentID string) (web.QRResponse, error)
+	PayQR(ctx context.Context, customerXID string, request web.QRPaymentRequest) (web.PaymentIntentResponse, error)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/qris"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// qrBillNumberMaxLength is the longest bill number EMVCo allows.
+const qrBillNumberMaxLength = 25
+
+type QRService struct {
+	MerchantRepository repository.MerchantRepository
+	MerchantService    MerchantServiceItf
+	Validate           *validator.Validate
+}
+
+func NewQRService(merchantRepository repository.MerchantRepository, merchantService MerchantServiceItf, validate *validator.Validate) QRServiceItf {
+	return &QRService{
+		MerchantRepository: merchantRepository,
+		MerchantService:    merchantService,
+		Validate:           validate,
+	}
+}
+
+// GetMerchantQR returns the static QR a merchant prints at the counter, the
+// customer enters the amount when paying it.
+func (svc *QRService) GetMerchantQR(ctx context.Context, customerXID string) (web.QRResponse, error) {
+	merchant, err := svc.getActiveMerchant(ctx, customerXID)
+	if err != nil {
+		return web.QRResponse{}, err
+	}
+
+	payload, err := qris.Encode(merchantPayload(merchant, qris.PointOfInitiationStatic, constants.DEFAULT_CURRENCY))
+	if err != nil {
+		return web.QRResponse{}, err
+	}
+
+	return web.QRResponse{
+		Type:    constants.QR_TYPE_STATIC,
+		Payload: payload,
+	}, nil
+}
+
+// GetPaymentIntentQR returns the dynamic QR of a single checkout.
+func (svc *QRService) GetPaymentIntentQR(ctx context.Context, customerXID, paymentIntentID string) (web.QRResponse, error) {
+	merchant, err := svc.getActiveMerchant(ctx, customerXID)
+	if err != nil {
+		return web.QRResponse{}, err
+	}
+
+	paymentIntent, err := svc.MerchantRepository.GetPaymentIntent(ctx, paymentIntentID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && paymentIntent.MerchantID != merchant.ID) {
+		return web.QRResponse{}, errors.New("payment intent not found")
+	}
+	if err != nil {
+		return web.QRResponse{}, err
+	}
+
+	if paymentIntent.Status != constants.STATUS_PENDING || !time.Now().Before(paymentIntent.ExpiresAt) {
+		return web.QRResponse{}, errors.New("payment intent is no longer pending")
+	}
+
+	payload := merchantPayload(merchant, qris.PointOfInitiationDynamic, paymentIntent.Amount.Currency)
+	payload.Amount = qris.FormatAmount(paymentIntent.Amount.Amount, constants.CurrencyMinorUnits[paymentIntent.Amount.Currency])
+	payload.ReferenceLabel = paymentIntent.ID
+	if len(paymentIntent.MerchantReference) <= qrBillNumberMaxLength {
+		payload.BillNumber = paymentIntent.MerchantReference
+	}
+
+	encoded, err := qris.Encode(payload)
+	if err != nil {
+		return web.QRResponse{}, err
+	}
+
+	return web.QRResponse{
+		Type:            constants.QR_TYPE_DYNAMIC,
+		Payload:         encoded,
+		PaymentIntentID: paymentIntent.ID,
+	}, nil
+}
+
+// PayQR pays a scanned payload. A dynamic QR confirms the payment intent it
+// carries, a static QR opens a new intent for the merchant and confirms it
+// right away.
+func (svc *QRService) PayQR(ctx context.Context, customerXID string, request web.QRPaymentRequest) (web.PaymentIntentResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	payload, err := qris.Parse(request.Payload)
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	if payload.GloballyUniqueID != constants.QR_GLOBALLY_UNIQUE_ID {
+		return web.PaymentIntentResponse{}, errors.New("qr is not issued by this wallet")
+	}
+
+	currency, ok := qris.CurrencyByCode(payload.CurrencyCode)
+	if !ok {
+		return web.PaymentIntentResponse{}, errors.New("unsupported currency")
+	}
+
+	amount := request.Amount
+	if payload.Amount != "" {
+		amount, err = qris.ParseAmount(payload.Amount, constants.CurrencyMinorUnits[currency])
+		if err != nil {
+			return web.PaymentIntentResponse{}, err
+		}
+
+		// the amount in the qr is the one the merchant asked for
+		if request.Amount != 0 && request.Amount != amount {
+			return web.PaymentIntentResponse{}, errors.New("amount does not match qr")
+		}
+	}
+	if amount <= 0 {
+		return web.PaymentIntentResponse{}, errors.New("amount is required")
+	}
+
+	merchant, err := svc.MerchantRepository.GetMerchant(ctx, payload.MerchantID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PaymentIntentResponse{}, errors.New("merchant not found")
+	}
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	if payload.IsDynamic() {
+		return svc.payDynamicQR(ctx, customerXID, payload, merchant, domain.NewMoney(amount, currency))
+	}
+
+	// a retried reference_id reaches the same intent, but only for the payer
+	// who sent it. Both are hashed to fit the merchant reference.
+	merchantReference := "qr-" + uuid.New().String()
+	if request.ReferenceID != "" {
+		merchantReference = "qr-" + uuid.NewSHA1(uuid.NameSpaceOID, []byte(customerXID+":"+request.ReferenceID)).String()
+	}
+
+	paymentIntent, err := svc.MerchantService.CreatePaymentIntent(ctx, merchant.CustomerXID, web.PaymentIntentCreateRequest{
+		MerchantReference: merchantReference,
+		Amount:            amount,
+		Currency:          currency,
+		Description:       "QR payment",
+	})
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// a retry of a payment that already went through
+	if paymentIntent.Status == constants.STATUS_SUCCESS && paymentIntent.CustomerXID == customerXID {
+		return paymentIntent, nil
+	}
+
+	result, err := svc.MerchantService.ConfirmPaymentIntent(ctx, customerXID, paymentIntent.ID)
+	if err != nil && request.ReferenceID == "" {
+		// nobody else can pay an intent opened for this scan. One with a
+		// reference_id stays pending so the payer can retry it, after a
+		// top-up for example.
+		_, errCancel := svc.MerchantRepository.UpdatePaymentIntentStatus(ctx, paymentIntent.ID, constants.STATUS_PENDING, constants.STATUS_CANCELLED)
+		if errCancel != nil {
+			log.Println("error cancel qr payment intent:", errCancel.Error())
+		}
+	}
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	return result, nil
+}
+
+func (svc *QRService) payDynamicQR(ctx context.Context, customerXID string, payload qris.Payload, merchant domain.Merchant, amount domain.Money) (web.PaymentIntentResponse, error) {
+	paymentIntent, err := svc.MerchantRepository.GetPaymentIntent(ctx, payload.ReferenceLabel)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.PaymentIntentResponse{}, errors.New("payment intent not found")
+	}
+	if err != nil {
+		return web.PaymentIntentResponse{}, err
+	}
+
+	// the qr must describe the intent exactly, otherwise it was tampered with
+	if paymentIntent.MerchantID != merchant.ID || paymentIntent.Amount != amount {
+		return web.PaymentIntentResponse{}, errors.New("qr does not match payment intent")
+	}
+
+	return svc.MerchantService.ConfirmPaymentIntent(ctx, customerXID, paymentIntent.ID)
+}
+
+func (svc *QRService) getActiveMerchant(ctx context.Context, customerXID string) (domain.Merchant, error) {
+	merchant, err := svc.MerchantRepository.GetMerchantByCustomerXID(ctx, customerXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Merchant{}, errors.New("merchant not found")
+	}
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+
+	if merchant.Status != constants.STATUS_ACTIVE {
+		return domain.Merchant{}, errors.New("merchant is not active")
+	}
+	return merchant, nil
+}
+
+func merchantPayload(merchant domain.Merchant, pointOfInitiation, currency string) qris.Payload {
+	return qris.Payload{
+		PointOfInitiation: pointOfInitiation,
+		GloballyUniqueID:  constants.QR_GLOBALLY_UNIQUE_ID,
+		MerchantID:        merchant.ID,
+		MerchantCategory:  merchant.CategoryCode,
+		CurrencyCode:      qris.CurrencyCodes[currency],
+		CountryCode:       constants.QR_COUNTRY_CODE,
+		MerchantName:      merchant.Name,
+		MerchantCity:      merchant.City,
+	}
+}
@@ -0,0 +1,197 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	mock_service "github.com/mozartmuhammad/julo-be-test/src/mock/service"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	qrSvc service.QRServiceItf
+
+	mockQRMerchantRepository *mock_repository.MockMerchantRepository
+	mockQRMerchantService    *mock_service.MockMerchantServiceItf
+)
+
+var qrMerchant = domain.Merchant{
+	ID:           "mock-merchant",
+	CustomerXID:  "1",
+	Name:         "Kopi Kita",
+	City:         "Jakarta",
+	CategoryCode: "5814",
+	Status:       "active",
+}
+
+func provideQRTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQRMerchantRepository = mock_repository.NewMockMerchantRepository(ctrl)
+	mockQRMerchantService = mock_service.NewMockMerchantServiceItf(ctrl)
+	validator := validator.New()
+	qrSvc = service.NewQRService(mockQRMerchantRepository, mockQRMerchantService, validator)
+
+	return func() {}
+}
+
+// generateQR returns the payload the merchant side produces for the intent,
+// or the static payload when paymentIntent is empty.
+func generateQR(t *testing.T, paymentIntent domain.PaymentIntent) string {
+	testDep := provideQRTest(t)
+	defer testDep()
+
+	mockQRMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(qrMerchant, nil)
+	if paymentIntent.ID == "" {
+		got, err := qrSvc.GetMerchantQR(context.Background(), "1")
+		assert.Nil(t, err)
+		return got.Payload
+	}
+
+	mockQRMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), paymentIntent.ID).Return(paymentIntent, nil)
+	got, err := qrSvc.GetPaymentIntentQR(context.Background(), "1", paymentIntent.ID)
+	assert.Nil(t, err)
+	return got.Payload
+}
+
+func TestPayQR(t *testing.T) {
+	paymentIntent := domain.PaymentIntent{
+		ID:                "mock-intent",
+		MerchantID:        "mock-merchant",
+		MerchantReference: "order-1",
+		Amount:            domain.Money{Amount: 25000, Currency: "IDR"},
+		Status:            "pending",
+		ExpiresAt:         time.Now().Add(time.Minute),
+	}
+	staticPayload := generateQR(t, domain.PaymentIntent{})
+	dynamicPayload := generateQR(t, paymentIntent)
+	tampered := paymentIntent
+	tampered.Amount = domain.Money{Amount: 1000, Currency: "IDR"}
+	tamperedPayload := generateQR(t, tampered)
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		payload  web.QRPaymentRequest
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - static qr opens and confirms an intent",
+			payload: web.QRPaymentRequest{
+				Payload:     staticPayload,
+				Amount:      15000,
+				ReferenceID: "scan-1",
+			},
+			mockFunc: func() {
+				mockQRMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(qrMerchant, nil)
+				mockQRMerchantService.EXPECT().CreatePaymentIntent(gomock.Any(), "1", web.PaymentIntentCreateRequest{
+					MerchantReference: "qr-" + uuid.NewSHA1(uuid.NameSpaceOID, []byte("2:scan-1")).String(),
+					Amount:            15000,
+					Currency:          "IDR",
+					Description:       "QR payment",
+				}).Return(web.PaymentIntentResponse{ID: "mock-static-intent", Status: "pending"}, nil)
+				mockQRMerchantService.EXPECT().ConfirmPaymentIntent(gomock.Any(), "2", "mock-static-intent").Return(web.PaymentIntentResponse{ID: "mock-static-intent", Status: "success"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - static qr without amount",
+			payload: web.QRPaymentRequest{
+				Payload: staticPayload,
+			},
+			mockFunc: func() {
+			},
+			wantErr: true,
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - static qr payment cancels the intent it opened",
+			payload: web.QRPaymentRequest{
+				Payload: staticPayload,
+				Amount:  15000,
+			},
+			mockFunc: func() {
+				mockQRMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(qrMerchant, nil)
+				mockQRMerchantService.EXPECT().CreatePaymentIntent(gomock.Any(), "1", gomock.Any()).Return(web.PaymentIntentResponse{ID: "mock-static-intent", Status: "pending"}, nil)
+				mockQRMerchantService.EXPECT().ConfirmPaymentIntent(gomock.Any(), "2", "mock-static-intent").Return(web.PaymentIntentResponse{}, errors.New("insufficient balance"))
+				mockQRMerchantRepository.EXPECT().UpdatePaymentIntentStatus(gomock.Any(), "mock-static-intent", "pending", "cancelled").Return(true, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - static qr payment with reference_id stays pending for a retry",
+			payload: web.QRPaymentRequest{
+				Payload:     staticPayload,
+				Amount:      15000,
+				ReferenceID: "scan-1",
+			},
+			mockFunc: func() {
+				mockQRMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(qrMerchant, nil)
+				mockQRMerchantService.EXPECT().CreatePaymentIntent(gomock.Any(), "1", gomock.Any()).Return(web.PaymentIntentResponse{ID: "mock-static-intent", Status: "pending"}, nil)
+				mockQRMerchantService.EXPECT().ConfirmPaymentIntent(gomock.Any(), "2", "mock-static-intent").Return(web.PaymentIntentResponse{}, errors.New("insufficient balance"))
+			},
+			wantErr: true,
+		},
+		{
+			testID:   5,
+			testDesc: "Success - dynamic qr confirms its intent",
+			payload: web.QRPaymentRequest{
+				Payload: dynamicPayload,
+			},
+			mockFunc: func() {
+				mockQRMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(qrMerchant, nil)
+				mockQRMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(paymentIntent, nil)
+				mockQRMerchantService.EXPECT().ConfirmPaymentIntent(gomock.Any(), "2", "mock-intent").Return(web.PaymentIntentResponse{ID: "mock-intent", Status: "success"}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - dynamic qr amount differs from intent",
+			payload: web.QRPaymentRequest{
+				Payload: tamperedPayload,
+			},
+			mockFunc: func() {
+				mockQRMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(qrMerchant, nil)
+				mockQRMerchantRepository.EXPECT().GetPaymentIntent(gomock.Any(), "mock-intent").Return(paymentIntent, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   7,
+			testDesc: "Failed - checksum mismatch",
+			payload: web.QRPaymentRequest{
+				Payload: dynamicPayload[:len(dynamicPayload)-4] + "0000",
+			},
+			mockFunc: func() {
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideQRTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			_, err := qrSvc.PayQR(context.Background(), "2", tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+		})
+	}
+}
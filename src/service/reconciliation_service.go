@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type ReconciliationServiceItf interface {
+	// ImportStatement parses a bank statement file, stores its lines and
+	// matches every line it can to a transaction by reference and amount.
+	ImportStatement(ctx context.Context, request web.StatementImportRequest) (web.StatementImportResponse, error)
+	GetStatementImports(ctx context.Context) ([]web.StatementImportResponse, error)
+	// GetReconciliationReport lists the matched and unmatched lines of a
+	// statement and the transactions of its period the bank does not show.
+	GetReconciliationReport(ctx context.Context, importID string) (web.ReconciliationReportResponse, error)
+	MatchStatementLine(ctx context.Context, importID, lineID string, request web.StatementMatchRequest) (web.StatementLineResponse, error)
+	UnmatchStatementLine(ctx context.Context, importID, lineID string) (web.StatementLineResponse, error)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/statement"
+)
+
+type ReconciliationService struct {
+	ReconciliationRepository repository.ReconciliationRepository
+	WalletRepository         repository.WalletRepository
+	Validate                 *validator.Validate
+	// Location decides which transactions fall on the value dates of a
+	// statement
+	Location *time.Location
+	// PayoutBankCode is the bank payouts are sent from, only its statements
+	// carry withdrawals
+	PayoutBankCode string
+}
+
+func NewReconciliationService(reconciliationRepository repository.ReconciliationRepository, walletRepository repository.WalletRepository, validate *validator.Validate, location *time.Location, payoutBankCode string) ReconciliationServiceItf {
+	return &ReconciliationService{
+		ReconciliationRepository: reconciliationRepository,
+		WalletRepository:         walletRepository,
+		Validate:                 validate,
+		Location:                 location,
+		PayoutBankCode:           payoutBankCode,
+	}
+}
+
+func (svc *ReconciliationService) ImportStatement(ctx context.Context, request web.StatementImportRequest) (web.StatementImportResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.StatementImportResponse{}, err
+	}
+
+	var parsed []statement.Line
+	switch request.Format {
+	case constants.STATEMENT_FORMAT_CSV:
+		parsed, err = statement.ParseCSV(request.Data)
+	case constants.STATEMENT_FORMAT_MT940:
+		parsed, err = statement.ParseMT940(request.Data)
+	}
+	if err != nil {
+		return web.StatementImportResponse{}, err
+	}
+	if len(parsed) == 0 {
+		return web.StatementImportResponse{}, errors.New("statement has no lines")
+	}
+
+	now := time.Now()
+	hash := sha256.Sum256(request.Data)
+	statementImport := domain.StatementImport{
+		ID:          uuid.New().String(),
+		BankCode:    request.BankCode,
+		Format:      request.Format,
+		FileName:    request.FileName,
+		FileHash:    hex.EncodeToString(hash[:]),
+		Currency:    parsed[0].Currency,
+		PeriodStart: parsed[0].ValueDate,
+		PeriodEnd:   parsed[0].ValueDate,
+		LineCount:   len(parsed),
+		CreatedAt:   now,
+	}
+
+	lines := make([]domain.StatementLine, 0, len(parsed))
+	for i, line := range parsed {
+		// a statement covers a single account, so a single currency
+		if line.Currency != statementImport.Currency {
+			return web.StatementImportResponse{}, errors.New("statement mixes currencies")
+		}
+		if line.ValueDate.Before(statementImport.PeriodStart) {
+			statementImport.PeriodStart = line.ValueDate
+		}
+		if line.ValueDate.After(statementImport.PeriodEnd) {
+			statementImport.PeriodEnd = line.ValueDate
+		}
+
+		lines = append(lines, domain.StatementLine{
+			ID:          uuid.New().String(),
+			ImportID:    statementImport.ID,
+			LineNumber:  i + 1,
+			ValueDate:   line.ValueDate,
+			Direction:   line.Direction,
+			Amount:      domain.NewMoney(line.Amount, line.Currency),
+			Reference:   truncate(line.Reference, 100),
+			Description: truncate(line.Description, 255),
+			CreatedAt:   now,
+		})
+	}
+
+	isCreated, err := svc.ReconciliationRepository.CreateStatementImport(ctx, statementImport, lines)
+	if err != nil {
+		return web.StatementImportResponse{}, err
+	}
+	if !isCreated {
+		return web.StatementImportResponse{}, errors.New("statement already imported")
+	}
+
+	matched := 0
+	for _, line := range lines {
+		isMatched, err := svc.autoMatch(ctx, statementImport.BankCode, line)
+		if err != nil {
+			log.Println("error matching statement line ", line.ID+":", err.Error())
+			continue
+		}
+		if isMatched {
+			matched++
+		}
+	}
+
+	result := toStatementImportResponse(statementImport)
+	result.MatchedCount = matched
+	return result, nil
+}
+
+func (svc *ReconciliationService) GetStatementImports(ctx context.Context) ([]web.StatementImportResponse, error) {
+	statementImports, err := svc.ReconciliationRepository.GetStatementImports(ctx)
+	if err != nil {
+		return []web.StatementImportResponse{}, err
+	}
+
+	result := []web.StatementImportResponse{}
+	for i := range statementImports {
+		result = append(result, toStatementImportResponse(statementImports[i]))
+	}
+	return result, nil
+}
+
+func (svc *ReconciliationService) GetReconciliationReport(ctx context.Context, importID string) (web.ReconciliationReportResponse, error) {
+	statementImport, err := svc.ReconciliationRepository.GetStatementImport(ctx, importID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.ReconciliationReportResponse{}, errors.New("statement not found")
+	}
+	if err != nil {
+		return web.ReconciliationReportResponse{}, err
+	}
+
+	lines, err := svc.ReconciliationRepository.GetStatementLines(ctx, statementImport.ID)
+	if err != nil {
+		return web.ReconciliationReportResponse{}, err
+	}
+
+	// value dates are calendar days of the bank, which books in business time
+	from := time.Date(statementImport.PeriodStart.Year(), statementImport.PeriodStart.Month(), statementImport.PeriodStart.Day(), 0, 0, 0, 0, svc.Location)
+	to := time.Date(statementImport.PeriodEnd.Year(), statementImport.PeriodEnd.Month(), statementImport.PeriodEnd.Day()+1, 0, 0, 0, 0, svc.Location)
+	transactions, err := svc.ReconciliationRepository.GetUnmatchedTransactions(ctx, statementImport.BankCode, statementImport.Currency, statementImport.BankCode == svc.PayoutBankCode, from, to)
+	if err != nil {
+		return web.ReconciliationReportResponse{}, err
+	}
+
+	result := web.ReconciliationReportResponse{
+		StatementImportResponse: toStatementImportResponse(statementImport),
+		Matched:                 []web.StatementLineResponse{},
+		UnmatchedBank:           []web.StatementLineResponse{},
+		UnmatchedWallet:         []web.TransactionResponse{},
+	}
+	for i := range lines {
+		if lines[i].TransactionID != "" {
+			result.Matched = append(result.Matched, toStatementLineResponse(lines[i]))
+		} else {
+			result.UnmatchedBank = append(result.UnmatchedBank, toStatementLineResponse(lines[i]))
+		}
+	}
+	for i := range transactions {
+		result.UnmatchedWallet = append(result.UnmatchedWallet, web.TransactionResponse{
+			ID:           transactions[i].ID,
+			Status:       transactions[i].Status,
+			TransactedAt: transactions[i].CreatedAt,
+			Type:         transactions[i].TransactionType,
+			Amount:       transactions[i].Amount,
+			ReferenceID:  transactions[i].ReferenceID,
+		})
+	}
+	return result, nil
+}
+
+func (svc *ReconciliationService) MatchStatementLine(ctx context.Context, importID, lineID string, request web.StatementMatchRequest) (web.StatementLineResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.StatementLineResponse{}, err
+	}
+
+	line, err := svc.getStatementLine(ctx, importID, lineID)
+	if err != nil {
+		return web.StatementLineResponse{}, err
+	}
+	if line.TransactionID != "" {
+		return web.StatementLineResponse{}, errors.New("statement line already matched")
+	}
+
+	transaction, err := svc.WalletRepository.GetTransaction(ctx, request.TransactionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.StatementLineResponse{}, errors.New("transaction not found")
+	}
+	if err != nil {
+		return web.StatementLineResponse{}, err
+	}
+
+	if transaction.Status != constants.STATUS_SUCCESS {
+		return web.StatementLineResponse{}, errors.New("transaction not successful")
+	}
+	if transaction.Amount != line.Amount {
+		return web.StatementLineResponse{}, errors.New("amount does not match")
+	}
+
+	// money the bank paid in can only book a credit to a wallet and the
+	// other way round
+	direction := statement.DirectionDebit
+	if constants.CreditTransactionTypes[transaction.TransactionType] {
+		direction = statement.DirectionCredit
+	}
+	if direction != line.Direction {
+		return web.StatementLineResponse{}, errors.New("direction does not match")
+	}
+
+	now := time.Now()
+	isMatched, err := svc.ReconciliationRepository.MatchStatementLine(ctx, line.ID, transaction.ID, constants.MATCH_TYPE_MANUAL, now)
+	if err != nil {
+		return web.StatementLineResponse{}, err
+	}
+	if !isMatched {
+		return web.StatementLineResponse{}, errors.New("transaction already matched")
+	}
+
+	line.TransactionID = transaction.ID
+	line.MatchType = constants.MATCH_TYPE_MANUAL
+	line.MatchedAt = &now
+	return toStatementLineResponse(line), nil
+}
+
+func (svc *ReconciliationService) UnmatchStatementLine(ctx context.Context, importID, lineID string) (web.StatementLineResponse, error) {
+	line, err := svc.getStatementLine(ctx, importID, lineID)
+	if err != nil {
+		return web.StatementLineResponse{}, err
+	}
+
+	isUnmatched, err := svc.ReconciliationRepository.UnmatchStatementLine(ctx, line.ID)
+	if err != nil {
+		return web.StatementLineResponse{}, err
+	}
+	if !isUnmatched {
+		return web.StatementLineResponse{}, errors.New("statement line not matched")
+	}
+
+	line.TransactionID = ""
+	line.MatchType = ""
+	line.MatchedAt = nil
+	return toStatementLineResponse(line), nil
+}
+
+// autoMatch looks for the transaction a line books. Deposits are found by
+// the bank reference, also in the form virtual account callbacks record it,
+// withdrawals by the payout ID or the provider reference sent to the bank.
+func (svc *ReconciliationService) autoMatch(ctx context.Context, bankCode string, line domain.StatementLine) (bool, error) {
+	if line.Reference == "" {
+		return false, nil
+	}
+
+	transactionType := constants.TRANSACTION_TYPE_DEPOSIT
+	if line.Direction == statement.DirectionDebit {
+		transactionType = constants.TRANSACTION_TYPE_WITHDRAWAL
+	}
+	depositReferenceID := domain.VirtualAccountPayment{BankCode: bankCode, BankReference: line.Reference}.ReferenceID()
+
+	transaction, err := svc.ReconciliationRepository.FindMatchableTransaction(ctx, transactionType, line.Amount, line.Reference, depositReferenceID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// a concurrent import may have taken the transaction, the line then
+	// stays unmatched
+	return svc.ReconciliationRepository.MatchStatementLine(ctx, line.ID, transaction.ID, constants.MATCH_TYPE_AUTO, time.Now())
+}
+
+func (svc *ReconciliationService) getStatementLine(ctx context.Context, importID, lineID string) (domain.StatementLine, error) {
+	line, err := svc.ReconciliationRepository.GetStatementLine(ctx, lineID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.StatementLine{}, errors.New("statement line not found")
+	}
+	if err != nil {
+		return domain.StatementLine{}, err
+	}
+
+	if line.ImportID != importID {
+		return domain.StatementLine{}, errors.New("statement line not found")
+	}
+	return line, nil
+}
+
+func toStatementImportResponse(statementImport domain.StatementImport) web.StatementImportResponse {
+	return web.StatementImportResponse{
+		ID:          statementImport.ID,
+		BankCode:    statementImport.BankCode,
+		Format:      statementImport.Format,
+		FileName:    statementImport.FileName,
+		Currency:    statementImport.Currency,
+		PeriodStart: statementImport.PeriodStart,
+		PeriodEnd:   statementImport.PeriodEnd,
+		LineCount:   statementImport.LineCount,
+		CreatedAt:   statementImport.CreatedAt,
+	}
+}
+
+func toStatementLineResponse(line domain.StatementLine) web.StatementLineResponse {
+	return web.StatementLineResponse{
+		ID:            line.ID,
+		LineNumber:    line.LineNumber,
+		ValueDate:     line.ValueDate,
+		Direction:     line.Direction,
+		Amount:        line.Amount,
+		Reference:     line.Reference,
+		Description:   line.Description,
+		TransactionID: line.TransactionID,
+		MatchType:     line.MatchType,
+		MatchedAt:     line.MatchedAt,
+	}
+}
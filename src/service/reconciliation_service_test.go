@@ -0,0 +1,363 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	reconciliationSvc service.ReconciliationServiceItf
+
+	mockReconciliationRepository       *mock_repository.MockReconciliationRepository
+	mockReconciliationWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideReconciliationTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReconciliationRepository = mock_repository.NewMockReconciliationRepository(ctrl)
+	mockReconciliationWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	reconciliationSvc = service.NewReconciliationService(mockReconciliationRepository, mockReconciliationWalletRepository, validator, time.UTC, "BCA")
+
+	return func() {}
+}
+
+const testMT940Statement = `{1:F01BANKIDJAXXXX0000000000}{2:O940}{4:
+:20:STMT230102
+:25:1234567890
+:28C:00001/001
+:60F:C230101EUR1000,00
+:61:2301020102C1250,50NTRFTOPUP-77//BK0001
+:86:TOP UP
+FROM JOHN DOE
+:61:230103RD99,NTRFNONREF//BK0002
+:62F:C230103EUR2151,50
+-}`
+
+func TestImportStatement(t *testing.T) {
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.StatementImportRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.StatementImportResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - csv lines matched by reference",
+			payload: web.StatementImportRequest{
+				BankCode: "BCA",
+				Format:   "csv",
+				Data: []byte("Date,Type,Amount,Currency,Reference,Description\n" +
+					"2023-01-02,C,150000,IDR,REF123,Top up\n" +
+					"2023-01-03,D,50000,IDR,mock-payout,Payout\n" +
+					"2023-01-04,C,20000,IDR,,Interest\n"),
+			},
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().CreateStatementImport(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, statementImport domain.StatementImport, lines []domain.StatementLine) (bool, error) {
+						assert.Equal(t, statementImport.Currency, "IDR")
+						assert.Equal(t, statementImport.PeriodStart, time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC))
+						assert.Equal(t, statementImport.PeriodEnd, time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC))
+						assert.Len(t, statementImport.FileHash, 64)
+						assert.Equal(t, len(lines), 3)
+						assert.Equal(t, lines[1].Direction, "debit")
+						assert.Equal(t, lines[1].Amount, domain.NewMoney(50000, "IDR"))
+						return true, nil
+					})
+				mockReconciliationRepository.EXPECT().FindMatchableTransaction(gomock.Any(), "deposit", domain.NewMoney(150000, "IDR"), "REF123", "va-BCA-REF123").
+					Return(domain.Transaction{ID: "mock-deposit"}, nil)
+				mockReconciliationRepository.EXPECT().MatchStatementLine(gomock.Any(), gomock.Any(), "mock-deposit", "auto", gomock.Any()).Return(true, nil)
+				mockReconciliationRepository.EXPECT().FindMatchableTransaction(gomock.Any(), "withdrawal", domain.NewMoney(50000, "IDR"), "mock-payout", "va-BCA-mock-payout").
+					Return(domain.Transaction{}, sql.ErrNoRows)
+			},
+			wantErr: nil,
+			wantResult: web.StatementImportResponse{
+				BankCode:     "BCA",
+				Format:       "csv",
+				Currency:     "IDR",
+				LineCount:    3,
+				MatchedCount: 1,
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - mt940 bookings",
+			payload: web.StatementImportRequest{
+				BankCode: "BCA",
+				Format:   "mt940",
+				Data:     []byte(testMT940Statement),
+			},
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().CreateStatementImport(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, statementImport domain.StatementImport, lines []domain.StatementLine) (bool, error) {
+						assert.Equal(t, len(lines), 2)
+						assert.Equal(t, lines[0].Amount, domain.NewMoney(125050, "EUR"))
+						assert.Equal(t, lines[0].Direction, "credit")
+						assert.Equal(t, lines[0].Reference, "TOPUP-77")
+						assert.Equal(t, lines[0].Description, "TOP UP FROM JOHN DOE")
+						// a reversed debit books as a credit
+						assert.Equal(t, lines[1].Amount, domain.NewMoney(9900, "EUR"))
+						assert.Equal(t, lines[1].Direction, "credit")
+						assert.Equal(t, lines[1].Reference, "BK0002")
+						return true, nil
+					})
+				mockReconciliationRepository.EXPECT().FindMatchableTransaction(gomock.Any(), "deposit", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.Transaction{}, sql.ErrNoRows).Times(2)
+			},
+			wantErr: nil,
+			wantResult: web.StatementImportResponse{
+				BankCode:  "BCA",
+				Format:    "mt940",
+				Currency:  "EUR",
+				LineCount: 2,
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - statement already imported",
+			payload: web.StatementImportRequest{
+				BankCode: "BCA",
+				Format:   "mt940",
+				Data:     []byte(testMT940Statement),
+			},
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().CreateStatementImport(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr:    fmt.Errorf("statement already imported"),
+			wantResult: web.StatementImportResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - statement mixes currencies",
+			payload: web.StatementImportRequest{
+				BankCode: "BCA",
+				Format:   "csv",
+				Data: []byte("date,amount,currency,reference\n" +
+					"2023-01-02,150000,IDR,REF1\n" +
+					"2023-01-02,-10.00,USD,REF2\n"),
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("statement mixes currencies"),
+			wantResult: web.StatementImportResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - amount with too many decimals",
+			payload: web.StatementImportRequest{
+				BankCode: "BCA",
+				Format:   "csv",
+				Data: []byte("date,amount,currency,reference\n" +
+					"2023-01-02,1500.50,IDR,REF1\n"),
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("row 2: amount has too many decimals 1500.50"),
+			wantResult: web.StatementImportResponse{},
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - csv without reference column",
+			payload: web.StatementImportRequest{
+				BankCode: "BCA",
+				Format:   "csv",
+				Data:     []byte("date,amount,currency\n2023-01-02,150000,IDR\n"),
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("statement is missing the reference column"),
+			wantResult: web.StatementImportResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideReconciliationTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := reconciliationSvc.ImportStatement(context.Background(), tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, got.BankCode, tc.wantResult.BankCode)
+			assert.Equal(t, got.Format, tc.wantResult.Format)
+			assert.Equal(t, got.Currency, tc.wantResult.Currency)
+			assert.Equal(t, got.LineCount, tc.wantResult.LineCount)
+			assert.Equal(t, got.MatchedCount, tc.wantResult.MatchedCount)
+		})
+	}
+}
+
+func TestMatchStatementLine(t *testing.T) {
+	line := domain.StatementLine{
+		ID:        "mock-line",
+		ImportID:  "mock-import",
+		Direction: "credit",
+		Amount:    domain.NewMoney(150000, "IDR"),
+	}
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		importID string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - matched by hand",
+			importID: "mock-import",
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().GetStatementLine(gomock.Any(), "mock-line").Return(line, nil)
+				mockReconciliationWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(domain.Transaction{
+					ID:              "mock-transaction",
+					TransactionType: "deposit",
+					Status:          "success",
+					Amount:          domain.NewMoney(150000, "IDR"),
+				}, nil)
+				mockReconciliationRepository.EXPECT().MatchStatementLine(gomock.Any(), "mock-line", "mock-transaction", "manual", gomock.Any()).Return(true, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - amount does not match",
+			importID: "mock-import",
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().GetStatementLine(gomock.Any(), "mock-line").Return(line, nil)
+				mockReconciliationWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(domain.Transaction{
+					ID:              "mock-transaction",
+					TransactionType: "deposit",
+					Status:          "success",
+					Amount:          domain.NewMoney(15000, "IDR"),
+				}, nil)
+			},
+			wantErr: fmt.Errorf("amount does not match"),
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - transaction matched to another line",
+			importID: "mock-import",
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().GetStatementLine(gomock.Any(), "mock-line").Return(line, nil)
+				mockReconciliationWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(domain.Transaction{
+					ID:              "mock-transaction",
+					TransactionType: "deposit",
+					Status:          "success",
+					Amount:          domain.NewMoney(150000, "IDR"),
+				}, nil)
+				mockReconciliationRepository.EXPECT().MatchStatementLine(gomock.Any(), "mock-line", "mock-transaction", "manual", gomock.Any()).Return(false, nil)
+			},
+			wantErr: fmt.Errorf("transaction already matched"),
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - line of another statement",
+			importID: "other-import",
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().GetStatementLine(gomock.Any(), "mock-line").Return(line, nil)
+			},
+			wantErr: fmt.Errorf("statement line not found"),
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - direction does not match",
+			importID: "mock-import",
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().GetStatementLine(gomock.Any(), "mock-line").Return(line, nil)
+				mockReconciliationWalletRepository.EXPECT().GetTransaction(gomock.Any(), "mock-transaction").Return(domain.Transaction{
+					ID:              "mock-transaction",
+					TransactionType: "withdrawal",
+					Status:          "success",
+					Amount:          domain.NewMoney(150000, "IDR"),
+				}, nil)
+			},
+			wantErr: fmt.Errorf("direction does not match"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideReconciliationTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := reconciliationSvc.MatchStatementLine(context.Background(), tc.importID, "mock-line", web.StatementMatchRequest{
+				TransactionID: "mock-transaction",
+			})
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.TransactionID, "mock-transaction")
+			assert.Equal(t, got.MatchType, "manual")
+		})
+	}
+}
+
+func TestGetReconciliationReport(t *testing.T) {
+	statementImport := domain.StatementImport{
+		ID:          "mock-import",
+		BankCode:    "BCA",
+		Currency:    "IDR",
+		PeriodStart: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+	from := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 4, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		mockFunc func()
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - statement of the payout bank lists withdrawals",
+			mockFunc: func() {
+				mockReconciliationRepository.EXPECT().GetStatementImport(gomock.Any(), "mock-import").Return(statementImport, nil)
+				mockReconciliationRepository.EXPECT().GetStatementLines(gomock.Any(), "mock-import").Return(nil, nil)
+				mockReconciliationRepository.EXPECT().GetUnmatchedTransactions(gomock.Any(), "BCA", "IDR", true, from, to).Return(nil, nil)
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - statement of another bank lists its deposits only",
+			mockFunc: func() {
+				other := statementImport
+				other.BankCode = "BNI"
+				mockReconciliationRepository.EXPECT().GetStatementImport(gomock.Any(), "mock-import").Return(other, nil)
+				mockReconciliationRepository.EXPECT().GetStatementLines(gomock.Any(), "mock-import").Return(nil, nil)
+				mockReconciliationRepository.EXPECT().GetUnmatchedTransactions(gomock.Any(), "BNI", "IDR", false, from, to).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideReconciliationTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			_, err := reconciliationSvc.GetReconciliationReport(context.Background(), "mock-import")
+			assert.Nil(t, err)
+		})
+	}
+}
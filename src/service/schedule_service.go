@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type ScheduleServiceItf interface {
+	CreateSchedule(ctx context.Context, customerXID string, request web.ScheduleCreateRequest) (web.ScheduleResponse, error)
+	GetSchedules(ctx context.Context, customerXID string) ([]web.ScheduleResponse, error)
+	GetScheduleRuns(ctx context.Context, customerXID, scheduleID string) ([]web.ScheduleRunResponse, error)
+	PauseSchedule(ctx context.Context, customerXID, scheduleID string) (web.ScheduleResponse, error)
+	ResumeSchedule(ctx context.Context, customerXID, scheduleID string) (web.ScheduleResponse, error)
+	CancelSchedule(ctx context.Context, customerXID, scheduleID string) (web.ScheduleResponse, error)
+	RunDueSchedules(ctx context.Context, now time.Time) error
+}
@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+const (
+	// scheduleBatchSize bounds how many due schedules a single tick executes.
+	scheduleBatchSize = 100
+	// a running schedule untouched for this long is no longer being executed
+	// by the runner that claimed it
+	scheduleStaleAfter = 10 * time.Minute
+)
+
+type ScheduleService struct {
+	ScheduleRepository repository.ScheduleRepository
+	WalletRepository   repository.WalletRepository
+	WalletService      WalletServiceItf
+	Validate           *validator.Validate
+}
+
+func NewScheduleService(scheduleRepository repository.ScheduleRepository, walletRepository repository.WalletRepository, walletService WalletServiceItf, validate *validator.Validate) ScheduleServiceItf {
+	return &ScheduleService{
+		ScheduleRepository: scheduleRepository,
+		WalletRepository:   walletRepository,
+		WalletService:      walletService,
+		Validate:           validate,
+	}
+}
+
+func (svc *ScheduleService) CreateSchedule(ctx context.Context, customerXID string, request web.ScheduleCreateRequest) (web.ScheduleResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+
+	now := time.Now()
+	if request.StartAt.Before(now) {
+		return web.ScheduleResponse{}, errors.New("start_at must be in the future")
+	}
+
+	if request.ScheduleType == constants.SCHEDULE_TYPE_TRANSFER && request.RecipientXID == customerXID {
+		return web.ScheduleResponse{}, errors.New("cannot transfer to own wallet")
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.ScheduleResponse{}, errors.New("wallet disabled")
+	}
+
+	schedule := domain.Schedule{
+		ID:           uuid.New().String(),
+		CustomerXID:  customerXID,
+		ScheduleType: request.ScheduleType,
+		Amount:       domain.NewMoney(request.Amount, wallet.Currency),
+		Frequency:    request.Frequency,
+		Status:       constants.STATUS_ACTIVE,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	switch schedule.ScheduleType {
+	case constants.SCHEDULE_TYPE_TRANSFER:
+		schedule.RecipientXID = request.RecipientXID
+	case constants.SCHEDULE_TYPE_WITHDRAWAL:
+		schedule.BankAccount = domain.BankAccount{
+			BankCode:      request.BankCode,
+			AccountNumber: request.AccountNumber,
+			AccountName:   request.AccountName,
+		}
+	}
+	if schedule.Frequency == constants.FREQUENCY_MONTHLY {
+		schedule.DayOfMonth = request.DayOfMonth
+	}
+	schedule.NextRunAt = schedule.FirstRunAt(request.StartAt)
+
+	err = svc.ScheduleRepository.CreateSchedule(ctx, schedule)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+
+	return toScheduleResponse(schedule), nil
+}
+
+func (svc *ScheduleService) GetSchedules(ctx context.Context, customerXID string) ([]web.ScheduleResponse, error) {
+	schedules, err := svc.ScheduleRepository.GetSchedules(ctx, customerXID)
+	if err != nil {
+		return []web.ScheduleResponse{}, err
+	}
+
+	result := []web.ScheduleResponse{}
+	for i := range schedules {
+		result = append(result, toScheduleResponse(schedules[i]))
+	}
+	return result, nil
+}
+
+func (svc *ScheduleService) GetScheduleRuns(ctx context.Context, customerXID, scheduleID string) ([]web.ScheduleRunResponse, error) {
+	schedule, err := svc.getOwnedSchedule(ctx, customerXID, scheduleID)
+	if err != nil {
+		return []web.ScheduleRunResponse{}, err
+	}
+
+	runs, err := svc.ScheduleRepository.GetScheduleRuns(ctx, schedule.ID)
+	if err != nil {
+		return []web.ScheduleRunResponse{}, err
+	}
+
+	result := []web.ScheduleRunResponse{}
+	for i := range runs {
+		result = append(result, web.ScheduleRunResponse{
+			ID:            runs[i].ID,
+			RunAt:         runs[i].RunAt,
+			ReferenceID:   runs[i].ReferenceID,
+			TransactionID: runs[i].TransactionID,
+			Status:        runs[i].Status,
+			ErrorMessage:  runs[i].ErrorMessage,
+			CreatedAt:     runs[i].CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (svc *ScheduleService) PauseSchedule(ctx context.Context, customerXID, scheduleID string) (web.ScheduleResponse, error) {
+	schedule, err := svc.getOwnedSchedule(ctx, customerXID, scheduleID)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+
+	if schedule.Status == constants.STATUS_RUNNING {
+		return web.ScheduleResponse{}, errors.New("schedule is running, please retry")
+	}
+	if schedule.Status != constants.STATUS_ACTIVE {
+		return web.ScheduleResponse{}, errors.New("only active schedules can be paused")
+	}
+
+	return svc.updateScheduleStatus(ctx, schedule, constants.STATUS_PAUSED, schedule.NextRunAt)
+}
+
+func (svc *ScheduleService) ResumeSchedule(ctx context.Context, customerXID, scheduleID string) (web.ScheduleResponse, error) {
+	schedule, err := svc.getOwnedSchedule(ctx, customerXID, scheduleID)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+
+	if schedule.Status != constants.STATUS_PAUSED {
+		return web.ScheduleResponse{}, errors.New("only paused schedules can be resumed")
+	}
+
+	// occurrences missed while paused are skipped, a one-off runs right away
+	nextRunAt := schedule.NextRunAt
+	if schedule.Frequency != constants.FREQUENCY_ONCE {
+		nextRunAt = skipMissedRuns(schedule, schedule.NextRunAt, time.Now())
+	}
+
+	return svc.updateScheduleStatus(ctx, schedule, constants.STATUS_ACTIVE, nextRunAt)
+}
+
+func (svc *ScheduleService) CancelSchedule(ctx context.Context, customerXID, scheduleID string) (web.ScheduleResponse, error) {
+	schedule, err := svc.getOwnedSchedule(ctx, customerXID, scheduleID)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+
+	if schedule.Status == constants.STATUS_RUNNING {
+		return web.ScheduleResponse{}, errors.New("schedule is running, please retry")
+	}
+	if schedule.Status != constants.STATUS_ACTIVE && schedule.Status != constants.STATUS_PAUSED {
+		return web.ScheduleResponse{}, errors.New("schedule already finished")
+	}
+
+	return svc.updateScheduleStatus(ctx, schedule, constants.STATUS_CANCELLED, schedule.NextRunAt)
+}
+
+// updateScheduleStatus moves the schedule out of the status it was read
+// with, failing if a runner claimed it in the meantime.
+func (svc *ScheduleService) updateScheduleStatus(ctx context.Context, schedule domain.Schedule, status string, nextRunAt time.Time) (web.ScheduleResponse, error) {
+	isUpdated, err := svc.ScheduleRepository.UpdateScheduleStatus(ctx, schedule, status, nextRunAt)
+	if err != nil {
+		return web.ScheduleResponse{}, err
+	}
+	if !isUpdated {
+		return web.ScheduleResponse{}, errors.New("schedule changed, please retry")
+	}
+
+	schedule.Status = status
+	schedule.NextRunAt = nextRunAt
+	return toScheduleResponse(schedule), nil
+}
+
+// RunDueSchedules executes every active schedule whose next run is due. A
+// schedule that hits an infrastructure error is left untouched and retried on
+// the next call with the same reference_id.
+func (svc *ScheduleService) RunDueSchedules(ctx context.Context, now time.Time) error {
+	schedules, err := svc.ScheduleRepository.GetDueSchedules(ctx, now, now.Add(-scheduleStaleAfter), scheduleBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range schedules {
+		err = svc.runSchedule(ctx, schedules[i], now)
+		if err != nil {
+			log.Println("error run schedule", schedules[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *ScheduleService) runSchedule(ctx context.Context, schedule domain.Schedule, now time.Time) error {
+	runAt := schedule.NextRunAt
+
+	// claim the run before moving money, a schedule paused or cancelled since
+	// it was read is not executed
+	isClaimed, err := svc.ScheduleRepository.ClaimSchedule(ctx, schedule.ID, runAt, now.Add(-scheduleStaleAfter))
+	if err != nil {
+		return err
+	}
+	if !isClaimed {
+		return nil
+	}
+
+	status, nextRunAt, err := svc.recordRun(ctx, schedule, runAt, now)
+	if err != nil {
+		// hand the run back to be retried, a runner that cannot do so leaves
+		// it stale and it is picked up again later
+		_, releaseErr := svc.ScheduleRepository.AdvanceSchedule(ctx, schedule.ID, runAt, runAt, constants.STATUS_ACTIVE)
+		if releaseErr != nil {
+			log.Println("error release schedule", schedule.ID+":", releaseErr.Error())
+		}
+		return err
+	}
+
+	_, err = svc.ScheduleRepository.AdvanceSchedule(ctx, schedule.ID, runAt, nextRunAt, status)
+	return err
+}
+
+// recordRun executes the occurrence at runAt and records its run, returning
+// the status and next run of the schedule afterwards. Only a payment the
+// wallet refused is recorded as a failed run, any other error is returned.
+func (svc *ScheduleService) recordRun(ctx context.Context, schedule domain.Schedule, runAt, now time.Time) (string, time.Time, error) {
+	run := domain.ScheduleRun{
+		ID:          uuid.New().String(),
+		ScheduleID:  schedule.ID,
+		RunAt:       runAt,
+		ReferenceID: schedule.ReferenceID(runAt),
+		Status:      constants.STATUS_SUCCESS,
+		CreatedAt:   now,
+	}
+
+	// a previous attempt may have posted the transaction before failing to
+	// record the run, pick it up instead of executing again
+	existing, err := svc.WalletRepository.GetTransactionByReference(ctx, scheduleTransactionType(schedule), run.ReferenceID)
+	switch {
+	case err == nil:
+		run.TransactionID = existing.ID
+	case errors.Is(err, sql.ErrNoRows):
+		run.TransactionID, err = svc.execute(ctx, schedule, run.ReferenceID)
+		if err != nil && !isRejection(err) {
+			return "", time.Time{}, err
+		}
+		if err != nil {
+			run.Status = constants.STATUS_FAILED
+			run.ErrorMessage = truncate(err.Error(), 255)
+		}
+	default:
+		return "", time.Time{}, err
+	}
+
+	err = svc.ScheduleRepository.AddScheduleRun(ctx, run)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	status := constants.STATUS_ACTIVE
+	nextRunAt := runAt
+	if schedule.Frequency == constants.FREQUENCY_ONCE {
+		status = constants.STATUS_COMPLETED
+		if run.Status == constants.STATUS_FAILED {
+			status = constants.STATUS_FAILED
+		}
+	} else {
+		nextRunAt = skipMissedRuns(schedule, schedule.NextRunAfter(runAt), now)
+	}
+	return status, nextRunAt, nil
+}
+
+func (svc *ScheduleService) execute(ctx context.Context, schedule domain.Schedule, referenceID string) (string, error) {
+	switch schedule.ScheduleType {
+	case constants.SCHEDULE_TYPE_WITHDRAWAL:
+		result, err := svc.WalletService.DeductWalletBalance(ctx, schedule.CustomerXID, web.WithdrawalRequest{
+			Amount:        schedule.Amount.Amount,
+			ReferenceID:   referenceID,
+			BankCode:      schedule.BankAccount.BankCode,
+			AccountNumber: schedule.BankAccount.AccountNumber,
+			AccountName:   schedule.BankAccount.AccountName,
+		})
+		return result.ID, err
+	case constants.SCHEDULE_TYPE_TRANSFER:
+		result, err := svc.WalletService.TransferBalance(ctx, schedule.CustomerXID, web.TransferRequest{
+			RecipientXID: schedule.RecipientXID,
+			Amount:       schedule.Amount.Amount,
+			ReferenceID:  referenceID,
+		})
+		return result.ID, err
+	}
+	return "", errors.New("unknown schedule type")
+}
+
+// retryableMySQLErrors are the server errors that say nothing about the
+// payment itself: too many connections, a server shutting down, a lock wait
+// timeout and a deadlock. Any other error such as a duplicate key fails the
+// same way every time.
+var retryableMySQLErrors = map[uint16]bool{
+	1040: true,
+	1053: true,
+	1205: true,
+	1213: true,
+}
+
+// isRejection tells a payment the wallet refused, such as one over the
+// balance, apart from a failure to reach the database that is worth retrying.
+func isRejection(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return !retryableMySQLErrors[mysqlErr.Number]
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr),
+		errors.Is(err, driver.ErrBadConn), errors.Is(err, mysql.ErrInvalidConn),
+		errors.Is(err, sql.ErrConnDone), errors.Is(err, sql.ErrTxDone),
+		errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled),
+		errors.Is(err, errWalletBalanceChanged):
+		return false
+	}
+	return true
+}
+
+func (svc *ScheduleService) getOwnedSchedule(ctx context.Context, customerXID, scheduleID string) (domain.Schedule, error) {
+	schedule, err := svc.ScheduleRepository.GetSchedule(ctx, scheduleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Schedule{}, errors.New("schedule not found")
+	}
+	if err != nil {
+		return domain.Schedule{}, err
+	}
+
+	if schedule.CustomerXID != customerXID {
+		return domain.Schedule{}, errors.New("schedule not found")
+	}
+	return schedule, nil
+}
+
+// skipMissedRuns moves nextRunAt past now, so a schedule that fell behind
+// does not fire every missed occurrence at once.
+func skipMissedRuns(schedule domain.Schedule, nextRunAt, now time.Time) time.Time {
+	for !nextRunAt.After(now) {
+		nextRunAt = schedule.NextRunAfter(nextRunAt)
+	}
+	return nextRunAt
+}
+
+func scheduleTransactionType(schedule domain.Schedule) string {
+	if schedule.ScheduleType == constants.SCHEDULE_TYPE_TRANSFER {
+		return constants.TRANSACTION_TYPE_TRANSFER_OUT
+	}
+	return constants.TRANSACTION_TYPE_WITHDRAWAL
+}
+
+func toScheduleResponse(schedule domain.Schedule) web.ScheduleResponse {
+	result := web.ScheduleResponse{
+		ID:            schedule.ID,
+		Type:          schedule.ScheduleType,
+		RecipientXID:  schedule.RecipientXID,
+		BankCode:      schedule.BankAccount.BankCode,
+		AccountNumber: schedule.BankAccount.AccountNumber,
+		AccountName:   schedule.BankAccount.AccountName,
+		Amount:        schedule.Amount,
+		Frequency:     schedule.Frequency,
+		DayOfMonth:    schedule.DayOfMonth,
+		Status:        schedule.Status,
+		CreatedAt:     schedule.CreatedAt,
+	}
+	if schedule.Status == constants.STATUS_ACTIVE || schedule.Status == constants.STATUS_PAUSED || schedule.Status == constants.STATUS_RUNNING {
+		nextRunAt := schedule.NextRunAt
+		result.NextRunAt = &nextRunAt
+	}
+	return result
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+	return value[:length]
+}
@@ -0,0 +1,406 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	mock_service "github.com/mozartmuhammad/julo-be-test/src/mock/service"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	scheduleSvc service.ScheduleServiceItf
+
+	mockScheduleRepository       *mock_repository.MockScheduleRepository
+	mockScheduleWalletRepository *mock_repository.MockWalletRepository
+	mockScheduleWalletService    *mock_service.MockWalletServiceItf
+)
+
+func provideScheduleTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockScheduleRepository = mock_repository.NewMockScheduleRepository(ctrl)
+	mockScheduleWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockScheduleWalletService = mock_service.NewMockWalletServiceItf(ctrl)
+	validator := validator.New()
+	scheduleSvc = service.NewScheduleService(mockScheduleRepository, mockScheduleWalletRepository, mockScheduleWalletService, validator)
+
+	return func() {}
+}
+
+func TestCreateSchedule(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.ScheduleCreateRequest
+		}
+	)
+
+	startAt := time.Date(time.Now().Year()+1, time.January, 31, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.ScheduleResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - monthly on day of month",
+			args: args{
+				customerXID: "1",
+				payload: web.ScheduleCreateRequest{
+					ScheduleType: "transfer",
+					RecipientXID: "2",
+					Amount:       1000,
+					Frequency:    "monthly",
+					DayOfMonth:   15,
+					StartAt:      startAt,
+				},
+			},
+			mockFunc: func() {
+				mockScheduleWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockScheduleRepository.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.ScheduleResponse{
+				Type:         "transfer",
+				RecipientXID: "2",
+				Frequency:    "monthly",
+				DayOfMonth:   15,
+				Status:       "active",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - monthly without day of month",
+			args: args{
+				customerXID: "1",
+				payload: web.ScheduleCreateRequest{
+					ScheduleType: "withdrawal",
+					Amount:       1000,
+					Frequency:    "monthly",
+					StartAt:      startAt,
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.ScheduleResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - transfer without recipient",
+			args: args{
+				customerXID: "1",
+				payload: web.ScheduleCreateRequest{
+					ScheduleType: "transfer",
+					Amount:       1000,
+					Frequency:    "daily",
+					StartAt:      startAt,
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.ScheduleResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - start in the past",
+			args: args{
+				customerXID: "1",
+				payload: web.ScheduleCreateRequest{
+					ScheduleType: "withdrawal",
+					Amount:       1000,
+					Frequency:    "once",
+					StartAt:      time.Now().Add(-time.Hour),
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.ScheduleResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - withdrawal without bank account",
+			args: args{
+				customerXID: "1",
+				payload: web.ScheduleCreateRequest{
+					ScheduleType: "withdrawal",
+					Amount:       1000,
+					Frequency:    "monthly",
+					DayOfMonth:   15,
+					StartAt:      startAt,
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.ScheduleResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideScheduleTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := scheduleSvc.CreateSchedule(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.Type, tc.wantResult.Type)
+			assert.Equal(t, got.RecipientXID, tc.wantResult.RecipientXID)
+			assert.Equal(t, got.Frequency, tc.wantResult.Frequency)
+			assert.Equal(t, got.DayOfMonth, tc.wantResult.DayOfMonth)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			if !tc.wantErr {
+				// the 31st start moves to the 15th of the following month
+				assert.Equal(t, time.Date(startAt.Year(), time.February, 15, 9, 0, 0, 0, time.UTC), *got.NextRunAt)
+			}
+		})
+	}
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	now := time.Date(2026, time.January, 31, 9, 0, 30, 0, time.UTC)
+	runAt := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	monthly := domain.Schedule{
+		ID:           "mock-schedule",
+		CustomerXID:  "1",
+		ScheduleType: "withdrawal",
+		BankAccount: domain.BankAccount{
+			BankCode:      "BCA",
+			AccountNumber: "1234567890",
+			AccountName:   "John Doe",
+		},
+		Amount:     domain.Money{Amount: 1000},
+		Frequency:  "monthly",
+		DayOfMonth: 31,
+		NextRunAt:  runAt,
+		Status:     "active",
+	}
+	referenceID := "sched-mock-schedule-20260131T090000"
+	// february has no 31st, the next run is clamped to its last day
+	nextRunAt := time.Date(2026, time.February, 28, 9, 0, 0, 0, time.UTC)
+	staleBefore := now.Add(-10 * time.Minute)
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - executes and advances",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(true, nil)
+				mockScheduleWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "withdrawal", referenceID).Return(domain.Transaction{}, sql.ErrNoRows)
+				mockScheduleWalletService.EXPECT().DeductWalletBalance(gomock.Any(), "1", web.WithdrawalRequest{
+					Amount:        1000,
+					ReferenceID:   referenceID,
+					BankCode:      "BCA",
+					AccountNumber: "1234567890",
+					AccountName:   "John Doe",
+				}).Return(web.WithdrawalResponse{ID: "mock-transaction"}, nil)
+				mockScheduleRepository.EXPECT().AddScheduleRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run domain.ScheduleRun) error {
+					assert.Equal(t, "success", run.Status)
+					assert.Equal(t, "mock-transaction", run.TransactionID)
+					return nil
+				})
+				mockScheduleRepository.EXPECT().AdvanceSchedule(gomock.Any(), "mock-schedule", runAt, nextRunAt, "active").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   2,
+			testDesc: "Success - failure is recorded and schedule advances",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(true, nil)
+				mockScheduleWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "withdrawal", referenceID).Return(domain.Transaction{}, sql.ErrNoRows)
+				mockScheduleWalletService.EXPECT().DeductWalletBalance(gomock.Any(), "1", gomock.Any()).Return(web.WithdrawalResponse{}, fmt.Errorf("insufficient balance"))
+				mockScheduleRepository.EXPECT().AddScheduleRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run domain.ScheduleRun) error {
+					assert.Equal(t, "failed", run.Status)
+					assert.Equal(t, "insufficient balance", run.ErrorMessage)
+					return nil
+				})
+				mockScheduleRepository.EXPECT().AdvanceSchedule(gomock.Any(), "mock-schedule", runAt, nextRunAt, "active").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   3,
+			testDesc: "Success - retried run reuses posted transaction",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(true, nil)
+				mockScheduleWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "withdrawal", referenceID).Return(domain.Transaction{ID: "mock-transaction"}, nil)
+				mockScheduleRepository.EXPECT().AddScheduleRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run domain.ScheduleRun) error {
+					assert.Equal(t, "success", run.Status)
+					assert.Equal(t, "mock-transaction", run.TransactionID)
+					return nil
+				})
+				mockScheduleRepository.EXPECT().AdvanceSchedule(gomock.Any(), "mock-schedule", runAt, nextRunAt, "active").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   4,
+			testDesc: "Success - lookup error leaves schedule for retry",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(true, nil)
+				mockScheduleWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "withdrawal", referenceID).Return(domain.Transaction{}, fmt.Errorf("error"))
+				mockScheduleRepository.EXPECT().AdvanceSchedule(gomock.Any(), "mock-schedule", runAt, runAt, "active").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - error GetDueSchedules",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return(nil, fmt.Errorf("error"))
+			},
+			wantErr: true,
+		},
+		{
+			testID:   6,
+			testDesc: "Success - schedule cancelled before the claim is not executed",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(false, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   7,
+			testDesc: "Success - database error while executing leaves schedule for retry",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(true, nil)
+				mockScheduleWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "withdrawal", referenceID).Return(domain.Transaction{}, sql.ErrNoRows)
+				mockScheduleWalletService.EXPECT().DeductWalletBalance(gomock.Any(), "1", gomock.Any()).Return(web.WithdrawalResponse{}, &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"})
+				mockScheduleRepository.EXPECT().AdvanceSchedule(gomock.Any(), "mock-schedule", runAt, runAt, "active").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   8,
+			testDesc: "Success - permanent database error is recorded and schedule advances",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetDueSchedules(gomock.Any(), now, staleBefore, gomock.Any()).Return([]domain.Schedule{monthly}, nil)
+				mockScheduleRepository.EXPECT().ClaimSchedule(gomock.Any(), "mock-schedule", runAt, staleBefore).Return(true, nil)
+				mockScheduleWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "withdrawal", referenceID).Return(domain.Transaction{}, sql.ErrNoRows)
+				mockScheduleWalletService.EXPECT().DeductWalletBalance(gomock.Any(), "1", gomock.Any()).Return(web.WithdrawalResponse{}, &mysql.MySQLError{Number: 1406, Message: "Data too long for column 'account_name' at row 1"})
+				mockScheduleRepository.EXPECT().AddScheduleRun(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, run domain.ScheduleRun) error {
+					assert.Equal(t, "failed", run.Status)
+					return nil
+				})
+				mockScheduleRepository.EXPECT().AdvanceSchedule(gomock.Any(), "mock-schedule", runAt, nextRunAt, "active").Return(true, nil)
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideScheduleTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			err := scheduleSvc.RunDueSchedules(context.Background(), now)
+			assert.Equal(t, err != nil, tc.wantErr)
+		})
+	}
+}
+
+func TestCancelSchedule(t *testing.T) {
+	runAt := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	schedule := domain.Schedule{
+		ID:           "mock-schedule",
+		CustomerXID:  "1",
+		ScheduleType: "transfer",
+		RecipientXID: "2",
+		Amount:       domain.Money{Amount: 1000},
+		Frequency:    "once",
+		NextRunAt:    runAt,
+		Status:       "active",
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		mockFunc   func()
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - cancel active schedule",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetSchedule(gomock.Any(), "mock-schedule").Return(schedule, nil)
+				mockScheduleRepository.EXPECT().UpdateScheduleStatus(gomock.Any(), schedule, "cancelled", runAt).Return(true, nil)
+			},
+			wantErr:    nil,
+			wantStatus: "cancelled",
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - schedule is running",
+			mockFunc: func() {
+				running := schedule
+				running.Status = "running"
+				mockScheduleRepository.EXPECT().GetSchedule(gomock.Any(), "mock-schedule").Return(running, nil)
+			},
+			wantErr:    fmt.Errorf("schedule is running, please retry"),
+			wantStatus: "",
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - claimed by the runner in the meantime",
+			mockFunc: func() {
+				mockScheduleRepository.EXPECT().GetSchedule(gomock.Any(), "mock-schedule").Return(schedule, nil)
+				mockScheduleRepository.EXPECT().UpdateScheduleStatus(gomock.Any(), schedule, "cancelled", runAt).Return(false, nil)
+			},
+			wantErr:    fmt.Errorf("schedule changed, please retry"),
+			wantStatus: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideScheduleTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := scheduleSvc.CancelSchedule(context.Background(), "1", "mock-schedule")
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.Status, tc.wantStatus)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type SettlementServiceItf interface {
+	RunSettlements(ctx context.Context, now time.Time) error
+	GetSettlements(ctx context.Context, customerXID string) ([]web.SettlementResponse, error)
+	// GetSettlementReport renders the settlement as CSV, one row per included
+	// transaction followed by a total row.
+	GetSettlementReport(ctx context.Context, customerXID, settlementID string) ([]byte, error)
+}
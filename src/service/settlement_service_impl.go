@@ -0,0 +1,320 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type SettlementService struct {
+	SettlementRepository repository.SettlementRepository
+	MerchantRepository   repository.MerchantRepository
+	WalletRepository     repository.WalletRepository
+	// FeeRate is the share of every payment kept as merchant fee, 0.007 is 0.7%
+	FeeRate float64
+	// Location decides where a settlement day starts and ends
+	Location *time.Location
+}
+
+func NewSettlementService(settlementRepository repository.SettlementRepository, merchantRepository repository.MerchantRepository, walletRepository repository.WalletRepository, feeRate float64, location *time.Location) SettlementServiceItf {
+	return &SettlementService{
+		SettlementRepository: settlementRepository,
+		MerchantRepository:   merchantRepository,
+		WalletRepository:     walletRepository,
+		FeeRate:              feeRate,
+		Location:             location,
+	}
+}
+
+// RunSettlements settles every merchant up to the last midnight. It is safe
+// to run as often as needed: items belong to one settlement only and a
+// merchant gets at most one settlement per currency and cutoff.
+func (svc *SettlementService) RunSettlements(ctx context.Context, now time.Time) error {
+	local := now.In(svc.Location)
+	cutoffAt := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, svc.Location)
+
+	merchantIDs, err := svc.SettlementRepository.GetUnsettledMerchantIDs(ctx, cutoffAt)
+	if err != nil {
+		return err
+	}
+
+	for _, merchantID := range merchantIDs {
+		err = svc.settleMerchant(ctx, merchantID, cutoffAt, now)
+		if err != nil {
+			log.Println("error settle merchant", merchantID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+func (svc *SettlementService) settleMerchant(ctx context.Context, merchantID string, cutoffAt, now time.Time) error {
+	merchant, err := svc.MerchantRepository.GetMerchant(ctx, merchantID)
+	if err != nil {
+		return err
+	}
+
+	items, err := svc.SettlementRepository.GetUnsettledItems(ctx, merchantID, cutoffAt)
+	if err != nil {
+		return err
+	}
+
+	// every currency is paid out into its own wallet
+	itemsByCurrency := map[string][]domain.SettlementItem{}
+	currencies := []string{}
+	for _, item := range items {
+		currency := item.Amount.Currency
+		if _, ok := itemsByCurrency[currency]; !ok {
+			currencies = append(currencies, currency)
+		}
+		itemsByCurrency[currency] = append(itemsByCurrency[currency], item)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		err = svc.settleCurrency(ctx, merchant, currency, itemsByCurrency[currency], cutoffAt, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (svc *SettlementService) settleCurrency(ctx context.Context, merchant domain.Merchant, currency string, items []domain.SettlementItem, cutoffAt, now time.Time) error {
+	settlement := domain.Settlement{
+		ID:            uuid.New().String(),
+		MerchantID:    merchant.ID,
+		CutoffAt:      cutoffAt,
+		GrossAmount:   domain.NewMoney(0, currency),
+		FeeAmount:     domain.NewMoney(0, currency),
+		RefundAmount:  domain.NewMoney(0, currency),
+		CarriedAmount: domain.NewMoney(0, currency),
+		Status:        constants.STATUS_SUCCESS,
+		CreatedAt:     now,
+	}
+
+	// refunds that outweighed the payments of an earlier settlement are
+	// taken out of this one
+	carried, err := svc.SettlementRepository.GetCarriedSettlement(ctx, merchant.ID, currency)
+	switch {
+	case err == nil:
+		settlement.CarriedAmount = carried.NetAmount
+		settlement.CarriedFromID = carried.ID
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	for i := range items {
+		// a refund gives back the fee of its payment, the merchant pays no
+		// fee on money returned to the customer
+		fee := applyRate(items[i].Amount, floatToRat(svc.FeeRate))
+		if items[i].TransactionType == constants.TRANSACTION_TYPE_PAYMENT_REFUND {
+			items[i].Fee = domain.NewMoney(-fee.Amount, currency)
+			settlement.RefundAmount, err = settlement.RefundAmount.Add(items[i].Amount)
+		} else {
+			items[i].Fee = fee
+			settlement.GrossAmount, err = settlement.GrossAmount.Add(items[i].Amount)
+		}
+		if err != nil {
+			return err
+		}
+		settlement.FeeAmount, err = settlement.FeeAmount.Add(items[i].Fee)
+		if err != nil {
+			return err
+		}
+	}
+
+	settlement.NetAmount, err = settlement.GrossAmount.Sub(settlement.FeeAmount)
+	if err != nil {
+		return err
+	}
+	settlement.NetAmount, err = settlement.NetAmount.Sub(settlement.RefundAmount)
+	if err != nil {
+		return err
+	}
+	settlement.NetAmount, err = settlement.NetAmount.Add(settlement.CarriedAmount)
+	if err != nil {
+		return err
+	}
+
+	// refunds outweighing payments are stored without a payout and carried
+	// over to the next settlement
+	if !settlement.NetAmount.IsPositive() {
+		if settlement.NetAmount.IsNegative() {
+			settlement.Status = constants.STATUS_CARRIED
+		}
+		return svc.createSettlement(ctx, settlement, items, nil)
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, merchant.CustomerXID, currency)
+	if err != nil {
+		return err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return errors.New("merchant wallet disabled")
+	}
+
+	// the merchant wallet must be able to hold the payout
+	_, err = wallet.Balance.Add(settlement.NetAmount)
+	if err != nil {
+		return err
+	}
+
+	payout := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_SETTLEMENT_PAYOUT,
+		Amount:          settlement.NetAmount,
+		ReferenceID:     settlement.ReferenceID(),
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	settlement.TransactionID = payout.ID
+
+	return svc.createSettlement(ctx, settlement, items, &payout)
+}
+
+func (svc *SettlementService) createSettlement(ctx context.Context, settlement domain.Settlement, items []domain.SettlementItem, payout *domain.Transaction) error {
+	isCreated, err := svc.SettlementRepository.CreateSettlement(ctx, settlement, items, payout)
+	if err != nil {
+		return err
+	}
+	if !isCreated {
+		return errors.New("carried settlement already recovered")
+	}
+	return nil
+}
+
+func (svc *SettlementService) GetSettlements(ctx context.Context, customerXID string) ([]web.SettlementResponse, error) {
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return []web.SettlementResponse{}, err
+	}
+
+	settlements, err := svc.SettlementRepository.GetSettlements(ctx, merchant.ID)
+	if err != nil {
+		return []web.SettlementResponse{}, err
+	}
+
+	result := []web.SettlementResponse{}
+	for _, settlement := range settlements {
+		result = append(result, web.SettlementResponse{
+			ID:            settlement.ID,
+			CutoffAt:      settlement.CutoffAt,
+			GrossAmount:   settlement.GrossAmount,
+			FeeAmount:     settlement.FeeAmount,
+			RefundAmount:  settlement.RefundAmount,
+			CarriedAmount: settlement.CarriedAmount,
+			NetAmount:     settlement.NetAmount,
+			Currency:      settlement.NetAmount.Currency,
+			TransactionID: settlement.TransactionID,
+			Status:        settlement.Status,
+			CreatedAt:     settlement.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+func (svc *SettlementService) GetSettlementReport(ctx context.Context, customerXID, settlementID string) ([]byte, error) {
+	merchant, err := svc.getMerchant(ctx, customerXID)
+	if err != nil {
+		return nil, err
+	}
+
+	settlement, err := svc.SettlementRepository.GetSettlement(ctx, settlementID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && settlement.MerchantID != merchant.ID) {
+		return nil, errors.New("settlement not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := svc.SettlementRepository.GetSettlementItems(ctx, settlement.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"transaction_id", "payment_intent_id", "type", "transacted_at", "amount", "fee", "net", "currency"})
+	for _, item := range items {
+		// refunds are taken out of the payout
+		amount := item.Amount.Amount
+		if item.TransactionType == constants.TRANSACTION_TYPE_PAYMENT_REFUND {
+			amount = -amount
+		}
+
+		_ = writer.Write([]string{
+			item.TransactionID,
+			item.PaymentIntentID,
+			item.TransactionType,
+			item.TransactedAt.UTC().Format(time.RFC3339),
+			strconv.FormatInt(amount, 10),
+			strconv.FormatInt(item.Fee.Amount, 10),
+			strconv.FormatInt(amount-item.Fee.Amount, 10),
+			item.Amount.Currency,
+		})
+	}
+	if !settlement.CarriedAmount.IsZero() {
+		_ = writer.Write([]string{
+			settlement.CarriedFromID,
+			"",
+			"carried",
+			"",
+			strconv.FormatInt(settlement.CarriedAmount.Amount, 10),
+			"0",
+			strconv.FormatInt(settlement.CarriedAmount.Amount, 10),
+			settlement.CarriedAmount.Currency,
+		})
+	}
+	_ = writer.Write([]string{
+		"total",
+		"",
+		"",
+		settlement.CutoffAt.UTC().Format(time.RFC3339),
+		strconv.FormatInt(settlement.GrossAmount.Amount-settlement.RefundAmount.Amount+settlement.CarriedAmount.Amount, 10),
+		strconv.FormatInt(settlement.FeeAmount.Amount, 10),
+		strconv.FormatInt(settlement.NetAmount.Amount, 10),
+		settlement.NetAmount.Currency,
+	})
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (svc *SettlementService) getMerchant(ctx context.Context, customerXID string) (domain.Merchant, error) {
+	merchant, err := svc.MerchantRepository.GetMerchantByCustomerXID(ctx, customerXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Merchant{}, errors.New("merchant not found")
+	}
+	if err != nil {
+		return domain.Merchant{}, err
+	}
+	return merchant, nil
+}
+
+// applyRate returns amount times rate, rounded half up to the minor unit.
+func applyRate(amount domain.Money, rate *big.Rat) domain.Money {
+	fee := new(big.Rat).Mul(big.NewRat(amount.Amount, 1), rate)
+	fee.Add(fee, big.NewRat(1, 2))
+	return domain.NewMoney(new(big.Int).Quo(fee.Num(), fee.Denom()).Int64(), amount.Currency)
+}
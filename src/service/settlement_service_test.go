@@ -0,0 +1,276 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	settlementSvc service.SettlementServiceItf
+
+	mockSettlementRepository         *mock_repository.MockSettlementRepository
+	mockSettlementMerchantRepository *mock_repository.MockMerchantRepository
+	mockSettlementWalletRepository   *mock_repository.MockWalletRepository
+)
+
+func provideSettlementTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSettlementRepository = mock_repository.NewMockSettlementRepository(ctrl)
+	mockSettlementMerchantRepository = mock_repository.NewMockMerchantRepository(ctrl)
+	mockSettlementWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	settlementSvc = service.NewSettlementService(mockSettlementRepository, mockSettlementMerchantRepository, mockSettlementWalletRepository, 0.007, time.UTC)
+
+	return func() {}
+}
+
+func TestRunSettlements(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC)
+	cutoffAt := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	merchant := domain.Merchant{
+		ID:          "mock-merchant",
+		CustomerXID: "1",
+	}
+	wallet := domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Status:      "enabled",
+		Balance:     domain.Money{Amount: 0, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		items      []domain.SettlementItem
+		mockFunc   func()
+		wantErr    bool
+		wantResult domain.Settlement
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - payout less fee and refund",
+			items: []domain.SettlementItem{
+				{TransactionID: "trx-1", TransactionType: "payment", Amount: domain.Money{Amount: 10000, Currency: "IDR"}},
+				{TransactionID: "trx-2", TransactionType: "payment", Amount: domain.Money{Amount: 25050, Currency: "IDR"}},
+				{TransactionID: "trx-3", TransactionType: "payment_refund", Amount: domain.Money{Amount: 5000, Currency: "IDR"}},
+			},
+			mockFunc: func() {
+				mockSettlementRepository.EXPECT().GetCarriedSettlement(gomock.Any(), "mock-merchant", "IDR").Return(domain.Settlement{}, sql.ErrNoRows)
+				mockSettlementWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+			},
+			wantErr: false,
+			wantResult: domain.Settlement{
+				GrossAmount:   domain.Money{Amount: 35050, Currency: "IDR"},
+				FeeAmount:     domain.Money{Amount: 210, Currency: "IDR"},
+				RefundAmount:  domain.Money{Amount: 5000, Currency: "IDR"},
+				CarriedAmount: domain.Money{Amount: 0, Currency: "IDR"},
+				NetAmount:     domain.Money{Amount: 29840, Currency: "IDR"},
+				Status:        "success",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - refunds outweighing payments are stored and carried over",
+			items: []domain.SettlementItem{
+				{TransactionID: "trx-1", TransactionType: "payment", Amount: domain.Money{Amount: 1000, Currency: "IDR"}},
+				{TransactionID: "trx-2", TransactionType: "payment_refund", Amount: domain.Money{Amount: 5000, Currency: "IDR"}},
+			},
+			mockFunc: func() {
+				mockSettlementRepository.EXPECT().GetCarriedSettlement(gomock.Any(), "mock-merchant", "IDR").Return(domain.Settlement{}, sql.ErrNoRows)
+			},
+			wantErr: false,
+			wantResult: domain.Settlement{
+				GrossAmount:   domain.Money{Amount: 1000, Currency: "IDR"},
+				FeeAmount:     domain.Money{Amount: -28, Currency: "IDR"},
+				RefundAmount:  domain.Money{Amount: 5000, Currency: "IDR"},
+				CarriedAmount: domain.Money{Amount: 0, Currency: "IDR"},
+				NetAmount:     domain.Money{Amount: -3972, Currency: "IDR"},
+				Status:        "carried",
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Success - payment refunded in the same window is charged no fee",
+			items: []domain.SettlementItem{
+				{TransactionID: "trx-1", PaymentIntentID: "intent-1", TransactionType: "payment", Amount: domain.Money{Amount: 10000, Currency: "IDR"}},
+				{TransactionID: "trx-2", PaymentIntentID: "intent-1", TransactionType: "payment_refund", Amount: domain.Money{Amount: 10000, Currency: "IDR"}},
+			},
+			mockFunc: func() {
+				mockSettlementRepository.EXPECT().GetCarriedSettlement(gomock.Any(), "mock-merchant", "IDR").Return(domain.Settlement{}, sql.ErrNoRows)
+			},
+			wantErr: false,
+			wantResult: domain.Settlement{
+				GrossAmount:   domain.Money{Amount: 10000, Currency: "IDR"},
+				FeeAmount:     domain.Money{Amount: 0, Currency: "IDR"},
+				RefundAmount:  domain.Money{Amount: 10000, Currency: "IDR"},
+				CarriedAmount: domain.Money{Amount: 0, Currency: "IDR"},
+				NetAmount:     domain.Money{Amount: 0, Currency: "IDR"},
+				Status:        "success",
+			},
+		},
+		{
+			testID:   4,
+			testDesc: "Success - carried settlement is recovered from the next payout",
+			items: []domain.SettlementItem{
+				{TransactionID: "trx-1", TransactionType: "payment", Amount: domain.Money{Amount: 10000, Currency: "IDR"}},
+			},
+			mockFunc: func() {
+				mockSettlementRepository.EXPECT().GetCarriedSettlement(gomock.Any(), "mock-merchant", "IDR").Return(domain.Settlement{
+					ID:        "mock-carried",
+					NetAmount: domain.Money{Amount: -3972, Currency: "IDR"},
+					Status:    "carried",
+				}, nil)
+				mockSettlementWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+			},
+			wantErr: false,
+			wantResult: domain.Settlement{
+				GrossAmount:   domain.Money{Amount: 10000, Currency: "IDR"},
+				FeeAmount:     domain.Money{Amount: 70, Currency: "IDR"},
+				RefundAmount:  domain.Money{Amount: 0, Currency: "IDR"},
+				CarriedAmount: domain.Money{Amount: -3972, Currency: "IDR"},
+				CarriedFromID: "mock-carried",
+				NetAmount:     domain.Money{Amount: 5958, Currency: "IDR"},
+				Status:        "success",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideSettlementTest(t)
+			defer testDep()
+
+			mockSettlementRepository.EXPECT().GetUnsettledMerchantIDs(gomock.Any(), cutoffAt).Return([]string{"mock-merchant"}, nil)
+			mockSettlementMerchantRepository.EXPECT().GetMerchant(gomock.Any(), "mock-merchant").Return(merchant, nil)
+			mockSettlementRepository.EXPECT().GetUnsettledItems(gomock.Any(), "mock-merchant", cutoffAt).Return(tc.items, nil)
+			tc.mockFunc()
+
+			var got domain.Settlement
+			var payout *domain.Transaction
+			mockSettlementRepository.EXPECT().CreateSettlement(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, settlement domain.Settlement, items []domain.SettlementItem, transaction *domain.Transaction) (bool, error) {
+					got = settlement
+					payout = transaction
+					return true, nil
+				})
+
+			err := settlementSvc.RunSettlements(context.Background(), now)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.GrossAmount, tc.wantResult.GrossAmount)
+			assert.Equal(t, got.FeeAmount, tc.wantResult.FeeAmount)
+			assert.Equal(t, got.RefundAmount, tc.wantResult.RefundAmount)
+			assert.Equal(t, got.CarriedAmount, tc.wantResult.CarriedAmount)
+			assert.Equal(t, got.CarriedFromID, tc.wantResult.CarriedFromID)
+			assert.Equal(t, got.NetAmount, tc.wantResult.NetAmount)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			if tc.wantResult.NetAmount.IsPositive() {
+				assert.Equal(t, payout.Amount, tc.wantResult.NetAmount)
+				assert.Equal(t, payout.ReferenceID, "settle-mock-merchant-IDR-20230102T000000")
+			} else {
+				assert.Nil(t, payout)
+			}
+		})
+	}
+}
+
+func TestGetSettlementReport(t *testing.T) {
+	merchant := domain.Merchant{
+		ID:          "mock-merchant",
+		CustomerXID: "1",
+	}
+	settlement := domain.Settlement{
+		ID:           "mock-settlement",
+		MerchantID:   "mock-merchant",
+		CutoffAt:     time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		GrossAmount:  domain.Money{Amount: 10000, Currency: "IDR"},
+		FeeAmount:    domain.Money{Amount: 56, Currency: "IDR"},
+		RefundAmount: domain.Money{Amount: 2000, Currency: "IDR"},
+		NetAmount:    domain.Money{Amount: 7944, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		mockFunc   func()
+		wantErr    bool
+		wantResult []string
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			mockFunc: func() {
+				mockSettlementMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockSettlementRepository.EXPECT().GetSettlement(gomock.Any(), "mock-settlement").Return(settlement, nil)
+				mockSettlementRepository.EXPECT().GetSettlementItems(gomock.Any(), "mock-settlement").Return([]domain.SettlementItem{
+					{
+						TransactionID:   "trx-1",
+						PaymentIntentID: "intent-1",
+						TransactionType: "payment",
+						Amount:          domain.Money{Amount: 10000, Currency: "IDR"},
+						Fee:             domain.Money{Amount: 70, Currency: "IDR"},
+						TransactedAt:    time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC),
+					},
+					{
+						TransactionID:   "trx-2",
+						PaymentIntentID: "intent-0",
+						TransactionType: "payment_refund",
+						Amount:          domain.Money{Amount: 2000, Currency: "IDR"},
+						Fee:             domain.Money{Amount: -14, Currency: "IDR"},
+						TransactedAt:    time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC),
+					},
+				}, nil)
+			},
+			wantErr: false,
+			wantResult: []string{
+				"transaction_id,payment_intent_id,type,transacted_at,amount,fee,net,currency",
+				"trx-1,intent-1,payment,2023-01-01T10:00:00Z,10000,70,9930,IDR",
+				"trx-2,intent-0,payment_refund,2023-01-01T11:00:00Z,-2000,-14,-1986,IDR",
+				"total,,,2023-01-02T00:00:00Z,8000,56,7944,IDR",
+				"",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - settlement of another merchant",
+			mockFunc: func() {
+				mockSettlementMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockSettlementRepository.EXPECT().GetSettlement(gomock.Any(), "mock-settlement").Return(domain.Settlement{ID: "mock-settlement", MerchantID: "other"}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - settlement not found",
+			mockFunc: func() {
+				mockSettlementMerchantRepository.EXPECT().GetMerchantByCustomerXID(gomock.Any(), "1").Return(merchant, nil)
+				mockSettlementRepository.EXPECT().GetSettlement(gomock.Any(), "mock-settlement").Return(domain.Settlement{}, sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideSettlementTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := settlementSvc.GetSettlementReport(context.Background(), "1", "mock-settlement")
+			assert.Equal(t, err != nil, tc.wantErr)
+			if tc.wantResult != nil {
+				assert.Equal(t, strings.Split(string(got), "\n"), tc.wantResult)
+			}
+		})
+	}
+}
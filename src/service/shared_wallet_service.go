@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type SharedWalletServiceItf interface {
+	// AddWalletMember shares the owner's wallet with another customer.
+	AddWalletMember(ctx context.Context, ownerXID string, request web.WalletMemberRequest) (web.WalletMemberResponse, error)
+	GetWalletMembers(ctx context.Context, ownerXID string) ([]web.WalletMemberResponse, error)
+	// UpdateWalletMember replaces the spending limit and allowed transaction
+	// types of a member.
+	UpdateWalletMember(ctx context.Context, ownerXID string, request web.WalletMemberRequest) (web.WalletMemberResponse, error)
+	SuspendWalletMember(ctx context.Context, ownerXID, memberXID string) (web.WalletMemberResponse, error)
+	ResumeWalletMember(ctx context.Context, ownerXID, memberXID string) (web.WalletMemberResponse, error)
+
+	// GetMemberships lists the wallets shared with the customer.
+	GetMemberships(ctx context.Context, memberXID string) ([]web.WalletMemberResponse, error)
+	// AuthorizeMember returns the membership of an active member, to act on
+	// the shared wallet with.
+	AuthorizeMember(ctx context.Context, memberXID, walletID string) (web.WalletMemberResponse, error)
+}
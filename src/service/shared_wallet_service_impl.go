@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type SharedWalletService struct {
+	WalletMemberRepository repository.WalletMemberRepository
+	WalletRepository       repository.WalletRepository
+	Validate               *validator.Validate
+}
+
+func NewSharedWalletService(walletMemberRepository repository.WalletMemberRepository, walletRepository repository.WalletRepository, validate *validator.Validate) SharedWalletServiceItf {
+	return &SharedWalletService{
+		WalletMemberRepository: walletMemberRepository,
+		WalletRepository:       walletRepository,
+		Validate:               validate,
+	}
+}
+
+func (svc *SharedWalletService) AddWalletMember(ctx context.Context, ownerXID string, request web.WalletMemberRequest) (web.WalletMemberResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	if request.MemberXID == ownerXID {
+		return web.WalletMemberResponse{}, errors.New("cannot add yourself as a member")
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, ownerXID)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.WalletMemberResponse{}, errors.New("wallet disabled")
+	}
+
+	// members have to be customers themselves
+	_, err = svc.WalletRepository.GetWallet(ctx, request.MemberXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.WalletMemberResponse{}, errors.New("member wallet not found")
+	}
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	now := time.Now()
+	member := domain.WalletMember{
+		ID:            uuid.New().String(),
+		WalletID:      wallet.ID,
+		OwnerXID:      wallet.CustomerXID,
+		MemberXID:     request.MemberXID,
+		SpendingLimit: domain.NewMoney(request.SpendingLimit, wallet.Currency),
+		AllowedTypes:  request.AllowedTypes,
+		Status:        constants.STATUS_ACTIVE,
+		Spent:         domain.NewMoney(0, wallet.Currency),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	isCreated, err := svc.WalletMemberRepository.CreateWalletMember(ctx, member)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+	if !isCreated {
+		return web.WalletMemberResponse{}, errors.New("customer is already a member")
+	}
+
+	return toWalletMemberResponse(member, now), nil
+}
+
+func (svc *SharedWalletService) GetWalletMembers(ctx context.Context, ownerXID string) ([]web.WalletMemberResponse, error) {
+	wallet, err := svc.WalletRepository.GetWallet(ctx, ownerXID)
+	if err != nil {
+		return []web.WalletMemberResponse{}, err
+	}
+
+	members, err := svc.WalletMemberRepository.GetWalletMembers(ctx, wallet.ID)
+	if err != nil {
+		return []web.WalletMemberResponse{}, err
+	}
+
+	now := time.Now()
+	result := []web.WalletMemberResponse{}
+	for i := range members {
+		result = append(result, toWalletMemberResponse(members[i], now))
+	}
+	return result, nil
+}
+
+func (svc *SharedWalletService) UpdateWalletMember(ctx context.Context, ownerXID string, request web.WalletMemberRequest) (web.WalletMemberResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	member, err := svc.getOwnedMember(ctx, ownerXID, request.MemberXID)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	member.SpendingLimit = domain.NewMoney(request.SpendingLimit, member.SpendingLimit.Currency)
+	member.AllowedTypes = request.AllowedTypes
+	err = svc.WalletMemberRepository.UpdateWalletMemberControls(ctx, member)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	now := time.Now()
+	member.UpdatedAt = now
+	return toWalletMemberResponse(member, now), nil
+}
+
+func (svc *SharedWalletService) SuspendWalletMember(ctx context.Context, ownerXID, memberXID string) (web.WalletMemberResponse, error) {
+	return svc.setWalletMemberStatus(ctx, ownerXID, memberXID, constants.STATUS_SUSPENDED)
+}
+
+func (svc *SharedWalletService) ResumeWalletMember(ctx context.Context, ownerXID, memberXID string) (web.WalletMemberResponse, error) {
+	return svc.setWalletMemberStatus(ctx, ownerXID, memberXID, constants.STATUS_ACTIVE)
+}
+
+func (svc *SharedWalletService) setWalletMemberStatus(ctx context.Context, ownerXID, memberXID, status string) (web.WalletMemberResponse, error) {
+	member, err := svc.getOwnedMember(ctx, ownerXID, memberXID)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	if member.Status == status {
+		return web.WalletMemberResponse{}, errors.New("member is already " + status)
+	}
+
+	err = svc.WalletMemberRepository.UpdateWalletMemberStatus(ctx, member.ID, status)
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	now := time.Now()
+	member.Status = status
+	member.UpdatedAt = now
+	return toWalletMemberResponse(member, now), nil
+}
+
+func (svc *SharedWalletService) GetMemberships(ctx context.Context, memberXID string) ([]web.WalletMemberResponse, error) {
+	memberships, err := svc.WalletMemberRepository.GetMemberships(ctx, memberXID)
+	if err != nil {
+		return []web.WalletMemberResponse{}, err
+	}
+
+	now := time.Now()
+	result := []web.WalletMemberResponse{}
+	for i := range memberships {
+		membership := toWalletMemberResponse(memberships[i], now)
+		if memberships[i].Status == constants.STATUS_ACTIVE {
+			wallet, err := svc.WalletRepository.GetWallet(ctx, memberships[i].OwnerXID)
+			if err != nil {
+				return []web.WalletMemberResponse{}, err
+			}
+			membership.Balance = &wallet.Balance
+		}
+		result = append(result, membership)
+	}
+	return result, nil
+}
+
+func (svc *SharedWalletService) AuthorizeMember(ctx context.Context, memberXID, walletID string) (web.WalletMemberResponse, error) {
+	member, err := svc.WalletMemberRepository.GetWalletMember(ctx, walletID, memberXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.WalletMemberResponse{}, errors.New("shared wallet not found")
+	}
+	if err != nil {
+		return web.WalletMemberResponse{}, err
+	}
+
+	if member.Status != constants.STATUS_ACTIVE {
+		return web.WalletMemberResponse{}, errors.New("membership suspended")
+	}
+	return toWalletMemberResponse(member, time.Now()), nil
+}
+
+// getOwnedMember returns the member of the owner's wallet.
+func (svc *SharedWalletService) getOwnedMember(ctx context.Context, ownerXID, memberXID string) (domain.WalletMember, error) {
+	wallet, err := svc.WalletRepository.GetWallet(ctx, ownerXID)
+	if err != nil {
+		return domain.WalletMember{}, err
+	}
+
+	member, err := svc.WalletMemberRepository.GetWalletMember(ctx, wallet.ID, memberXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.WalletMember{}, errors.New("member not found")
+	}
+	if err != nil {
+		return domain.WalletMember{}, err
+	}
+	return member, nil
+}
+
+func toWalletMemberResponse(member domain.WalletMember, now time.Time) web.WalletMemberResponse {
+	return web.WalletMemberResponse{
+		ID:            member.ID,
+		WalletID:      member.WalletID,
+		OwnerXID:      member.OwnerXID,
+		MemberXID:     member.MemberXID,
+		SpendingLimit: member.SpendingLimit,
+		AllowedTypes:  member.AllowedTypes,
+		Status:        member.Status,
+		Spent:         member.SpentIn(domain.SpendingPeriod(now)),
+		CreatedAt:     member.CreatedAt,
+		UpdatedAt:     member.UpdatedAt,
+	}
+}
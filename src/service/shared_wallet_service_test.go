@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	sharedWalletSvc service.SharedWalletServiceItf
+
+	mockSharedWalletMemberRepository *mock_repository.MockWalletMemberRepository
+	mockSharedWalletWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideSharedWalletTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSharedWalletMemberRepository = mock_repository.NewMockWalletMemberRepository(ctrl)
+	mockSharedWalletWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	sharedWalletSvc = service.NewSharedWalletService(mockSharedWalletMemberRepository, mockSharedWalletWalletRepository, validator)
+
+	return func() {}
+}
+
+func TestAddWalletMember(t *testing.T) {
+	owner := domain.Wallet{
+		ID:          "mock-wallet",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.WalletMemberRequest
+		mockFunc   func()
+		wantErr    error
+		wantResult web.WalletMemberResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - member added",
+			payload: web.WalletMemberRequest{
+				MemberXID:     "2",
+				SpendingLimit: 50000,
+				AllowedTypes:  []string{"transfer_out"},
+			},
+			mockFunc: func() {
+				mockSharedWalletWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockSharedWalletWalletRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{ID: "member-wallet"}, nil)
+				mockSharedWalletMemberRepository.EXPECT().CreateWalletMember(gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: nil,
+			wantResult: web.WalletMemberResponse{
+				WalletID:      "mock-wallet",
+				OwnerXID:      "1",
+				MemberXID:     "2",
+				SpendingLimit: domain.NewMoney(50000, "IDR"),
+				AllowedTypes:  []string{"transfer_out"},
+				Status:        "active",
+				Spent:         domain.NewMoney(0, "IDR"),
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - owner as member",
+			payload: web.WalletMemberRequest{
+				MemberXID:    "1",
+				AllowedTypes: []string{"transfer_out"},
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("cannot add yourself as a member"),
+			wantResult: web.WalletMemberResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - member is not a customer",
+			payload: web.WalletMemberRequest{
+				MemberXID:    "2",
+				AllowedTypes: []string{"withdrawal"},
+			},
+			mockFunc: func() {
+				mockSharedWalletWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockSharedWalletWalletRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{}, sql.ErrNoRows)
+			},
+			wantErr:    fmt.Errorf("member wallet not found"),
+			wantResult: web.WalletMemberResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - already a member",
+			payload: web.WalletMemberRequest{
+				MemberXID:    "2",
+				AllowedTypes: []string{"withdrawal"},
+			},
+			mockFunc: func() {
+				mockSharedWalletWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockSharedWalletWalletRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{ID: "member-wallet"}, nil)
+				mockSharedWalletMemberRepository.EXPECT().CreateWalletMember(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr:    fmt.Errorf("customer is already a member"),
+			wantResult: web.WalletMemberResponse{},
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - transaction type members cannot make",
+			payload: web.WalletMemberRequest{
+				MemberXID:    "2",
+				AllowedTypes: []string{"payment"},
+			},
+			mockFunc:   func() {},
+			wantErr:    fmt.Errorf("Key: 'WalletMemberRequest.AllowedTypes[0]' Error:Field validation for 'AllowedTypes[0]' failed on the 'oneof' tag"),
+			wantResult: web.WalletMemberResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideSharedWalletTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := sharedWalletSvc.AddWalletMember(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.WalletID, tc.wantResult.WalletID)
+			assert.Equal(t, got.OwnerXID, tc.wantResult.OwnerXID)
+			assert.Equal(t, got.MemberXID, tc.wantResult.MemberXID)
+			assert.Equal(t, got.SpendingLimit, tc.wantResult.SpendingLimit)
+			assert.Equal(t, got.AllowedTypes, tc.wantResult.AllowedTypes)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+			assert.Equal(t, got.Spent, tc.wantResult.Spent)
+		})
+	}
+}
+
+func TestAuthorizeMember(t *testing.T) {
+	testDep := provideSharedWalletTest(t)
+	defer testDep()
+
+	member := domain.WalletMember{
+		ID:        "mock-member",
+		WalletID:  "mock-wallet",
+		OwnerXID:  "1",
+		MemberXID: "2",
+		Status:    "suspended",
+	}
+
+	mockSharedWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-wallet", "2").Return(member, nil)
+	_, err := sharedWalletSvc.AuthorizeMember(context.Background(), "2", "mock-wallet")
+	assert.Equal(t, err.Error(), "membership suspended")
+
+	mockSharedWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-wallet", "3").Return(domain.WalletMember{}, sql.ErrNoRows)
+	_, err = sharedWalletSvc.AuthorizeMember(context.Background(), "3", "mock-wallet")
+	assert.Equal(t, err.Error(), "shared wallet not found")
+
+	member.Status = "active"
+	mockSharedWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-wallet", "2").Return(member, nil)
+	got, err := sharedWalletSvc.AuthorizeMember(context.Background(), "2", "mock-wallet")
+	assert.Nil(t, err)
+	assert.Equal(t, got.OwnerXID, "1")
+	assert.Equal(t, got.MemberXID, "2")
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type SplitBillServiceItf interface {
+	CreateSplitBill(ctx context.Context, customerXID string, request web.SplitBillCreateRequest) (web.SplitBillResponse, error)
+	GetSplitBills(ctx context.Context, customerXID string) ([]web.SplitBillResponse, error)
+	GetSplitBill(ctx context.Context, customerXID, splitBillID string) (web.SplitBillResponse, error)
+	CancelSplitBill(ctx context.Context, customerXID, splitBillID string) (web.SplitBillResponse, error)
+	ExpireSplitBills(ctx context.Context, now time.Time) error
+}
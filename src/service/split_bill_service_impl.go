@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// splitBillBatchSize caps how many expired bills one job run closes.
+const splitBillBatchSize = 100
+
+type SplitBillService struct {
+	SplitBillRepository      repository.SplitBillRepository
+	PaymentRequestRepository repository.PaymentRequestRepository
+	WalletRepository         repository.WalletRepository
+	WalletService            WalletServiceItf
+	Validate                 *validator.Validate
+}
+
+func NewSplitBillService(splitBillRepository repository.SplitBillRepository, paymentRequestRepository repository.PaymentRequestRepository, walletRepository repository.WalletRepository, walletService WalletServiceItf, validate *validator.Validate) SplitBillServiceItf {
+	return &SplitBillService{
+		SplitBillRepository:      splitBillRepository,
+		PaymentRequestRepository: paymentRequestRepository,
+		WalletRepository:         walletRepository,
+		WalletService:            walletService,
+		Validate:                 validate,
+	}
+}
+
+func (svc *SplitBillService) CreateSplitBill(ctx context.Context, customerXID string, request web.SplitBillCreateRequest) (web.SplitBillResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	now := time.Now()
+	expiresAt := request.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(defaultPaymentRequestTTL)
+	}
+	if !expiresAt.After(now) {
+		return web.SplitBillResponse{}, errors.New("expires_at must be in the future")
+	}
+
+	expiryPolicy := request.ExpiryPolicy
+	if expiryPolicy == "" {
+		expiryPolicy = constants.EXPIRY_POLICY_KEEP
+	}
+
+	participantXIDs := map[string]bool{}
+	for _, participant := range request.Participants {
+		if participant.CustomerXID == customerXID {
+			return web.SplitBillResponse{}, errors.New("cannot add own wallet as participant")
+		}
+		if participantXIDs[participant.CustomerXID] {
+			return web.SplitBillResponse{}, errors.New("duplicate participant " + participant.CustomerXID)
+		}
+		participantXIDs[participant.CustomerXID] = true
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.SplitBillResponse{}, errors.New("wallet disabled")
+	}
+
+	total := domain.NewMoney(request.TotalAmount, wallet.Currency)
+	amounts, err := splitAmounts(total, request)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	for _, participant := range request.Participants {
+		_, err = svc.WalletRepository.GetWallet(ctx, participant.CustomerXID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return web.SplitBillResponse{}, errors.New("participant wallet not found: " + participant.CustomerXID)
+		}
+		if err != nil {
+			return web.SplitBillResponse{}, err
+		}
+	}
+
+	splitBill := domain.SplitBill{
+		ID:           uuid.New().String(),
+		CreatorXID:   customerXID,
+		Title:        request.Title,
+		TotalAmount:  total,
+		SplitType:    request.SplitType,
+		ExpiryPolicy: expiryPolicy,
+		Status:       constants.STATUS_PENDING,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	shares := []domain.PaymentRequest{}
+	for i, participant := range request.Participants {
+		shares = append(shares, domain.PaymentRequest{
+			ID:           uuid.New().String(),
+			SplitBillID:  splitBill.ID,
+			RequesterXID: customerXID,
+			PayerXID:     participant.CustomerXID,
+			Amount:       amounts[i],
+			Note:         request.Title,
+			Status:       constants.STATUS_PENDING,
+			ExpiresAt:    expiresAt,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+
+	err = svc.SplitBillRepository.CreateSplitBill(ctx, splitBill, shares)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	return toSplitBillResponse(splitBill, shares, now), nil
+}
+
+func (svc *SplitBillService) GetSplitBills(ctx context.Context, customerXID string) ([]web.SplitBillResponse, error) {
+	splitBills, err := svc.SplitBillRepository.GetSplitBills(ctx, customerXID)
+	if err != nil {
+		return []web.SplitBillResponse{}, err
+	}
+
+	now := time.Now()
+	result := []web.SplitBillResponse{}
+	for i := range splitBills {
+		shares, err := svc.SplitBillRepository.GetSplitBillShares(ctx, splitBills[i].ID)
+		if err != nil {
+			return []web.SplitBillResponse{}, err
+		}
+		result = append(result, toSplitBillResponse(splitBills[i], shares, now))
+	}
+	return result, nil
+}
+
+func (svc *SplitBillService) GetSplitBill(ctx context.Context, customerXID, splitBillID string) (web.SplitBillResponse, error) {
+	splitBill, err := svc.SplitBillRepository.GetSplitBill(ctx, splitBillID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.SplitBillResponse{}, errors.New("split bill not found")
+	}
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	shares, err := svc.SplitBillRepository.GetSplitBillShares(ctx, splitBill.ID)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	// the creator and every participant can follow the bill
+	isVisible := splitBill.CreatorXID == customerXID
+	for i := range shares {
+		if shares[i].PayerXID == customerXID {
+			isVisible = true
+		}
+	}
+	if !isVisible {
+		return web.SplitBillResponse{}, errors.New("split bill not found")
+	}
+
+	return toSplitBillResponse(splitBill, shares, time.Now()), nil
+}
+
+func (svc *SplitBillService) CancelSplitBill(ctx context.Context, customerXID, splitBillID string) (web.SplitBillResponse, error) {
+	splitBill, err := svc.SplitBillRepository.GetSplitBill(ctx, splitBillID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.SplitBillResponse{}, errors.New("split bill not found")
+	}
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	if splitBill.CreatorXID != customerXID {
+		return web.SplitBillResponse{}, errors.New("split bill not found")
+	}
+
+	if splitBill.Status != constants.STATUS_PENDING {
+		return web.SplitBillResponse{}, errors.New("split bill is no longer pending")
+	}
+
+	return svc.closeSplitBill(ctx, splitBill, constants.STATUS_CANCELLED)
+}
+
+// ExpireSplitBills applies the expiry policy of every pending bill past its
+// expiry. A bill whose refunds fail stays pending and is retried on the next
+// call; refunds already made are recognised by their reference_id.
+func (svc *SplitBillService) ExpireSplitBills(ctx context.Context, now time.Time) error {
+	splitBills, err := svc.SplitBillRepository.GetExpiredSplitBills(ctx, now, splitBillBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range splitBills {
+		_, err = svc.closeSplitBill(ctx, splitBills[i], constants.STATUS_EXPIRED)
+		if err != nil {
+			log.Println("error expire split bill", splitBills[i].ID+":", err.Error())
+		}
+	}
+	return nil
+}
+
+// closeSplitBill stops the unpaid shares from being paid, refunds the paid
+// ones when the bill asks for it and finally moves the bill to status.
+func (svc *SplitBillService) closeSplitBill(ctx context.Context, splitBill domain.SplitBill, status string) (web.SplitBillResponse, error) {
+	// every share may have been paid in the meantime
+	isCompleted, err := svc.SplitBillRepository.CompleteSplitBill(ctx, splitBill.ID)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+	if isCompleted {
+		return web.SplitBillResponse{}, errors.New("split bill is already settled")
+	}
+
+	err = svc.SplitBillRepository.UpdateSplitBillSharesStatus(ctx, splitBill.ID, constants.STATUS_PENDING, status)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	shares, err := svc.SplitBillRepository.GetSplitBillShares(ctx, splitBill.ID)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+
+	if splitBill.ExpiryPolicy == constants.EXPIRY_POLICY_REFUND {
+		for i := range shares {
+			if shares[i].Status != constants.STATUS_ACCEPTED {
+				continue
+			}
+
+			err = svc.refundShare(ctx, shares[i])
+			if err != nil {
+				return web.SplitBillResponse{}, err
+			}
+			shares[i].Status = constants.STATUS_REFUNDED
+		}
+	}
+
+	isUpdated, err := svc.SplitBillRepository.UpdateSplitBillStatus(ctx, splitBill.ID, constants.STATUS_PENDING, status)
+	if err != nil {
+		return web.SplitBillResponse{}, err
+	}
+	if !isUpdated {
+		return web.SplitBillResponse{}, errors.New("split bill is no longer pending")
+	}
+
+	splitBill.Status = status
+	return toSplitBillResponse(splitBill, shares, time.Now()), nil
+}
+
+// refundShare pays an accepted share back to its payer. Both the payment and
+// a previous refund are looked up by reference_id, so the refund is only
+// posted once and only for money that actually arrived.
+func (svc *SplitBillService) refundShare(ctx context.Context, share domain.PaymentRequest) error {
+	payment, err := svc.WalletRepository.GetTransactionByReference(ctx, constants.TRANSACTION_TYPE_TRANSFER_OUT, share.ReferenceID())
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("payment of share " + share.ID + " is still in progress")
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.WalletRepository.GetTransactionByReference(ctx, constants.TRANSACTION_TYPE_TRANSFER_OUT, share.RefundReferenceID())
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = svc.WalletService.TransferBalance(ctx, share.RequesterXID, web.TransferRequest{
+			RecipientXID:          share.PayerXID,
+			Amount:                share.Amount.Amount,
+			ReferenceID:           share.RefundReferenceID(),
+			RefundedTransactionID: payment.ID,
+		})
+		// a share a dispute already paid back counts as refunded
+		if err != nil && !errors.Is(err, domain.ErrTransactionReversed) {
+			return err
+		}
+	case err != nil:
+		return err
+	}
+
+	_, err = svc.PaymentRequestRepository.UpdatePaymentRequestStatus(ctx, share.ID, constants.STATUS_ACCEPTED, constants.STATUS_REFUNDED)
+	return err
+}
+
+// splitAmounts returns the amount owed by every participant, in the order of
+// request.Participants.
+func splitAmounts(total domain.Money, request web.SplitBillCreateRequest) ([]domain.Money, error) {
+	if request.SplitType == constants.SPLIT_TYPE_EQUAL {
+		if total.Amount < int64(len(request.Participants)) {
+			return nil, errors.New("total_amount is too small to split")
+		}
+		return domain.EqualShares(total, len(request.Participants)), nil
+	}
+
+	amounts := []domain.Money{}
+	sum := domain.NewMoney(0, total.Currency)
+	for _, participant := range request.Participants {
+		if participant.Amount <= 0 {
+			return nil, errors.New("every participant needs an amount in a custom split")
+		}
+
+		amount := domain.NewMoney(participant.Amount, total.Currency)
+		var err error
+		sum, err = sum.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, amount)
+	}
+
+	cmp, err := sum.Cmp(total)
+	if err != nil {
+		return nil, err
+	}
+	if cmp != 0 {
+		return nil, errors.New("participant amounts must add up to total_amount")
+	}
+	return amounts, nil
+}
+
+func toSplitBillResponse(splitBill domain.SplitBill, shares []domain.PaymentRequest, now time.Time) web.SplitBillResponse {
+	status := splitBill.Status
+	if status == constants.STATUS_PENDING && !now.Before(splitBill.ExpiresAt) {
+		status = constants.STATUS_EXPIRED
+	}
+
+	paid := domain.NewMoney(0, splitBill.TotalAmount.Currency)
+	for i := range shares {
+		if shares[i].Status == constants.STATUS_ACCEPTED {
+			// shares never add up to more than the total
+			paid, _ = paid.Add(shares[i].Amount)
+		}
+	}
+
+	return web.SplitBillResponse{
+		ID:           splitBill.ID,
+		CreatorXID:   splitBill.CreatorXID,
+		Title:        splitBill.Title,
+		TotalAmount:  splitBill.TotalAmount,
+		PaidAmount:   paid,
+		SplitType:    splitBill.SplitType,
+		ExpiryPolicy: splitBill.ExpiryPolicy,
+		Status:       status,
+		ExpiresAt:    splitBill.ExpiresAt,
+		CreatedAt:    splitBill.CreatedAt,
+		Shares:       toPaymentRequestResponses(shares, now),
+	}
+}
@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	mock_service "github.com/mozartmuhammad/julo-be-test/src/mock/service"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	splitBillSvc service.SplitBillServiceItf
+
+	mockSplitBillRepository               *mock_repository.MockSplitBillRepository
+	mockSplitBillPaymentRequestRepository *mock_repository.MockPaymentRequestRepository
+	mockSplitBillWalletRepository         *mock_repository.MockWalletRepository
+	mockSplitBillWalletService            *mock_service.MockWalletServiceItf
+)
+
+func provideSplitBillTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSplitBillRepository = mock_repository.NewMockSplitBillRepository(ctrl)
+	mockSplitBillPaymentRequestRepository = mock_repository.NewMockPaymentRequestRepository(ctrl)
+	mockSplitBillWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockSplitBillWalletService = mock_service.NewMockWalletServiceItf(ctrl)
+	validator := validator.New()
+	splitBillSvc = service.NewSplitBillService(mockSplitBillRepository, mockSplitBillPaymentRequestRepository, mockSplitBillWalletRepository, mockSplitBillWalletService, validator)
+
+	return func() {}
+}
+
+func TestCreateSplitBill(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.SplitBillCreateRequest
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantShares []int64
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - equal split spreads the remainder",
+			args: args{
+				customerXID: "1",
+				payload: web.SplitBillCreateRequest{
+					Title:       "dinner",
+					TotalAmount: 1000,
+					SplitType:   "equal",
+					Participants: []web.SplitBillParticipant{
+						{CustomerXID: "2"},
+						{CustomerXID: "3"},
+						{CustomerXID: "4"},
+					},
+				},
+			},
+			mockFunc: func() {
+				mockSplitBillWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:       "mock-id",
+					Status:   "enabled",
+					Currency: "IDR",
+				}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{}, nil).Times(3)
+				mockSplitBillRepository.EXPECT().CreateSplitBill(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr:    false,
+			wantShares: []int64{334, 333, 333},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - custom split",
+			args: args{
+				customerXID: "1",
+				payload: web.SplitBillCreateRequest{
+					Title:       "dinner",
+					TotalAmount: 1000,
+					SplitType:   "custom",
+					Participants: []web.SplitBillParticipant{
+						{CustomerXID: "2", Amount: 700},
+						{CustomerXID: "3", Amount: 300},
+					},
+				},
+			},
+			mockFunc: func() {
+				mockSplitBillWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:       "mock-id",
+					Status:   "enabled",
+					Currency: "IDR",
+				}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{}, nil).Times(2)
+				mockSplitBillRepository.EXPECT().CreateSplitBill(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr:    false,
+			wantShares: []int64{700, 300},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - custom shares do not add up",
+			args: args{
+				customerXID: "1",
+				payload: web.SplitBillCreateRequest{
+					Title:       "dinner",
+					TotalAmount: 1000,
+					SplitType:   "custom",
+					Participants: []web.SplitBillParticipant{
+						{CustomerXID: "2", Amount: 700},
+						{CustomerXID: "3", Amount: 200},
+					},
+				},
+			},
+			mockFunc: func() {
+				mockSplitBillWalletRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:       "mock-id",
+					Status:   "enabled",
+					Currency: "IDR",
+				}, nil)
+			},
+			wantErr:    true,
+			wantShares: nil,
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - duplicate participant",
+			args: args{
+				customerXID: "1",
+				payload: web.SplitBillCreateRequest{
+					Title:       "dinner",
+					TotalAmount: 1000,
+					SplitType:   "equal",
+					Participants: []web.SplitBillParticipant{
+						{CustomerXID: "2"},
+						{CustomerXID: "2"},
+					},
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantShares: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideSplitBillTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := splitBillSvc.CreateSplitBill(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			var gotShares []int64
+			for _, share := range got.Shares {
+				gotShares = append(gotShares, share.Amount.Amount)
+			}
+			assert.Equal(t, tc.wantShares, gotShares)
+			if !tc.wantErr {
+				assert.Equal(t, "pending", got.Status)
+				assert.Equal(t, "keep", got.ExpiryPolicy)
+			}
+		})
+	}
+}
+
+func TestExpireSplitBills(t *testing.T) {
+	now := time.Now()
+	splitBill := domain.SplitBill{
+		ID:           "mock-bill",
+		CreatorXID:   "1",
+		TotalAmount:  domain.Money{Amount: 1000, Currency: "IDR"},
+		SplitType:    "equal",
+		ExpiryPolicy: "refund",
+		Status:       "pending",
+		ExpiresAt:    now.Add(-time.Minute),
+	}
+	paid := domain.PaymentRequest{
+		ID:           "mock-paid",
+		SplitBillID:  "mock-bill",
+		RequesterXID: "1",
+		PayerXID:     "2",
+		Amount:       domain.Money{Amount: 500, Currency: "IDR"},
+		Status:       "accepted",
+	}
+	unpaid := domain.PaymentRequest{
+		ID:           "mock-unpaid",
+		SplitBillID:  "mock-bill",
+		RequesterXID: "1",
+		PayerXID:     "3",
+		Amount:       domain.Money{Amount: 500, Currency: "IDR"},
+		Status:       "expired",
+	}
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - refunds paid shares",
+			mockFunc: func() {
+				mockSplitBillRepository.EXPECT().GetExpiredSplitBills(gomock.Any(), now, gomock.Any()).Return([]domain.SplitBill{splitBill}, nil)
+				mockSplitBillRepository.EXPECT().CompleteSplitBill(gomock.Any(), "mock-bill").Return(false, nil)
+				mockSplitBillRepository.EXPECT().UpdateSplitBillSharesStatus(gomock.Any(), "mock-bill", "pending", "expired").Return(nil)
+				mockSplitBillRepository.EXPECT().GetSplitBillShares(gomock.Any(), "mock-bill").Return([]domain.PaymentRequest{paid, unpaid}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "transfer_out", "payreq-mock-paid").Return(domain.Transaction{ID: "mock-payment"}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "transfer_out", "payreq-refund-mock-paid").Return(domain.Transaction{}, sql.ErrNoRows)
+				mockSplitBillWalletService.EXPECT().TransferBalance(gomock.Any(), "1", web.TransferRequest{
+					RecipientXID:          "2",
+					Amount:                500,
+					ReferenceID:           "payreq-refund-mock-paid",
+					RefundedTransactionID: "mock-payment",
+				}).Return(web.TransferResponse{ID: "mock-refund"}, nil)
+				mockSplitBillPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-paid", "accepted", "refunded").Return(true, nil)
+				mockSplitBillRepository.EXPECT().UpdateSplitBillStatus(gomock.Any(), "mock-bill", "pending", "expired").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   2,
+			testDesc: "Success - refund already posted is not repeated",
+			mockFunc: func() {
+				mockSplitBillRepository.EXPECT().GetExpiredSplitBills(gomock.Any(), now, gomock.Any()).Return([]domain.SplitBill{splitBill}, nil)
+				mockSplitBillRepository.EXPECT().CompleteSplitBill(gomock.Any(), "mock-bill").Return(false, nil)
+				mockSplitBillRepository.EXPECT().UpdateSplitBillSharesStatus(gomock.Any(), "mock-bill", "pending", "expired").Return(nil)
+				mockSplitBillRepository.EXPECT().GetSplitBillShares(gomock.Any(), "mock-bill").Return([]domain.PaymentRequest{paid, unpaid}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "transfer_out", "payreq-mock-paid").Return(domain.Transaction{ID: "mock-payment"}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "transfer_out", "payreq-refund-mock-paid").Return(domain.Transaction{ID: "mock-refund"}, nil)
+				mockSplitBillPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-paid", "accepted", "refunded").Return(true, nil)
+				mockSplitBillRepository.EXPECT().UpdateSplitBillStatus(gomock.Any(), "mock-bill", "pending", "expired").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   3,
+			testDesc: "Success - share paid back by a dispute is not refunded again",
+			mockFunc: func() {
+				mockSplitBillRepository.EXPECT().GetExpiredSplitBills(gomock.Any(), now, gomock.Any()).Return([]domain.SplitBill{splitBill}, nil)
+				mockSplitBillRepository.EXPECT().CompleteSplitBill(gomock.Any(), "mock-bill").Return(false, nil)
+				mockSplitBillRepository.EXPECT().UpdateSplitBillSharesStatus(gomock.Any(), "mock-bill", "pending", "expired").Return(nil)
+				mockSplitBillRepository.EXPECT().GetSplitBillShares(gomock.Any(), "mock-bill").Return([]domain.PaymentRequest{paid, unpaid}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "transfer_out", "payreq-mock-paid").Return(domain.Transaction{ID: "mock-payment"}, nil)
+				mockSplitBillWalletRepository.EXPECT().GetTransactionByReference(gomock.Any(), "transfer_out", "payreq-refund-mock-paid").Return(domain.Transaction{}, sql.ErrNoRows)
+				mockSplitBillWalletService.EXPECT().TransferBalance(gomock.Any(), "1", gomock.Any()).Return(web.TransferResponse{}, domain.ErrTransactionReversed)
+				mockSplitBillPaymentRequestRepository.EXPECT().UpdatePaymentRequestStatus(gomock.Any(), "mock-paid", "accepted", "refunded").Return(true, nil)
+				mockSplitBillRepository.EXPECT().UpdateSplitBillStatus(gomock.Any(), "mock-bill", "pending", "expired").Return(true, nil)
+			},
+			wantErr: false,
+		},
+		{
+			testID:   4,
+			testDesc: "Success - settled bill is left alone",
+			mockFunc: func() {
+				mockSplitBillRepository.EXPECT().GetExpiredSplitBills(gomock.Any(), now, gomock.Any()).Return([]domain.SplitBill{splitBill}, nil)
+				mockSplitBillRepository.EXPECT().CompleteSplitBill(gomock.Any(), "mock-bill").Return(true, nil)
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideSplitBillTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			err := splitBillSvc.ExpireSplitBills(context.Background(), now)
+			assert.Equal(t, err != nil, tc.wantErr)
+		})
+	}
+}
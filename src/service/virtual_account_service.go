@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type VirtualAccountServiceItf interface {
+	CreateVirtualAccount(ctx context.Context, customerXID string, request web.VirtualAccountCreateRequest) (web.VirtualAccountResponse, error)
+	GetVirtualAccounts(ctx context.Context, customerXID string) ([]web.VirtualAccountResponse, error)
+	// HandleCallback credits the wallet behind the virtual account. A bank
+	// reference is credited once, repeating the callback returns the same
+	// payment.
+	HandleCallback(ctx context.Context, request web.VirtualAccountCallbackRequest) (web.VirtualAccountPaymentResponse, error)
+}
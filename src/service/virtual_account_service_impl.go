@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+type VirtualAccountService struct {
+	VirtualAccountRepository repository.VirtualAccountRepository
+	WalletRepository         repository.WalletRepository
+	Validate                 *validator.Validate
+}
+
+func NewVirtualAccountService(virtualAccountRepository repository.VirtualAccountRepository, walletRepository repository.WalletRepository, validate *validator.Validate) VirtualAccountServiceItf {
+	return &VirtualAccountService{
+		VirtualAccountRepository: virtualAccountRepository,
+		WalletRepository:         walletRepository,
+		Validate:                 validate,
+	}
+}
+
+func (svc *VirtualAccountService) CreateVirtualAccount(ctx context.Context, customerXID string, request web.VirtualAccountCreateRequest) (web.VirtualAccountResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.VirtualAccountResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.VirtualAccountResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.VirtualAccountResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.VirtualAccountResponse{}, errors.New("wallet disabled")
+	}
+
+	// a wallet keeps one number per bank
+	bankCode := strings.ToUpper(request.BankCode)
+	existing, err := svc.VirtualAccountRepository.GetVirtualAccountByWallet(ctx, wallet.ID, bankCode)
+	if err == nil {
+		return toVirtualAccountResponse(existing), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.VirtualAccountResponse{}, err
+	}
+
+	number, err := domain.NewVirtualAccountNumber(constants.VIRTUAL_ACCOUNT_PREFIX, constants.VIRTUAL_ACCOUNT_LENGTH)
+	if err != nil {
+		return web.VirtualAccountResponse{}, err
+	}
+
+	virtualAccount := domain.VirtualAccount{
+		ID:          uuid.New().String(),
+		WalletID:    wallet.ID,
+		CustomerXID: wallet.CustomerXID,
+		BankCode:    bankCode,
+		Number:      number,
+		Currency:    wallet.Currency,
+		CreatedAt:   time.Now(),
+	}
+	err = svc.VirtualAccountRepository.CreateVirtualAccount(ctx, virtualAccount)
+	if err != nil {
+		return web.VirtualAccountResponse{}, err
+	}
+	return toVirtualAccountResponse(virtualAccount), nil
+}
+
+func (svc *VirtualAccountService) GetVirtualAccounts(ctx context.Context, customerXID string) ([]web.VirtualAccountResponse, error) {
+	virtualAccounts, err := svc.VirtualAccountRepository.GetVirtualAccounts(ctx, customerXID)
+	if err != nil {
+		return []web.VirtualAccountResponse{}, err
+	}
+
+	result := []web.VirtualAccountResponse{}
+	for _, virtualAccount := range virtualAccounts {
+		result = append(result, toVirtualAccountResponse(virtualAccount))
+	}
+	return result, nil
+}
+
+func (svc *VirtualAccountService) HandleCallback(ctx context.Context, request web.VirtualAccountCallbackRequest) (web.VirtualAccountPaymentResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.VirtualAccountPaymentResponse{}, err
+	}
+
+	bankCode := strings.ToUpper(request.BankCode)
+	virtualAccount, err := svc.VirtualAccountRepository.GetVirtualAccountByNumber(ctx, request.VirtualAccountNumber)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && virtualAccount.BankCode != bankCode) {
+		return web.VirtualAccountPaymentResponse{}, errors.New("virtual account not found")
+	}
+	if err != nil {
+		return web.VirtualAccountPaymentResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+	if currency != virtualAccount.Currency {
+		return web.VirtualAccountPaymentResponse{}, errors.New("currency mismatch")
+	}
+	amount := domain.NewMoney(request.Amount, currency)
+
+	// a repeated callback returns the payment it already credited
+	existing, err := svc.VirtualAccountRepository.GetVirtualAccountPayment(ctx, bankCode, request.BankReference)
+	if err == nil {
+		return existingVirtualAccountPayment(existing, virtualAccount, amount)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.VirtualAccountPaymentResponse{}, err
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, virtualAccount.CustomerXID, virtualAccount.Currency)
+	if err != nil {
+		return web.VirtualAccountPaymentResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.VirtualAccountPaymentResponse{}, errors.New("wallet disabled")
+	}
+
+	// the wallet must be able to hold the credit
+	_, err = wallet.Balance.Add(amount)
+	if err != nil {
+		return web.VirtualAccountPaymentResponse{}, err
+	}
+
+	now := time.Now()
+	payment := domain.VirtualAccountPayment{
+		ID:               uuid.New().String(),
+		VirtualAccountID: virtualAccount.ID,
+		WalletID:         wallet.ID,
+		BankCode:         bankCode,
+		BankReference:    request.BankReference,
+		Amount:           amount,
+		PaidAt:           request.PaidAt,
+		CreatedAt:        now,
+	}
+	credit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_DEPOSIT,
+		Amount:          amount,
+		ReferenceID:     payment.ReferenceID(),
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	payment.TransactionID = credit.ID
+
+	isCredited, err := svc.VirtualAccountRepository.CreditVirtualAccountPayment(ctx, payment, credit)
+	if err != nil {
+		return web.VirtualAccountPaymentResponse{}, err
+	}
+
+	// a concurrent delivery of the same callback won
+	if !isCredited {
+		existing, err = svc.VirtualAccountRepository.GetVirtualAccountPayment(ctx, bankCode, request.BankReference)
+		if err != nil {
+			return web.VirtualAccountPaymentResponse{}, err
+		}
+		return existingVirtualAccountPayment(existing, virtualAccount, amount)
+	}
+	return toVirtualAccountPaymentResponse(payment), nil
+}
+
+func existingVirtualAccountPayment(payment domain.VirtualAccountPayment, virtualAccount domain.VirtualAccount, amount domain.Money) (web.VirtualAccountPaymentResponse, error) {
+	if payment.VirtualAccountID != virtualAccount.ID || payment.Amount != amount {
+		return web.VirtualAccountPaymentResponse{}, errors.New("bank_reference already used for another payment")
+	}
+	return toVirtualAccountPaymentResponse(payment), nil
+}
+
+func toVirtualAccountResponse(virtualAccount domain.VirtualAccount) web.VirtualAccountResponse {
+	return web.VirtualAccountResponse{
+		ID:        virtualAccount.ID,
+		BankCode:  virtualAccount.BankCode,
+		Number:    virtualAccount.Number,
+		Currency:  virtualAccount.Currency,
+		CreatedAt: virtualAccount.CreatedAt,
+	}
+}
+
+func toVirtualAccountPaymentResponse(payment domain.VirtualAccountPayment) web.VirtualAccountPaymentResponse {
+	return web.VirtualAccountPaymentResponse{
+		ID:            payment.ID,
+		BankReference: payment.BankReference,
+		Amount:        payment.Amount,
+		TransactionID: payment.TransactionID,
+		PaidAt:        payment.PaidAt,
+	}
+}
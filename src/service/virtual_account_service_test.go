@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	virtualAccountSvc service.VirtualAccountServiceItf
+
+	mockVirtualAccountRepository       *mock_repository.MockVirtualAccountRepository
+	mockVirtualAccountWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideVirtualAccountTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVirtualAccountRepository = mock_repository.NewMockVirtualAccountRepository(ctrl)
+	mockVirtualAccountWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	virtualAccountSvc = service.NewVirtualAccountService(mockVirtualAccountRepository, mockVirtualAccountWalletRepository, validator)
+
+	return func() {}
+}
+
+func TestCreateVirtualAccount(t *testing.T) {
+	testDep := provideVirtualAccountTest(t)
+	defer testDep()
+
+	mockVirtualAccountWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+	}, nil)
+	mockVirtualAccountRepository.EXPECT().GetVirtualAccountByWallet(gomock.Any(), "mock-id", "BCA").Return(domain.VirtualAccount{}, sql.ErrNoRows)
+	mockVirtualAccountRepository.EXPECT().CreateVirtualAccount(gomock.Any(), gomock.Any()).Return(nil)
+
+	got, err := virtualAccountSvc.CreateVirtualAccount(context.Background(), "1", web.VirtualAccountCreateRequest{BankCode: "bca"})
+	assert.Nil(t, err)
+	assert.Equal(t, got.BankCode, "BCA")
+	assert.Equal(t, got.Currency, "IDR")
+	assert.Equal(t, len(got.Number), 16)
+	assert.Equal(t, got.Number[:4], "8808")
+}
+
+func TestHandleVirtualAccountCallback(t *testing.T) {
+	paidAt := time.Date(2023, 3, 15, 1, 0, 0, 0, time.UTC)
+	virtualAccount := domain.VirtualAccount{
+		ID:          "mock-va",
+		WalletID:    "mock-id",
+		CustomerXID: "1",
+		BankCode:    "BCA",
+		Number:      "8808123456789012",
+		Currency:    "IDR",
+	}
+	wallet := domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.Money{Amount: 1000, Currency: "IDR"},
+	}
+	payload := web.VirtualAccountCallbackRequest{
+		BankCode:             "BCA",
+		VirtualAccountNumber: "8808123456789012",
+		BankReference:        "REF1",
+		Amount:               50000,
+		PaidAt:               paidAt,
+	}
+	credited := domain.VirtualAccountPayment{
+		ID:               "mock-payment",
+		VirtualAccountID: "mock-va",
+		BankCode:         "BCA",
+		BankReference:    "REF1",
+		Amount:           domain.Money{Amount: 50000, Currency: "IDR"},
+		TransactionID:    "mock-transaction",
+		PaidAt:           paidAt,
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.VirtualAccountCallbackRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.VirtualAccountPaymentResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			payload:  payload,
+			mockFunc: func() {
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountByNumber(gomock.Any(), "8808123456789012").Return(virtualAccount, nil)
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountPayment(gomock.Any(), "BCA", "REF1").Return(domain.VirtualAccountPayment{}, sql.ErrNoRows)
+				mockVirtualAccountWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockVirtualAccountRepository.EXPECT().CreditVirtualAccountPayment(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payment domain.VirtualAccountPayment, credit domain.Transaction) (bool, error) {
+						assert.Equal(t, credit.TransactionType, "deposit")
+						assert.Equal(t, credit.ReferenceID, "va-BCA-REF1")
+						assert.Equal(t, credit.WalletID, "mock-id")
+						assert.Equal(t, payment.TransactionID, credit.ID)
+						return true, nil
+					})
+			},
+			wantErr: false,
+			wantResult: web.VirtualAccountPaymentResponse{
+				BankReference: "REF1",
+				Amount:        domain.Money{Amount: 50000, Currency: "IDR"},
+				PaidAt:        paidAt,
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Success - repeated callback",
+			payload:  payload,
+			mockFunc: func() {
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountByNumber(gomock.Any(), "8808123456789012").Return(virtualAccount, nil)
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountPayment(gomock.Any(), "BCA", "REF1").Return(credited, nil)
+			},
+			wantErr: false,
+			wantResult: web.VirtualAccountPaymentResponse{
+				ID:            "mock-payment",
+				BankReference: "REF1",
+				Amount:        domain.Money{Amount: 50000, Currency: "IDR"},
+				TransactionID: "mock-transaction",
+				PaidAt:        paidAt,
+			},
+		},
+		{
+			testID:   3,
+			testDesc: "Success - concurrent callback won",
+			payload:  payload,
+			mockFunc: func() {
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountByNumber(gomock.Any(), "8808123456789012").Return(virtualAccount, nil)
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountPayment(gomock.Any(), "BCA", "REF1").Return(domain.VirtualAccountPayment{}, sql.ErrNoRows)
+				mockVirtualAccountWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockVirtualAccountRepository.EXPECT().CreditVirtualAccountPayment(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountPayment(gomock.Any(), "BCA", "REF1").Return(credited, nil)
+			},
+			wantErr: false,
+			wantResult: web.VirtualAccountPaymentResponse{
+				ID:            "mock-payment",
+				BankReference: "REF1",
+				Amount:        domain.Money{Amount: 50000, Currency: "IDR"},
+				TransactionID: "mock-transaction",
+				PaidAt:        paidAt,
+			},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - reference reused with another amount",
+			payload: web.VirtualAccountCallbackRequest{
+				BankCode:             "BCA",
+				VirtualAccountNumber: "8808123456789012",
+				BankReference:        "REF1",
+				Amount:               70000,
+				PaidAt:               paidAt,
+			},
+			mockFunc: func() {
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountByNumber(gomock.Any(), "8808123456789012").Return(virtualAccount, nil)
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountPayment(gomock.Any(), "BCA", "REF1").Return(credited, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - number of another bank",
+			payload: web.VirtualAccountCallbackRequest{
+				BankCode:             "BNI",
+				VirtualAccountNumber: "8808123456789012",
+				BankReference:        "REF1",
+				Amount:               50000,
+				PaidAt:               paidAt,
+			},
+			mockFunc: func() {
+				mockVirtualAccountRepository.EXPECT().GetVirtualAccountByNumber(gomock.Any(), "8808123456789012").Return(virtualAccount, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideVirtualAccountTest(t)
+			defer testDep()
+
+			tc.mockFunc()
+			got, err := virtualAccountSvc.HandleCallback(context.Background(), tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			if !tc.wantErr && tc.wantResult.ID == "" {
+				tc.wantResult.ID = got.ID
+				tc.wantResult.TransactionID = got.TransactionID
+			}
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+)
+
+type VoucherServiceItf interface {
+	CreateVoucherBatch(ctx context.Context, request web.VoucherBatchCreateRequest) (web.VoucherBatchResponse, error)
+	GetVoucherBatches(ctx context.Context) ([]web.VoucherBatchResponse, error)
+	// GetVoucherBatchReport summarises how far the codes of the batch were
+	// redeemed, with the status of every code.
+	GetVoucherBatchReport(ctx context.Context, batchID string) (web.VoucherBatchReportResponse, error)
+	RedeemVoucher(ctx context.Context, customerXID string, request web.VoucherRedeemRequest) (web.VoucherRedemptionResponse, error)
+}
@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/repository"
+)
+
+// voucherCodeLength is the number of random characters of a code, enough
+// that guessing a valid code is impractical.
+const voucherCodeLength = 10
+
+type VoucherService struct {
+	VoucherRepository repository.VoucherRepository
+	WalletRepository  repository.WalletRepository
+	Validate          *validator.Validate
+}
+
+func NewVoucherService(voucherRepository repository.VoucherRepository, walletRepository repository.WalletRepository, validate *validator.Validate) VoucherServiceItf {
+	return &VoucherService{
+		VoucherRepository: voucherRepository,
+		WalletRepository:  walletRepository,
+		Validate:          validate,
+	}
+}
+
+func (svc *VoucherService) CreateVoucherBatch(ctx context.Context, request web.VoucherBatchCreateRequest) (web.VoucherBatchResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.VoucherBatchResponse{}, err
+	}
+
+	currency := request.Currency
+	if currency == "" {
+		currency = constants.DEFAULT_CURRENCY
+	}
+	if !isSupportedCurrency(currency) {
+		return web.VoucherBatchResponse{}, errors.New("unsupported currency")
+	}
+
+	now := time.Now()
+	if !request.ExpiresAt.After(now) {
+		return web.VoucherBatchResponse{}, errors.New("expires_at must be in the future")
+	}
+
+	batch := domain.VoucherBatch{
+		ID:               uuid.New().String(),
+		Name:             request.Name,
+		Value:            domain.NewMoney(request.Value, currency),
+		CodeCount:        request.CodeCount,
+		MaxUses:          request.MaxUses,
+		PerCustomerLimit: request.PerCustomerLimit,
+		ExpiresAt:        request.ExpiresAt,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	// codes are unique within the batch here and across batches by the
+	// database
+	prefix := strings.ToUpper(request.Prefix)
+	codes := map[string]bool{}
+	vouchers := []domain.Voucher{}
+	for len(vouchers) < request.CodeCount {
+		code, err := domain.NewVoucherCode(prefix, voucherCodeLength)
+		if err != nil {
+			return web.VoucherBatchResponse{}, err
+		}
+		if codes[code] {
+			continue
+		}
+		codes[code] = true
+		vouchers = append(vouchers, domain.Voucher{
+			ID:        uuid.New().String(),
+			BatchID:   batch.ID,
+			Code:      code,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+
+	err = svc.VoucherRepository.CreateVoucherBatch(ctx, batch, vouchers)
+	if err != nil {
+		return web.VoucherBatchResponse{}, err
+	}
+
+	result := toVoucherBatchResponse(batch)
+	result.Codes = []string{}
+	for _, voucher := range vouchers {
+		result.Codes = append(result.Codes, voucher.Code)
+	}
+	return result, nil
+}
+
+func (svc *VoucherService) GetVoucherBatches(ctx context.Context) ([]web.VoucherBatchResponse, error) {
+	batches, err := svc.VoucherRepository.GetVoucherBatches(ctx)
+	if err != nil {
+		return []web.VoucherBatchResponse{}, err
+	}
+
+	result := []web.VoucherBatchResponse{}
+	for _, batch := range batches {
+		result = append(result, toVoucherBatchResponse(batch))
+	}
+	return result, nil
+}
+
+func (svc *VoucherService) GetVoucherBatchReport(ctx context.Context, batchID string) (web.VoucherBatchReportResponse, error) {
+	batch, err := svc.VoucherRepository.GetVoucherBatch(ctx, batchID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.VoucherBatchReportResponse{}, errors.New("voucher batch not found")
+	}
+	if err != nil {
+		return web.VoucherBatchReportResponse{}, err
+	}
+
+	vouchers, err := svc.VoucherRepository.GetVouchers(ctx, batch.ID)
+	if err != nil {
+		return web.VoucherBatchReportResponse{}, err
+	}
+
+	customers, err := svc.VoucherRepository.GetVoucherBatchCustomerCount(ctx, batch.ID)
+	if err != nil {
+		return web.VoucherBatchReportResponse{}, err
+	}
+
+	isExpired := !time.Now().Before(batch.ExpiresAt)
+	result := web.VoucherBatchReportResponse{
+		VoucherBatchResponse: toVoucherBatchResponse(batch),
+		Status:               constants.STATUS_ACTIVE,
+		Customers:            customers,
+		Vouchers:             []web.VoucherCodeResponse{},
+	}
+	if isExpired {
+		result.Status = constants.STATUS_EXPIRED
+	}
+
+	for _, voucher := range vouchers {
+		status := constants.STATUS_UNUSED
+		switch {
+		case voucher.UsedCount >= batch.MaxUses:
+			status = constants.STATUS_USED
+			result.UsedCodes++
+		case voucher.UsedCount > 0:
+			status = constants.STATUS_PARTIAL
+			result.PartiallyUsedCodes++
+		default:
+			result.UnusedCodes++
+		}
+		if isExpired && status != constants.STATUS_USED {
+			status = constants.STATUS_EXPIRED
+		}
+
+		result.Redemptions += voucher.UsedCount
+		result.Vouchers = append(result.Vouchers, web.VoucherCodeResponse{
+			Code:      voucher.Code,
+			UsedCount: voucher.UsedCount,
+			Status:    status,
+		})
+	}
+	result.RedeemedAmount = domain.NewMoney(batch.Value.Amount*int64(result.Redemptions), batch.Value.Currency)
+	return result, nil
+}
+
+func (svc *VoucherService) RedeemVoucher(ctx context.Context, customerXID string, request web.VoucherRedeemRequest) (web.VoucherRedemptionResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+
+	voucher, err := svc.VoucherRepository.GetVoucherByCode(ctx, strings.ToUpper(strings.TrimSpace(request.Code)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.VoucherRedemptionResponse{}, errors.New("voucher not found")
+	}
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+
+	batch, err := svc.VoucherRepository.GetVoucherBatch(ctx, voucher.BatchID)
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+
+	now := time.Now()
+	if !now.Before(batch.ExpiresAt) {
+		return web.VoucherRedemptionResponse{}, errors.New("voucher expired")
+	}
+	if voucher.UsedCount >= batch.MaxUses {
+		return web.VoucherRedemptionResponse{}, errors.New("voucher already redeemed")
+	}
+
+	used, err := svc.VoucherRepository.GetCustomerVoucherUsage(ctx, batch.ID, customerXID)
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+	if used >= batch.PerCustomerLimit {
+		return web.VoucherRedemptionResponse{}, errors.New("voucher limit reached")
+	}
+
+	wallet, err := svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, batch.Value.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.VoucherRedemptionResponse{}, errors.New("wallet not found")
+	}
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.VoucherRedemptionResponse{}, errors.New("wallet disabled")
+	}
+
+	// the wallet must be able to hold the credit
+	_, err = wallet.Balance.Add(batch.Value)
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+
+	redemption := domain.VoucherRedemption{
+		ID:          uuid.New().String(),
+		BatchID:     batch.ID,
+		VoucherID:   voucher.ID,
+		CustomerXID: wallet.CustomerXID,
+		WalletID:    wallet.ID,
+		Amount:      batch.Value,
+		CreatedAt:   now,
+	}
+	credit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_VOUCHER,
+		Amount:          batch.Value,
+		ReferenceID:     redemption.ID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	redemption.TransactionID = credit.ID
+
+	// the checks above only give a clear error, the redemption itself is
+	// claimed atomically
+	isRedeemed, err := svc.VoucherRepository.RedeemVoucher(ctx, batch, redemption, credit)
+	if err != nil {
+		return web.VoucherRedemptionResponse{}, err
+	}
+	if !isRedeemed {
+		return web.VoucherRedemptionResponse{}, errors.New("voucher already redeemed or limit reached")
+	}
+
+	return web.VoucherRedemptionResponse{
+		ID:            redemption.ID,
+		Code:          voucher.Code,
+		Amount:        redemption.Amount,
+		TransactionID: credit.ID,
+		RedeemedAt:    now,
+	}, nil
+}
+
+func toVoucherBatchResponse(batch domain.VoucherBatch) web.VoucherBatchResponse {
+	return web.VoucherBatchResponse{
+		ID:               batch.ID,
+		Name:             batch.Name,
+		Value:            batch.Value,
+		Currency:         batch.Value.Currency,
+		CodeCount:        batch.CodeCount,
+		MaxUses:          batch.MaxUses,
+		PerCustomerLimit: batch.PerCustomerLimit,
+		ExpiresAt:        batch.ExpiresAt,
+		CreatedAt:        batch.CreatedAt,
+	}
+}
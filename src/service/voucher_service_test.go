@@ -0,0 +1,231 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
+	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
+	"github.com/mozartmuhammad/julo-be-test/src/model/web"
+	"github.com/mozartmuhammad/julo-be-test/src/service"
+)
+
+var (
+	voucherSvc service.VoucherServiceItf
+
+	mockVoucherRepository       *mock_repository.MockVoucherRepository
+	mockVoucherWalletRepository *mock_repository.MockWalletRepository
+)
+
+func provideVoucherTest(t *testing.T) func() {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVoucherRepository = mock_repository.NewMockVoucherRepository(ctrl)
+	mockVoucherWalletRepository = mock_repository.NewMockWalletRepository(ctrl)
+	validator := validator.New()
+	voucherSvc = service.NewVoucherService(mockVoucherRepository, mockVoucherWalletRepository, validator)
+
+	return func() {}
+}
+
+func TestCreateVoucherBatch(t *testing.T) {
+	testDep := provideVoucherTest(t)
+	defer testDep()
+
+	var vouchers []domain.Voucher
+	mockVoucherRepository.EXPECT().CreateVoucherBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, batch domain.VoucherBatch, got []domain.Voucher) error {
+			assert.Equal(t, batch.Value, domain.Money{Amount: 25000, Currency: "IDR"})
+			vouchers = got
+			return nil
+		})
+
+	got, err := voucherSvc.CreateVoucherBatch(context.Background(), web.VoucherBatchCreateRequest{
+		Name:             "Launch",
+		Value:            25000,
+		Prefix:           "launch",
+		CodeCount:        50,
+		MaxUses:          1,
+		PerCustomerLimit: 1,
+		ExpiresAt:        time.Now().AddDate(0, 1, 0),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, len(got.Codes), 50)
+	assert.Equal(t, len(vouchers), 50)
+
+	codes := map[string]bool{}
+	for _, code := range got.Codes {
+		assert.True(t, strings.HasPrefix(code, "LAUNCH"))
+		assert.Equal(t, len(code), 16)
+		codes[code] = true
+	}
+	assert.Equal(t, len(codes), 50)
+}
+
+func TestRedeemVoucher(t *testing.T) {
+	batch := domain.VoucherBatch{
+		ID:               "mock-batch",
+		Value:            domain.Money{Amount: 25000, Currency: "IDR"},
+		MaxUses:          3,
+		PerCustomerLimit: 1,
+		ExpiresAt:        time.Now().AddDate(0, 1, 0),
+	}
+	voucher := domain.Voucher{
+		ID:      "mock-voucher",
+		BatchID: "mock-batch",
+		Code:    "LAUNCHABCDEFGHJK",
+	}
+	wallet := domain.Wallet{
+		ID:          "mock-id",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.Money{Amount: 1000, Currency: "IDR"},
+	}
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		payload    web.VoucherRedeemRequest
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.VoucherRedemptionResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			payload:  web.VoucherRedeemRequest{Code: " launchabcdefghjk "},
+			mockFunc: func() {
+				mockVoucherRepository.EXPECT().GetVoucherByCode(gomock.Any(), "LAUNCHABCDEFGHJK").Return(voucher, nil)
+				mockVoucherRepository.EXPECT().GetVoucherBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+				mockVoucherRepository.EXPECT().GetCustomerVoucherUsage(gomock.Any(), "mock-batch", "1").Return(0, nil)
+				mockVoucherWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockVoucherRepository.EXPECT().RedeemVoucher(gomock.Any(), batch, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, batch domain.VoucherBatch, redemption domain.VoucherRedemption, credit domain.Transaction) (bool, error) {
+						assert.Equal(t, redemption.VoucherID, "mock-voucher")
+						assert.Equal(t, credit.TransactionType, "voucher")
+						assert.Equal(t, credit.ReferenceID, redemption.ID)
+						assert.Equal(t, credit.WalletID, "mock-id")
+						return true, nil
+					})
+			},
+			wantErr: false,
+			wantResult: web.VoucherRedemptionResponse{
+				Code:   "LAUNCHABCDEFGHJK",
+				Amount: domain.Money{Amount: 25000, Currency: "IDR"},
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - code not found",
+			payload:  web.VoucherRedeemRequest{Code: "UNKNOWN"},
+			mockFunc: func() {
+				mockVoucherRepository.EXPECT().GetVoucherByCode(gomock.Any(), "UNKNOWN").Return(domain.Voucher{}, sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - expired",
+			payload:  web.VoucherRedeemRequest{Code: "LAUNCHABCDEFGHJK"},
+			mockFunc: func() {
+				expired := batch
+				expired.ExpiresAt = time.Now().AddDate(0, 0, -1)
+				mockVoucherRepository.EXPECT().GetVoucherByCode(gomock.Any(), "LAUNCHABCDEFGHJK").Return(voucher, nil)
+				mockVoucherRepository.EXPECT().GetVoucherBatch(gomock.Any(), "mock-batch").Return(expired, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - used up",
+			payload:  web.VoucherRedeemRequest{Code: "LAUNCHABCDEFGHJK"},
+			mockFunc: func() {
+				used := voucher
+				used.UsedCount = 3
+				mockVoucherRepository.EXPECT().GetVoucherByCode(gomock.Any(), "LAUNCHABCDEFGHJK").Return(used, nil)
+				mockVoucherRepository.EXPECT().GetVoucherBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - customer limit reached",
+			payload:  web.VoucherRedeemRequest{Code: "LAUNCHABCDEFGHJK"},
+			mockFunc: func() {
+				mockVoucherRepository.EXPECT().GetVoucherByCode(gomock.Any(), "LAUNCHABCDEFGHJK").Return(voucher, nil)
+				mockVoucherRepository.EXPECT().GetVoucherBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+				mockVoucherRepository.EXPECT().GetCustomerVoucherUsage(gomock.Any(), "mock-batch", "1").Return(1, nil)
+			},
+			wantErr: true,
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - lost concurrent redemption",
+			payload:  web.VoucherRedeemRequest{Code: "LAUNCHABCDEFGHJK"},
+			mockFunc: func() {
+				mockVoucherRepository.EXPECT().GetVoucherByCode(gomock.Any(), "LAUNCHABCDEFGHJK").Return(voucher, nil)
+				mockVoucherRepository.EXPECT().GetVoucherBatch(gomock.Any(), "mock-batch").Return(batch, nil)
+				mockVoucherRepository.EXPECT().GetCustomerVoucherUsage(gomock.Any(), "mock-batch", "1").Return(0, nil)
+				mockVoucherWalletRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "IDR").Return(wallet, nil)
+				mockVoucherRepository.EXPECT().RedeemVoucher(gomock.Any(), batch, gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideVoucherTest(t)
+			defer testDep()
+
+			tc.mockFunc()
+			got, err := voucherSvc.RedeemVoucher(context.Background(), "1", tc.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			if !tc.wantErr {
+				tc.wantResult.ID = got.ID
+				tc.wantResult.TransactionID = got.TransactionID
+				tc.wantResult.RedeemedAt = got.RedeemedAt
+			}
+			assert.Equal(t, got, tc.wantResult)
+		})
+	}
+}
+
+func TestGetVoucherBatchReport(t *testing.T) {
+	testDep := provideVoucherTest(t)
+	defer testDep()
+
+	mockVoucherRepository.EXPECT().GetVoucherBatch(gomock.Any(), "mock-batch").Return(domain.VoucherBatch{
+		ID:        "mock-batch",
+		Value:     domain.Money{Amount: 25000, Currency: "IDR"},
+		CodeCount: 3,
+		MaxUses:   2,
+		ExpiresAt: time.Now().AddDate(0, 1, 0),
+	}, nil)
+	mockVoucherRepository.EXPECT().GetVouchers(gomock.Any(), "mock-batch").Return([]domain.Voucher{
+		{Code: "A", UsedCount: 0},
+		{Code: "B", UsedCount: 1},
+		{Code: "C", UsedCount: 2},
+	}, nil)
+	mockVoucherRepository.EXPECT().GetVoucherBatchCustomerCount(gomock.Any(), "mock-batch").Return(2, nil)
+
+	got, err := voucherSvc.GetVoucherBatchReport(context.Background(), "mock-batch")
+	assert.Nil(t, err)
+	assert.Equal(t, got.Status, "active")
+	assert.Equal(t, got.UnusedCodes, 1)
+	assert.Equal(t, got.PartiallyUsedCodes, 1)
+	assert.Equal(t, got.UsedCodes, 1)
+	assert.Equal(t, got.Redemptions, 3)
+	assert.Equal(t, got.RedeemedAmount, domain.Money{Amount: 75000, Currency: "IDR"})
+	assert.Equal(t, got.Customers, 2)
+	assert.Equal(t, got.Vouchers[1], web.VoucherCodeResponse{Code: "B", UsedCount: 1, Status: "partial"})
+}
@@ -9,9 +9,12 @@ import (
 type WalletServiceItf interface {
 	InitializeWallet(ctx context.Context, request web.WalletCreateRequest) error
 	GetWalletBalance(ctx context.Context, customerXID string) (web.WalletResponse, error)
+	GetWallets(ctx context.Context, customerXID string) ([]web.WalletResponse, error)
+	OpenCurrencyWallet(ctx context.Context, customerXID string, request web.CurrencyWalletRequest) (web.WalletResponse, error)
 	EnableWallet(ctx context.Context, customerXID string) (web.WalletResponse, error)
 	DisableWallet(ctx context.Context, customerXID string) (web.WalletResponse, error)
 	GetWalletTransactions(ctx context.Context, customerXID string) ([]web.TransactionResponse, error)
 	AddWalletBalance(ctx context.Context, customerXID string, request web.TransactionRequest) (web.DepositResponse, error)
-	DeductWalletBalance(ctx context.Context, customerXID string, request web.TransactionRequest) (web.WithdrawalResponse, error)
+	DeductWalletBalance(ctx context.Context, customerXID string, request web.WithdrawalRequest) (web.WithdrawalResponse, error)
+	TransferBalance(ctx context.Context, customerXID string, request web.TransferRequest) (web.TransferResponse, error)
 }
@@ -2,28 +2,42 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"log"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
 	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
 	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
 	"github.com/mozartmuhammad/julo-be-test/src/model/web"
 	"github.com/mozartmuhammad/julo-be-test/src/repository"
 )
 
+// errWalletBalanceChanged is returned when the balance moved between reading
+// and debiting the wallet, the same request can simply be sent again.
+var errWalletBalanceChanged = errors.New("wallet balance changed, please retry")
+
 type WalletService struct {
-	WalletRepository repository.WalletRepository
-	Validate         *validator.Validate
-	DelayDuration    time.Duration
+	WalletRepository       repository.WalletRepository
+	PocketRepository       repository.PocketRepository
+	PayoutRepository       repository.PayoutRepository
+	WalletMemberRepository repository.WalletMemberRepository
+	PayoutProvider         bank.PayoutProvider
+	Validate               *validator.Validate
+	DelayDuration          time.Duration
 }
 
-func NewWalletService(walletRepository repository.WalletRepository, validate *validator.Validate, delay time.Duration) WalletServiceItf {
+func NewWalletService(walletRepository repository.WalletRepository, pocketRepository repository.PocketRepository, payoutRepository repository.PayoutRepository, walletMemberRepository repository.WalletMemberRepository, payoutProvider bank.PayoutProvider, validate *validator.Validate, delay time.Duration) WalletServiceItf {
 	return &WalletService{
-		WalletRepository: walletRepository,
-		Validate:         validate,
+		WalletRepository:       walletRepository,
+		PocketRepository:       pocketRepository,
+		PayoutRepository:       payoutRepository,
+		WalletMemberRepository: walletMemberRepository,
+		PayoutProvider:         payoutProvider,
+		Validate:               validate,
 	}
 }
 
@@ -37,6 +51,8 @@ func (svc *WalletService) InitializeWallet(ctx context.Context, request web.Wall
 	wallet := domain.Wallet{
 		ID:          uuid.New().String(),
 		CustomerXID: request.CustomerXID,
+		Currency:    constants.DEFAULT_CURRENCY,
+		Status:      constants.STATUS_DISABLED,
 	}
 
 	err = svc.WalletRepository.CreateWallet(ctx, wallet)
@@ -53,9 +69,88 @@ func (svc *WalletService) GetWalletBalance(ctx context.Context, customerXID stri
 		return web.WalletResponse{}, err
 	}
 
+	pockets, err := svc.PocketRepository.GetPockets(ctx, wallet.ID)
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+
+	result := web.WalletResponse{
+		ID:        wallet.ID,
+		OwnedBy:   wallet.CustomerXID,
+		Currency:  wallet.Currency,
+		Status:    wallet.Status,
+		EnabledAt: wallet.EnabledAt,
+		Balance:   wallet.Balance,
+	}
+	setCreditLine(&result, wallet)
+	for i := range pockets {
+		result.Pockets = append(result.Pockets, toPocketResponse(pockets[i]))
+	}
+	return result, nil
+}
+
+func (svc *WalletService) GetWallets(ctx context.Context, customerXID string) ([]web.WalletResponse, error) {
+	wallets, err := svc.WalletRepository.GetWallets(ctx, customerXID)
+	if err != nil {
+		return []web.WalletResponse{}, err
+	}
+
+	result := []web.WalletResponse{}
+	for i := range wallets {
+		response := web.WalletResponse{
+			ID:        wallets[i].ID,
+			OwnedBy:   wallets[i].CustomerXID,
+			Currency:  wallets[i].Currency,
+			Status:    wallets[i].Status,
+			EnabledAt: wallets[i].EnabledAt,
+			Balance:   wallets[i].Balance,
+		}
+		setCreditLine(&response, wallets[i])
+		result = append(result, response)
+	}
+	return result, nil
+}
+
+func (svc *WalletService) OpenCurrencyWallet(ctx context.Context, customerXID string, request web.CurrencyWalletRequest) (web.WalletResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+
+	if _, ok := constants.CurrencyMinorUnits[request.Currency]; !ok {
+		return web.WalletResponse{}, errors.New("unsupported currency")
+	}
+
+	// the default wallet must exist, its status is shared by every currency wallet
+	primary, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+
+	_, err = svc.WalletRepository.GetWalletByCurrency(ctx, customerXID, request.Currency)
+	if err == nil {
+		return web.WalletResponse{}, errors.New("currency wallet already exists")
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return web.WalletResponse{}, err
+	}
+
+	wallet := domain.Wallet{
+		ID:          uuid.New().String(),
+		CustomerXID: customerXID,
+		Currency:    request.Currency,
+		Status:      primary.Status,
+		EnabledAt:   primary.EnabledAt,
+	}
+	err = svc.WalletRepository.CreateWallet(ctx, wallet)
+	if err != nil {
+		return web.WalletResponse{}, err
+	}
+
 	return web.WalletResponse{
 		ID:        wallet.ID,
 		OwnedBy:   wallet.CustomerXID,
+		Currency:  wallet.Currency,
 		Status:    wallet.Status,
 		EnabledAt: wallet.EnabledAt,
 		Balance:   wallet.Balance,
@@ -89,6 +184,7 @@ func (svc *WalletService) EnableWallet(ctx context.Context, customerXID string)
 	return web.WalletResponse{
 		ID:        wallet.ID,
 		OwnedBy:   wallet.CustomerXID,
+		Currency:  wallet.Currency,
 		Status:    wallet.Status,
 		EnabledAt: wallet.EnabledAt,
 		Balance:   wallet.Balance,
@@ -111,6 +207,7 @@ func (svc *WalletService) DisableWallet(ctx context.Context, customerXID string)
 	return web.WalletResponse{
 		ID:        wallet.ID,
 		OwnedBy:   wallet.CustomerXID,
+		Currency:  wallet.Currency,
 		Status:    wallet.Status,
 		EnabledAt: wallet.EnabledAt,
 		Balance:   wallet.Balance,
@@ -142,6 +239,7 @@ func (svc *WalletService) GetWalletTransactions(ctx context.Context, customerXID
 			Type:         transaction[i].TransactionType,
 			Amount:       transaction[i].Amount,
 			ReferenceID:  transaction[i].ReferenceID,
+			InitiatedBy:  transaction[i].InitiatedBy,
 		})
 	}
 	return result, nil
@@ -163,13 +261,20 @@ func (svc *WalletService) AddWalletBalance(ctx context.Context, customerXID stri
 		return web.DepositResponse{}, errors.New("wallet disabled")
 	}
 
+	// reject deposits that would overflow the balance
+	amount := domain.NewMoney(request.Amount, wallet.Currency)
+	finalBalance, err := wallet.Balance.Add(amount)
+	if err != nil {
+		return web.DepositResponse{}, err
+	}
+
 	// insert transaction with status pending
 	transaction := domain.Transaction{
 		ID:              uuid.New().String(),
 		WalletID:        wallet.ID,
 		CustomerXID:     wallet.CustomerXID,
 		TransactionType: constants.TRANSACTION_TYPE_DEPOSIT,
-		Amount:          request.Amount,
+		Amount:          amount,
 		ReferenceID:     request.ReferenceID,
 		Status:          constants.STATUS_PENDING,
 		CreatedAt:       time.Now(),
@@ -183,7 +288,7 @@ func (svc *WalletService) AddWalletBalance(ctx context.Context, customerXID stri
 	go func() {
 		// delay 5 seconds for update wallet balance
 		time.Sleep(svc.DelayDuration)
-		isUpdated, err := svc.WalletRepository.UpdateWalletBalance(context.Background(), wallet.ID, wallet.Balance, wallet.Balance+request.Amount)
+		isUpdated, err := svc.WalletRepository.UpdateWalletBalance(context.Background(), wallet.ID, wallet.Balance, finalBalance)
 		if err != nil {
 			log.Println("error update wallet balance:", err.Error())
 		}
@@ -199,17 +304,28 @@ func (svc *WalletService) AddWalletBalance(ctx context.Context, customerXID stri
 		}
 	}()
 
-	return web.DepositResponse{
+	result := web.DepositResponse{
 		ID:          transaction.ID,
 		DepositedBy: transaction.CustomerXID,
 		Status:      transaction.Status,
 		DepositedAt: transaction.CreatedAt,
 		Amount:      transaction.Amount,
 		ReferenceID: transaction.ReferenceID,
-	}, nil
+	}
+
+	// the deposit pays off the overdraft before adding to the balance
+	creditUsed := wallet.CreditUsed
+	if creditUsed.IsPositive() {
+		repaid := creditUsed
+		if amount.Amount < creditUsed.Amount {
+			repaid = amount
+		}
+		result.OverdraftRepaid = &repaid
+	}
+	return result, nil
 }
 
-func (svc *WalletService) DeductWalletBalance(ctx context.Context, customerXID string, request web.TransactionRequest) (web.WithdrawalResponse, error) {
+func (svc *WalletService) DeductWalletBalance(ctx context.Context, customerXID string, request web.WithdrawalRequest) (web.WithdrawalResponse, error) {
 	err := svc.Validate.StructCtx(ctx, request)
 	if err != nil {
 		return web.WithdrawalResponse{}, err
@@ -225,44 +341,80 @@ func (svc *WalletService) DeductWalletBalance(ctx context.Context, customerXID s
 		return web.WithdrawalResponse{}, errors.New("wallet disabled")
 	}
 
-	// compare amount with balance
-	if request.Amount > wallet.Balance {
+	amount := domain.NewMoney(request.Amount, wallet.Currency)
+	finalBalance, err := wallet.Balance.Sub(amount)
+	if err != nil {
+		return web.WithdrawalResponse{}, err
+	}
+
+	// compare amount with balance, the credit limit lets it go below zero
+	remainingCredit, err := finalBalance.Add(wallet.CreditLimit)
+	if err != nil {
+		return web.WithdrawalResponse{}, err
+	}
+	if remainingCredit.IsNegative() {
 		return web.WithdrawalResponse{}, errors.New("insufficient balance")
 	}
 
-	// insert transaction with status pending
+	member, err := svc.getSpendingMember(ctx, wallet, request.MemberXID, constants.TRANSACTION_TYPE_WITHDRAWAL)
+	if err != nil {
+		return web.WithdrawalResponse{}, err
+	}
+
+	now := time.Now()
+	beneficiary, err := svc.withdrawalBeneficiary(ctx, customerXID, request, now)
+	if err != nil {
+		return web.WithdrawalResponse{}, err
+	}
+
+	// the wallet is debited as the payout is created, a payout the bank
+	// fails is credited back by a reversal
 	transaction := domain.Transaction{
 		ID:              uuid.New().String(),
 		WalletID:        wallet.ID,
 		CustomerXID:     wallet.CustomerXID,
+		InitiatedBy:     request.MemberXID,
 		TransactionType: constants.TRANSACTION_TYPE_WITHDRAWAL,
-		Amount:          request.Amount,
+		Amount:          amount,
 		ReferenceID:     request.ReferenceID,
-		Status:          constants.STATUS_PENDING,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}
-	err = svc.WalletRepository.AddTransaction(ctx, transaction)
+	payout := domain.Payout{
+		ID:            uuid.New().String(),
+		WalletID:      wallet.ID,
+		CustomerXID:   wallet.CustomerXID,
+		BeneficiaryID: beneficiary.ID,
+		TransactionID: transaction.ID,
+		BankAccount:   beneficiary.BankAccount,
+		Amount:        amount,
+		Status:        constants.STATUS_PENDING,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	period := domain.SpendingPeriod(now)
+	err = svc.reserveMemberSpend(ctx, member, amount, period)
 	if err != nil {
 		return web.WithdrawalResponse{}, err
 	}
 
-	go func() {
-		time.Sleep(svc.DelayDuration)
-		isUpdated, err := svc.WalletRepository.UpdateWalletBalance(context.Background(), wallet.ID, wallet.Balance, wallet.Balance-request.Amount)
-		if err != nil {
-			log.Println("error update wallet balance:", err.Error())
-		}
+	isCreated, err := svc.PayoutRepository.CreatePayout(ctx, payout, transaction, wallet.Balance, finalBalance)
+	if err != nil {
+		svc.releaseMemberSpend(ctx, member, amount, period)
+		return web.WithdrawalResponse{}, err
+	}
+	if !isCreated {
+		svc.releaseMemberSpend(ctx, member, amount, period)
+		return web.WithdrawalResponse{}, errWalletBalanceChanged
+	}
 
-		status := constants.STATUS_FAILED
-		if isUpdated {
-			status = constants.STATUS_SUCCESS
-		}
-		err = svc.WalletRepository.UpdateTransactionStatus(context.Background(), transaction.ID, status)
-		if err != nil {
-			log.Println("error update transaction status:", err.Error())
-		}
-	}()
+	// a payout the provider did not take stays pending and is submitted
+	// again by the payouts job
+	payout, err = submitPayout(ctx, svc.PayoutRepository, svc.PayoutProvider, payout)
+	if err != nil {
+		log.Println("error submit payout", payout.ID+":", err.Error())
+	}
 
 	return web.WithdrawalResponse{
 		ID:          transaction.ID,
@@ -271,5 +423,227 @@ func (svc *WalletService) DeductWalletBalance(ctx context.Context, customerXID s
 		WithdrawnAt: transaction.CreatedAt,
 		Amount:      transaction.Amount,
 		ReferenceID: transaction.ReferenceID,
+		InitiatedBy: transaction.InitiatedBy,
+		Payout:      toPayoutResponse(payout),
 	}, nil
 }
+
+// withdrawalBeneficiary returns the verified beneficiary the withdrawal
+// refers to. A bank account given in full is kept as a beneficiary of the
+// customer, unverified until a name inquiry confirms it, and is paid out
+// with the details as given. The beneficiaries belong to the owner, a member
+// can only withdraw to a bank account given in full and it is not kept.
+func (svc *WalletService) withdrawalBeneficiary(ctx context.Context, customerXID string, request web.WithdrawalRequest, now time.Time) (domain.Beneficiary, error) {
+	if request.MemberXID != "" && request.BeneficiaryID != "" {
+		return domain.Beneficiary{}, errors.New("members cannot withdraw to saved beneficiaries")
+	}
+
+	if request.BeneficiaryID == "" {
+		bankAccount := domain.BankAccount{
+			BankCode:      request.BankCode,
+			AccountNumber: request.AccountNumber,
+			AccountName:   request.AccountName,
+		}
+		if request.MemberXID != "" {
+			return domain.Beneficiary{BankAccount: bankAccount}, nil
+		}
+
+		beneficiary, err := svc.PayoutRepository.SaveBeneficiary(ctx, domain.Beneficiary{
+			ID:          uuid.New().String(),
+			CustomerXID: customerXID,
+			BankAccount: bankAccount,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+		if err != nil {
+			return domain.Beneficiary{}, err
+		}
+
+		beneficiary.BankAccount = bankAccount
+		return beneficiary, nil
+	}
+
+	beneficiary, err := getOwnedBeneficiary(ctx, svc.PayoutRepository, customerXID, request.BeneficiaryID)
+	if err != nil {
+		return domain.Beneficiary{}, err
+	}
+
+	if beneficiary.VerifiedAt == nil {
+		return domain.Beneficiary{}, errors.New("beneficiary not verified")
+	}
+	return beneficiary, nil
+}
+
+func (svc *WalletService) TransferBalance(ctx context.Context, customerXID string, request web.TransferRequest) (web.TransferResponse, error) {
+	err := svc.Validate.StructCtx(ctx, request)
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	if request.RecipientXID == customerXID {
+		return web.TransferResponse{}, errors.New("cannot transfer to own wallet")
+	}
+
+	wallet, err := svc.WalletRepository.GetWallet(ctx, customerXID)
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	recipient, err := svc.WalletRepository.GetWallet(ctx, request.RecipientXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.TransferResponse{}, errors.New("recipient wallet not found")
+	}
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	// check wallet status
+	if wallet.Status == constants.STATUS_DISABLED {
+		return web.TransferResponse{}, errors.New("wallet disabled")
+	}
+	if recipient.Status == constants.STATUS_DISABLED {
+		return web.TransferResponse{}, errors.New("recipient wallet disabled")
+	}
+
+	amount := domain.NewMoney(request.Amount, wallet.Currency)
+	finalBalance, err := wallet.Balance.Sub(amount)
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	// compare amount with balance
+	if finalBalance.IsNegative() {
+		return web.TransferResponse{}, errors.New("insufficient balance")
+	}
+
+	// the recipient must be able to hold the amount as well
+	_, err = recipient.Balance.Add(domain.NewMoney(request.Amount, recipient.Currency))
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	member, err := svc.getSpendingMember(ctx, wallet, request.MemberXID, constants.TRANSACTION_TYPE_TRANSFER_OUT)
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	debit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        wallet.ID,
+		CustomerXID:     wallet.CustomerXID,
+		InitiatedBy:     request.MemberXID,
+		TransactionType: constants.TRANSACTION_TYPE_TRANSFER_OUT,
+		Amount:          amount,
+		ReferenceID:     request.ReferenceID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	credit := domain.Transaction{
+		ID:              uuid.New().String(),
+		WalletID:        recipient.ID,
+		CustomerXID:     recipient.CustomerXID,
+		TransactionType: constants.TRANSACTION_TYPE_TRANSFER_IN,
+		Amount:          domain.NewMoney(request.Amount, recipient.Currency),
+		ReferenceID:     request.ReferenceID,
+		Status:          constants.STATUS_SUCCESS,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	period := domain.SpendingPeriod(debit.CreatedAt)
+	err = svc.reserveMemberSpend(ctx, member, amount, period)
+	if err != nil {
+		return web.TransferResponse{}, err
+	}
+
+	isTransferred, err := svc.WalletRepository.TransferBalance(ctx, debit, credit, domain.TransferGuard{
+		SplitBillID:           request.SplitBillID,
+		RefundedTransactionID: request.RefundedTransactionID,
+	})
+	if err != nil {
+		svc.releaseMemberSpend(ctx, member, amount, period)
+		return web.TransferResponse{}, err
+	}
+	if !isTransferred {
+		svc.releaseMemberSpend(ctx, member, amount, period)
+		return web.TransferResponse{}, errors.New("insufficient balance")
+	}
+
+	return web.TransferResponse{
+		ID:            debit.ID,
+		TransferredBy: debit.CustomerXID,
+		RecipientXID:  credit.CustomerXID,
+		Status:        debit.Status,
+		TransferredAt: debit.CreatedAt,
+		Amount:        debit.Amount,
+		ReferenceID:   debit.ReferenceID,
+		InitiatedBy:   debit.InitiatedBy,
+	}, nil
+}
+
+// getSpendingMember returns the membership of the member spending from the
+// shared wallet, nothing when the owner is spending. Suspended members and
+// transaction types the owner did not allow are refused.
+func (svc *WalletService) getSpendingMember(ctx context.Context, wallet domain.Wallet, memberXID, transactionType string) (domain.WalletMember, error) {
+	if memberXID == "" {
+		return domain.WalletMember{}, nil
+	}
+
+	member, err := svc.WalletMemberRepository.GetWalletMember(ctx, wallet.ID, memberXID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.WalletMember{}, errors.New("not a member of the wallet")
+	}
+	if err != nil {
+		return domain.WalletMember{}, err
+	}
+
+	if member.Status != constants.STATUS_ACTIVE {
+		return domain.WalletMember{}, errors.New("membership suspended")
+	}
+	if !member.Allows(transactionType) {
+		return domain.WalletMember{}, errors.New("transaction type not allowed for member")
+	}
+	return member, nil
+}
+
+// reserveMemberSpend counts amount against the member's spending limit for
+// period before the money moves.
+func (svc *WalletService) reserveMemberSpend(ctx context.Context, member domain.WalletMember, amount domain.Money, period string) error {
+	if member.ID == "" {
+		return nil
+	}
+
+	isReserved, err := svc.WalletMemberRepository.ReserveMemberSpend(ctx, member.ID, amount, period)
+	if err != nil {
+		return err
+	}
+	if !isReserved {
+		return errors.New("member spending limit exceeded")
+	}
+	return nil
+}
+
+// releaseMemberSpend gives back the reservation of a transaction that did
+// not go through.
+func (svc *WalletService) releaseMemberSpend(ctx context.Context, member domain.WalletMember, amount domain.Money, period string) {
+	if member.ID == "" {
+		return
+	}
+
+	err := svc.WalletMemberRepository.ReleaseMemberSpend(ctx, member.ID, amount, period)
+	if err != nil {
+		log.Println("error release member spend", member.ID+":", err.Error())
+	}
+}
+
+// setCreditLine adds the credit figures to wallets that have a credit line.
+func setCreditLine(result *web.WalletResponse, wallet domain.Wallet) {
+	creditUsed := wallet.CreditUsed
+	if !wallet.CreditLimit.IsPositive() && !creditUsed.IsPositive() {
+		return
+	}
+
+	creditLimit := wallet.CreditLimit
+	result.CreditLimit = &creditLimit
+	result.CreditUsed = &creditUsed
+}
@@ -2,13 +2,17 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/mozartmuhammad/julo-be-test/src/bank"
 	mock_repository "github.com/mozartmuhammad/julo-be-test/src/mock/repository"
 	"github.com/mozartmuhammad/julo-be-test/src/model/domain"
 	"github.com/mozartmuhammad/julo-be-test/src/model/web"
@@ -18,7 +22,11 @@ import (
 var (
 	svc service.WalletServiceItf
 
-	mockRepository *mock_repository.MockWalletRepository
+	mockRepository       *mock_repository.MockWalletRepository
+	mockPocketRepository *mock_repository.MockPocketRepository
+	mockPayoutRepository *mock_repository.MockPayoutRepository
+
+	mockWalletMemberRepository *mock_repository.MockWalletMemberRepository
 )
 
 func provideTest(t *testing.T) func() {
@@ -26,8 +34,11 @@ func provideTest(t *testing.T) func() {
 	defer ctrl.Finish()
 
 	mockRepository = mock_repository.NewMockWalletRepository(ctrl)
+	mockPocketRepository = mock_repository.NewMockPocketRepository(ctrl)
+	mockPayoutRepository = mock_repository.NewMockPayoutRepository(ctrl)
+	mockWalletMemberRepository = mock_repository.NewMockWalletMemberRepository(ctrl)
 	validator := validator.New()
-	svc = service.NewWalletService(mockRepository, validator, 0)
+	svc = service.NewWalletService(mockRepository, mockPocketRepository, mockPayoutRepository, mockWalletMemberRepository, bank.NewStubPayoutProvider(0), validator, 0)
 
 	return func() {}
 }
@@ -123,6 +134,7 @@ func TestGetWalletBalance(t *testing.T) {
 				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
 					ID: "mock-id",
 				}, nil)
+				mockPocketRepository.EXPECT().GetPockets(gomock.Any(), "mock-id").Return(nil, nil)
 			},
 			wantErr: false,
 			wantResult: web.WalletResponse{
@@ -131,6 +143,40 @@ func TestGetWalletBalance(t *testing.T) {
 		},
 		{
 			testID:   2,
+			testDesc: "Success - with pockets",
+			args: args{
+				customerXID: "1",
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:      "mock-id",
+					Balance: domain.Money{Amount: 1000},
+				}, nil)
+				mockPocketRepository.EXPECT().GetPockets(gomock.Any(), "mock-id").Return([]domain.Pocket{
+					{
+						ID:      "mock-pocket",
+						Name:    "holiday",
+						Balance: domain.Money{Amount: 5000},
+						Status:  "active",
+					},
+				}, nil)
+			},
+			wantErr: false,
+			wantResult: web.WalletResponse{
+				ID:      "mock-id",
+				Balance: domain.Money{Amount: 1000},
+				Pockets: []web.PocketResponse{
+					{
+						ID:      "mock-pocket",
+						Name:    "holiday",
+						Balance: domain.Money{Amount: 5000},
+						Status:  "active",
+					},
+				},
+			},
+		},
+		{
+			testID:   3,
 			testDesc: "Failed - error call GetWallet",
 			args: args{
 				customerXID: "1",
@@ -372,12 +418,12 @@ func TestWalletTransactions(t *testing.T) {
 				mockRepository.EXPECT().GetWalletTransactions(gomock.Any(), "mock-id").Return([]domain.Transaction{
 					{
 						ID:              "mock-id-1",
-						Amount:          20000,
+						Amount:          domain.Money{Amount: 20000},
 						TransactionType: "deposit",
 					},
 					{
 						ID:              "mock-id-2",
-						Amount:          10000,
+						Amount:          domain.Money{Amount: 10000},
 						TransactionType: "withdrawal",
 					},
 				}, nil)
@@ -387,12 +433,12 @@ func TestWalletTransactions(t *testing.T) {
 			wantResult: []web.TransactionResponse{
 				{
 					ID:     "mock-id-1",
-					Amount: 20000,
+					Amount: domain.Money{Amount: 20000},
 					Type:   "deposit",
 				},
 				{
 					ID:     "mock-id-2",
-					Amount: 10000,
+					Amount: domain.Money{Amount: 10000},
 					Type:   "withdrawal",
 				},
 			},
@@ -493,7 +539,7 @@ func TestAddWalletBalance(t *testing.T) {
 			},
 			wantErr: false,
 			wantResult: web.DepositResponse{
-				Amount:      1000,
+				Amount:      domain.Money{Amount: 1000},
 				ReferenceID: "mock-ref",
 			},
 		},
@@ -571,6 +617,55 @@ func TestAddWalletBalance(t *testing.T) {
 			wantErr:    true,
 			wantResult: web.DepositResponse{},
 		},
+		{
+			testID:   6,
+			testDesc: "Failed - balance overflow",
+			args: args{
+				customerXID: "1",
+				payload: web.TransactionRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: math.MaxInt64 - 10},
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.DepositResponse{},
+		},
+		{
+			testID:   7,
+			testDesc: "Success - pays off overdraft first",
+			args: args{
+				customerXID: "1",
+				payload: web.TransactionRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:          "mock-id",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: -400},
+					CreditLimit: domain.Money{Amount: 1000},
+					CreditUsed:  domain.Money{Amount: 400},
+				}, nil)
+				mockRepository.EXPECT().AddTransaction(gomock.Any(), gomock.Any()).Return(nil)
+				mockRepository.EXPECT().UpdateWalletBalance(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockRepository.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.DepositResponse{
+				Amount:          domain.Money{Amount: 1000},
+				ReferenceID:     "mock-ref",
+				OverdraftRepaid: &domain.Money{Amount: 400},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -583,6 +678,7 @@ func TestAddWalletBalance(t *testing.T) {
 			assert.Equal(t, err != nil, tc.wantErr)
 			assert.Equal(t, got.Amount, tc.wantResult.Amount)
 			assert.Equal(t, got.ReferenceID, tc.wantResult.ReferenceID)
+			assert.Equal(t, got.OverdraftRepaid, tc.wantResult.OverdraftRepaid)
 		})
 	}
 }
@@ -591,10 +687,27 @@ func TestDeductWalletBalance(t *testing.T) {
 	type (
 		args struct {
 			customerXID string
-			payload     web.TransactionRequest
+			payload     web.WithdrawalRequest
 		}
 	)
 
+	payload := web.WithdrawalRequest{
+		Amount:        1000,
+		ReferenceID:   "mock-ref",
+		BankCode:      "BCA",
+		AccountNumber: "1234567890",
+		AccountName:   "John Doe",
+	}
+	beneficiary := domain.Beneficiary{
+		ID:          "mock-beneficiary",
+		CustomerXID: "1",
+		BankAccount: domain.BankAccount{
+			BankCode:      "BCA",
+			AccountNumber: "1234567890",
+			AccountName:   "John Doe",
+		},
+	}
+
 	testCases := []struct {
 		testID     int
 		testDesc   string
@@ -608,25 +721,38 @@ func TestDeductWalletBalance(t *testing.T) {
 			testDesc: "Success",
 			args: args{
 				customerXID: "1",
-				payload: web.TransactionRequest{
-					Amount:      1000,
-					ReferenceID: "mock-ref",
-				},
+				payload:     payload,
 			},
 			mockFunc: func() {
 				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
-					ID:      "mock-id",
-					Status:  "enabled",
-					Balance: 1000000,
+					ID:          "mock-id",
+					CustomerXID: "1",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: 1000000},
 				}, nil)
-				mockRepository.EXPECT().AddTransaction(gomock.Any(), gomock.Any()).Return(nil)
-				mockRepository.EXPECT().UpdateWalletBalance(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
-				mockRepository.EXPECT().UpdateTransactionStatus(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockPayoutRepository.EXPECT().SaveBeneficiary(gomock.Any(), gomock.Any()).Return(beneficiary, nil)
+				mockPayoutRepository.EXPECT().CreatePayout(gomock.Any(), gomock.Any(), gomock.Any(), domain.Money{Amount: 1000000}, domain.Money{Amount: 999000}).
+					DoAndReturn(func(ctx context.Context, payout domain.Payout, withdrawal domain.Transaction, balance, newBalance domain.Money) (bool, error) {
+						assert.Equal(t, payout.BeneficiaryID, "mock-beneficiary")
+						assert.Equal(t, payout.TransactionID, withdrawal.ID)
+						assert.Equal(t, payout.Status, "pending")
+						assert.Equal(t, withdrawal.TransactionType, "withdrawal")
+						assert.Equal(t, withdrawal.Status, "success")
+						return true, nil
+					})
+				mockPayoutRepository.EXPECT().MarkPayoutSubmitted(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
 			},
 			wantErr: false,
 			wantResult: web.WithdrawalResponse{
-				Amount:      1000,
+				Amount:      domain.Money{Amount: 1000},
 				ReferenceID: "mock-ref",
+				Payout: web.PayoutResponse{
+					BeneficiaryID: "mock-beneficiary",
+					BankCode:      "BCA",
+					AccountNumber: "1234567890",
+					AccountName:   "John Doe",
+					Status:        "submitted",
+				},
 			},
 		},
 		{
@@ -634,9 +760,12 @@ func TestDeductWalletBalance(t *testing.T) {
 			testDesc: "Failed - error validate",
 			args: args{
 				customerXID: "1",
-				payload: web.TransactionRequest{
-					Amount:      0,
-					ReferenceID: "mock-ref",
+				payload: web.WithdrawalRequest{
+					Amount:        0,
+					ReferenceID:   "mock-ref",
+					BankCode:      "BCA",
+					AccountNumber: "1234567890",
+					AccountName:   "John Doe",
 				},
 			},
 			mockFunc: func() {
@@ -650,10 +779,7 @@ func TestDeductWalletBalance(t *testing.T) {
 			testDesc: "Failed - error GetWallet",
 			args: args{
 				customerXID: "1",
-				payload: web.TransactionRequest{
-					Amount:      1000,
-					ReferenceID: "mock-ref",
-				},
+				payload:     payload,
 			},
 			mockFunc: func() {
 				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
@@ -669,58 +795,209 @@ func TestDeductWalletBalance(t *testing.T) {
 			testDesc: "Failed - wallet disable",
 			args: args{
 				customerXID: "1",
-				payload: web.TransactionRequest{
-					Amount:      1000,
-					ReferenceID: "mock-ref",
-				},
+				payload:     payload,
 			},
 			mockFunc: func() {
 				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
 					ID:      "mock-id",
 					Status:  "disabled",
-					Balance: 1000000,
+					Balance: domain.Money{Amount: 1000000},
 				}, nil)
 			},
 			wantErr:    true,
 			wantResult: web.WithdrawalResponse{},
 		},
 		{
-			testID:   4,
+			testID:   5,
 			testDesc: "Failed - insufficient balance",
 			args: args{
 				customerXID: "1",
-				payload: web.TransactionRequest{
-					Amount:      1000,
-					ReferenceID: "mock-ref",
-				},
+				payload:     payload,
 			},
 			mockFunc: func() {
 				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
 					ID:      "mock-id",
 					Status:  "enabled",
-					Balance: 100,
+					Balance: domain.Money{Amount: 100},
 				}, nil)
 			},
 			wantErr:    true,
 			wantResult: web.WithdrawalResponse{},
 		},
 		{
-			testID:   5,
-			testDesc: "Failed - error AddTransaction",
+			testID:   6,
+			testDesc: "Failed - error CreatePayout",
 			args: args{
 				customerXID: "1",
-				payload: web.TransactionRequest{
-					Amount:      1000,
-					ReferenceID: "mock-ref",
+				payload:     payload,
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 1000000},
+				}, nil)
+				mockPayoutRepository.EXPECT().SaveBeneficiary(gomock.Any(), gomock.Any()).Return(beneficiary, nil)
+				mockPayoutRepository.EXPECT().CreatePayout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("error"))
+			},
+			wantErr:    true,
+			wantResult: web.WithdrawalResponse{},
+		},
+		{
+			testID:   7,
+			testDesc: "Failed - balance changed meanwhile",
+			args: args{
+				customerXID: "1",
+				payload:     payload,
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 1000000},
+				}, nil)
+				mockPayoutRepository.EXPECT().SaveBeneficiary(gomock.Any(), gomock.Any()).Return(beneficiary, nil)
+				mockPayoutRepository.EXPECT().CreatePayout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr:    true,
+			wantResult: web.WithdrawalResponse{},
+		},
+		{
+			testID:   8,
+			testDesc: "Success - within credit limit",
+			args: args{
+				customerXID: "1",
+				payload:     payload,
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:          "mock-id",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: 100},
+					CreditLimit: domain.Money{Amount: 900},
+				}, nil)
+				mockPayoutRepository.EXPECT().SaveBeneficiary(gomock.Any(), gomock.Any()).Return(beneficiary, nil)
+				mockPayoutRepository.EXPECT().CreatePayout(gomock.Any(), gomock.Any(), gomock.Any(), domain.Money{Amount: 100}, domain.Money{Amount: -900}).Return(true, nil)
+				mockPayoutRepository.EXPECT().MarkPayoutSubmitted(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.WithdrawalResponse{
+				Amount:      domain.Money{Amount: 1000},
+				ReferenceID: "mock-ref",
+				Payout: web.PayoutResponse{
+					BeneficiaryID: "mock-beneficiary",
+					BankCode:      "BCA",
+					AccountNumber: "1234567890",
+					AccountName:   "John Doe",
+					Status:        "submitted",
+				},
+			},
+		},
+		{
+			testID:   9,
+			testDesc: "Failed - beyond credit limit",
+			args: args{
+				customerXID: "1",
+				payload:     payload,
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:          "mock-id",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: -500},
+					CreditLimit: domain.Money{Amount: 1000},
+					CreditUsed:  domain.Money{Amount: 500},
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.WithdrawalResponse{},
+		},
+		{
+			testID:   10,
+			testDesc: "Success - to a verified beneficiary",
+			args: args{
+				customerXID: "1",
+				payload: web.WithdrawalRequest{
+					Amount:        1000,
+					ReferenceID:   "mock-ref",
+					BeneficiaryID: "mock-beneficiary",
 				},
 			},
 			mockFunc: func() {
+				verified := beneficiary
+				verified.VerifiedAt = &time.Time{}
 				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
 					ID:      "mock-id",
 					Status:  "enabled",
-					Balance: 1000000,
+					Balance: domain.Money{Amount: 1000000},
 				}, nil)
-				mockRepository.EXPECT().AddTransaction(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+				mockPayoutRepository.EXPECT().GetBeneficiary(gomock.Any(), "mock-beneficiary").Return(verified, nil)
+				mockPayoutRepository.EXPECT().CreatePayout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				mockPayoutRepository.EXPECT().MarkPayoutSubmitted(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.WithdrawalResponse{
+				Amount:      domain.Money{Amount: 1000},
+				ReferenceID: "mock-ref",
+				Payout: web.PayoutResponse{
+					BeneficiaryID: "mock-beneficiary",
+					BankCode:      "BCA",
+					AccountNumber: "1234567890",
+					AccountName:   "John Doe",
+					Status:        "submitted",
+				},
+			},
+		},
+		{
+			testID:   11,
+			testDesc: "Failed - beneficiary not verified",
+			args: args{
+				customerXID: "1",
+				payload: web.WithdrawalRequest{
+					Amount:        1000,
+					ReferenceID:   "mock-ref",
+					BeneficiaryID: "mock-beneficiary",
+				},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), gomock.Any()).Return(domain.Wallet{
+					ID:      "mock-id",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 1000000},
+				}, nil)
+				mockPayoutRepository.EXPECT().GetBeneficiary(gomock.Any(), "mock-beneficiary").Return(beneficiary, nil)
+			},
+			wantErr:    true,
+			wantResult: web.WithdrawalResponse{},
+		},
+		{
+			testID:   12,
+			testDesc: "Failed - beneficiary and bank account both given",
+			args: args{
+				customerXID: "1",
+				payload: web.WithdrawalRequest{
+					Amount:        1000,
+					ReferenceID:   "mock-ref",
+					BeneficiaryID: "mock-beneficiary",
+					BankCode:      "BCA",
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.WithdrawalResponse{},
+		},
+		{
+			testID:   13,
+			testDesc: "Failed - neither beneficiary nor bank account given",
+			args: args{
+				customerXID: "1",
+				payload: web.WithdrawalRequest{
+					Amount:      1000,
+					ReferenceID: "mock-ref",
+				},
+			},
+			mockFunc: func() {
 			},
 			wantErr:    true,
 			wantResult: web.WithdrawalResponse{},
@@ -737,6 +1014,443 @@ func TestDeductWalletBalance(t *testing.T) {
 			assert.Equal(t, err != nil, tc.wantErr)
 			assert.Equal(t, got.Amount, tc.wantResult.Amount)
 			assert.Equal(t, got.ReferenceID, tc.wantResult.ReferenceID)
+			assert.Equal(t, got.Payout.BeneficiaryID, tc.wantResult.Payout.BeneficiaryID)
+			assert.Equal(t, got.Payout.BankCode, tc.wantResult.Payout.BankCode)
+			assert.Equal(t, got.Payout.AccountNumber, tc.wantResult.Payout.AccountNumber)
+			assert.Equal(t, got.Payout.AccountName, tc.wantResult.Payout.AccountName)
+			assert.Equal(t, got.Payout.Status, tc.wantResult.Payout.Status)
+		})
+	}
+}
+
+func TestOpenCurrencyWallet(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.CurrencyWalletRequest
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.WalletResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			args: args{
+				customerXID: "1",
+				payload:     web.CurrencyWalletRequest{Currency: "USD"},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{}, sql.ErrNoRows)
+				mockRepository.EXPECT().CreateWallet(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+			wantResult: web.WalletResponse{
+				OwnedBy:  "1",
+				Currency: "USD",
+				Status:   "enabled",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - unsupported currency",
+			args: args{
+				customerXID: "1",
+				payload:     web.CurrencyWalletRequest{Currency: "XXX"},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.WalletResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - currency wallet already exists",
+			args: args{
+				customerXID: "1",
+				payload:     web.CurrencyWalletRequest{Currency: "USD"},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id",
+					Status: "enabled",
+				}, nil)
+				mockRepository.EXPECT().GetWalletByCurrency(gomock.Any(), "1", "USD").Return(domain.Wallet{ID: "mock-usd"}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.WalletResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := svc.OpenCurrencyWallet(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.OwnedBy, tc.wantResult.OwnedBy)
+			assert.Equal(t, got.Currency, tc.wantResult.Currency)
+			assert.Equal(t, got.Status, tc.wantResult.Status)
+		})
+	}
+}
+
+func TestTransferBalance(t *testing.T) {
+	type (
+		args struct {
+			customerXID string
+			payload     web.TransferRequest
+		}
+	)
+
+	testCases := []struct {
+		testID     int
+		testDesc   string
+		args       args
+		mockFunc   func()
+		wantErr    bool
+		wantResult web.TransferResponse
+	}{
+		{
+			testID:   1,
+			testDesc: "Success",
+			args: args{
+				customerXID: "1",
+				payload: web.TransferRequest{
+					RecipientXID: "2",
+					Amount:       1000,
+					ReferenceID:  "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:          "mock-id-1",
+					CustomerXID: "1",
+					Status:      "enabled",
+					Balance:     domain.Money{Amount: 5000},
+				}, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{
+					ID:          "mock-id-2",
+					CustomerXID: "2",
+					Status:      "enabled",
+				}, nil)
+				mockRepository.EXPECT().TransferBalance(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: false,
+			wantResult: web.TransferResponse{
+				TransferredBy: "1",
+				RecipientXID:  "2",
+				Amount:        domain.Money{Amount: 1000},
+				ReferenceID:   "mock-ref",
+			},
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - transfer to self",
+			args: args{
+				customerXID: "1",
+				payload: web.TransferRequest{
+					RecipientXID: "1",
+					Amount:       1000,
+					ReferenceID:  "mock-ref",
+				},
+			},
+			mockFunc: func() {
+			},
+			wantErr:    true,
+			wantResult: web.TransferResponse{},
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - recipient not found",
+			args: args{
+				customerXID: "1",
+				payload: web.TransferRequest{
+					RecipientXID: "2",
+					Amount:       1000,
+					ReferenceID:  "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:     "mock-id-1",
+					Status: "enabled",
+				}, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{}, sql.ErrNoRows)
+			},
+			wantErr:    true,
+			wantResult: web.TransferResponse{},
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - insufficient balance",
+			args: args{
+				customerXID: "1",
+				payload: web.TransferRequest{
+					RecipientXID: "2",
+					Amount:       1000,
+					ReferenceID:  "mock-ref",
+				},
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(domain.Wallet{
+					ID:      "mock-id-1",
+					Status:  "enabled",
+					Balance: domain.Money{Amount: 100},
+				}, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "2").Return(domain.Wallet{
+					ID:     "mock-id-2",
+					Status: "enabled",
+				}, nil)
+			},
+			wantErr:    true,
+			wantResult: web.TransferResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := svc.TransferBalance(context.Background(), tc.args.customerXID, tc.args.payload)
+			assert.Equal(t, err != nil, tc.wantErr)
+			assert.Equal(t, got.TransferredBy, tc.wantResult.TransferredBy)
+			assert.Equal(t, got.RecipientXID, tc.wantResult.RecipientXID)
+			assert.Equal(t, got.Amount, tc.wantResult.Amount)
+			assert.Equal(t, got.ReferenceID, tc.wantResult.ReferenceID)
+		})
+	}
+}
+
+func TestMemberTransferBalance(t *testing.T) {
+	owner := domain.Wallet{
+		ID:          "mock-id-1",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.NewMoney(5000, "IDR"),
+	}
+	recipient := domain.Wallet{
+		ID:          "mock-id-3",
+		CustomerXID: "3",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.NewMoney(0, "IDR"),
+	}
+	member := domain.WalletMember{
+		ID:            "mock-member",
+		WalletID:      "mock-id-1",
+		OwnerXID:      "1",
+		MemberXID:     "2",
+		SpendingLimit: domain.NewMoney(1500, "IDR"),
+		AllowedTypes:  []string{"transfer_out"},
+		Status:        "active",
+	}
+	payload := web.TransferRequest{
+		RecipientXID: "3",
+		Amount:       1000,
+		ReferenceID:  "mock-ref",
+		MemberXID:    "2",
+	}
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - member transfer recorded on the member",
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(recipient, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(member, nil)
+				mockWalletMemberRepository.EXPECT().ReserveMemberSpend(gomock.Any(), "mock-member", domain.NewMoney(1000, "IDR"), time.Now().Format("2006-01")).Return(true, nil)
+				mockRepository.EXPECT().TransferBalance(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, debit, credit domain.Transaction, guard domain.TransferGuard) (bool, error) {
+						assert.Equal(t, debit.CustomerXID, "1")
+						assert.Equal(t, debit.InitiatedBy, "2")
+						return true, nil
+					})
+			},
+			wantErr: nil,
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - transaction type not allowed",
+			mockFunc: func() {
+				withdrawOnly := member
+				withdrawOnly.AllowedTypes = []string{"withdrawal"}
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(recipient, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(withdrawOnly, nil)
+			},
+			wantErr: fmt.Errorf("transaction type not allowed for member"),
+		},
+		{
+			testID:   3,
+			testDesc: "Failed - member suspended",
+			mockFunc: func() {
+				suspended := member
+				suspended.Status = "suspended"
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(recipient, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(suspended, nil)
+			},
+			wantErr: fmt.Errorf("membership suspended"),
+		},
+		{
+			testID:   4,
+			testDesc: "Failed - spending limit exceeded",
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(recipient, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(member, nil)
+				mockWalletMemberRepository.EXPECT().ReserveMemberSpend(gomock.Any(), "mock-member", gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantErr: fmt.Errorf("member spending limit exceeded"),
+		},
+		{
+			testID:   5,
+			testDesc: "Failed - reservation released when the transfer fails",
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(recipient, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(member, nil)
+				mockWalletMemberRepository.EXPECT().ReserveMemberSpend(gomock.Any(), "mock-member", gomock.Any(), gomock.Any()).Return(true, nil)
+				mockRepository.EXPECT().TransferBalance(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+				mockWalletMemberRepository.EXPECT().ReleaseMemberSpend(gomock.Any(), "mock-member", domain.NewMoney(1000, "IDR"), gomock.Any()).Return(nil)
+			},
+			wantErr: fmt.Errorf("insufficient balance"),
+		},
+		{
+			testID:   6,
+			testDesc: "Failed - not a member",
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "3").Return(recipient, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(domain.WalletMember{}, sql.ErrNoRows)
+			},
+			wantErr: fmt.Errorf("not a member of the wallet"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := svc.TransferBalance(context.Background(), "1", payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.TransferredBy, "1")
+			assert.Equal(t, got.InitiatedBy, "2")
+		})
+	}
+}
+
+func TestMemberDeductWalletBalance(t *testing.T) {
+	owner := domain.Wallet{
+		ID:          "mock-id-1",
+		CustomerXID: "1",
+		Currency:    "IDR",
+		Status:      "enabled",
+		Balance:     domain.NewMoney(5000, "IDR"),
+		CreditLimit: domain.NewMoney(0, "IDR"),
+	}
+	member := domain.WalletMember{
+		ID:            "mock-member",
+		WalletID:      "mock-id-1",
+		OwnerXID:      "1",
+		MemberXID:     "2",
+		SpendingLimit: domain.NewMoney(1500, "IDR"),
+		AllowedTypes:  []string{"withdrawal"},
+		Status:        "active",
+	}
+
+	testCases := []struct {
+		testID   int
+		testDesc string
+		payload  web.WithdrawalRequest
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			testID:   1,
+			testDesc: "Success - bank account of a member withdrawal not saved",
+			payload: web.WithdrawalRequest{
+				Amount:        1000,
+				ReferenceID:   "mock-ref",
+				BankCode:      "BCA",
+				AccountNumber: "1234567890",
+				AccountName:   "John Doe",
+				MemberXID:     "2",
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(member, nil)
+				mockWalletMemberRepository.EXPECT().ReserveMemberSpend(gomock.Any(), "mock-member", domain.NewMoney(1000, "IDR"), gomock.Any()).Return(true, nil)
+				mockPayoutRepository.EXPECT().CreatePayout(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payout domain.Payout, withdrawal domain.Transaction, balance, newBalance domain.Money) (bool, error) {
+						assert.Equal(t, payout.BeneficiaryID, "")
+						assert.Equal(t, payout.BankAccount.AccountNumber, "1234567890")
+						assert.Equal(t, withdrawal.InitiatedBy, "2")
+						return true, nil
+					})
+				mockPayoutRepository.EXPECT().MarkPayoutSubmitted(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			testID:   2,
+			testDesc: "Failed - member withdrawal to a saved beneficiary",
+			payload: web.WithdrawalRequest{
+				Amount:        1000,
+				ReferenceID:   "mock-ref",
+				BeneficiaryID: "mock-beneficiary",
+				MemberXID:     "2",
+			},
+			mockFunc: func() {
+				mockRepository.EXPECT().GetWallet(gomock.Any(), "1").Return(owner, nil)
+				mockWalletMemberRepository.EXPECT().GetWalletMember(gomock.Any(), "mock-id-1", "2").Return(member, nil)
+			},
+			wantErr: fmt.Errorf("members cannot withdraw to saved beneficiaries"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testDesc, func(t *testing.T) {
+			testDep := provideTest(t)
+			defer testDep()
+			tc.mockFunc()
+
+			got, err := svc.DeductWalletBalance(context.Background(), "1", tc.payload)
+			if tc.wantErr != nil {
+				assert.Equal(t, err.Error(), tc.wantErr.Error())
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, got.WithdrawnBy, "1")
+			assert.Equal(t, got.InitiatedBy, "2")
 		})
 	}
 }
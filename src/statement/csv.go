@@ -0,0 +1,97 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const csvDateLayout = "2006-01-02"
+
+// ParseCSV reads a statement export with a header row naming its columns.
+// date (YYYY-MM-DD), amount, currency and reference are required, description
+// is optional. The direction comes from a type column (C/D, CR/DR or
+// credit/debit), without one a negative amount is a debit.
+func ParseCSV(data []byte) ([]Line, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("statement is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "amount", "currency", "reference"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New("statement is missing the " + name + " column")
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var lines []Line
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		valueDate, err := time.Parse(csvDateLayout, field(record, "date"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid date", row)
+		}
+
+		amount := field(record, "amount")
+		direction := DirectionCredit
+		if strings.HasPrefix(amount, "-") {
+			direction = DirectionDebit
+			amount = amount[1:]
+		}
+		if _, ok := columns["type"]; ok {
+			switch strings.ToUpper(field(record, "type")) {
+			case "C", "CR", "CREDIT":
+				direction = DirectionCredit
+			case "D", "DR", "DEBIT":
+				direction = DirectionDebit
+			default:
+				return nil, fmt.Errorf("row %d: invalid type", row)
+			}
+		}
+
+		currency := strings.ToUpper(field(record, "currency"))
+		minor, err := parseAmount(amount, '.', currency)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %s", row, err.Error())
+		}
+
+		lines = append(lines, Line{
+			ValueDate:   valueDate,
+			Direction:   direction,
+			Amount:      minor,
+			Currency:    currency,
+			Reference:   field(record, "reference"),
+			Description: field(record, "description"),
+		})
+	}
+	return lines, nil
+}
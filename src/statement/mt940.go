@@ -0,0 +1,117 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	mt940TagPattern = regexp.MustCompile(`^:([0-9]{2}[A-Z]?):(.*)$`)
+
+	// value date, optional entry date, mark, optional funds code, amount,
+	// transaction type and the references
+	mt940LinePattern = regexp.MustCompile(`^([0-9]{6})([0-9]{4})?(RC|RD|C|D)([A-Z])?([0-9]+,[0-9]*)([SNF][A-Z0-9]{3})(.*)$`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 reads a SWIFT MT940 customer statement. Bookings come from the
+// :61: fields, their currency from the opening balance and the description
+// from the :86: field that follows them. A reversal (RC, RD) books in the
+// opposite direction of the original entry.
+func ParseMT940(data []byte) ([]Line, error) {
+	var fields []mt940Field
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	for _, raw := range strings.Split(text, "\n") {
+		row := strings.TrimRight(raw, " ")
+		if match := mt940TagPattern.FindStringSubmatch(row); match != nil {
+			fields = append(fields, mt940Field{tag: match[1], value: match[2]})
+			continue
+		}
+		// block headers, the trailer and blank lines carry no bookings
+		if row == "" || strings.HasPrefix(row, "{") || strings.HasPrefix(row, "-") {
+			continue
+		}
+		if len(fields) == 0 {
+			return nil, errors.New("statement is not an mt940 file")
+		}
+		fields[len(fields)-1].value += "\n" + row
+	}
+
+	var (
+		lines    []Line
+		currency string
+	)
+	for i, f := range fields {
+		switch f.tag {
+		case "60F", "60M":
+			if len(f.value) < 10 {
+				return nil, fmt.Errorf("field %d: invalid opening balance", i+1)
+			}
+			currency = f.value[7:10]
+		case "61":
+			if currency == "" {
+				return nil, fmt.Errorf("field %d: booking before the opening balance", i+1)
+			}
+			line, err := parseMT940Line(f.value, currency)
+			if err != nil {
+				return nil, fmt.Errorf("field %d: %s", i+1, err.Error())
+			}
+			lines = append(lines, line)
+		case "86":
+			if i > 0 && fields[i-1].tag == "61" {
+				lines[len(lines)-1].Description = strings.Join(strings.Fields(f.value), " ")
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("statement is empty")
+	}
+	return lines, nil
+}
+
+func parseMT940Line(value, currency string) (Line, error) {
+	// a second line holds supplementary details, only the first is read
+	first := strings.SplitN(value, "\n", 2)[0]
+	match := mt940LinePattern.FindStringSubmatch(first)
+	if match == nil {
+		return Line{}, errors.New("invalid booking " + first)
+	}
+
+	valueDate, err := time.Parse("060102", match[1])
+	if err != nil {
+		return Line{}, errors.New("invalid value date " + match[1])
+	}
+
+	direction := DirectionCredit
+	if match[3] == "D" || match[3] == "RC" {
+		direction = DirectionDebit
+	}
+
+	amount, err := parseAmount(match[5], ',', currency)
+	if err != nil {
+		return Line{}, err
+	}
+
+	reference, bankReference := match[7], ""
+	if i := strings.Index(reference, "//"); i >= 0 {
+		reference, bankReference = reference[:i], reference[i+2:]
+	}
+	if reference == "" || reference == "NONREF" {
+		reference = bankReference
+	}
+
+	return Line{
+		ValueDate: valueDate,
+		Direction: direction,
+		Amount:    amount,
+		Currency:  currency,
+		Reference: strings.TrimSpace(reference),
+	}, nil
+}
@@ -0,0 +1,61 @@
+// Package statement parses bank statement files into lines the wallet can
+// reconcile against its transactions. CSV exports and SWIFT MT940 files are
+// supported, both are read fully into memory as statements are small.
+package statement
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/mozartmuhammad/julo-be-test/src/model/constants"
+)
+
+const (
+	DirectionCredit = constants.DIRECTION_CREDIT
+	DirectionDebit  = constants.DIRECTION_DEBIT
+)
+
+// Line is one booking on the bank account. Amount is in minor units of
+// Currency and is always positive, Direction tells which way the money went.
+type Line struct {
+	ValueDate   time.Time
+	Direction   string
+	Amount      int64
+	Currency    string
+	Reference   string
+	Description string
+}
+
+// parseAmount converts a decimal amount written with separator into minor
+// units of currency.
+func parseAmount(value string, separator byte, currency string) (int64, error) {
+	minorUnits, ok := constants.CurrencyMinorUnits[currency]
+	if !ok {
+		return 0, errors.New("unsupported currency " + currency)
+	}
+
+	whole, fraction := value, ""
+	if i := strings.IndexByte(value, separator); i >= 0 {
+		whole, fraction = value[:i], value[i+1:]
+	}
+	if whole == "" && fraction == "" {
+		return 0, errors.New("invalid amount " + value)
+	}
+	if len(fraction) > minorUnits {
+		return 0, errors.New("amount has too many decimals " + value)
+	}
+
+	var amount int64
+	digits := whole + fraction + strings.Repeat("0", minorUnits-len(fraction))
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, errors.New("invalid amount " + value)
+		}
+		if amount > (1<<63-1-int64(digits[i]-'0'))/10 {
+			return 0, errors.New("amount out of range " + value)
+		}
+		amount = amount*10 + int64(digits[i]-'0')
+	}
+	return amount, nil
+}